@@ -0,0 +1,103 @@
+// Command api-compat reports changes to the nexus.v4.config API definitions
+// that break existing clients.
+//
+// It compares two revisions of the protobuf descriptors and EDM bindings.
+// A revision is captured by the snapshot subcommand, built from that
+// revision, or given as the binary FileDescriptorSet written by
+// protoc --include_imports --descriptor_set_out, in which case only the
+// descriptors are compared.
+//
+// Usage:
+//
+//	api-compat snapshot [-out api-snapshot.json]
+//	api-compat check -old api-snapshot.json [-new other-snapshot.json]
+//
+// check compares -old with -new, by default the definitions api-compat was
+// built from, prints every breaking change and exits with status 1 if there
+// is any.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	errorpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/error"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	edmconfig "github.com/nutanix/ntnx-api-golang-mock-pc/generated-code/edm/nexus/v4/config"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/compat"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("api-compat: ")
+	if len(os.Args) < 2 {
+		log.Fatal("usage: api-compat snapshot|check [flags]")
+	}
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "snapshot":
+		snapshot(args)
+	case "check":
+		check(args)
+	default:
+		log.Fatalf("unknown subcommand %q", cmd)
+	}
+}
+
+// current returns the snapshot of the definitions linked into the binary.
+func current() *compat.Snapshot {
+	files := []protoreflect.FileDescriptor{
+		pb.File_nexus_v4_config_item_service_proto,
+		errorpb.File_nexus_v4_error_error_proto,
+	}
+	return compat.Current(files, edmconfig.GetAllEntityBindings())
+}
+
+func snapshot(args []string) {
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	out := fs.String("out", "api-snapshot.json", "file to write the snapshot to")
+	fs.Parse(args)
+
+	data, err := json.Marshal(current())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func check(args []string) {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	oldPath := fs.String("old", "", "snapshot or descriptor set of the older revision")
+	newPath := fs.String("new", "", "snapshot or descriptor set of the newer revision (default: the built-in definitions)")
+	fs.Parse(args)
+	if *oldPath == "" {
+		log.Fatal("check: -old is required")
+	}
+
+	before, err := compat.Load(*oldPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	after := current()
+	if *newPath != "" {
+		if after, err = compat.Load(*newPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+	changes, err := compat.Compare(before, after)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, c := range changes {
+		fmt.Println(c)
+	}
+	if len(changes) > 0 {
+		log.Fatalf("%d breaking changes", len(changes))
+	}
+}
@@ -0,0 +1,59 @@
+// Command item-fixtures seeds a store with the items and associations of a
+// dataset, see package fixtures.
+//
+// Usage:
+//
+//	item-fixtures -store file:DIR [-dataset idf|FILE] [-seed N]
+//
+// -store names the store as for store.Open. -dataset is the name of a
+// built-in dataset or a YAML or JSON file, and -seed overrides its seed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/fixtures"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("item-fixtures: ")
+	storeSpec := flag.String("store", "", "store to seed, file:DIR or kv:PATH")
+	dataset := flag.String("dataset", "idf", "built-in dataset or dataset file to load")
+	seed := flag.Uint64("seed", 0, "seed of pseudo-random values (default: that of the dataset)")
+	flag.Parse()
+	if *storeSpec == "" {
+		log.Fatal("-store is required")
+	}
+
+	d, err := fixtures.Builtin(*dataset)
+	if err != nil {
+		if d, err = fixtures.ReadFile(*dataset); err != nil {
+			log.Fatal(err)
+		}
+	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			d.Seed = *seed
+		}
+	})
+	e, err := d.Build()
+	if err != nil {
+		log.Fatal(err)
+	}
+	s, err := store.Open(*storeSpec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = fixtures.Load(s, e)
+	if cerr := s.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%d items and %d associations loaded\n", len(e.Items), len(e.Associations))
+}
@@ -0,0 +1,55 @@
+// Command mock-cat-server serves mock.v4.config.ItemService over gRPC with a
+// seeded set of cats, their locations and countries.
+//
+// Usage:
+//
+//	mock-cat-server [-listen :9090] [-dataset FILE]
+//
+// -dataset serves the cats of a fixtures dataset file instead of the
+// built-in ones.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/mock/v4/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/fixtures"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/mockserver"
+)
+
+func main() {
+	listen := flag.String("listen", ":9090", "address to serve gRPC on")
+	dataset := flag.String("dataset", "", "fixtures dataset file declaring the cats to serve")
+	flag.Parse()
+
+	cats := mockserver.SeedItems()
+	if *dataset != "" {
+		d, err := fixtures.ReadFile(*dataset)
+		if err != nil {
+			log.Fatal(err)
+		}
+		e, err := d.Build()
+		if err != nil {
+			log.Fatal(err)
+		}
+		cats = e.Cats
+	}
+
+	lis, err := net.Listen("tcp", *listen)
+	if err != nil {
+		log.Fatalf("listen on %s: %v", *listen, err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterItemServiceServer(srv, mockserver.NewItemService(cats))
+	reflection.Register(srv)
+
+	log.Printf("mock.v4.config.ItemService listening on %s", lis.Addr())
+	if err := srv.Serve(lis); err != nil {
+		log.Fatalf("serve: %v", err)
+	}
+}
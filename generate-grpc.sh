@@ -82,6 +82,21 @@ protoc --proto_path="${SWAGGER_PROTO_ROOT}" \
     --go-grpc_opt=paths=source_relative \
     nexus/v4/config/item_service.proto
 
+# Generate the mock.v4 cat service served by cmd/mock-cat-server
+echo "  → mock error.proto, config.proto and cat_service.proto"
+protoc --proto_path="${SWAGGER_PROTO_ROOT}" \
+    --proto_path="$(go env GOROOT)/src" \
+    --go_out="${PROTO_OUT_ROOT}" \
+    --go_opt=paths=source_relative \
+    mock/v4/error/error.proto mock/v4/config/config.proto
+protoc --proto_path="${SWAGGER_PROTO_ROOT}" \
+    --proto_path="$(go env GOROOT)/src" \
+    --go_out="${PROTO_OUT_ROOT}" \
+    --go_opt=paths=source_relative \
+    --go-grpc_out="${PROTO_OUT_ROOT}" \
+    --go-grpc_opt=paths=source_relative \
+    mock/v4/config/cat_service.proto
+
 # Post-process: Fix import paths in generated .pb.go files
 # IMPORTANT: Only fix Go import statements, NOT the raw descriptor (binary data)
 echo ""
@@ -124,6 +139,7 @@ for i, line in enumerate(lines):
     if line.strip().startswith('import ') or (in_import_block and (line.strip().startswith('"') or line.strip() == ')')):
         # This is an import statement
         line = re.sub(r'"nexus/v4/', r'"github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/', line)
+        line = re.sub(r'"mock/v4/', r'"github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/mock/v4/', line)
         if line.strip() == 'import (':
             in_import_block = True
         elif line.strip() == ')':
@@ -138,6 +154,8 @@ content = '\n'.join(result_lines)
 # Remove blank imports
 content = re.sub(r'^[ \t]*_[ \t]*"github\.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4"$', '', content, flags=re.MULTILINE)
 content = re.sub(r'^[ \t]*_[ \t]*"nexus/v4"$', '', content, flags=re.MULTILINE)
+content = re.sub(r'^[ \t]*_[ \t]*"github\.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/mock/v4"$', '', content, flags=re.MULTILINE)
+content = re.sub(r'^[ \t]*_[ \t]*"mock/v4"$', '', content, flags=re.MULTILINE)
 
 with open("$file", "w") as f:
     f.write(content)
@@ -146,12 +164,13 @@ PYEOF
 done
 
 # Fix common/v1 and nexus/v4/error imports (these are in import statements, safe to fix)
-echo "  🔧 Fixing common/v1 and nexus/v4/error import paths..."
+echo "  🔧 Fixing common/v1, nexus/v4/error and mock/v4/error import paths..."
 for file in $(find "${PROTO_OUT_DIR}" -name "*.pb.go" -type f); do
     if [[ -f "$file" ]]; then
         sed -i '' 's|response "common/v1/response"|response "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/response"|g' "$file"
         sed -i '' 's|config "common/v1/config"|config "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"|g' "$file"
         sed -i '' 's|"nexus/v4/error"|"github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/error"|g' "$file"
+        sed -i '' 's|"mock/v4/error"|"github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/mock/v4/error"|g' "$file"
     fi
 done
 
@@ -571,6 +571,104 @@ func (e MessageSeverity) Ref() *MessageSeverity {
   return &e
 }
 
+/*
+A model base class whose instances are bound to a specific tenant.  This model adds a tenantId to the base model class that it extends and is automatically set by the server.
+*/
+type TenantAwareModel struct {
+  
+  ObjectType_ *string `json:"$objectType,omitempty"`
+  
+  Reserved_ map[string]interface{} `json:"$reserved,omitempty"`
+  
+  UnknownFields_ map[string]interface{} `json:"$unknownFields,omitempty"`
+  /*
+  A globally unique identifier that represents the tenant that owns this entity. The system automatically assigns it, and it and is immutable from an API consumer perspective (some use cases may cause this ID to change - For instance, a use case may require the transfer of ownership of the entity, but these cases are handled automatically on the server). 
+  */
+  TenantId *string `json:"tenantId,omitempty"`
+}
+
+func (p *TenantAwareModel) MarshalJSON() ([]byte, error) {
+  // Create Alias to avoid infinite recursion
+  type Alias TenantAwareModel
+
+  // Step 1: Marshal the known fields
+  known, err := json.Marshal(Alias(*p))
+  if err != nil {
+  	return nil, err
+  }
+
+    // Step 2: Convert known to map for merging
+    var knownMap map[string]interface{}
+    if err := json.Unmarshal(known, &knownMap); err != nil {
+    	return nil, err
+    }
+    delete(knownMap, "$unknownFields")
+  
+    // Step 3: Merge unknown fields
+    for k, v := range p.UnknownFields_ {
+    	knownMap[k] = v
+    }
+  
+    // Step 4: Marshal final merged map
+    return json.Marshal(knownMap)
+}
+
+func (p *TenantAwareModel) UnmarshalJSON(b []byte) error {
+    // Step 1: Unmarshal into a generic map to capture all fields
+    var allFields map[string]interface{}
+	if err := json.Unmarshal(b, &allFields); err != nil {
+		return err
+	}
+
+    // Step 2: Unmarshal into a temporary struct with known fields
+	type Alias TenantAwareModel
+	known := &Alias{}
+	if err := json.Unmarshal(b, known); err != nil {
+		return err
+	}
+
+    // Step 3: Assign known fields
+	*p = *NewTenantAwareModel()
+
+    if known.ObjectType_ != nil {
+        p.ObjectType_ = known.ObjectType_
+    }
+    if known.Reserved_ != nil {
+        p.Reserved_ = known.Reserved_
+    }
+    if known.UnknownFields_ != nil {
+        p.UnknownFields_ = known.UnknownFields_
+    }
+    if known.TenantId != nil {
+        p.TenantId = known.TenantId
+    }
+
+    // Step 4: Remove known JSON fields from allFields map
+	delete(allFields, "$objectType")
+	delete(allFields, "$reserved")
+	delete(allFields, "$unknownFields")
+	delete(allFields, "tenantId")
+
+    // Step 5: Assign remaining fields to UnknownFields_
+	for key, value := range allFields {
+      p.UnknownFields_[key] = value
+    }
+
+	return nil
+}
+
+func NewTenantAwareModel() *TenantAwareModel {
+  p := new(TenantAwareModel)
+  p.ObjectType_ = new(string)
+  *p.ObjectType_ = "common.v1.config.TenantAwareModel"
+  p.Reserved_ = map[string]interface{}{"$fv": "v1.r0"}
+  p.UnknownFields_ = map[string]interface{}{}
+
+
+
+  return p
+}
+
 type OneOfKVPairValue struct {
   Discriminator *string `json:"-"`
   ObjectType_ *string `json:"-"`
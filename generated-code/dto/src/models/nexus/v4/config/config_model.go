@@ -18,7 +18,9 @@ import (
   "encoding/json"
   "errors"
   "fmt"
+  "time"
   import1 "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/nexus/v4/error"
+  import3 "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/common/v1/config"
 )
 /*
 Item entity for mock REST API
@@ -35,6 +37,10 @@ type Item struct {
   */
   Associations []ItemAssociation `json:"associations,omitempty"`
   /*
+  Time at which the item was deleted. Deleted items are kept, excluded from listings unless $filter refers to isDeleted, until they are restored or purged once the retention period of the service has elapsed.
+  */
+  DeletedAt *time.Time `json:"deletedAt,omitempty"`
+  /*
   Description of the item
   */
   Description *string `json:"description,omitempty"`
@@ -54,6 +60,14 @@ type Item struct {
   Type of item
   */
   ItemType *string `json:"itemType"`
+  /*
+  A HATEOAS style link for the response. Each link contains a user-friendly name identifying the link and an address for retrieving the particular resource. Every item carries a self link and an associations link.
+  */
+  Links []import2.ApiLink `json:"links,omitempty"`
+  /*
+  Tenant that owns the item. It is assigned from the tenant of the request that created the item and cannot be changed.
+  */
+  TenantInfo *import3.TenantAwareModel `json:"tenantInfo,omitempty"`
 }
 
 func (p *Item) MarshalJSON() ([]byte, error) {
@@ -120,6 +134,9 @@ func (p *Item) UnmarshalJSON(b []byte) error {
     if known.Associations != nil {
         p.Associations = known.Associations
     }
+    if known.DeletedAt != nil {
+        p.DeletedAt = known.DeletedAt
+    }
     if known.Description != nil {
         p.Description = known.Description
     }
@@ -135,17 +152,26 @@ func (p *Item) UnmarshalJSON(b []byte) error {
     if known.ItemType != nil {
         p.ItemType = known.ItemType
     }
+    if known.Links != nil {
+        p.Links = known.Links
+    }
+    if known.TenantInfo != nil {
+        p.TenantInfo = known.TenantInfo
+    }
 
     // Step 4: Remove known JSON fields from allFields map
 	delete(allFields, "$objectType")
 	delete(allFields, "$reserved")
 	delete(allFields, "$unknownFields")
 	delete(allFields, "associations")
+	delete(allFields, "deletedAt")
 	delete(allFields, "description")
 	delete(allFields, "extId")
 	delete(allFields, "itemId")
 	delete(allFields, "itemName")
 	delete(allFields, "itemType")
+	delete(allFields, "links")
+	delete(allFields, "tenantInfo")
 
     // Step 5: Assign remaining fields to UnknownFields_
 	for key, value := range allFields {
@@ -169,6 +195,106 @@ func NewItem() *Item {
 
 
 
+/*
+A row of the result of $apply on items, with one key-value pair per grouping property and per aggregate, in the order $apply lists them
+*/
+type ItemAggregate struct {
+  
+  ObjectType_ *string `json:"$objectType,omitempty"`
+  
+  Reserved_ map[string]interface{} `json:"$reserved,omitempty"`
+  
+  UnknownFields_ map[string]interface{} `json:"$unknownFields,omitempty"`
+  /*
+  The grouping values and aggregates of the row. Grouping properties are named by their path and aggregates by their alias.
+  */
+  Values []import3.KVPair `json:"values,omitempty"`
+}
+
+func (p *ItemAggregate) MarshalJSON() ([]byte, error) {
+  // Create Alias to avoid infinite recursion
+  type Alias ItemAggregate
+
+  // Step 1: Marshal the known fields
+  known, err := json.Marshal(Alias(*p))
+  if err != nil {
+  	return nil, err
+  }
+
+    // Step 2: Convert known to map for merging
+    var knownMap map[string]interface{}
+    if err := json.Unmarshal(known, &knownMap); err != nil {
+    	return nil, err
+    }
+    delete(knownMap, "$unknownFields")
+  
+    // Step 3: Merge unknown fields
+    for k, v := range p.UnknownFields_ {
+    	knownMap[k] = v
+    }
+  
+    // Step 4: Marshal final merged map
+    return json.Marshal(knownMap)
+}
+
+func (p *ItemAggregate) UnmarshalJSON(b []byte) error {
+    // Step 1: Unmarshal into a generic map to capture all fields
+    var allFields map[string]interface{}
+	if err := json.Unmarshal(b, &allFields); err != nil {
+		return err
+	}
+
+    // Step 2: Unmarshal into a temporary struct with known fields
+	type Alias ItemAggregate
+	known := &Alias{}
+	if err := json.Unmarshal(b, known); err != nil {
+		return err
+	}
+
+    // Step 3: Assign known fields
+	*p = *NewItemAggregate()
+
+    if known.ObjectType_ != nil {
+        p.ObjectType_ = known.ObjectType_
+    }
+    if known.Reserved_ != nil {
+        p.Reserved_ = known.Reserved_
+    }
+    if known.UnknownFields_ != nil {
+        p.UnknownFields_ = known.UnknownFields_
+    }
+    if known.Values != nil {
+        p.Values = known.Values
+    }
+
+    // Step 4: Remove known JSON fields from allFields map
+	delete(allFields, "$objectType")
+	delete(allFields, "$reserved")
+	delete(allFields, "$unknownFields")
+	delete(allFields, "values")
+
+    // Step 5: Assign remaining fields to UnknownFields_
+	for key, value := range allFields {
+      p.UnknownFields_[key] = value
+    }
+
+	return nil
+}
+
+func NewItemAggregate() *ItemAggregate {
+  p := new(ItemAggregate)
+  p.ObjectType_ = new(string)
+  *p.ObjectType_ = "nexus.v4.config.ItemAggregate"
+  p.Reserved_ = map[string]interface{}{"$fv": "v4.r1"}
+  p.UnknownFields_ = map[string]interface{}{}
+
+
+
+  return p
+}
+
+
+
 /*
 Association entity for items, representing related entities associated with an item
 */
@@ -195,6 +321,10 @@ type ItemAssociation struct {
   The item ID this association belongs to
   */
   ItemId *string `json:"itemId,omitempty"`
+  /*
+  Tenant that owns the association. It is always the tenant of the item the association belongs to.
+  */
+  TenantInfo *import3.TenantAwareModel `json:"tenantInfo,omitempty"`
 }
 
 func (p *ItemAssociation) MarshalJSON() ([]byte, error) {
@@ -261,6 +391,9 @@ func (p *ItemAssociation) UnmarshalJSON(b []byte) error {
     if known.ItemId != nil {
         p.ItemId = known.ItemId
     }
+    if known.TenantInfo != nil {
+        p.TenantInfo = known.TenantInfo
+    }
 
     // Step 4: Remove known JSON fields from allFields map
 	delete(allFields, "$objectType")
@@ -270,6 +403,7 @@ func (p *ItemAssociation) UnmarshalJSON(b []byte) error {
 	delete(allFields, "entityId")
 	delete(allFields, "entityType")
 	delete(allFields, "itemId")
+	delete(allFields, "tenantInfo")
 
     // Step 5: Assign remaining fields to UnknownFields_
 	for key, value := range allFields {
@@ -317,6 +451,10 @@ type ItemAssociationProjection struct {
   The item ID this association belongs to
   */
   ItemId *string `json:"itemId,omitempty"`
+  /*
+  Tenant that owns the association. It is always the tenant of the item the association belongs to.
+  */
+  TenantInfo *import3.TenantAwareModel `json:"tenantInfo,omitempty"`
 }
 
 func (p *ItemAssociationProjection) MarshalJSON() ([]byte, error) {
@@ -383,6 +521,9 @@ func (p *ItemAssociationProjection) UnmarshalJSON(b []byte) error {
     if known.ItemId != nil {
         p.ItemId = known.ItemId
     }
+    if known.TenantInfo != nil {
+        p.TenantInfo = known.TenantInfo
+    }
 
     // Step 4: Remove known JSON fields from allFields map
 	delete(allFields, "$objectType")
@@ -392,6 +533,7 @@ func (p *ItemAssociationProjection) UnmarshalJSON(b []byte) error {
 	delete(allFields, "entityId")
 	delete(allFields, "entityType")
 	delete(allFields, "itemId")
+	delete(allFields, "tenantInfo")
 
     // Step 5: Assign remaining fields to UnknownFields_
 	for key, value := range allFields {
@@ -416,6 +558,211 @@ func NewItemAssociationProjection() *ItemAssociationProjection {
 
 
 
+/*
+A change made to an item or to one of its associations, as recorded in the change history of items
+*/
+type ItemChange struct {
+  
+  ObjectType_ *string `json:"$objectType,omitempty"`
+  
+  Reserved_ map[string]interface{} `json:"$reserved,omitempty"`
+  
+  UnknownFields_ map[string]interface{} `json:"$unknownFields,omitempty"`
+  /*
+  User that made the change, or empty for changes not made through the API
+  */
+  Actor *string `json:"actor,omitempty"`
+  /*
+  The association after the change. It is absent for changes made to items and for the deletion of an association.
+  */
+  AssociationAfter *ItemAssociation `json:"associationAfter,omitempty"`
+  /*
+  The association before the change. It is absent for changes made to items and for the creation of an association.
+  */
+  AssociationBefore *ItemAssociation `json:"associationBefore,omitempty"`
+  /*
+  The properties the change set, modified or removed, in the order of their names
+  */
+  Changes []PropertyChange `json:"changes,omitempty"`
+  /*
+  ID of the entity of the changed association. It is only present for changes made to associations.
+  */
+  EntityId *string `json:"entityId,omitempty"`
+  /*
+  Type of the entity of the changed association. It is only present for changes made to associations.
+  */
+  EntityType *string `json:"entityType,omitempty"`
+  /*
+  The item after the change. It is absent for changes made to associations and for the purge of an item.
+  */
+  ItemAfter *Item `json:"itemAfter,omitempty"`
+  /*
+  The item before the change. It is absent for changes made to associations and for the creation of an item.
+  */
+  ItemBefore *Item `json:"itemBefore,omitempty"`
+  /*
+  External identifier of the item that was changed, or of the item the changed association belongs to
+  */
+  ItemExtId *string `json:"itemExtId,omitempty"`
+  /*
+  What the change did to the item or association, one of CREATE, UPDATE, DELETE, RESTORE and PURGE. Only items are restored or purged.
+  */
+  Operation *string `json:"operation,omitempty"`
+  /*
+  Identifier of the request that made the change, as sent in its X-Request-Id header
+  */
+  RequestId *string `json:"requestId,omitempty"`
+  /*
+  Position of the change in the history of all items. Changes are numbered in the order they were made, starting from 1.
+  */
+  Sequence *int64 `json:"sequence,omitempty"`
+  /*
+  Tenant that owns the changed item or association
+  */
+  TenantInfo *import3.TenantAwareModel `json:"tenantInfo,omitempty"`
+  /*
+  Time at which the change was made
+  */
+  Time *time.Time `json:"time,omitempty"`
+}
+
+func (p *ItemChange) MarshalJSON() ([]byte, error) {
+  // Create Alias to avoid infinite recursion
+  type Alias ItemChange
+
+  // Step 1: Marshal the known fields
+  known, err := json.Marshal(Alias(*p))
+  if err != nil {
+  	return nil, err
+  }
+
+    // Step 2: Convert known to map for merging
+    var knownMap map[string]interface{}
+    if err := json.Unmarshal(known, &knownMap); err != nil {
+    	return nil, err
+    }
+    delete(knownMap, "$unknownFields")
+  
+    // Step 3: Merge unknown fields
+    for k, v := range p.UnknownFields_ {
+    	knownMap[k] = v
+    }
+  
+    // Step 4: Marshal final merged map
+    return json.Marshal(knownMap)
+}
+
+func (p *ItemChange) UnmarshalJSON(b []byte) error {
+    // Step 1: Unmarshal into a generic map to capture all fields
+    var allFields map[string]interface{}
+	if err := json.Unmarshal(b, &allFields); err != nil {
+		return err
+	}
+
+    // Step 2: Unmarshal into a temporary struct with known fields
+	type Alias ItemChange
+	known := &Alias{}
+	if err := json.Unmarshal(b, known); err != nil {
+		return err
+	}
+
+    // Step 3: Assign known fields
+	*p = *NewItemChange()
+
+    if known.ObjectType_ != nil {
+        p.ObjectType_ = known.ObjectType_
+    }
+    if known.Reserved_ != nil {
+        p.Reserved_ = known.Reserved_
+    }
+    if known.UnknownFields_ != nil {
+        p.UnknownFields_ = known.UnknownFields_
+    }
+    if known.Actor != nil {
+        p.Actor = known.Actor
+    }
+    if known.AssociationAfter != nil {
+        p.AssociationAfter = known.AssociationAfter
+    }
+    if known.AssociationBefore != nil {
+        p.AssociationBefore = known.AssociationBefore
+    }
+    if known.Changes != nil {
+        p.Changes = known.Changes
+    }
+    if known.EntityId != nil {
+        p.EntityId = known.EntityId
+    }
+    if known.EntityType != nil {
+        p.EntityType = known.EntityType
+    }
+    if known.ItemAfter != nil {
+        p.ItemAfter = known.ItemAfter
+    }
+    if known.ItemBefore != nil {
+        p.ItemBefore = known.ItemBefore
+    }
+    if known.ItemExtId != nil {
+        p.ItemExtId = known.ItemExtId
+    }
+    if known.Operation != nil {
+        p.Operation = known.Operation
+    }
+    if known.RequestId != nil {
+        p.RequestId = known.RequestId
+    }
+    if known.Sequence != nil {
+        p.Sequence = known.Sequence
+    }
+    if known.TenantInfo != nil {
+        p.TenantInfo = known.TenantInfo
+    }
+    if known.Time != nil {
+        p.Time = known.Time
+    }
+
+    // Step 4: Remove known JSON fields from allFields map
+	delete(allFields, "$objectType")
+	delete(allFields, "$reserved")
+	delete(allFields, "$unknownFields")
+	delete(allFields, "actor")
+	delete(allFields, "associationAfter")
+	delete(allFields, "associationBefore")
+	delete(allFields, "changes")
+	delete(allFields, "entityId")
+	delete(allFields, "entityType")
+	delete(allFields, "itemAfter")
+	delete(allFields, "itemBefore")
+	delete(allFields, "itemExtId")
+	delete(allFields, "operation")
+	delete(allFields, "requestId")
+	delete(allFields, "sequence")
+	delete(allFields, "tenantInfo")
+	delete(allFields, "time")
+
+    // Step 5: Assign remaining fields to UnknownFields_
+	for key, value := range allFields {
+      p.UnknownFields_[key] = value
+    }
+
+	return nil
+}
+
+func NewItemChange() *ItemChange {
+  p := new(ItemChange)
+  p.ObjectType_ = new(string)
+  *p.ObjectType_ = "nexus.v4.config.ItemChange"
+  p.Reserved_ = map[string]interface{}{"$fv": "v4.r1"}
+  p.UnknownFields_ = map[string]interface{}{}
+
+
+
+  return p
+}
+
+
+
+
 type ItemProjection struct {
   
   ObjectType_ *string `json:"$objectType,omitempty"`
@@ -428,6 +775,10 @@ type ItemProjection struct {
   */
   Associations []ItemAssociation `json:"associations,omitempty"`
   /*
+  Time at which the item was deleted. Deleted items are kept, excluded from listings unless $filter refers to isDeleted, until they are restored or purged once the retention period of the service has elapsed.
+  */
+  DeletedAt *time.Time `json:"deletedAt,omitempty"`
+  /*
   Description of the item
   */
   Description *string `json:"description,omitempty"`
@@ -447,6 +798,14 @@ type ItemProjection struct {
   Type of item
   */
   ItemType *string `json:"itemType"`
+  /*
+  A HATEOAS style link for the response. Each link contains a user-friendly name identifying the link and an address for retrieving the particular resource. Every item carries a self link and an associations link.
+  */
+  Links []import2.ApiLink `json:"links,omitempty"`
+  /*
+  Tenant that owns the item. It is assigned from the tenant of the request that created the item and cannot be changed.
+  */
+  TenantInfo *import3.TenantAwareModel `json:"tenantInfo,omitempty"`
 }
 
 func (p *ItemProjection) MarshalJSON() ([]byte, error) {
@@ -513,6 +872,9 @@ func (p *ItemProjection) UnmarshalJSON(b []byte) error {
     if known.Associations != nil {
         p.Associations = known.Associations
     }
+    if known.DeletedAt != nil {
+        p.DeletedAt = known.DeletedAt
+    }
     if known.Description != nil {
         p.Description = known.Description
     }
@@ -528,17 +890,26 @@ func (p *ItemProjection) UnmarshalJSON(b []byte) error {
     if known.ItemType != nil {
         p.ItemType = known.ItemType
     }
+    if known.Links != nil {
+        p.Links = known.Links
+    }
+    if known.TenantInfo != nil {
+        p.TenantInfo = known.TenantInfo
+    }
 
     // Step 4: Remove known JSON fields from allFields map
 	delete(allFields, "$objectType")
 	delete(allFields, "$reserved")
 	delete(allFields, "$unknownFields")
 	delete(allFields, "associations")
+	delete(allFields, "deletedAt")
 	delete(allFields, "description")
 	delete(allFields, "extId")
 	delete(allFields, "itemId")
 	delete(allFields, "itemName")
 	delete(allFields, "itemType")
+	delete(allFields, "links")
+	delete(allFields, "tenantInfo")
 
     // Step 5: Assign remaining fields to UnknownFields_
 	for key, value := range allFields {
@@ -563,9 +934,9 @@ func NewItemProjection() *ItemProjection {
 
 
 /*
-REST response for all response codes in API path /nexus/v4.1/config/items Get operation
+The values of a property of an item or association before and after a change
 */
-type ListItemsApiResponse struct {
+type PropertyChange struct {
   
   ObjectType_ *string `json:"$objectType,omitempty"`
   
@@ -573,18 +944,570 @@ type ListItemsApiResponse struct {
   
   UnknownFields_ map[string]interface{} `json:"$unknownFields,omitempty"`
   /*
-  
+  JSON encoding of the value of the property after the change. It is absent if the property was removed.
   */
-  DataItemDiscriminator_ *string `json:"$dataItemDiscriminator,omitempty"`
-  
-  Data *OneOfListItemsApiResponseData `json:"data,omitempty"`
-  
-  Metadata *import2.ApiResponseMetadata `json:"metadata,omitempty"`
+  After *string `json:"after,omitempty"`
+  /*
+  JSON encoding of the value of the property before the change. It is absent if the property was not set.
+  */
+  Before *string `json:"before,omitempty"`
+  /*
+  Name of the property
+  */
+  Path *string `json:"path,omitempty"`
 }
 
-func (p *ListItemsApiResponse) MarshalJSON() ([]byte, error) {
+func (p *PropertyChange) MarshalJSON() ([]byte, error) {
   // Create Alias to avoid infinite recursion
-  type Alias ListItemsApiResponse
+  type Alias PropertyChange
+
+  // Step 1: Marshal the known fields
+  known, err := json.Marshal(Alias(*p))
+  if err != nil {
+  	return nil, err
+  }
+
+    // Step 2: Convert known to map for merging
+    var knownMap map[string]interface{}
+    if err := json.Unmarshal(known, &knownMap); err != nil {
+    	return nil, err
+    }
+    delete(knownMap, "$unknownFields")
+  
+    // Step 3: Merge unknown fields
+    for k, v := range p.UnknownFields_ {
+    	knownMap[k] = v
+    }
+  
+    // Step 4: Marshal final merged map
+    return json.Marshal(knownMap)
+}
+
+func (p *PropertyChange) UnmarshalJSON(b []byte) error {
+    // Step 1: Unmarshal into a generic map to capture all fields
+    var allFields map[string]interface{}
+	if err := json.Unmarshal(b, &allFields); err != nil {
+		return err
+	}
+
+    // Step 2: Unmarshal into a temporary struct with known fields
+	type Alias PropertyChange
+	known := &Alias{}
+	if err := json.Unmarshal(b, known); err != nil {
+		return err
+	}
+
+    // Step 3: Assign known fields
+	*p = *NewPropertyChange()
+
+    if known.ObjectType_ != nil {
+        p.ObjectType_ = known.ObjectType_
+    }
+    if known.Reserved_ != nil {
+        p.Reserved_ = known.Reserved_
+    }
+    if known.UnknownFields_ != nil {
+        p.UnknownFields_ = known.UnknownFields_
+    }
+    if known.After != nil {
+        p.After = known.After
+    }
+    if known.Before != nil {
+        p.Before = known.Before
+    }
+    if known.Path != nil {
+        p.Path = known.Path
+    }
+
+    // Step 4: Remove known JSON fields from allFields map
+	delete(allFields, "$objectType")
+	delete(allFields, "$reserved")
+	delete(allFields, "$unknownFields")
+	delete(allFields, "after")
+	delete(allFields, "before")
+	delete(allFields, "path")
+
+    // Step 5: Assign remaining fields to UnknownFields_
+	for key, value := range allFields {
+      p.UnknownFields_[key] = value
+    }
+
+	return nil
+}
+
+func NewPropertyChange() *PropertyChange {
+  p := new(PropertyChange)
+  p.ObjectType_ = new(string)
+  *p.ObjectType_ = "nexus.v4.config.PropertyChange"
+  p.Reserved_ = map[string]interface{}{"$fv": "v4.r1"}
+  p.UnknownFields_ = map[string]interface{}{}
+
+
+
+  return p
+}
+
+
+
+/*
+REST response for all response codes in API path /nexus/v4.1/config/items/{extId} Get operation
+*/
+type GetItemApiResponse struct {
+  
+  ObjectType_ *string `json:"$objectType,omitempty"`
+  
+  Reserved_ map[string]interface{} `json:"$reserved,omitempty"`
+  
+  UnknownFields_ map[string]interface{} `json:"$unknownFields,omitempty"`
+  /*
+  
+  */
+  DataItemDiscriminator_ *string `json:"$dataItemDiscriminator,omitempty"`
+  
+  Data *OneOfGetItemApiResponseData `json:"data,omitempty"`
+  
+  Metadata *import2.ApiResponseMetadata `json:"metadata,omitempty"`
+}
+
+func (p *GetItemApiResponse) MarshalJSON() ([]byte, error) {
+  // Create Alias to avoid infinite recursion
+  type Alias GetItemApiResponse
+
+  // Step 1: Marshal the known fields
+  known, err := json.Marshal(Alias(*p))
+  if err != nil {
+  	return nil, err
+  }
+
+    // Step 2: Convert known to map for merging
+    var knownMap map[string]interface{}
+    if err := json.Unmarshal(known, &knownMap); err != nil {
+    	return nil, err
+    }
+    delete(knownMap, "$unknownFields")
+  
+    // Step 3: Merge unknown fields
+    for k, v := range p.UnknownFields_ {
+    	knownMap[k] = v
+    }
+  
+    // Step 4: Marshal final merged map
+    return json.Marshal(knownMap)
+}
+
+func (p *GetItemApiResponse) UnmarshalJSON(b []byte) error {
+    // Step 1: Unmarshal into a generic map to capture all fields
+    var allFields map[string]interface{}
+	if err := json.Unmarshal(b, &allFields); err != nil {
+		return err
+	}
+
+    // Step 2: Unmarshal into a temporary struct with known fields
+	type Alias GetItemApiResponse
+	known := &Alias{}
+	if err := json.Unmarshal(b, known); err != nil {
+		return err
+	}
+
+    // Step 3: Assign known fields
+	*p = *NewGetItemApiResponse()
+
+    if known.ObjectType_ != nil {
+        p.ObjectType_ = known.ObjectType_
+    }
+    if known.Reserved_ != nil {
+        p.Reserved_ = known.Reserved_
+    }
+    if known.UnknownFields_ != nil {
+        p.UnknownFields_ = known.UnknownFields_
+    }
+    if known.DataItemDiscriminator_ != nil {
+        p.DataItemDiscriminator_ = known.DataItemDiscriminator_
+    }
+    if known.Data != nil {
+        p.Data = known.Data
+    }
+    if known.Metadata != nil {
+        p.Metadata = known.Metadata
+    }
+
+    // Step 4: Remove known JSON fields from allFields map
+	delete(allFields, "$objectType")
+	delete(allFields, "$reserved")
+	delete(allFields, "$unknownFields")
+	delete(allFields, "$dataItemDiscriminator")
+	delete(allFields, "data")
+	delete(allFields, "metadata")
+
+    // Step 5: Assign remaining fields to UnknownFields_
+	for key, value := range allFields {
+      p.UnknownFields_[key] = value
+    }
+
+	return nil
+}
+
+func NewGetItemApiResponse() *GetItemApiResponse {
+  p := new(GetItemApiResponse)
+  p.ObjectType_ = new(string)
+  *p.ObjectType_ = "nexus.v4.config.GetItemApiResponse"
+  p.Reserved_ = map[string]interface{}{"$fv": "v4.r1"}
+  p.UnknownFields_ = map[string]interface{}{}
+
+
+
+  return p
+}
+
+func (p *GetItemApiResponse) GetData() interface{} {
+  if nil == p.Data {
+    return nil
+  }
+  return p.Data.GetValue()
+}
+
+func (p *GetItemApiResponse) SetData(v interface{}) error {
+  if nil == p.Data {
+    p.Data = NewOneOfGetItemApiResponseData()
+  }
+  e := p.Data.SetValue(v)
+  if nil == e {
+    if nil == p.DataItemDiscriminator_ {
+      p.DataItemDiscriminator_ = new(string)
+    }
+    *p.DataItemDiscriminator_ = *p.Data.Discriminator
+  }
+  return e
+}
+
+
+type OneOfGetItemApiResponseData struct {
+  Discriminator *string `json:"-"`
+  ObjectType_ *string `json:"-"`
+  oneOfType2001 *Item `json:"-"`
+  oneOfType400 *import1.ErrorResponse `json:"-"`
+}
+
+func NewOneOfGetItemApiResponseData() *OneOfGetItemApiResponseData {
+  p := new(OneOfGetItemApiResponseData)
+  p.Discriminator = new(string)
+  p.ObjectType_ = new(string)
+  return p
+}
+
+func (p *OneOfGetItemApiResponseData) SetValue (v interface {}) error {
+  if nil == p {
+    return errors.New(fmt.Sprintf("OneOfGetItemApiResponseData is nil"))
+  }
+  switch v.(type) {
+    case Item:
+      if nil == p.oneOfType2001 {p.oneOfType2001 = new(Item)}
+      *p.oneOfType2001 = v.(Item)
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = *p.oneOfType2001.ObjectType_
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = *p.oneOfType2001.ObjectType_
+    case import1.ErrorResponse:
+      if nil == p.oneOfType400 {p.oneOfType400 = new(import1.ErrorResponse)}
+      *p.oneOfType400 = v.(import1.ErrorResponse)
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = *p.oneOfType400.ObjectType_
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = *p.oneOfType400.ObjectType_
+    default:
+      return errors.New(fmt.Sprintf("%T(%v) is not expected type", v,v))
+  }
+  return nil
+}
+
+func (p *OneOfGetItemApiResponseData) GetValue() interface{} {
+  if p.oneOfType2001 != nil && *p.oneOfType2001.ObjectType_ == *p.Discriminator {
+    return *p.oneOfType2001
+  }
+  if p.oneOfType400 != nil && *p.oneOfType400.ObjectType_ == *p.Discriminator {
+    return *p.oneOfType400
+  }
+  return nil
+}
+
+func (p *OneOfGetItemApiResponseData) UnmarshalJSON(b []byte) error {
+  vOneOfType2001 := new(Item)
+  if err := json.Unmarshal(b, vOneOfType2001); err == nil {
+    if "nexus.v4.config.Item" == *vOneOfType2001.ObjectType_ {
+      if nil == p.oneOfType2001 {p.oneOfType2001 = new(Item)}
+      *p.oneOfType2001 = *vOneOfType2001
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = *p.oneOfType2001.ObjectType_
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = *p.oneOfType2001.ObjectType_
+      return nil
+    }
+  }
+  vOneOfType400 := new(import1.ErrorResponse)
+  if err := json.Unmarshal(b, vOneOfType400); err == nil {
+    if "nexus.v4.error.ErrorResponse" == *vOneOfType400.ObjectType_ {
+      if nil == p.oneOfType400 {p.oneOfType400 = new(import1.ErrorResponse)}
+      *p.oneOfType400 = *vOneOfType400
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = *p.oneOfType400.ObjectType_
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = *p.oneOfType400.ObjectType_
+      return nil
+    }
+  }
+  return errors.New(fmt.Sprintf("Unable to unmarshal for OneOfGetItemApiResponseData"))
+}
+
+func (p *OneOfGetItemApiResponseData) MarshalJSON() ([]byte, error) {
+  if p.oneOfType2001 != nil && *p.oneOfType2001.ObjectType_ == *p.Discriminator {
+    return json.Marshal(p.oneOfType2001)
+  }
+  if p.oneOfType400 != nil && *p.oneOfType400.ObjectType_ == *p.Discriminator {
+    return json.Marshal(p.oneOfType400)
+  }
+  return nil, errors.New("No value to marshal for OneOfGetItemApiResponseData")
+}
+
+/*
+REST response for all response codes in API path /nexus/v4.1/config/item-history Get operation
+*/
+type ListItemHistoryApiResponse struct {
+  
+  ObjectType_ *string `json:"$objectType,omitempty"`
+  
+  Reserved_ map[string]interface{} `json:"$reserved,omitempty"`
+  
+  UnknownFields_ map[string]interface{} `json:"$unknownFields,omitempty"`
+  /*
+  
+  */
+  DataItemDiscriminator_ *string `json:"$dataItemDiscriminator,omitempty"`
+  
+  Data *OneOfListItemHistoryApiResponseData `json:"data,omitempty"`
+  
+  Metadata *import2.ApiResponseMetadata `json:"metadata,omitempty"`
+}
+
+func (p *ListItemHistoryApiResponse) MarshalJSON() ([]byte, error) {
+  // Create Alias to avoid infinite recursion
+  type Alias ListItemHistoryApiResponse
+
+  // Step 1: Marshal the known fields
+  known, err := json.Marshal(Alias(*p))
+  if err != nil {
+  	return nil, err
+  }
+
+    // Step 2: Convert known to map for merging
+    var knownMap map[string]interface{}
+    if err := json.Unmarshal(known, &knownMap); err != nil {
+    	return nil, err
+    }
+    delete(knownMap, "$unknownFields")
+  
+    // Step 3: Merge unknown fields
+    for k, v := range p.UnknownFields_ {
+    	knownMap[k] = v
+    }
+  
+    // Step 4: Marshal final merged map
+    return json.Marshal(knownMap)
+}
+
+func (p *ListItemHistoryApiResponse) UnmarshalJSON(b []byte) error {
+    // Step 1: Unmarshal into a generic map to capture all fields
+    var allFields map[string]interface{}
+	if err := json.Unmarshal(b, &allFields); err != nil {
+		return err
+	}
+
+    // Step 2: Unmarshal into a temporary struct with known fields
+	type Alias ListItemHistoryApiResponse
+	known := &Alias{}
+	if err := json.Unmarshal(b, known); err != nil {
+		return err
+	}
+
+    // Step 3: Assign known fields
+	*p = *NewListItemHistoryApiResponse()
+
+    if known.ObjectType_ != nil {
+        p.ObjectType_ = known.ObjectType_
+    }
+    if known.Reserved_ != nil {
+        p.Reserved_ = known.Reserved_
+    }
+    if known.UnknownFields_ != nil {
+        p.UnknownFields_ = known.UnknownFields_
+    }
+    if known.DataItemDiscriminator_ != nil {
+        p.DataItemDiscriminator_ = known.DataItemDiscriminator_
+    }
+    if known.Data != nil {
+        p.Data = known.Data
+    }
+    if known.Metadata != nil {
+        p.Metadata = known.Metadata
+    }
+
+    // Step 4: Remove known JSON fields from allFields map
+	delete(allFields, "$objectType")
+	delete(allFields, "$reserved")
+	delete(allFields, "$unknownFields")
+	delete(allFields, "$dataItemDiscriminator")
+	delete(allFields, "data")
+	delete(allFields, "metadata")
+
+    // Step 5: Assign remaining fields to UnknownFields_
+	for key, value := range allFields {
+      p.UnknownFields_[key] = value
+    }
+
+	return nil
+}
+
+func NewListItemHistoryApiResponse() *ListItemHistoryApiResponse {
+  p := new(ListItemHistoryApiResponse)
+  p.ObjectType_ = new(string)
+  *p.ObjectType_ = "nexus.v4.config.ListItemHistoryApiResponse"
+  p.Reserved_ = map[string]interface{}{"$fv": "v4.r1"}
+  p.UnknownFields_ = map[string]interface{}{}
+
+
+
+  return p
+}
+
+func (p *ListItemHistoryApiResponse) GetData() interface{} {
+  if nil == p.Data {
+    return nil
+  }
+  return p.Data.GetValue()
+}
+
+func (p *ListItemHistoryApiResponse) SetData(v interface{}) error {
+  if nil == p.Data {
+    p.Data = NewOneOfListItemHistoryApiResponseData()
+  }
+  e := p.Data.SetValue(v)
+  if nil == e {
+    if nil == p.DataItemDiscriminator_ {
+      p.DataItemDiscriminator_ = new(string)
+    }
+    *p.DataItemDiscriminator_ = *p.Data.Discriminator
+  }
+  return e
+}
+
+
+type OneOfListItemHistoryApiResponseData struct {
+  Discriminator *string `json:"-"`
+  ObjectType_ *string `json:"-"`
+  oneOfType2001 []ItemChange `json:"-"`
+  oneOfType400 *import1.ErrorResponse `json:"-"`
+}
+
+func NewOneOfListItemHistoryApiResponseData() *OneOfListItemHistoryApiResponseData {
+  p := new(OneOfListItemHistoryApiResponseData)
+  p.Discriminator = new(string)
+  p.ObjectType_ = new(string)
+  return p
+}
+
+func (p *OneOfListItemHistoryApiResponseData) SetValue (v interface {}) error {
+  if nil == p {
+    return errors.New(fmt.Sprintf("OneOfListItemHistoryApiResponseData is nil"))
+  }
+  switch v.(type) {
+    case []ItemChange:
+      p.oneOfType2001 = v.([]ItemChange)
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = "List<nexus.v4.config.ItemChange>"
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = "List<nexus.v4.config.ItemChange>"
+    case import1.ErrorResponse:
+      if nil == p.oneOfType400 {p.oneOfType400 = new(import1.ErrorResponse)}
+      *p.oneOfType400 = v.(import1.ErrorResponse)
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = *p.oneOfType400.ObjectType_
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = *p.oneOfType400.ObjectType_
+    default:
+      return errors.New(fmt.Sprintf("%T(%v) is not expected type", v,v))
+  }
+  return nil
+}
+
+func (p *OneOfListItemHistoryApiResponseData) GetValue() interface{} {
+  if "List<nexus.v4.config.ItemChange>" == *p.Discriminator {
+    return p.oneOfType2001
+  }
+  if p.oneOfType400 != nil && *p.oneOfType400.ObjectType_ == *p.Discriminator {
+    return *p.oneOfType400
+  }
+  return nil
+}
+
+func (p *OneOfListItemHistoryApiResponseData) UnmarshalJSON(b []byte) error {
+  vOneOfType2001 := new([]ItemChange)
+  if err := json.Unmarshal(b, vOneOfType2001); err == nil {
+    if len(*vOneOfType2001) == 0 || "nexus.v4.config.ItemChange" == *((*vOneOfType2001)[0].ObjectType_) {
+      p.oneOfType2001 = *vOneOfType2001
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = "List<nexus.v4.config.ItemChange>"
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = "List<nexus.v4.config.ItemChange>"
+      return nil
+    }
+  }
+  vOneOfType400 := new(import1.ErrorResponse)
+  if err := json.Unmarshal(b, vOneOfType400); err == nil {
+    if "nexus.v4.error.ErrorResponse" == *vOneOfType400.ObjectType_ {
+      if nil == p.oneOfType400 {p.oneOfType400 = new(import1.ErrorResponse)}
+      *p.oneOfType400 = *vOneOfType400
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = *p.oneOfType400.ObjectType_
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = *p.oneOfType400.ObjectType_
+      return nil
+    }
+  }
+  return errors.New(fmt.Sprintf("Unable to unmarshal for OneOfListItemHistoryApiResponseData"))
+}
+
+func (p *OneOfListItemHistoryApiResponseData) MarshalJSON() ([]byte, error) {
+  if "List<nexus.v4.config.ItemChange>" == *p.Discriminator {
+    return json.Marshal(p.oneOfType2001)
+  }
+  if p.oneOfType400 != nil && *p.oneOfType400.ObjectType_ == *p.Discriminator {
+    return json.Marshal(p.oneOfType400)
+  }
+  return nil, errors.New("No value to marshal for OneOfListItemHistoryApiResponseData")
+}
+
+/*
+REST response for all response codes in API path /nexus/v4.1/config/items Get operation
+*/
+type ListItemsApiResponse struct {
+  
+  ObjectType_ *string `json:"$objectType,omitempty"`
+  
+  Reserved_ map[string]interface{} `json:"$reserved,omitempty"`
+  
+  UnknownFields_ map[string]interface{} `json:"$unknownFields,omitempty"`
+  /*
+  
+  */
+  DataItemDiscriminator_ *string `json:"$dataItemDiscriminator,omitempty"`
+  
+  Data *OneOfListItemsApiResponseData `json:"data,omitempty"`
+  
+  Metadata *import2.ApiResponseMetadata `json:"metadata,omitempty"`
+}
+
+func (p *ListItemsApiResponse) MarshalJSON() ([]byte, error) {
+  // Create Alias to avoid infinite recursion
+  type Alias ListItemsApiResponse
 
   // Step 1: Marshal the known fields
   known, err := json.Marshal(Alias(*p))
@@ -616,14 +1539,279 @@ func (p *ListItemsApiResponse) UnmarshalJSON(b []byte) error {
 	}
 
     // Step 2: Unmarshal into a temporary struct with known fields
-	type Alias ListItemsApiResponse
+	type Alias ListItemsApiResponse
+	known := &Alias{}
+	if err := json.Unmarshal(b, known); err != nil {
+		return err
+	}
+
+    // Step 3: Assign known fields
+	*p = *NewListItemsApiResponse()
+
+    if known.ObjectType_ != nil {
+        p.ObjectType_ = known.ObjectType_
+    }
+    if known.Reserved_ != nil {
+        p.Reserved_ = known.Reserved_
+    }
+    if known.UnknownFields_ != nil {
+        p.UnknownFields_ = known.UnknownFields_
+    }
+    if known.DataItemDiscriminator_ != nil {
+        p.DataItemDiscriminator_ = known.DataItemDiscriminator_
+    }
+    if known.Data != nil {
+        p.Data = known.Data
+    }
+    if known.Metadata != nil {
+        p.Metadata = known.Metadata
+    }
+
+    // Step 4: Remove known JSON fields from allFields map
+	delete(allFields, "$objectType")
+	delete(allFields, "$reserved")
+	delete(allFields, "$unknownFields")
+	delete(allFields, "$dataItemDiscriminator")
+	delete(allFields, "data")
+	delete(allFields, "metadata")
+
+    // Step 5: Assign remaining fields to UnknownFields_
+	for key, value := range allFields {
+      p.UnknownFields_[key] = value
+    }
+
+	return nil
+}
+
+func NewListItemsApiResponse() *ListItemsApiResponse {
+  p := new(ListItemsApiResponse)
+  p.ObjectType_ = new(string)
+  *p.ObjectType_ = "nexus.v4.config.ListItemsApiResponse"
+  p.Reserved_ = map[string]interface{}{"$fv": "v4.r1"}
+  p.UnknownFields_ = map[string]interface{}{}
+
+
+
+  return p
+}
+
+func (p *ListItemsApiResponse) GetData() interface{} {
+  if nil == p.Data {
+    return nil
+  }
+  return p.Data.GetValue()
+}
+
+func (p *ListItemsApiResponse) SetData(v interface{}) error {
+  if nil == p.Data {
+    p.Data = NewOneOfListItemsApiResponseData()
+  }
+  e := p.Data.SetValue(v)
+  if nil == e {
+    if nil == p.DataItemDiscriminator_ {
+      p.DataItemDiscriminator_ = new(string)
+    }
+    *p.DataItemDiscriminator_ = *p.Data.Discriminator
+  }
+  return e
+}
+
+
+type OneOfListItemsApiResponseData struct {
+  Discriminator *string `json:"-"`
+  ObjectType_ *string `json:"-"`
+  oneOfType401 []ItemProjection `json:"-"`
+  oneOfType402 []ItemAggregate `json:"-"`
+  oneOfType2001 []Item `json:"-"`
+  oneOfType400 *import1.ErrorResponse `json:"-"`
+}
+
+func NewOneOfListItemsApiResponseData() *OneOfListItemsApiResponseData {
+  p := new(OneOfListItemsApiResponseData)
+  p.Discriminator = new(string)
+  p.ObjectType_ = new(string)
+  return p
+}
+
+func (p *OneOfListItemsApiResponseData) SetValue (v interface {}) error {
+  if nil == p {
+    return errors.New(fmt.Sprintf("OneOfListItemsApiResponseData is nil"))
+  }
+  switch v.(type) {
+    case []ItemProjection:
+      p.oneOfType401 = v.([]ItemProjection)
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = "List<nexus.v4.config.ItemProjection>"
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = "List<nexus.v4.config.ItemProjection>"
+    case []ItemAggregate:
+      p.oneOfType402 = v.([]ItemAggregate)
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = "List<nexus.v4.config.ItemAggregate>"
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = "List<nexus.v4.config.ItemAggregate>"
+    case []Item:
+      p.oneOfType2001 = v.([]Item)
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = "List<nexus.v4.config.Item>"
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = "List<nexus.v4.config.Item>"
+    case import1.ErrorResponse:
+      if nil == p.oneOfType400 {p.oneOfType400 = new(import1.ErrorResponse)}
+      *p.oneOfType400 = v.(import1.ErrorResponse)
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = *p.oneOfType400.ObjectType_
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = *p.oneOfType400.ObjectType_
+    default:
+      return errors.New(fmt.Sprintf("%T(%v) is not expected type", v,v))
+  }
+  return nil
+}
+
+func (p *OneOfListItemsApiResponseData) GetValue() interface{} {
+  if "List<nexus.v4.config.ItemProjection>" == *p.Discriminator {
+    return p.oneOfType401
+  }
+  if "List<nexus.v4.config.ItemAggregate>" == *p.Discriminator {
+    return p.oneOfType402
+  }
+  if "List<nexus.v4.config.Item>" == *p.Discriminator {
+    return p.oneOfType2001
+  }
+  if p.oneOfType400 != nil && *p.oneOfType400.ObjectType_ == *p.Discriminator {
+    return *p.oneOfType400
+  }
+  return nil
+}
+
+func (p *OneOfListItemsApiResponseData) UnmarshalJSON(b []byte) error {
+  vOneOfType401 := new([]ItemProjection)
+  if err := json.Unmarshal(b, vOneOfType401); err == nil {
+    if len(*vOneOfType401) == 0 || "nexus.v4.config.ItemProjection" == *((*vOneOfType401)[0].ObjectType_) {
+      p.oneOfType401 = *vOneOfType401
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = "List<nexus.v4.config.ItemProjection>"
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = "List<nexus.v4.config.ItemProjection>"
+      return nil
+    }
+  }
+  vOneOfType402 := new([]ItemAggregate)
+  if err := json.Unmarshal(b, vOneOfType402); err == nil {
+    if len(*vOneOfType402) == 0 || "nexus.v4.config.ItemAggregate" == *((*vOneOfType402)[0].ObjectType_) {
+      p.oneOfType402 = *vOneOfType402
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = "List<nexus.v4.config.ItemAggregate>"
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = "List<nexus.v4.config.ItemAggregate>"
+      return nil
+    }
+  }
+  vOneOfType2001 := new([]Item)
+  if err := json.Unmarshal(b, vOneOfType2001); err == nil {
+    if len(*vOneOfType2001) == 0 || "nexus.v4.config.Item" == *((*vOneOfType2001)[0].ObjectType_) {
+      p.oneOfType2001 = *vOneOfType2001
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = "List<nexus.v4.config.Item>"
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = "List<nexus.v4.config.Item>"
+      return nil
+    }
+  }
+  vOneOfType400 := new(import1.ErrorResponse)
+  if err := json.Unmarshal(b, vOneOfType400); err == nil {
+    if "nexus.v4.error.ErrorResponse" == *vOneOfType400.ObjectType_ {
+      if nil == p.oneOfType400 {p.oneOfType400 = new(import1.ErrorResponse)}
+      *p.oneOfType400 = *vOneOfType400
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = *p.oneOfType400.ObjectType_
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = *p.oneOfType400.ObjectType_
+      return nil
+    }
+  }
+  return errors.New(fmt.Sprintf("Unable to unmarshal for OneOfListItemsApiResponseData"))
+}
+
+func (p *OneOfListItemsApiResponseData) MarshalJSON() ([]byte, error) {
+  if "List<nexus.v4.config.ItemProjection>" == *p.Discriminator {
+    return json.Marshal(p.oneOfType401)
+  }
+  if "List<nexus.v4.config.ItemAggregate>" == *p.Discriminator {
+    return json.Marshal(p.oneOfType402)
+  }
+  if "List<nexus.v4.config.Item>" == *p.Discriminator {
+    return json.Marshal(p.oneOfType2001)
+  }
+  if p.oneOfType400 != nil && *p.oneOfType400.ObjectType_ == *p.Discriminator {
+    return json.Marshal(p.oneOfType400)
+  }
+  return nil, errors.New("No value to marshal for OneOfListItemsApiResponseData")
+}
+
+/*
+REST response for all response codes in API path /nexus/v4.1/config/items/{extId} Patch operation
+*/
+type PatchItemApiResponse struct {
+  
+  ObjectType_ *string `json:"$objectType,omitempty"`
+  
+  Reserved_ map[string]interface{} `json:"$reserved,omitempty"`
+  
+  UnknownFields_ map[string]interface{} `json:"$unknownFields,omitempty"`
+  /*
+  
+  */
+  DataItemDiscriminator_ *string `json:"$dataItemDiscriminator,omitempty"`
+  
+  Data *OneOfPatchItemApiResponseData `json:"data,omitempty"`
+  
+  Metadata *import2.ApiResponseMetadata `json:"metadata,omitempty"`
+}
+
+func (p *PatchItemApiResponse) MarshalJSON() ([]byte, error) {
+  // Create Alias to avoid infinite recursion
+  type Alias PatchItemApiResponse
+
+  // Step 1: Marshal the known fields
+  known, err := json.Marshal(Alias(*p))
+  if err != nil {
+  	return nil, err
+  }
+
+    // Step 2: Convert known to map for merging
+    var knownMap map[string]interface{}
+    if err := json.Unmarshal(known, &knownMap); err != nil {
+    	return nil, err
+    }
+    delete(knownMap, "$unknownFields")
+  
+    // Step 3: Merge unknown fields
+    for k, v := range p.UnknownFields_ {
+    	knownMap[k] = v
+    }
+  
+    // Step 4: Marshal final merged map
+    return json.Marshal(knownMap)
+}
+
+func (p *PatchItemApiResponse) UnmarshalJSON(b []byte) error {
+    // Step 1: Unmarshal into a generic map to capture all fields
+    var allFields map[string]interface{}
+	if err := json.Unmarshal(b, &allFields); err != nil {
+		return err
+	}
+
+    // Step 2: Unmarshal into a temporary struct with known fields
+	type Alias PatchItemApiResponse
 	known := &Alias{}
 	if err := json.Unmarshal(b, known); err != nil {
 		return err
 	}
 
     // Step 3: Assign known fields
-	*p = *NewListItemsApiResponse()
+	*p = *NewPatchItemApiResponse()
 
     if known.ObjectType_ != nil {
         p.ObjectType_ = known.ObjectType_
@@ -660,10 +1848,10 @@ func (p *ListItemsApiResponse) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-func NewListItemsApiResponse() *ListItemsApiResponse {
-  p := new(ListItemsApiResponse)
+func NewPatchItemApiResponse() *PatchItemApiResponse {
+  p := new(PatchItemApiResponse)
   p.ObjectType_ = new(string)
-  *p.ObjectType_ = "nexus.v4.config.ListItemsApiResponse"
+  *p.ObjectType_ = "nexus.v4.config.PatchItemApiResponse"
   p.Reserved_ = map[string]interface{}{"$fv": "v4.r1"}
   p.UnknownFields_ = map[string]interface{}{}
 
@@ -672,16 +1860,16 @@ func NewListItemsApiResponse() *ListItemsApiResponse {
   return p
 }
 
-func (p *ListItemsApiResponse) GetData() interface{} {
+func (p *PatchItemApiResponse) GetData() interface{} {
   if nil == p.Data {
     return nil
   }
   return p.Data.GetValue()
 }
 
-func (p *ListItemsApiResponse) SetData(v interface{}) error {
+func (p *PatchItemApiResponse) SetData(v interface{}) error {
   if nil == p.Data {
-    p.Data = NewOneOfListItemsApiResponseData()
+    p.Data = NewOneOfPatchItemApiResponseData()
   }
   e := p.Data.SetValue(v)
   if nil == e {
@@ -694,38 +1882,32 @@ func (p *ListItemsApiResponse) SetData(v interface{}) error {
 }
 
 
-type OneOfListItemsApiResponseData struct {
+type OneOfPatchItemApiResponseData struct {
   Discriminator *string `json:"-"`
   ObjectType_ *string `json:"-"`
-  oneOfType401 []ItemProjection `json:"-"`
-  oneOfType2001 []Item `json:"-"`
+  oneOfType2001 *Item `json:"-"`
   oneOfType400 *import1.ErrorResponse `json:"-"`
 }
 
-func NewOneOfListItemsApiResponseData() *OneOfListItemsApiResponseData {
-  p := new(OneOfListItemsApiResponseData)
+func NewOneOfPatchItemApiResponseData() *OneOfPatchItemApiResponseData {
+  p := new(OneOfPatchItemApiResponseData)
   p.Discriminator = new(string)
   p.ObjectType_ = new(string)
   return p
 }
 
-func (p *OneOfListItemsApiResponseData) SetValue (v interface {}) error {
+func (p *OneOfPatchItemApiResponseData) SetValue (v interface {}) error {
   if nil == p {
-    return errors.New(fmt.Sprintf("OneOfListItemsApiResponseData is nil"))
+    return errors.New(fmt.Sprintf("OneOfPatchItemApiResponseData is nil"))
   }
   switch v.(type) {
-    case []ItemProjection:
-      p.oneOfType401 = v.([]ItemProjection)
+    case Item:
+      if nil == p.oneOfType2001 {p.oneOfType2001 = new(Item)}
+      *p.oneOfType2001 = v.(Item)
       if nil == p.Discriminator {p.Discriminator = new(string)}
-      *p.Discriminator = "List<nexus.v4.config.ItemProjection>"
-      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
-      *p.ObjectType_ = "List<nexus.v4.config.ItemProjection>"
-    case []Item:
-      p.oneOfType2001 = v.([]Item)
-      if nil == p.Discriminator {p.Discriminator = new(string)}
-      *p.Discriminator = "List<nexus.v4.config.Item>"
+      *p.Discriminator = *p.oneOfType2001.ObjectType_
       if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
-      *p.ObjectType_ = "List<nexus.v4.config.Item>"
+      *p.ObjectType_ = *p.oneOfType2001.ObjectType_
     case import1.ErrorResponse:
       if nil == p.oneOfType400 {p.oneOfType400 = new(import1.ErrorResponse)}
       *p.oneOfType400 = v.(import1.ErrorResponse)
@@ -739,12 +1921,9 @@ func (p *OneOfListItemsApiResponseData) SetValue (v interface {}) error {
   return nil
 }
 
-func (p *OneOfListItemsApiResponseData) GetValue() interface{} {
-  if "List<nexus.v4.config.ItemProjection>" == *p.Discriminator {
-    return p.oneOfType401
-  }
-  if "List<nexus.v4.config.Item>" == *p.Discriminator {
-    return p.oneOfType2001
+func (p *OneOfPatchItemApiResponseData) GetValue() interface{} {
+  if p.oneOfType2001 != nil && *p.oneOfType2001.ObjectType_ == *p.Discriminator {
+    return *p.oneOfType2001
   }
   if p.oneOfType400 != nil && *p.oneOfType400.ObjectType_ == *p.Discriminator {
     return *p.oneOfType400
@@ -752,26 +1931,235 @@ func (p *OneOfListItemsApiResponseData) GetValue() interface{} {
   return nil
 }
 
-func (p *OneOfListItemsApiResponseData) UnmarshalJSON(b []byte) error {
-  vOneOfType401 := new([]ItemProjection)
-  if err := json.Unmarshal(b, vOneOfType401); err == nil {
-    if len(*vOneOfType401) == 0 || "nexus.v4.config.ItemProjection" == *((*vOneOfType401)[0].ObjectType_) {
-      p.oneOfType401 = *vOneOfType401
+func (p *OneOfPatchItemApiResponseData) UnmarshalJSON(b []byte) error {
+  vOneOfType2001 := new(Item)
+  if err := json.Unmarshal(b, vOneOfType2001); err == nil {
+    if "nexus.v4.config.Item" == *vOneOfType2001.ObjectType_ {
+      if nil == p.oneOfType2001 {p.oneOfType2001 = new(Item)}
+      *p.oneOfType2001 = *vOneOfType2001
       if nil == p.Discriminator {p.Discriminator = new(string)}
-      *p.Discriminator = "List<nexus.v4.config.ItemProjection>"
+      *p.Discriminator = *p.oneOfType2001.ObjectType_
       if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
-      *p.ObjectType_ = "List<nexus.v4.config.ItemProjection>"
+      *p.ObjectType_ = *p.oneOfType2001.ObjectType_
       return nil
     }
   }
-  vOneOfType2001 := new([]Item)
+  vOneOfType400 := new(import1.ErrorResponse)
+  if err := json.Unmarshal(b, vOneOfType400); err == nil {
+    if "nexus.v4.error.ErrorResponse" == *vOneOfType400.ObjectType_ {
+      if nil == p.oneOfType400 {p.oneOfType400 = new(import1.ErrorResponse)}
+      *p.oneOfType400 = *vOneOfType400
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = *p.oneOfType400.ObjectType_
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = *p.oneOfType400.ObjectType_
+      return nil
+    }
+  }
+  return errors.New(fmt.Sprintf("Unable to unmarshal for OneOfPatchItemApiResponseData"))
+}
+
+func (p *OneOfPatchItemApiResponseData) MarshalJSON() ([]byte, error) {
+  if p.oneOfType2001 != nil && *p.oneOfType2001.ObjectType_ == *p.Discriminator {
+    return json.Marshal(p.oneOfType2001)
+  }
+  if p.oneOfType400 != nil && *p.oneOfType400.ObjectType_ == *p.Discriminator {
+    return json.Marshal(p.oneOfType400)
+  }
+  return nil, errors.New("No value to marshal for OneOfPatchItemApiResponseData")
+}
+
+/*
+REST response for all response codes in API path /nexus/v4.1/config/items/{extId}/$actions/restore Post operation
+*/
+type RestoreItemApiResponse struct {
+  
+  ObjectType_ *string `json:"$objectType,omitempty"`
+  
+  Reserved_ map[string]interface{} `json:"$reserved,omitempty"`
+  
+  UnknownFields_ map[string]interface{} `json:"$unknownFields,omitempty"`
+  /*
+  
+  */
+  DataItemDiscriminator_ *string `json:"$dataItemDiscriminator,omitempty"`
+  
+  Data *OneOfRestoreItemApiResponseData `json:"data,omitempty"`
+  
+  Metadata *import2.ApiResponseMetadata `json:"metadata,omitempty"`
+}
+
+func (p *RestoreItemApiResponse) MarshalJSON() ([]byte, error) {
+  // Create Alias to avoid infinite recursion
+  type Alias RestoreItemApiResponse
+
+  // Step 1: Marshal the known fields
+  known, err := json.Marshal(Alias(*p))
+  if err != nil {
+  	return nil, err
+  }
+
+    // Step 2: Convert known to map for merging
+    var knownMap map[string]interface{}
+    if err := json.Unmarshal(known, &knownMap); err != nil {
+    	return nil, err
+    }
+    delete(knownMap, "$unknownFields")
+  
+    // Step 3: Merge unknown fields
+    for k, v := range p.UnknownFields_ {
+    	knownMap[k] = v
+    }
+  
+    // Step 4: Marshal final merged map
+    return json.Marshal(knownMap)
+}
+
+func (p *RestoreItemApiResponse) UnmarshalJSON(b []byte) error {
+    // Step 1: Unmarshal into a generic map to capture all fields
+    var allFields map[string]interface{}
+	if err := json.Unmarshal(b, &allFields); err != nil {
+		return err
+	}
+
+    // Step 2: Unmarshal into a temporary struct with known fields
+	type Alias RestoreItemApiResponse
+	known := &Alias{}
+	if err := json.Unmarshal(b, known); err != nil {
+		return err
+	}
+
+    // Step 3: Assign known fields
+	*p = *NewRestoreItemApiResponse()
+
+    if known.ObjectType_ != nil {
+        p.ObjectType_ = known.ObjectType_
+    }
+    if known.Reserved_ != nil {
+        p.Reserved_ = known.Reserved_
+    }
+    if known.UnknownFields_ != nil {
+        p.UnknownFields_ = known.UnknownFields_
+    }
+    if known.DataItemDiscriminator_ != nil {
+        p.DataItemDiscriminator_ = known.DataItemDiscriminator_
+    }
+    if known.Data != nil {
+        p.Data = known.Data
+    }
+    if known.Metadata != nil {
+        p.Metadata = known.Metadata
+    }
+
+    // Step 4: Remove known JSON fields from allFields map
+	delete(allFields, "$objectType")
+	delete(allFields, "$reserved")
+	delete(allFields, "$unknownFields")
+	delete(allFields, "$dataItemDiscriminator")
+	delete(allFields, "data")
+	delete(allFields, "metadata")
+
+    // Step 5: Assign remaining fields to UnknownFields_
+	for key, value := range allFields {
+      p.UnknownFields_[key] = value
+    }
+
+	return nil
+}
+
+func NewRestoreItemApiResponse() *RestoreItemApiResponse {
+  p := new(RestoreItemApiResponse)
+  p.ObjectType_ = new(string)
+  *p.ObjectType_ = "nexus.v4.config.RestoreItemApiResponse"
+  p.Reserved_ = map[string]interface{}{"$fv": "v4.r1"}
+  p.UnknownFields_ = map[string]interface{}{}
+
+
+
+  return p
+}
+
+func (p *RestoreItemApiResponse) GetData() interface{} {
+  if nil == p.Data {
+    return nil
+  }
+  return p.Data.GetValue()
+}
+
+func (p *RestoreItemApiResponse) SetData(v interface{}) error {
+  if nil == p.Data {
+    p.Data = NewOneOfRestoreItemApiResponseData()
+  }
+  e := p.Data.SetValue(v)
+  if nil == e {
+    if nil == p.DataItemDiscriminator_ {
+      p.DataItemDiscriminator_ = new(string)
+    }
+    *p.DataItemDiscriminator_ = *p.Data.Discriminator
+  }
+  return e
+}
+
+
+type OneOfRestoreItemApiResponseData struct {
+  Discriminator *string `json:"-"`
+  ObjectType_ *string `json:"-"`
+  oneOfType2001 *Item `json:"-"`
+  oneOfType400 *import1.ErrorResponse `json:"-"`
+}
+
+func NewOneOfRestoreItemApiResponseData() *OneOfRestoreItemApiResponseData {
+  p := new(OneOfRestoreItemApiResponseData)
+  p.Discriminator = new(string)
+  p.ObjectType_ = new(string)
+  return p
+}
+
+func (p *OneOfRestoreItemApiResponseData) SetValue (v interface {}) error {
+  if nil == p {
+    return errors.New(fmt.Sprintf("OneOfRestoreItemApiResponseData is nil"))
+  }
+  switch v.(type) {
+    case Item:
+      if nil == p.oneOfType2001 {p.oneOfType2001 = new(Item)}
+      *p.oneOfType2001 = v.(Item)
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = *p.oneOfType2001.ObjectType_
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = *p.oneOfType2001.ObjectType_
+    case import1.ErrorResponse:
+      if nil == p.oneOfType400 {p.oneOfType400 = new(import1.ErrorResponse)}
+      *p.oneOfType400 = v.(import1.ErrorResponse)
+      if nil == p.Discriminator {p.Discriminator = new(string)}
+      *p.Discriminator = *p.oneOfType400.ObjectType_
+      if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
+      *p.ObjectType_ = *p.oneOfType400.ObjectType_
+    default:
+      return errors.New(fmt.Sprintf("%T(%v) is not expected type", v,v))
+  }
+  return nil
+}
+
+func (p *OneOfRestoreItemApiResponseData) GetValue() interface{} {
+  if p.oneOfType2001 != nil && *p.oneOfType2001.ObjectType_ == *p.Discriminator {
+    return *p.oneOfType2001
+  }
+  if p.oneOfType400 != nil && *p.oneOfType400.ObjectType_ == *p.Discriminator {
+    return *p.oneOfType400
+  }
+  return nil
+}
+
+func (p *OneOfRestoreItemApiResponseData) UnmarshalJSON(b []byte) error {
+  vOneOfType2001 := new(Item)
   if err := json.Unmarshal(b, vOneOfType2001); err == nil {
-    if len(*vOneOfType2001) == 0 || "nexus.v4.config.Item" == *((*vOneOfType2001)[0].ObjectType_) {
-      p.oneOfType2001 = *vOneOfType2001
+    if "nexus.v4.config.Item" == *vOneOfType2001.ObjectType_ {
+      if nil == p.oneOfType2001 {p.oneOfType2001 = new(Item)}
+      *p.oneOfType2001 = *vOneOfType2001
       if nil == p.Discriminator {p.Discriminator = new(string)}
-      *p.Discriminator = "List<nexus.v4.config.Item>"
+      *p.Discriminator = *p.oneOfType2001.ObjectType_
       if nil == p.ObjectType_ {p.ObjectType_ = new(string)}
-      *p.ObjectType_ = "List<nexus.v4.config.Item>"
+      *p.ObjectType_ = *p.oneOfType2001.ObjectType_
       return nil
     }
   }
@@ -787,20 +2175,17 @@ func (p *OneOfListItemsApiResponseData) UnmarshalJSON(b []byte) error {
       return nil
     }
   }
-  return errors.New(fmt.Sprintf("Unable to unmarshal for OneOfListItemsApiResponseData"))
+  return errors.New(fmt.Sprintf("Unable to unmarshal for OneOfRestoreItemApiResponseData"))
 }
 
-func (p *OneOfListItemsApiResponseData) MarshalJSON() ([]byte, error) {
-  if "List<nexus.v4.config.ItemProjection>" == *p.Discriminator {
-    return json.Marshal(p.oneOfType401)
-  }
-  if "List<nexus.v4.config.Item>" == *p.Discriminator {
+func (p *OneOfRestoreItemApiResponseData) MarshalJSON() ([]byte, error) {
+  if p.oneOfType2001 != nil && *p.oneOfType2001.ObjectType_ == *p.Discriminator {
     return json.Marshal(p.oneOfType2001)
   }
   if p.oneOfType400 != nil && *p.oneOfType400.ObjectType_ == *p.Discriminator {
     return json.Marshal(p.oneOfType400)
   }
-  return nil, errors.New("No value to marshal for OneOfListItemsApiResponseData")
+  return nil, errors.New("No value to marshal for OneOfRestoreItemApiResponseData")
 }
 
 
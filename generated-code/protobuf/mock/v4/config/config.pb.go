@@ -0,0 +1,611 @@
+//
+// Generated file mock/v4/config/config.proto.
+//
+// Product version: 1.0.0-SNAPSHOT
+//
+// Part of the GoLang Mock API - REST API for Mock Item Service
+//
+// (c) 2025 Nutanix Inc.  All rights reserved
+//
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: mock/v4/config/config.proto
+
+package config
+
+import (
+	response "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/response"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	error1 "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/mock/v4/error"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Map wrapper message
+type ObjectMapWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in wrapper message
+	Value         map[string]*anypb.Any `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectMapWrapper) Reset() {
+	*x = ObjectMapWrapper{}
+	mi := &file_mock_v4_config_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectMapWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectMapWrapper) ProtoMessage() {}
+
+func (x *ObjectMapWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_config_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectMapWrapper.ProtoReflect.Descriptor instead.
+func (*ObjectMapWrapper) Descriptor() ([]byte, []int) {
+	return file_mock_v4_config_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ObjectMapWrapper) GetValue() map[string]*anypb.Any {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Item entity for mock REST API
+type Item struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique identifier for the item
+	ItemId *int32 `protobuf:"varint,2001,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	// Name of the item
+	ItemName *string `protobuf:"bytes,2002,opt,name=item_name,json=itemName" json:"item_name,omitempty"`
+	// Type of item
+	ItemType *string `protobuf:"bytes,2003,opt,name=item_type,json=itemType" json:"item_type,omitempty"`
+	// Description of the item
+	Description *string `protobuf:"bytes,2004,opt,name=description" json:"description,omitempty"`
+	// Path to item image file
+	ItemImageFile *string `protobuf:"bytes,2005,opt,name=item_image_file,json=itemImageFile" json:"item_image_file,omitempty"`
+	Loitemion *Loitemion `protobuf:"bytes,2006,opt,name=loitemion" json:"loitemion,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_mock_v4_config_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_config_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_mock_v4_config_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Item) GetItemId() int32 {
+	if x != nil && x.ItemId != nil {
+		return *x.ItemId
+	}
+	return 0
+}
+
+func (x *Item) GetItemName() string {
+	if x != nil && x.ItemName != nil {
+		return *x.ItemName
+	}
+	return ""
+}
+
+func (x *Item) GetItemType() string {
+	if x != nil && x.ItemType != nil {
+		return *x.ItemType
+	}
+	return ""
+}
+
+func (x *Item) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Item) GetItemImageFile() string {
+	if x != nil && x.ItemImageFile != nil {
+		return *x.ItemImageFile
+	}
+	return ""
+}
+
+func (x *Item) GetLoitemion() *Loitemion {
+	if x != nil {
+		return x.Loitemion
+	}
+	return nil
+}
+
+func (x *Item) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+// Country information
+type Country struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State or province name
+	State *string `protobuf:"bytes,2001,opt,name=state" json:"state,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Country) Reset() {
+	*x = Country{}
+	mi := &file_mock_v4_config_config_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Country) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Country) ProtoMessage() {}
+
+func (x *Country) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_config_config_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Country.ProtoReflect.Descriptor instead.
+func (*Country) Descriptor() ([]byte, []int) {
+	return file_mock_v4_config_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Country) GetState() string {
+	if x != nil && x.State != nil {
+		return *x.State
+	}
+	return ""
+}
+
+func (x *Country) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+// OneOf item wrapper message
+type ItemArrayWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in oneOf item wrapper message
+	Value         []*Item `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemArrayWrapper) Reset() {
+	*x = ItemArrayWrapper{}
+	mi := &file_mock_v4_config_config_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemArrayWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemArrayWrapper) ProtoMessage() {}
+
+func (x *ItemArrayWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_config_config_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemArrayWrapper.ProtoReflect.Descriptor instead.
+func (*ItemArrayWrapper) Descriptor() ([]byte, []int) {
+	return file_mock_v4_config_config_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ItemArrayWrapper) GetValue() []*Item {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// OneOf item wrapper message
+type ErrorResponseWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in oneOf item wrapper message
+	Value         *error1.ErrorResponse `protobuf:"bytes,1000,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorResponseWrapper) Reset() {
+	*x = ErrorResponseWrapper{}
+	mi := &file_mock_v4_config_config_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorResponseWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorResponseWrapper) ProtoMessage() {}
+
+func (x *ErrorResponseWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_config_config_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorResponseWrapper.ProtoReflect.Descriptor instead.
+func (*ErrorResponseWrapper) Descriptor() ([]byte, []int) {
+	return file_mock_v4_config_config_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ErrorResponseWrapper) GetValue() *error1.ErrorResponse {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// REST response for all response codes in API path /mock/v4.1/config/items Get operation
+type ListItemsApiResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// REST response for all response codes in API path /mock/v4.1/config/items Get operation
+	//
+	// Types that are valid to be assigned to Data:
+	//
+	//	*ListItemsApiResponse_ItemArrayData
+	//	*ListItemsApiResponse_ErrorResponseData
+	Data isListItemsApiResponse_Data `protobuf_oneof:"data"`
+	Metadata *response.ApiResponseMetadata `protobuf:"bytes,1001,opt,name=metadata" json:"metadata,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemsApiResponse) Reset() {
+	*x = ListItemsApiResponse{}
+	mi := &file_mock_v4_config_config_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsApiResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsApiResponse) ProtoMessage() {}
+
+func (x *ListItemsApiResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_config_config_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsApiResponse.ProtoReflect.Descriptor instead.
+func (*ListItemsApiResponse) Descriptor() ([]byte, []int) {
+	return file_mock_v4_config_config_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListItemsApiResponse) GetData() isListItemsApiResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListItemsApiResponse) GetItemArrayData() *ItemArrayWrapper {
+	if x != nil {
+		if x, ok := x.Data.(*ListItemsApiResponse_ItemArrayData); ok {
+			return x.ItemArrayData
+		}
+	}
+	return nil
+}
+
+func (x *ListItemsApiResponse) GetErrorResponseData() *ErrorResponseWrapper {
+	if x != nil {
+		if x, ok := x.Data.(*ListItemsApiResponse_ErrorResponseData); ok {
+			return x.ErrorResponseData
+		}
+	}
+	return nil
+}
+
+func (x *ListItemsApiResponse) GetMetadata() *response.ApiResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListItemsApiResponse) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+type isListItemsApiResponse_Data interface {
+	isListItemsApiResponse_Data()
+}
+
+type ListItemsApiResponse_ItemArrayData struct {
+	ItemArrayData *ItemArrayWrapper `protobuf:"bytes,2001,opt,name=item_array_data,json=itemArrayData,oneof"`
+}
+
+type ListItemsApiResponse_ErrorResponseData struct {
+	ErrorResponseData *ErrorResponseWrapper `protobuf:"bytes,400,opt,name=error_response_data,json=errorResponseData,oneof"`
+}
+
+func (*ListItemsApiResponse_ItemArrayData) isListItemsApiResponse_Data() {}
+
+func (*ListItemsApiResponse_ErrorResponseData) isListItemsApiResponse_Data() {}
+
+// Geographical loitemion information
+type Loitemion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Country *Country `protobuf:"bytes,2001,opt,name=country" json:"country,omitempty"`
+	// City name
+	City *string `protobuf:"bytes,2002,opt,name=city" json:"city,omitempty"`
+	// ZIP or postal code
+	Zip *string `protobuf:"bytes,2003,opt,name=zip" json:"zip,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Loitemion) Reset() {
+	*x = Loitemion{}
+	mi := &file_mock_v4_config_config_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Loitemion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Loitemion) ProtoMessage() {}
+
+func (x *Loitemion) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_config_config_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Loitemion.ProtoReflect.Descriptor instead.
+func (*Loitemion) Descriptor() ([]byte, []int) {
+	return file_mock_v4_config_config_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Loitemion) GetCountry() *Country {
+	if x != nil {
+		return x.Country
+	}
+	return nil
+}
+
+func (x *Loitemion) GetCity() string {
+	if x != nil && x.City != nil {
+		return *x.City
+	}
+	return ""
+}
+
+func (x *Loitemion) GetZip() string {
+	if x != nil && x.Zip != nil {
+		return *x.Zip
+	}
+	return ""
+}
+
+func (x *Loitemion) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+var File_mock_v4_config_config_proto protoreflect.FileDescriptor
+
+const file_mock_v4_config_config_proto_rawDesc = "" +
+	"\n" +
+	"\x1bmock/v4/config/config.proto\x12\x0emock.v4.config\x1a\x19google/protobuf/any.proto\x1a\x19mock/v4/error/error.proto\x1a!common/v1/response/response.proto\"\xa6\x01\n" +
+	"\x10ObjectMapWrapper\x12B\n" +
+	"\x05value\x18\xe8\a \x03(\v2+.mock.v4.config.ObjectMapWrapper.ValueEntryR\x05value\x1aN\n" +
+	"\n" +
+	"ValueEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x05value:\x028\x01\"\xa3\x02\n" +
+	"\x04Item\x12\x18\n" +
+	"\aitem_id\x18\xd1\x0f \x01(\x05R\x06itemId\x12\x1c\n" +
+	"\titem_name\x18\xd2\x0f \x01(\tR\bitemName\x12\x1c\n" +
+	"\titem_type\x18\xd3\x0f \x01(\tR\bitemType\x12!\n" +
+	"\vdescription\x18\xd4\x0f \x01(\tR\vdescription\x12'\n" +
+	"\x0fitem_image_file\x18\xd5\x0f \x01(\tR\ritemImageFile\x128\n" +
+	"\tloitemion\x18\xd6\x0f \x01(\v2\x19.mock.v4.config.LoitemionR\tloitemion\x12?\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2 .mock.v4.config.ObjectMapWrapperR\bReserved\"a\n" +
+	"\aCountry\x12\x15\n" +
+	"\x05state\x18\xd1\x0f \x01(\tR\x05state\x12?\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2 .mock.v4.config.ObjectMapWrapperR\bReserved\"?\n" +
+	"\x10ItemArrayWrapper\x12+\n" +
+	"\x05value\x18\xe8\a \x03(\v2\x14.mock.v4.config.ItemR\x05value\"K\n" +
+	"\x14ErrorResponseWrapper\x123\n" +
+	"\x05value\x18\xe8\a \x01(\v2\x1c.mock.v4.error.ErrorResponseR\x05value\"\xcb\x02\n" +
+	"\x14ListItemsApiResponse\x12K\n" +
+	"\x0fitem_array_data\x18\xd1\x0f \x01(\v2 .mock.v4.config.ItemArrayWrapperH\x00R\ritemArrayData\x12W\n" +
+	"\x13error_response_data\x18\x90\x03 \x01(\v2$.mock.v4.config.ErrorResponseWrapperH\x00R\x11errorResponseData\x12D\n" +
+	"\bmetadata\x18\xe9\a \x01(\v2'.common.v1.response.ApiResponseMetadataR\bmetadata\x12?\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2 .mock.v4.config.ObjectMapWrapperR\bReservedB\x06\n" +
+	"\x04data\"\xa8\x01\n" +
+	"\tLoitemion\x122\n" +
+	"\acountry\x18\xd1\x0f \x01(\v2\x17.mock.v4.config.CountryR\acountry\x12\x13\n" +
+	"\x04city\x18\xd2\x0f \x01(\tR\x04city\x12\x11\n" +
+	"\x03zip\x18\xd3\x0f \x01(\tR\x03zip\x12?\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2 .mock.v4.config.ObjectMapWrapperR\bReservedB\"\n" +
+	"\x0emock.v4.configP\x01Z\x0emock/v4/config"
+
+var (
+	file_mock_v4_config_config_proto_rawDescOnce sync.Once
+	file_mock_v4_config_config_proto_rawDescData []byte
+)
+
+func file_mock_v4_config_config_proto_rawDescGZIP() []byte {
+	file_mock_v4_config_config_proto_rawDescOnce.Do(func() {
+		file_mock_v4_config_config_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mock_v4_config_config_proto_rawDesc), len(file_mock_v4_config_config_proto_rawDesc)))
+	})
+	return file_mock_v4_config_config_proto_rawDescData
+}
+
+var file_mock_v4_config_config_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_mock_v4_config_config_proto_goTypes = []any{
+	(*ObjectMapWrapper)(nil),             // 0: mock.v4.config.ObjectMapWrapper
+	(*Item)(nil),                         // 1: mock.v4.config.Item
+	(*Country)(nil),                      // 2: mock.v4.config.Country
+	(*ItemArrayWrapper)(nil),             // 3: mock.v4.config.ItemArrayWrapper
+	(*ErrorResponseWrapper)(nil),         // 4: mock.v4.config.ErrorResponseWrapper
+	(*ListItemsApiResponse)(nil),         // 5: mock.v4.config.ListItemsApiResponse
+	(*Loitemion)(nil),                    // 6: mock.v4.config.Loitemion
+	nil,                                  // 7: mock.v4.config.ObjectMapWrapper.ValueEntry
+	(*error1.ErrorResponse)(nil),         // 8: mock.v4.error.ErrorResponse
+	(*response.ApiResponseMetadata)(nil), // 9: common.v1.response.ApiResponseMetadata
+	(*anypb.Any)(nil),                    // 10: google.protobuf.Any
+}
+var file_mock_v4_config_config_proto_depIdxs = []int32{
+	7,  // 0: mock.v4.config.ObjectMapWrapper.value:type_name -> mock.v4.config.ObjectMapWrapper.ValueEntry
+	6,  // 1: mock.v4.config.Item.loitemion:type_name -> mock.v4.config.Loitemion
+	0,  // 2: mock.v4.config.Item._reserved:type_name -> mock.v4.config.ObjectMapWrapper
+	0,  // 3: mock.v4.config.Country._reserved:type_name -> mock.v4.config.ObjectMapWrapper
+	1,  // 4: mock.v4.config.ItemArrayWrapper.value:type_name -> mock.v4.config.Item
+	8,  // 5: mock.v4.config.ErrorResponseWrapper.value:type_name -> mock.v4.error.ErrorResponse
+	3,  // 6: mock.v4.config.ListItemsApiResponse.item_array_data:type_name -> mock.v4.config.ItemArrayWrapper
+	4,  // 7: mock.v4.config.ListItemsApiResponse.error_response_data:type_name -> mock.v4.config.ErrorResponseWrapper
+	9,  // 8: mock.v4.config.ListItemsApiResponse.metadata:type_name -> common.v1.response.ApiResponseMetadata
+	0,  // 9: mock.v4.config.ListItemsApiResponse._reserved:type_name -> mock.v4.config.ObjectMapWrapper
+	2,  // 10: mock.v4.config.Loitemion.country:type_name -> mock.v4.config.Country
+	0,  // 11: mock.v4.config.Loitemion._reserved:type_name -> mock.v4.config.ObjectMapWrapper
+	10, // 12: mock.v4.config.ObjectMapWrapper.ValueEntry.value:type_name -> google.protobuf.Any
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_mock_v4_config_config_proto_init() }
+func file_mock_v4_config_config_proto_init() {
+	if File_mock_v4_config_config_proto != nil {
+		return
+	}
+	file_mock_v4_config_config_proto_msgTypes[5].OneofWrappers = []any{
+		(*ListItemsApiResponse_ItemArrayData)(nil),
+		(*ListItemsApiResponse_ErrorResponseData)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mock_v4_config_config_proto_rawDesc), len(file_mock_v4_config_config_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_mock_v4_config_config_proto_goTypes,
+		DependencyIndexes: file_mock_v4_config_config_proto_depIdxs,
+		MessageInfos:      file_mock_v4_config_config_proto_msgTypes,
+	}.Build()
+	File_mock_v4_config_config_proto = out.File
+	file_mock_v4_config_config_proto_goTypes = nil
+	file_mock_v4_config_config_proto_depIdxs = nil
+}
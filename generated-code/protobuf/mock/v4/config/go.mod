@@ -0,0 +1,9 @@
+module github.com/nutanix/ntnx-api-golang-mock-pc/generated-code/protobuf/mock/v4/config
+
+go 1.24
+
+require (
+	google.golang.org/grpc v1.76.0
+	google.golang.org/protobuf v1.36.10
+)
+
@@ -0,0 +1,757 @@
+//
+// Generated file mock/v4/error/error.proto.
+//
+// Product version: 1.0.0-SNAPSHOT
+//
+// Part of the GoLang Mock API - REST API for Mock Item Service
+//
+// (c) 2025 Nutanix Inc.  All rights reserved
+//
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: mock/v4/error/error.proto
+
+package error
+
+import (
+	config "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Map wrapper message
+type StringMapWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in wrapper message
+	Value         map[string]string `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringMapWrapper) Reset() {
+	*x = StringMapWrapper{}
+	mi := &file_mock_v4_error_error_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringMapWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringMapWrapper) ProtoMessage() {}
+
+func (x *StringMapWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_error_error_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringMapWrapper.ProtoReflect.Descriptor instead.
+func (*StringMapWrapper) Descriptor() ([]byte, []int) {
+	return file_mock_v4_error_error_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StringMapWrapper) GetValue() map[string]string {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Map wrapper message
+type ObjectMapWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in wrapper message
+	Value         map[string]*anypb.Any `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectMapWrapper) Reset() {
+	*x = ObjectMapWrapper{}
+	mi := &file_mock_v4_error_error_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectMapWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectMapWrapper) ProtoMessage() {}
+
+func (x *ObjectMapWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_error_error_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectMapWrapper.ProtoReflect.Descriptor instead.
+func (*ObjectMapWrapper) Descriptor() ([]byte, []int) {
+	return file_mock_v4_error_error_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ObjectMapWrapper) GetValue() map[string]*anypb.Any {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Message with associated severity describing status of the current operation.
+type AppMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The message string.
+	Message *string `protobuf:"bytes,201,opt,name=message" json:"message,omitempty"`
+	Severity *config.MessageSeverityMessage_MessageSeverity `protobuf:"varint,202,opt,name=severity,enum=common.v1.config.MessageSeverityMessage_MessageSeverity" json:"severity,omitempty"`
+	// The code associated with this message. This string is typically prefixed with the namespace to which the endpoint belongs. For example: VMM-40000
+	Code *string `protobuf:"bytes,203,opt,name=code" json:"code,omitempty"`
+	// Locale for this message. The default locale would be 'en-US'.
+	Locale *string `protobuf:"bytes,204,opt,name=locale,def=en_US" json:"locale,omitempty"`
+	// The error group associated with this message of severity ERROR.
+	ErrorGroup *string `protobuf:"bytes,205,opt,name=error_group,json=errorGroup" json:"error_group,omitempty"`
+	// The map of argument name to value.
+	ArgumentsMap *StringMapWrapper `protobuf:"bytes,206,opt,name=arguments_map,json=argumentsMap" json:"arguments_map,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+// Default values for AppMessage fields.
+const (
+	Default_AppMessage_Locale = string("en_US")
+)
+
+func (x *AppMessage) Reset() {
+	*x = AppMessage{}
+	mi := &file_mock_v4_error_error_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppMessage) ProtoMessage() {}
+
+func (x *AppMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_error_error_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppMessage.ProtoReflect.Descriptor instead.
+func (*AppMessage) Descriptor() ([]byte, []int) {
+	return file_mock_v4_error_error_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AppMessage) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *AppMessage) GetSeverity() config.MessageSeverityMessage_MessageSeverity {
+	if x != nil && x.Severity != nil {
+		return *x.Severity
+	}
+	return config.MessageSeverityMessage_MessageSeverity(0)
+}
+
+func (x *AppMessage) GetCode() string {
+	if x != nil && x.Code != nil {
+		return *x.Code
+	}
+	return ""
+}
+
+func (x *AppMessage) GetLocale() string {
+	if x != nil && x.Locale != nil {
+		return *x.Locale
+	}
+	return Default_AppMessage_Locale
+}
+
+func (x *AppMessage) GetErrorGroup() string {
+	if x != nil && x.ErrorGroup != nil {
+		return *x.ErrorGroup
+	}
+	return ""
+}
+
+func (x *AppMessage) GetArgumentsMap() *StringMapWrapper {
+	if x != nil {
+		return x.ArgumentsMap
+	}
+	return nil
+}
+
+func (x *AppMessage) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+// OneOf item wrapper message
+type AppMessageArrayWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in oneOf item wrapper message
+	Value         []*AppMessage `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppMessageArrayWrapper) Reset() {
+	*x = AppMessageArrayWrapper{}
+	mi := &file_mock_v4_error_error_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppMessageArrayWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppMessageArrayWrapper) ProtoMessage() {}
+
+func (x *AppMessageArrayWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_error_error_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppMessageArrayWrapper.ProtoReflect.Descriptor instead.
+func (*AppMessageArrayWrapper) Descriptor() ([]byte, []int) {
+	return file_mock_v4_error_error_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AppMessageArrayWrapper) GetValue() []*AppMessage {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// OneOf item wrapper message
+type SchemaValidationErrorWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in oneOf item wrapper message
+	Value         *SchemaValidationError `protobuf:"bytes,1000,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaValidationErrorWrapper) Reset() {
+	*x = SchemaValidationErrorWrapper{}
+	mi := &file_mock_v4_error_error_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaValidationErrorWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaValidationErrorWrapper) ProtoMessage() {}
+
+func (x *SchemaValidationErrorWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_error_error_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaValidationErrorWrapper.ProtoReflect.Descriptor instead.
+func (*SchemaValidationErrorWrapper) Descriptor() ([]byte, []int) {
+	return file_mock_v4_error_error_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SchemaValidationErrorWrapper) GetValue() *SchemaValidationError {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// An error response indiitemes that the operation has failed either due to a client error(4XX) or server error(5XX). Please look at the HTTP status code and namespace specific error code and error message for further details.
+type ErrorResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An error response indiitemes that the operation has failed either due to a client error(4XX) or server error(5XX). Please look at the HTTP status code and namespace specific error code and error message for further details.
+	//
+	// Types that are valid to be assigned to Error:
+	//
+	//	*ErrorResponse_AppMessageArrayError
+	//	*ErrorResponse_SchemaValidationErrorError
+	Error isErrorResponse_Error `protobuf_oneof:"error"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorResponse) Reset() {
+	*x = ErrorResponse{}
+	mi := &file_mock_v4_error_error_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorResponse) ProtoMessage() {}
+
+func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_error_error_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorResponse.ProtoReflect.Descriptor instead.
+func (*ErrorResponse) Descriptor() ([]byte, []int) {
+	return file_mock_v4_error_error_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ErrorResponse) GetError() isErrorResponse_Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *ErrorResponse) GetAppMessageArrayError() *AppMessageArrayWrapper {
+	if x != nil {
+		if x, ok := x.Error.(*ErrorResponse_AppMessageArrayError); ok {
+			return x.AppMessageArrayError
+		}
+	}
+	return nil
+}
+
+func (x *ErrorResponse) GetSchemaValidationErrorError() *SchemaValidationErrorWrapper {
+	if x != nil {
+		if x, ok := x.Error.(*ErrorResponse_SchemaValidationErrorError); ok {
+			return x.SchemaValidationErrorError
+		}
+	}
+	return nil
+}
+
+func (x *ErrorResponse) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+type isErrorResponse_Error interface {
+	isErrorResponse_Error()
+}
+
+type ErrorResponse_AppMessageArrayError struct {
+	AppMessageArrayError *AppMessageArrayWrapper `protobuf:"bytes,201,opt,name=app_message_array_error,json=appMessageArrayError,oneof"`
+}
+
+type ErrorResponse_SchemaValidationErrorError struct {
+	SchemaValidationErrorError *SchemaValidationErrorWrapper `protobuf:"bytes,202,opt,name=schema_validation_error_error,json=schemaValidationErrorError,oneof"`
+}
+
+func (*ErrorResponse_AppMessageArrayError) isErrorResponse_Error() {}
+
+func (*ErrorResponse_SchemaValidationErrorError) isErrorResponse_Error() {}
+
+// Array wrapper message
+type SchemaValidationErrorMessageArrayWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in wrapper message
+	Value         []*SchemaValidationErrorMessage `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaValidationErrorMessageArrayWrapper) Reset() {
+	*x = SchemaValidationErrorMessageArrayWrapper{}
+	mi := &file_mock_v4_error_error_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaValidationErrorMessageArrayWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaValidationErrorMessageArrayWrapper) ProtoMessage() {}
+
+func (x *SchemaValidationErrorMessageArrayWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_error_error_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaValidationErrorMessageArrayWrapper.ProtoReflect.Descriptor instead.
+func (*SchemaValidationErrorMessageArrayWrapper) Descriptor() ([]byte, []int) {
+	return file_mock_v4_error_error_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SchemaValidationErrorMessageArrayWrapper) GetValue() []*SchemaValidationErrorMessage {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// This schema is generated from SchemaValidationError.java
+type SchemaValidationError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Timestamp of the response.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,201,opt,name=timestamp" json:"timestamp,omitempty"`
+	// The HTTP status code of the response.
+	StatusCode *int32 `protobuf:"varint,202,opt,name=status_code,json=statusCode" json:"status_code,omitempty"`
+	// The generic error message for the response.
+	Error *string `protobuf:"bytes,203,opt,name=error" json:"error,omitempty"`
+	// API path on which the request was made.
+	Path *string `protobuf:"bytes,204,opt,name=path" json:"path,omitempty"`
+	// List of validation error messages
+	ValidationErrorMessages *SchemaValidationErrorMessageArrayWrapper `protobuf:"bytes,205,opt,name=validation_error_messages,json=validationErrorMessages" json:"validation_error_messages,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaValidationError) Reset() {
+	*x = SchemaValidationError{}
+	mi := &file_mock_v4_error_error_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaValidationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaValidationError) ProtoMessage() {}
+
+func (x *SchemaValidationError) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_error_error_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaValidationError.ProtoReflect.Descriptor instead.
+func (*SchemaValidationError) Descriptor() ([]byte, []int) {
+	return file_mock_v4_error_error_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SchemaValidationError) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SchemaValidationError) GetStatusCode() int32 {
+	if x != nil && x.StatusCode != nil {
+		return *x.StatusCode
+	}
+	return 0
+}
+
+func (x *SchemaValidationError) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+func (x *SchemaValidationError) GetPath() string {
+	if x != nil && x.Path != nil {
+		return *x.Path
+	}
+	return ""
+}
+
+func (x *SchemaValidationError) GetValidationErrorMessages() *SchemaValidationErrorMessageArrayWrapper {
+	if x != nil {
+		return x.ValidationErrorMessages
+	}
+	return nil
+}
+
+func (x *SchemaValidationError) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+// This schema is generated from SchemaValidationErrorMessage.java
+type SchemaValidationErrorMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The part of the request that failed validation. Validation can fail for path, query parameters, and request body.
+	Loitemion *string `protobuf:"bytes,201,opt,name=loitemion" json:"loitemion,omitempty"`
+	// The detailed message for the validation error.
+	Message *string `protobuf:"bytes,202,opt,name=message" json:"message,omitempty"`
+	// The path of the attribute that failed validation in the schema.
+	AttributePath *string `protobuf:"bytes,203,opt,name=attribute_path,json=attributePath" json:"attribute_path,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaValidationErrorMessage) Reset() {
+	*x = SchemaValidationErrorMessage{}
+	mi := &file_mock_v4_error_error_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaValidationErrorMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaValidationErrorMessage) ProtoMessage() {}
+
+func (x *SchemaValidationErrorMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_mock_v4_error_error_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaValidationErrorMessage.ProtoReflect.Descriptor instead.
+func (*SchemaValidationErrorMessage) Descriptor() ([]byte, []int) {
+	return file_mock_v4_error_error_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SchemaValidationErrorMessage) GetLoitemion() string {
+	if x != nil && x.Loitemion != nil {
+		return *x.Loitemion
+	}
+	return ""
+}
+
+func (x *SchemaValidationErrorMessage) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *SchemaValidationErrorMessage) GetAttributePath() string {
+	if x != nil && x.AttributePath != nil {
+		return *x.AttributePath
+	}
+	return ""
+}
+
+func (x *SchemaValidationErrorMessage) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+var File_mock_v4_error_error_proto protoreflect.FileDescriptor
+
+const file_mock_v4_error_error_proto_rawDesc = "" +
+	"\n" +
+	"\x19mock/v4/error/error.proto\x12\rmock.v4.error\x1a\x1dcommon/v1/config/config.proto\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8f\x01\n" +
+	"\x10StringMapWrapper\x12A\n" +
+	"\x05value\x18\xe8\a \x03(\v2*.mock.v4.error.StringMapWrapper.ValueEntryR\x05value\x1a8\n" +
+	"\n" +
+	"ValueEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa5\x01\n" +
+	"\x10ObjectMapWrapper\x12A\n" +
+	"\x05value\x18\xe8\a \x03(\v2*.mock.v4.error.ObjectMapWrapper.ValueEntryR\x05value\x1aN\n" +
+	"\n" +
+	"ValueEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x05value:\x028\x01\"\xdc\x02\n" +
+	"\n" +
+	"AppMessage\x12\x19\n" +
+	"\amessage\x18\xc9\x01 \x01(\tR\amessage\x12U\n" +
+	"\bseverity\x18\xca\x01 \x01(\x0e28.common.v1.config.MessageSeverityMessage.MessageSeverityR\bseverity\x12\x13\n" +
+	"\x04code\x18\xcb\x01 \x01(\tR\x04code\x12\x1e\n" +
+	"\x06locale\x18\xcc\x01 \x01(\t:\x05en_USR\x06locale\x12 \n" +
+	"\verror_group\x18\xcd\x01 \x01(\tR\n" +
+	"errorGroup\x12E\n" +
+	"\rarguments_map\x18\xce\x01 \x01(\v2\x1f.mock.v4.error.StringMapWrapperR\fargumentsMap\x12>\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2\x1f.mock.v4.error.ObjectMapWrapperR\bReserved\"J\n" +
+	"\x16AppMessageArrayWrapper\x120\n" +
+	"\x05value\x18\xe8\a \x03(\v2\x19.mock.v4.error.AppMessageR\x05value\"[\n" +
+	"\x1cSchemaValidationErrorWrapper\x12;\n" +
+	"\x05value\x18\xe8\a \x01(\v2$.mock.v4.error.SchemaValidationErrorR\x05value\"\xac\x02\n" +
+	"\rErrorResponse\x12_\n" +
+	"\x17app_message_array_error\x18\xc9\x01 \x01(\v2%.mock.v4.error.AppMessageArrayWrapperH\x00R\x14appMessageArrayError\x12q\n" +
+	"\x1dschema_validation_error_error\x18\xca\x01 \x01(\v2+.mock.v4.error.SchemaValidationErrorWrapperH\x00R\x1aschemaValidationErrorError\x12>\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2\x1f.mock.v4.error.ObjectMapWrapperR\bReservedB\a\n" +
+	"\x05error\"n\n" +
+	"(SchemaValidationErrorMessageArrayWrapper\x12B\n" +
+	"\x05value\x18\xe8\a \x03(\v2+.mock.v4.error.SchemaValidationErrorMessageR\x05value\"\xd6\x02\n" +
+	"\x15SchemaValidationError\x129\n" +
+	"\ttimestamp\x18\xc9\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12 \n" +
+	"\vstatus_code\x18\xca\x01 \x01(\x05R\n" +
+	"statusCode\x12\x15\n" +
+	"\x05error\x18\xcb\x01 \x01(\tR\x05error\x12\x13\n" +
+	"\x04path\x18\xcc\x01 \x01(\tR\x04path\x12t\n" +
+	"\x19validation_error_messages\x18\xcd\x01 \x01(\v27.mock.v4.error.SchemaValidationErrorMessageArrayWrapperR\x17validationErrorMessages\x12>\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2\x1f.mock.v4.error.ObjectMapWrapperR\bReserved\"\xc0\x01\n" +
+	"\x1cSchemaValidationErrorMessage\x12\x1d\n" +
+	"\tloitemion\x18\xc9\x01 \x01(\tR\tloitemion\x12\x19\n" +
+	"\amessage\x18\xca\x01 \x01(\tR\amessage\x12&\n" +
+	"\x0eattribute_path\x18\xcb\x01 \x01(\tR\rattributePath\x12>\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2\x1f.mock.v4.error.ObjectMapWrapperR\bReservedB \n" +
+	"\rmock.v4.errorP\x01Z\rmock/v4/error"
+
+var (
+	file_mock_v4_error_error_proto_rawDescOnce sync.Once
+	file_mock_v4_error_error_proto_rawDescData []byte
+)
+
+func file_mock_v4_error_error_proto_rawDescGZIP() []byte {
+	file_mock_v4_error_error_proto_rawDescOnce.Do(func() {
+		file_mock_v4_error_error_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mock_v4_error_error_proto_rawDesc), len(file_mock_v4_error_error_proto_rawDesc)))
+	})
+	return file_mock_v4_error_error_proto_rawDescData
+}
+
+var file_mock_v4_error_error_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_mock_v4_error_error_proto_goTypes = []any{
+	(*StringMapWrapper)(nil),                         // 0: mock.v4.error.StringMapWrapper
+	(*ObjectMapWrapper)(nil),                         // 1: mock.v4.error.ObjectMapWrapper
+	(*AppMessage)(nil),                               // 2: mock.v4.error.AppMessage
+	(*AppMessageArrayWrapper)(nil),                   // 3: mock.v4.error.AppMessageArrayWrapper
+	(*SchemaValidationErrorWrapper)(nil),             // 4: mock.v4.error.SchemaValidationErrorWrapper
+	(*ErrorResponse)(nil),                            // 5: mock.v4.error.ErrorResponse
+	(*SchemaValidationErrorMessageArrayWrapper)(nil), // 6: mock.v4.error.SchemaValidationErrorMessageArrayWrapper
+	(*SchemaValidationError)(nil),                    // 7: mock.v4.error.SchemaValidationError
+	(*SchemaValidationErrorMessage)(nil),             // 8: mock.v4.error.SchemaValidationErrorMessage
+	nil,                                              // 9: mock.v4.error.StringMapWrapper.ValueEntry
+	nil,                                              // 10: mock.v4.error.ObjectMapWrapper.ValueEntry
+	(config.MessageSeverityMessage_MessageSeverity)(0), // 11: common.v1.config.MessageSeverityMessage.MessageSeverity
+	(*timestamppb.Timestamp)(nil),                      // 12: google.protobuf.Timestamp
+	(*anypb.Any)(nil),                                  // 13: google.protobuf.Any
+}
+var file_mock_v4_error_error_proto_depIdxs = []int32{
+	9,  // 0: mock.v4.error.StringMapWrapper.value:type_name -> mock.v4.error.StringMapWrapper.ValueEntry
+	10, // 1: mock.v4.error.ObjectMapWrapper.value:type_name -> mock.v4.error.ObjectMapWrapper.ValueEntry
+	11, // 2: mock.v4.error.AppMessage.severity:type_name -> common.v1.config.MessageSeverityMessage.MessageSeverity
+	0,  // 3: mock.v4.error.AppMessage.arguments_map:type_name -> mock.v4.error.StringMapWrapper
+	1,  // 4: mock.v4.error.AppMessage._reserved:type_name -> mock.v4.error.ObjectMapWrapper
+	2,  // 5: mock.v4.error.AppMessageArrayWrapper.value:type_name -> mock.v4.error.AppMessage
+	7,  // 6: mock.v4.error.SchemaValidationErrorWrapper.value:type_name -> mock.v4.error.SchemaValidationError
+	3,  // 7: mock.v4.error.ErrorResponse.app_message_array_error:type_name -> mock.v4.error.AppMessageArrayWrapper
+	4,  // 8: mock.v4.error.ErrorResponse.schema_validation_error_error:type_name -> mock.v4.error.SchemaValidationErrorWrapper
+	1,  // 9: mock.v4.error.ErrorResponse._reserved:type_name -> mock.v4.error.ObjectMapWrapper
+	8,  // 10: mock.v4.error.SchemaValidationErrorMessageArrayWrapper.value:type_name -> mock.v4.error.SchemaValidationErrorMessage
+	12, // 11: mock.v4.error.SchemaValidationError.timestamp:type_name -> google.protobuf.Timestamp
+	6,  // 12: mock.v4.error.SchemaValidationError.validation_error_messages:type_name -> mock.v4.error.SchemaValidationErrorMessageArrayWrapper
+	1,  // 13: mock.v4.error.SchemaValidationError._reserved:type_name -> mock.v4.error.ObjectMapWrapper
+	1,  // 14: mock.v4.error.SchemaValidationErrorMessage._reserved:type_name -> mock.v4.error.ObjectMapWrapper
+	13, // 15: mock.v4.error.ObjectMapWrapper.ValueEntry.value:type_name -> google.protobuf.Any
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_mock_v4_error_error_proto_init() }
+func file_mock_v4_error_error_proto_init() {
+	if File_mock_v4_error_error_proto != nil {
+		return
+	}
+	file_mock_v4_error_error_proto_msgTypes[5].OneofWrappers = []any{
+		(*ErrorResponse_AppMessageArrayError)(nil),
+		(*ErrorResponse_SchemaValidationErrorError)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mock_v4_error_error_proto_rawDesc), len(file_mock_v4_error_error_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_mock_v4_error_error_proto_goTypes,
+		DependencyIndexes: file_mock_v4_error_error_proto_depIdxs,
+		MessageInfos:      file_mock_v4_error_error_proto_msgTypes,
+	}.Build()
+	File_mock_v4_error_error_proto = out.File
+	file_mock_v4_error_error_proto_goTypes = nil
+	file_mock_v4_error_error_proto_depIdxs = nil
+}
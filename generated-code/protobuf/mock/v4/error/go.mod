@@ -0,0 +1,4 @@
+module github.com/nutanix/ntnx-api-golang-mock-pc/generated-code/protobuf/mock/v4/error
+
+go 1.24.0
+
@@ -17,10 +17,12 @@
 package config
 
 import (
+	config "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
 	response "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/response"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	error1 "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/error"
 	reflect "reflect"
 	sync "sync"
@@ -141,6 +143,12 @@ type Item struct {
 	ExtId *string `protobuf:"bytes,2005,opt,name=ext_id,json=extId" json:"ext_id,omitempty"`
 	// Associated entities for this item. This field is only present when $expand=associations is specified in the query.
 	Associations *ItemAssociationArrayWrapper `protobuf:"bytes,2006,opt,name=associations" json:"associations,omitempty"`
+	// Tenant that owns the item. It is assigned from the tenant of the request that created the item and cannot be changed.
+	TenantInfo *config.TenantAwareModel `protobuf:"bytes,2007,opt,name=tenant_info,json=tenantInfo" json:"tenant_info,omitempty"`
+	// A HATEOAS style link for the response. Each link contains a user-friendly name identifying the link and an address for retrieving the particular resource. Every item carries a self link and an associations link.
+	Links *response.ApiLinkArrayWrapper `protobuf:"bytes,2008,opt,name=links" json:"links,omitempty"`
+	// Time at which the item was deleted. Deleted items are kept, excluded from listings unless $filter refers to isDeleted, until they are restored or purged once the retention period of the service has elapsed.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,2009,opt,name=deleted_at,json=deletedAt" json:"deleted_at,omitempty"`
 	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -218,6 +226,27 @@ func (x *Item) GetAssociations() *ItemAssociationArrayWrapper {
 	return nil
 }
 
+func (x *Item) GetTenantInfo() *config.TenantAwareModel {
+	if x != nil {
+		return x.TenantInfo
+	}
+	return nil
+}
+
+func (x *Item) GetLinks() *response.ApiLinkArrayWrapper {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Item) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
 func (x *Item) GetXReserved() *ObjectMapWrapper {
 	if x != nil {
 		return x.XReserved
@@ -225,6 +254,60 @@ func (x *Item) GetXReserved() *ObjectMapWrapper {
 	return nil
 }
 
+// A row of the result of $apply on items, with one key-value pair per grouping property and per aggregate, in the order $apply lists them
+type ItemAggregate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The grouping values and aggregates of the row. Grouping properties are named by their path and aggregates by their alias.
+	Values *config.KVPairArrayWrapper `protobuf:"bytes,4001,opt,name=values" json:"values,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemAggregate) Reset() {
+	*x = ItemAggregate{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemAggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemAggregate) ProtoMessage() {}
+
+func (x *ItemAggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemAggregate.ProtoReflect.Descriptor instead.
+func (*ItemAggregate) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ItemAggregate) GetValues() *config.KVPairArrayWrapper {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ItemAggregate) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
 // Association entity for items, representing related entities associated with an item
 type ItemAssociation struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -236,6 +319,8 @@ type ItemAssociation struct {
 	EntityId *string `protobuf:"bytes,3003,opt,name=entity_id,json=entityId" json:"entity_id,omitempty"`
 	// Count of associations of this type
 	Count *int32 `protobuf:"varint,3004,opt,name=count" json:"count,omitempty"`
+	// Tenant that owns the association. It is always the tenant of the item the association belongs to.
+	TenantInfo *config.TenantAwareModel `protobuf:"bytes,3005,opt,name=tenant_info,json=tenantInfo" json:"tenant_info,omitempty"`
 	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -243,7 +328,7 @@ type ItemAssociation struct {
 
 func (x *ItemAssociation) Reset() {
 	*x = ItemAssociation{}
-	mi := &file_nexus_v4_config_config_proto_msgTypes[3]
+	mi := &file_nexus_v4_config_config_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -255,7 +340,7 @@ func (x *ItemAssociation) String() string {
 func (*ItemAssociation) ProtoMessage() {}
 
 func (x *ItemAssociation) ProtoReflect() protoreflect.Message {
-	mi := &file_nexus_v4_config_config_proto_msgTypes[3]
+	mi := &file_nexus_v4_config_config_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -268,7 +353,7 @@ func (x *ItemAssociation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ItemAssociation.ProtoReflect.Descriptor instead.
 func (*ItemAssociation) Descriptor() ([]byte, []int) {
-	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{3}
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ItemAssociation) GetItemId() string {
@@ -299,6 +384,13 @@ func (x *ItemAssociation) GetCount() int32 {
 	return 0
 }
 
+func (x *ItemAssociation) GetTenantInfo() *config.TenantAwareModel {
+	if x != nil {
+		return x.TenantInfo
+	}
+	return nil
+}
+
 func (x *ItemAssociation) GetXReserved() *ObjectMapWrapper {
 	if x != nil {
 		return x.XReserved
@@ -306,28 +398,57 @@ func (x *ItemAssociation) GetXReserved() *ObjectMapWrapper {
 	return nil
 }
 
-type ItemAssociationProjection struct {
+// A change made to an item or to one of its associations, as recorded in the change history of items
+type ItemChange struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	Base          *ItemAssociation `protobuf:"bytes,100,opt,name=base" json:"base,omitempty"`
+	// Position of the change in the history of all items. Changes are numbered in the order they were made, starting from 1.
+	Sequence *int64 `protobuf:"varint,5001,opt,name=sequence" json:"sequence,omitempty"`
+	// Time at which the change was made
+	Time *timestamppb.Timestamp `protobuf:"bytes,5002,opt,name=time" json:"time,omitempty"`
+	// User that made the change, or empty for changes not made through the API
+	Actor *string `protobuf:"bytes,5003,opt,name=actor" json:"actor,omitempty"`
+	// Identifier of the request that made the change, as sent in its X-Request-Id header
+	RequestId *string `protobuf:"bytes,5004,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	// What the change did to the item or association, one of CREATE, UPDATE, DELETE, RESTORE and PURGE. Only items are restored or purged.
+	Operation *string `protobuf:"bytes,5005,opt,name=operation" json:"operation,omitempty"`
+	// External identifier of the item that was changed, or of the item the changed association belongs to
+	ItemExtId *string `protobuf:"bytes,5006,opt,name=item_ext_id,json=itemExtId" json:"item_ext_id,omitempty"`
+	// Type of the entity of the changed association. It is only present for changes made to associations.
+	EntityType *string `protobuf:"bytes,5007,opt,name=entity_type,json=entityType" json:"entity_type,omitempty"`
+	// ID of the entity of the changed association. It is only present for changes made to associations.
+	EntityId *string `protobuf:"bytes,5008,opt,name=entity_id,json=entityId" json:"entity_id,omitempty"`
+	// Tenant that owns the changed item or association
+	TenantInfo *config.TenantAwareModel `protobuf:"bytes,5009,opt,name=tenant_info,json=tenantInfo" json:"tenant_info,omitempty"`
+	// The properties the change set, modified or removed, in the order of their names
+	Changes *PropertyChangeArrayWrapper `protobuf:"bytes,5010,opt,name=changes" json:"changes,omitempty"`
+	// The item before the change. It is absent for changes made to associations and for the creation of an item.
+	ItemBefore *Item `protobuf:"bytes,5011,opt,name=item_before,json=itemBefore" json:"item_before,omitempty"`
+	// The item after the change. It is absent for changes made to associations and for the purge of an item.
+	ItemAfter *Item `protobuf:"bytes,5012,opt,name=item_after,json=itemAfter" json:"item_after,omitempty"`
+	// The association before the change. It is absent for changes made to items and for the creation of an association.
+	AssociationBefore *ItemAssociation `protobuf:"bytes,5013,opt,name=association_before,json=associationBefore" json:"association_before,omitempty"`
+	// The association after the change. It is absent for changes made to items and for the deletion of an association.
+	AssociationAfter *ItemAssociation `protobuf:"bytes,5014,opt,name=association_after,json=associationAfter" json:"association_after,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ItemAssociationProjection) Reset() {
-	*x = ItemAssociationProjection{}
-	mi := &file_nexus_v4_config_config_proto_msgTypes[4]
+func (x *ItemChange) Reset() {
+	*x = ItemChange{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ItemAssociationProjection) String() string {
+func (x *ItemChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ItemAssociationProjection) ProtoMessage() {}
+func (*ItemChange) ProtoMessage() {}
 
-func (x *ItemAssociationProjection) ProtoReflect() protoreflect.Message {
-	mi := &file_nexus_v4_config_config_proto_msgTypes[4]
+func (x *ItemChange) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -338,85 +459,144 @@ func (x *ItemAssociationProjection) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ItemAssociationProjection.ProtoReflect.Descriptor instead.
-func (*ItemAssociationProjection) Descriptor() ([]byte, []int) {
-	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ItemChange.ProtoReflect.Descriptor instead.
+func (*ItemChange) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ItemAssociationProjection) GetBase() *ItemAssociation {
+func (x *ItemChange) GetSequence() int64 {
+	if x != nil && x.Sequence != nil {
+		return *x.Sequence
+	}
+	return 0
+}
+
+func (x *ItemChange) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Base
+		return x.Time
 	}
 	return nil
 }
 
-type ItemProjection struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	Base          *Item `protobuf:"bytes,100,opt,name=base" json:"base,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ItemChange) GetActor() string {
+	if x != nil && x.Actor != nil {
+		return *x.Actor
+	}
+	return ""
 }
 
-func (x *ItemProjection) Reset() {
-	*x = ItemProjection{}
-	mi := &file_nexus_v4_config_config_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ItemChange) GetRequestId() string {
+	if x != nil && x.RequestId != nil {
+		return *x.RequestId
+	}
+	return ""
 }
 
-func (x *ItemProjection) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ItemChange) GetOperation() string {
+	if x != nil && x.Operation != nil {
+		return *x.Operation
+	}
+	return ""
 }
 
-func (*ItemProjection) ProtoMessage() {}
+func (x *ItemChange) GetItemExtId() string {
+	if x != nil && x.ItemExtId != nil {
+		return *x.ItemExtId
+	}
+	return ""
+}
 
-func (x *ItemProjection) ProtoReflect() protoreflect.Message {
-	mi := &file_nexus_v4_config_config_proto_msgTypes[5]
+func (x *ItemChange) GetEntityType() string {
+	if x != nil && x.EntityType != nil {
+		return *x.EntityType
+	}
+	return ""
+}
+
+func (x *ItemChange) GetEntityId() string {
+	if x != nil && x.EntityId != nil {
+		return *x.EntityId
+	}
+	return ""
+}
+
+func (x *ItemChange) GetTenantInfo() *config.TenantAwareModel {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.TenantInfo
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ItemProjection.ProtoReflect.Descriptor instead.
-func (*ItemProjection) Descriptor() ([]byte, []int) {
-	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{5}
+func (x *ItemChange) GetChanges() *PropertyChangeArrayWrapper {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
 }
 
-func (x *ItemProjection) GetBase() *Item {
+func (x *ItemChange) GetItemBefore() *Item {
 	if x != nil {
-		return x.Base
+		return x.ItemBefore
 	}
 	return nil
 }
 
-// OneOf item wrapper message
-type ItemArrayWrapper struct {
+func (x *ItemChange) GetItemAfter() *Item {
+	if x != nil {
+		return x.ItemAfter
+	}
+	return nil
+}
+
+func (x *ItemChange) GetAssociationBefore() *ItemAssociation {
+	if x != nil {
+		return x.AssociationBefore
+	}
+	return nil
+}
+
+func (x *ItemChange) GetAssociationAfter() *ItemAssociation {
+	if x != nil {
+		return x.AssociationAfter
+	}
+	return nil
+}
+
+func (x *ItemChange) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+// The values of a property of an item or association before and after a change
+type PropertyChange struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value field in oneOf item wrapper message
-	Value         []*Item `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty"`
+	// Name of the property
+	Path *string `protobuf:"bytes,6001,opt,name=path" json:"path,omitempty"`
+	// JSON encoding of the value of the property before the change. It is absent if the property was not set.
+	Before *string `protobuf:"bytes,6002,opt,name=before" json:"before,omitempty"`
+	// JSON encoding of the value of the property after the change. It is absent if the property was removed.
+	After *string `protobuf:"bytes,6003,opt,name=after" json:"after,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ItemArrayWrapper) Reset() {
-	*x = ItemArrayWrapper{}
+func (x *PropertyChange) Reset() {
+	*x = PropertyChange{}
 	mi := &file_nexus_v4_config_config_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ItemArrayWrapper) String() string {
+func (x *PropertyChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ItemArrayWrapper) ProtoMessage() {}
+func (*PropertyChange) ProtoMessage() {}
 
-func (x *ItemArrayWrapper) ProtoReflect() protoreflect.Message {
+func (x *PropertyChange) ProtoReflect() protoreflect.Message {
 	mi := &file_nexus_v4_config_config_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -428,41 +608,62 @@ func (x *ItemArrayWrapper) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ItemArrayWrapper.ProtoReflect.Descriptor instead.
-func (*ItemArrayWrapper) Descriptor() ([]byte, []int) {
+// Deprecated: Use PropertyChange.ProtoReflect.Descriptor instead.
+func (*PropertyChange) Descriptor() ([]byte, []int) {
 	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ItemArrayWrapper) GetValue() []*Item {
+func (x *PropertyChange) GetPath() string {
+	if x != nil && x.Path != nil {
+		return *x.Path
+	}
+	return ""
+}
+
+func (x *PropertyChange) GetBefore() string {
+	if x != nil && x.Before != nil {
+		return *x.Before
+	}
+	return ""
+}
+
+func (x *PropertyChange) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+func (x *PropertyChange) GetXReserved() *ObjectMapWrapper {
 	if x != nil {
-		return x.Value
+		return x.XReserved
 	}
 	return nil
 }
 
-// OneOf item wrapper message
-type ErrorResponseWrapper struct {
+// Array wrapper message
+type PropertyChangeArrayWrapper struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value field in oneOf item wrapper message
-	Value         *error1.ErrorResponse `protobuf:"bytes,1000,opt,name=value" json:"value,omitempty"`
+	// Value field in wrapper message
+	Value         []*PropertyChange `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ErrorResponseWrapper) Reset() {
-	*x = ErrorResponseWrapper{}
+func (x *PropertyChangeArrayWrapper) Reset() {
+	*x = PropertyChangeArrayWrapper{}
 	mi := &file_nexus_v4_config_config_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ErrorResponseWrapper) String() string {
+func (x *PropertyChangeArrayWrapper) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ErrorResponseWrapper) ProtoMessage() {}
+func (*PropertyChangeArrayWrapper) ProtoMessage() {}
 
-func (x *ErrorResponseWrapper) ProtoReflect() protoreflect.Message {
+func (x *PropertyChangeArrayWrapper) ProtoReflect() protoreflect.Message {
 	mi := &file_nexus_v4_config_config_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -474,41 +675,39 @@ func (x *ErrorResponseWrapper) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ErrorResponseWrapper.ProtoReflect.Descriptor instead.
-func (*ErrorResponseWrapper) Descriptor() ([]byte, []int) {
+// Deprecated: Use PropertyChangeArrayWrapper.ProtoReflect.Descriptor instead.
+func (*PropertyChangeArrayWrapper) Descriptor() ([]byte, []int) {
 	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ErrorResponseWrapper) GetValue() *error1.ErrorResponse {
+func (x *PropertyChangeArrayWrapper) GetValue() []*PropertyChange {
 	if x != nil {
 		return x.Value
 	}
 	return nil
 }
 
-// OneOf item wrapper message
-type ItemProjectionArrayWrapper struct {
+type ItemAssociationProjection struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Value field in oneOf item wrapper message
-	Value         []*ItemProjection `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty"`
+	Base          *ItemAssociation `protobuf:"bytes,100,opt,name=base" json:"base,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ItemProjectionArrayWrapper) Reset() {
-	*x = ItemProjectionArrayWrapper{}
+func (x *ItemAssociationProjection) Reset() {
+	*x = ItemAssociationProjection{}
 	mi := &file_nexus_v4_config_config_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ItemProjectionArrayWrapper) String() string {
+func (x *ItemAssociationProjection) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ItemProjectionArrayWrapper) ProtoMessage() {}
+func (*ItemAssociationProjection) ProtoMessage() {}
 
-func (x *ItemProjectionArrayWrapper) ProtoReflect() protoreflect.Message {
+func (x *ItemAssociationProjection) ProtoReflect() protoreflect.Message {
 	mi := &file_nexus_v4_config_config_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -520,49 +719,39 @@ func (x *ItemProjectionArrayWrapper) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ItemProjectionArrayWrapper.ProtoReflect.Descriptor instead.
-func (*ItemProjectionArrayWrapper) Descriptor() ([]byte, []int) {
+// Deprecated: Use ItemAssociationProjection.ProtoReflect.Descriptor instead.
+func (*ItemAssociationProjection) Descriptor() ([]byte, []int) {
 	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ItemProjectionArrayWrapper) GetValue() []*ItemProjection {
+func (x *ItemAssociationProjection) GetBase() *ItemAssociation {
 	if x != nil {
-		return x.Value
+		return x.Base
 	}
 	return nil
 }
 
-// REST response for all response codes in API path /nexus/v4.1/config/items Get operation
-type ListItemsApiResponse struct {
+type ItemProjection struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// REST response for all response codes in API path /nexus/v4.1/config/items Get operation
-	//
-	// Types that are valid to be assigned to Data:
-	//
-	//	*ListItemsApiResponse_ItemArrayData
-	//	*ListItemsApiResponse_ErrorResponseData
-	//	*ListItemsApiResponse_ItemProjectionArrayData
-	Data isListItemsApiResponse_Data `protobuf_oneof:"data"`
-	Metadata *response.ApiResponseMetadata `protobuf:"bytes,1001,opt,name=metadata" json:"metadata,omitempty"`
-	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	Base          *Item `protobuf:"bytes,100,opt,name=base" json:"base,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListItemsApiResponse) Reset() {
-	*x = ListItemsApiResponse{}
+func (x *ItemProjection) Reset() {
+	*x = ItemProjection{}
 	mi := &file_nexus_v4_config_config_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListItemsApiResponse) String() string {
+func (x *ItemProjection) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListItemsApiResponse) ProtoMessage() {}
+func (*ItemProjection) ProtoMessage() {}
 
-func (x *ListItemsApiResponse) ProtoReflect() protoreflect.Message {
+func (x *ItemProjection) ProtoReflect() protoreflect.Message {
 	mi := &file_nexus_v4_config_config_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -574,86 +763,790 @@ func (x *ListItemsApiResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListItemsApiResponse.ProtoReflect.Descriptor instead.
-func (*ListItemsApiResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ItemProjection.ProtoReflect.Descriptor instead.
+func (*ItemProjection) Descriptor() ([]byte, []int) {
 	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListItemsApiResponse) GetData() isListItemsApiResponse_Data {
+func (x *ItemProjection) GetBase() *Item {
 	if x != nil {
-		return x.Data
+		return x.Base
+	}
+	return nil
+}
+
+// OneOf item wrapper message
+type ItemArrayWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in oneOf item wrapper message
+	Value         []*Item `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemArrayWrapper) Reset() {
+	*x = ItemArrayWrapper{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemArrayWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemArrayWrapper) ProtoMessage() {}
+
+func (x *ItemArrayWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemArrayWrapper.ProtoReflect.Descriptor instead.
+func (*ItemArrayWrapper) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ItemArrayWrapper) GetValue() []*Item {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// OneOf item wrapper message
+type ErrorResponseWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in oneOf item wrapper message
+	Value         *error1.ErrorResponse `protobuf:"bytes,1000,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorResponseWrapper) Reset() {
+	*x = ErrorResponseWrapper{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorResponseWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorResponseWrapper) ProtoMessage() {}
+
+func (x *ErrorResponseWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorResponseWrapper.ProtoReflect.Descriptor instead.
+func (*ErrorResponseWrapper) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ErrorResponseWrapper) GetValue() *error1.ErrorResponse {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// OneOf item wrapper message
+type ItemProjectionArrayWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in oneOf item wrapper message
+	Value         []*ItemProjection `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemProjectionArrayWrapper) Reset() {
+	*x = ItemProjectionArrayWrapper{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemProjectionArrayWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemProjectionArrayWrapper) ProtoMessage() {}
+
+func (x *ItemProjectionArrayWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemProjectionArrayWrapper.ProtoReflect.Descriptor instead.
+func (*ItemProjectionArrayWrapper) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ItemProjectionArrayWrapper) GetValue() []*ItemProjection {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// OneOf item wrapper message
+type ItemAggregateArrayWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in oneOf item wrapper message
+	Value         []*ItemAggregate `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemAggregateArrayWrapper) Reset() {
+	*x = ItemAggregateArrayWrapper{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemAggregateArrayWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemAggregateArrayWrapper) ProtoMessage() {}
+
+func (x *ItemAggregateArrayWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemAggregateArrayWrapper.ProtoReflect.Descriptor instead.
+func (*ItemAggregateArrayWrapper) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ItemAggregateArrayWrapper) GetValue() []*ItemAggregate {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// OneOf item wrapper message
+type ItemChangeArrayWrapper struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Value field in oneOf item wrapper message
+	Value         []*ItemChange `protobuf:"bytes,1000,rep,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemChangeArrayWrapper) Reset() {
+	*x = ItemChangeArrayWrapper{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemChangeArrayWrapper) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemChangeArrayWrapper) ProtoMessage() {}
+
+func (x *ItemChangeArrayWrapper) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemChangeArrayWrapper.ProtoReflect.Descriptor instead.
+func (*ItemChangeArrayWrapper) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ItemChangeArrayWrapper) GetValue() []*ItemChange {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// REST response for all response codes in API path /nexus/v4.1/config/items Get operation
+type ListItemsApiResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// REST response for all response codes in API path /nexus/v4.1/config/items Get operation
+	//
+	// Types that are valid to be assigned to Data:
+	//
+	//	*ListItemsApiResponse_ItemArrayData
+	//	*ListItemsApiResponse_ErrorResponseData
+	//	*ListItemsApiResponse_ItemProjectionArrayData
+	//	*ListItemsApiResponse_ItemAggregateArrayData
+	Data isListItemsApiResponse_Data `protobuf_oneof:"data"`
+	Metadata *response.ApiResponseMetadata `protobuf:"bytes,1001,opt,name=metadata" json:"metadata,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemsApiResponse) Reset() {
+	*x = ListItemsApiResponse{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsApiResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsApiResponse) ProtoMessage() {}
+
+func (x *ListItemsApiResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsApiResponse.ProtoReflect.Descriptor instead.
+func (*ListItemsApiResponse) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListItemsApiResponse) GetData() isListItemsApiResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListItemsApiResponse) GetItemArrayData() *ItemArrayWrapper {
+	if x != nil {
+		if x, ok := x.Data.(*ListItemsApiResponse_ItemArrayData); ok {
+			return x.ItemArrayData
+		}
+	}
+	return nil
+}
+
+func (x *ListItemsApiResponse) GetErrorResponseData() *ErrorResponseWrapper {
+	if x != nil {
+		if x, ok := x.Data.(*ListItemsApiResponse_ErrorResponseData); ok {
+			return x.ErrorResponseData
+		}
+	}
+	return nil
+}
+
+func (x *ListItemsApiResponse) GetItemProjectionArrayData() *ItemProjectionArrayWrapper {
+	if x != nil {
+		if x, ok := x.Data.(*ListItemsApiResponse_ItemProjectionArrayData); ok {
+			return x.ItemProjectionArrayData
+		}
+	}
+	return nil
+}
+
+func (x *ListItemsApiResponse) GetItemAggregateArrayData() *ItemAggregateArrayWrapper {
+	if x != nil {
+		if x, ok := x.Data.(*ListItemsApiResponse_ItemAggregateArrayData); ok {
+			return x.ItemAggregateArrayData
+		}
+	}
+	return nil
+}
+
+func (x *ListItemsApiResponse) GetMetadata() *response.ApiResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListItemsApiResponse) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+type isListItemsApiResponse_Data interface {
+	isListItemsApiResponse_Data()
+}
+
+type ListItemsApiResponse_ItemArrayData struct {
+	ItemArrayData *ItemArrayWrapper `protobuf:"bytes,2001,opt,name=item_array_data,json=itemArrayData,oneof"`
+}
+
+type ListItemsApiResponse_ErrorResponseData struct {
+	ErrorResponseData *ErrorResponseWrapper `protobuf:"bytes,400,opt,name=error_response_data,json=errorResponseData,oneof"`
+}
+
+type ListItemsApiResponse_ItemProjectionArrayData struct {
+	ItemProjectionArrayData *ItemProjectionArrayWrapper `protobuf:"bytes,401,opt,name=item_projection_array_data,json=itemProjectionArrayData,oneof"`
+}
+
+type ListItemsApiResponse_ItemAggregateArrayData struct {
+	ItemAggregateArrayData *ItemAggregateArrayWrapper `protobuf:"bytes,402,opt,name=item_aggregate_array_data,json=itemAggregateArrayData,oneof"`
+}
+
+func (*ListItemsApiResponse_ItemArrayData) isListItemsApiResponse_Data() {}
+
+func (*ListItemsApiResponse_ErrorResponseData) isListItemsApiResponse_Data() {}
+
+func (*ListItemsApiResponse_ItemProjectionArrayData) isListItemsApiResponse_Data() {}
+
+func (*ListItemsApiResponse_ItemAggregateArrayData) isListItemsApiResponse_Data() {}
+
+// REST response for all response codes in API path /nexus/v4.1/config/items/{extId} Patch operation
+type PatchItemApiResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// REST response for all response codes in API path /nexus/v4.1/config/items/{extId} Patch operation
+	//
+	// Types that are valid to be assigned to Data:
+	//
+	//	*PatchItemApiResponse_ItemData
+	//	*PatchItemApiResponse_ErrorResponseData
+	Data isPatchItemApiResponse_Data `protobuf_oneof:"data"`
+	Metadata *response.ApiResponseMetadata `protobuf:"bytes,1001,opt,name=metadata" json:"metadata,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchItemApiResponse) Reset() {
+	*x = PatchItemApiResponse{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchItemApiResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchItemApiResponse) ProtoMessage() {}
+
+func (x *PatchItemApiResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchItemApiResponse.ProtoReflect.Descriptor instead.
+func (*PatchItemApiResponse) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PatchItemApiResponse) GetData() isPatchItemApiResponse_Data {
+	if x != nil {
+		return x.Data
 	}
 	return nil
 }
 
-func (x *ListItemsApiResponse) GetItemArrayData() *ItemArrayWrapper {
+func (x *PatchItemApiResponse) GetItemData() *Item {
 	if x != nil {
-		if x, ok := x.Data.(*ListItemsApiResponse_ItemArrayData); ok {
-			return x.ItemArrayData
+		if x, ok := x.Data.(*PatchItemApiResponse_ItemData); ok {
+			return x.ItemData
 		}
 	}
 	return nil
 }
 
-func (x *ListItemsApiResponse) GetErrorResponseData() *ErrorResponseWrapper {
+func (x *PatchItemApiResponse) GetErrorResponseData() *ErrorResponseWrapper {
 	if x != nil {
-		if x, ok := x.Data.(*ListItemsApiResponse_ErrorResponseData); ok {
+		if x, ok := x.Data.(*PatchItemApiResponse_ErrorResponseData); ok {
 			return x.ErrorResponseData
 		}
 	}
 	return nil
 }
 
-func (x *ListItemsApiResponse) GetItemProjectionArrayData() *ItemProjectionArrayWrapper {
+func (x *PatchItemApiResponse) GetMetadata() *response.ApiResponseMetadata {
 	if x != nil {
-		if x, ok := x.Data.(*ListItemsApiResponse_ItemProjectionArrayData); ok {
-			return x.ItemProjectionArrayData
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *PatchItemApiResponse) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+type isPatchItemApiResponse_Data interface {
+	isPatchItemApiResponse_Data()
+}
+
+type PatchItemApiResponse_ItemData struct {
+	ItemData *Item `protobuf:"bytes,2001,opt,name=item_data,json=itemData,oneof"`
+}
+
+type PatchItemApiResponse_ErrorResponseData struct {
+	ErrorResponseData *ErrorResponseWrapper `protobuf:"bytes,400,opt,name=error_response_data,json=errorResponseData,oneof"`
+}
+
+func (*PatchItemApiResponse_ItemData) isPatchItemApiResponse_Data() {}
+
+func (*PatchItemApiResponse_ErrorResponseData) isPatchItemApiResponse_Data() {}
+
+// REST response for all response codes in API path /nexus/v4.1/config/items/{extId}/$actions/restore Post operation
+type RestoreItemApiResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// REST response for all response codes in API path /nexus/v4.1/config/items/{extId}/$actions/restore Post operation
+	//
+	// Types that are valid to be assigned to Data:
+	//
+	//	*RestoreItemApiResponse_ItemData
+	//	*RestoreItemApiResponse_ErrorResponseData
+	Data isRestoreItemApiResponse_Data `protobuf_oneof:"data"`
+	Metadata *response.ApiResponseMetadata `protobuf:"bytes,1001,opt,name=metadata" json:"metadata,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreItemApiResponse) Reset() {
+	*x = RestoreItemApiResponse{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreItemApiResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreItemApiResponse) ProtoMessage() {}
+
+func (x *RestoreItemApiResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreItemApiResponse.ProtoReflect.Descriptor instead.
+func (*RestoreItemApiResponse) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RestoreItemApiResponse) GetData() isRestoreItemApiResponse_Data {
+	if x != nil {
+		return x.Data
 	}
 	return nil
 }
 
-func (x *ListItemsApiResponse) GetMetadata() *response.ApiResponseMetadata {
+func (x *RestoreItemApiResponse) GetItemData() *Item {
+	if x != nil {
+		if x, ok := x.Data.(*RestoreItemApiResponse_ItemData); ok {
+			return x.ItemData
+		}
+	}
+	return nil
+}
+
+func (x *RestoreItemApiResponse) GetErrorResponseData() *ErrorResponseWrapper {
+	if x != nil {
+		if x, ok := x.Data.(*RestoreItemApiResponse_ErrorResponseData); ok {
+			return x.ErrorResponseData
+		}
+	}
+	return nil
+}
+
+func (x *RestoreItemApiResponse) GetMetadata() *response.ApiResponseMetadata {
 	if x != nil {
 		return x.Metadata
 	}
 	return nil
 }
 
-func (x *ListItemsApiResponse) GetXReserved() *ObjectMapWrapper {
+func (x *RestoreItemApiResponse) GetXReserved() *ObjectMapWrapper {
 	if x != nil {
 		return x.XReserved
 	}
 	return nil
 }
 
-type isListItemsApiResponse_Data interface {
-	isListItemsApiResponse_Data()
+type isRestoreItemApiResponse_Data interface {
+	isRestoreItemApiResponse_Data()
 }
 
-type ListItemsApiResponse_ItemArrayData struct {
-	ItemArrayData *ItemArrayWrapper `protobuf:"bytes,2001,opt,name=item_array_data,json=itemArrayData,oneof"`
+type RestoreItemApiResponse_ItemData struct {
+	ItemData *Item `protobuf:"bytes,2001,opt,name=item_data,json=itemData,oneof"`
 }
 
-type ListItemsApiResponse_ErrorResponseData struct {
+type RestoreItemApiResponse_ErrorResponseData struct {
 	ErrorResponseData *ErrorResponseWrapper `protobuf:"bytes,400,opt,name=error_response_data,json=errorResponseData,oneof"`
 }
 
-type ListItemsApiResponse_ItemProjectionArrayData struct {
-	ItemProjectionArrayData *ItemProjectionArrayWrapper `protobuf:"bytes,401,opt,name=item_projection_array_data,json=itemProjectionArrayData,oneof"`
+func (*RestoreItemApiResponse_ItemData) isRestoreItemApiResponse_Data() {}
+
+func (*RestoreItemApiResponse_ErrorResponseData) isRestoreItemApiResponse_Data() {}
+
+// REST response for all response codes in API path /nexus/v4.1/config/items/{extId} Get operation
+type GetItemApiResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// REST response for all response codes in API path /nexus/v4.1/config/items/{extId} Get operation
+	//
+	// Types that are valid to be assigned to Data:
+	//
+	//	*GetItemApiResponse_ItemData
+	//	*GetItemApiResponse_ErrorResponseData
+	Data isGetItemApiResponse_Data `protobuf_oneof:"data"`
+	Metadata *response.ApiResponseMetadata `protobuf:"bytes,1001,opt,name=metadata" json:"metadata,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (*ListItemsApiResponse_ItemArrayData) isListItemsApiResponse_Data() {}
+func (x *GetItemApiResponse) Reset() {
+	*x = GetItemApiResponse{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func (*ListItemsApiResponse_ErrorResponseData) isListItemsApiResponse_Data() {}
+func (x *GetItemApiResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (*ListItemsApiResponse_ItemProjectionArrayData) isListItemsApiResponse_Data() {}
+func (*GetItemApiResponse) ProtoMessage() {}
+
+func (x *GetItemApiResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemApiResponse.ProtoReflect.Descriptor instead.
+func (*GetItemApiResponse) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetItemApiResponse) GetData() isGetItemApiResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetItemApiResponse) GetItemData() *Item {
+	if x != nil {
+		if x, ok := x.Data.(*GetItemApiResponse_ItemData); ok {
+			return x.ItemData
+		}
+	}
+	return nil
+}
+
+func (x *GetItemApiResponse) GetErrorResponseData() *ErrorResponseWrapper {
+	if x != nil {
+		if x, ok := x.Data.(*GetItemApiResponse_ErrorResponseData); ok {
+			return x.ErrorResponseData
+		}
+	}
+	return nil
+}
+
+func (x *GetItemApiResponse) GetMetadata() *response.ApiResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetItemApiResponse) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+type isGetItemApiResponse_Data interface {
+	isGetItemApiResponse_Data()
+}
+
+type GetItemApiResponse_ItemData struct {
+	ItemData *Item `protobuf:"bytes,2001,opt,name=item_data,json=itemData,oneof"`
+}
+
+type GetItemApiResponse_ErrorResponseData struct {
+	ErrorResponseData *ErrorResponseWrapper `protobuf:"bytes,400,opt,name=error_response_data,json=errorResponseData,oneof"`
+}
+
+func (*GetItemApiResponse_ItemData) isGetItemApiResponse_Data() {}
+
+func (*GetItemApiResponse_ErrorResponseData) isGetItemApiResponse_Data() {}
+
+// REST response for all response codes in API path /nexus/v4.1/config/item-history Get operation
+type ListItemHistoryApiResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// REST response for all response codes in API path /nexus/v4.1/config/item-history Get operation
+	//
+	// Types that are valid to be assigned to Data:
+	//
+	//	*ListItemHistoryApiResponse_ItemChangeArrayData
+	//	*ListItemHistoryApiResponse_ErrorResponseData
+	Data isListItemHistoryApiResponse_Data `protobuf_oneof:"data"`
+	Metadata *response.ApiResponseMetadata `protobuf:"bytes,1001,opt,name=metadata" json:"metadata,omitempty"`
+	XReserved     *ObjectMapWrapper `protobuf:"bytes,900000,opt,name=_reserved,json=Reserved" json:"_reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemHistoryApiResponse) Reset() {
+	*x = ListItemHistoryApiResponse{}
+	mi := &file_nexus_v4_config_config_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemHistoryApiResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemHistoryApiResponse) ProtoMessage() {}
+
+func (x *ListItemHistoryApiResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_config_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemHistoryApiResponse.ProtoReflect.Descriptor instead.
+func (*ListItemHistoryApiResponse) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_config_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListItemHistoryApiResponse) GetData() isListItemHistoryApiResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListItemHistoryApiResponse) GetItemChangeArrayData() *ItemChangeArrayWrapper {
+	if x != nil {
+		if x, ok := x.Data.(*ListItemHistoryApiResponse_ItemChangeArrayData); ok {
+			return x.ItemChangeArrayData
+		}
+	}
+	return nil
+}
+
+func (x *ListItemHistoryApiResponse) GetErrorResponseData() *ErrorResponseWrapper {
+	if x != nil {
+		if x, ok := x.Data.(*ListItemHistoryApiResponse_ErrorResponseData); ok {
+			return x.ErrorResponseData
+		}
+	}
+	return nil
+}
+
+func (x *ListItemHistoryApiResponse) GetMetadata() *response.ApiResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListItemHistoryApiResponse) GetXReserved() *ObjectMapWrapper {
+	if x != nil {
+		return x.XReserved
+	}
+	return nil
+}
+
+type isListItemHistoryApiResponse_Data interface {
+	isListItemHistoryApiResponse_Data()
+}
+
+type ListItemHistoryApiResponse_ItemChangeArrayData struct {
+	ItemChangeArrayData *ItemChangeArrayWrapper `protobuf:"bytes,2001,opt,name=item_change_array_data,json=itemChangeArrayData,oneof"`
+}
+
+type ListItemHistoryApiResponse_ErrorResponseData struct {
+	ErrorResponseData *ErrorResponseWrapper `protobuf:"bytes,400,opt,name=error_response_data,json=errorResponseData,oneof"`
+}
+
+func (*ListItemHistoryApiResponse_ItemChangeArrayData) isListItemHistoryApiResponse_Data() {}
+
+func (*ListItemHistoryApiResponse_ErrorResponseData) isListItemHistoryApiResponse_Data() {}
 
 var File_nexus_v4_config_config_proto protoreflect.FileDescriptor
 
 const file_nexus_v4_config_config_proto_rawDesc = "" +
 	"\n" +
-	"\x1cnexus/v4/config/config.proto\x12\x0fnexus.v4.config\x1a\x19google/protobuf/any.proto\x1a\x1anexus/v4/error/error.proto\x1a!common/v1/response/response.proto\"V\n" +
+	"\x1cnexus/v4/config/config.proto\x12\x0fnexus.v4.config\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1anexus/v4/error/error.proto\x1a\x1dcommon/v1/config/config.proto\x1a!common/v1/response/response.proto\"V\n" +
 	"\x1bItemAssociationArrayWrapper\x127\n" +
 	"\x05value\x18\xe8\a \x03(\v2 .nexus.v4.config.ItemAssociationR\x05value\"\xa7\x01\n" +
 	"\x10ObjectMapWrapper\x12C\n" +
@@ -661,22 +1554,61 @@ const file_nexus_v4_config_config_proto_rawDesc = "" +
 	"\n" +
 	"ValueEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
-	"\x05value\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x05value:\x028\x01\"\xac\x02\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x05value:\x028\x01\"\xee\x03\n" +
 	"\x04Item\x12\x18\n" +
 	"\aitem_id\x18\xd1\x0f \x01(\x05R\x06itemId\x12\x1c\n" +
 	"\titem_name\x18\xd2\x0f \x01(\tR\bitemName\x12\x1c\n" +
 	"\titem_type\x18\xd3\x0f \x01(\tR\bitemType\x12!\n" +
 	"\vdescription\x18\xd4\x0f \x01(\tR\vdescription\x12\x16\n" +
 	"\x06ext_id\x18\xd5\x0f \x01(\tR\x05extId\x12Q\n" +
-	"\fassociations\x18\xd6\x0f \x01(\v2,.nexus.v4.config.ItemAssociationArrayWrapperR\fassociations\x12@\n" +
-	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReserved\"\xc4\x01\n" +
+	"\fassociations\x18\xd6\x0f \x01(\v2,.nexus.v4.config.ItemAssociationArrayWrapperR\fassociations\x12D\n" +
+	"\vtenant_info\x18\xd7\x0f \x01(\v2\".common.v1.config.TenantAwareModelR\n" +
+	"tenantInfo\x12>\n" +
+	"\x05links\x18\xd8\x0f \x01(\v2'.common.v1.response.ApiLinkArrayWrapperR\x05links\x12:\n" +
+	"\n" +
+	"deleted_at\x18\xd9\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12@\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReserved\"\x90\x01\n" +
+	"\rItemAggregate\x12=\n" +
+	"\x06values\x18\xa1\x1f \x01(\v2$.common.v1.config.KVPairArrayWrapperR\x06values\x12@\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReserved\"\x8a\x02\n" +
 	"\x0fItemAssociation\x12\x18\n" +
 	"\aitem_id\x18\xb9\x17 \x01(\tR\x06itemId\x12 \n" +
 	"\ventity_type\x18\xba\x17 \x01(\tR\n" +
 	"entityType\x12\x1c\n" +
 	"\tentity_id\x18\xbb\x17 \x01(\tR\bentityId\x12\x15\n" +
-	"\x05count\x18\xbc\x17 \x01(\x05R\x05count\x12@\n" +
-	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReserved\"Q\n" +
+	"\x05count\x18\xbc\x17 \x01(\x05R\x05count\x12D\n" +
+	"\vtenant_info\x18\xbd\x17 \x01(\v2\".common.v1.config.TenantAwareModelR\n" +
+	"tenantInfo\x12@\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReserved\"\xf3\x05\n" +
+	"\n" +
+	"ItemChange\x12\x1b\n" +
+	"\bsequence\x18\x89' \x01(\x03R\bsequence\x12/\n" +
+	"\x04time\x18\x8a' \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x15\n" +
+	"\x05actor\x18\x8b' \x01(\tR\x05actor\x12\x1e\n" +
+	"\n" +
+	"request_id\x18\x8c' \x01(\tR\trequestId\x12\x1d\n" +
+	"\toperation\x18\x8d' \x01(\tR\toperation\x12\x1f\n" +
+	"\vitem_ext_id\x18\x8e' \x01(\tR\titemExtId\x12 \n" +
+	"\ventity_type\x18\x8f' \x01(\tR\n" +
+	"entityType\x12\x1c\n" +
+	"\tentity_id\x18\x90' \x01(\tR\bentityId\x12D\n" +
+	"\vtenant_info\x18\x91' \x01(\v2\".common.v1.config.TenantAwareModelR\n" +
+	"tenantInfo\x12F\n" +
+	"\achanges\x18\x92' \x01(\v2+.nexus.v4.config.PropertyChangeArrayWrapperR\achanges\x127\n" +
+	"\vitem_before\x18\x93' \x01(\v2\x15.nexus.v4.config.ItemR\n" +
+	"itemBefore\x125\n" +
+	"\n" +
+	"item_after\x18\x94' \x01(\v2\x15.nexus.v4.config.ItemR\titemAfter\x12P\n" +
+	"\x12association_before\x18\x95' \x01(\v2 .nexus.v4.config.ItemAssociationR\x11associationBefore\x12N\n" +
+	"\x11association_after\x18\x96' \x01(\v2 .nexus.v4.config.ItemAssociationR\x10associationAfter\x12@\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReserved\"\x97\x01\n" +
+	"\x0ePropertyChange\x12\x13\n" +
+	"\x04path\x18\xf1. \x01(\tR\x04path\x12\x17\n" +
+	"\x06before\x18\xf2. \x01(\tR\x06before\x12\x15\n" +
+	"\x05after\x18\xf3. \x01(\tR\x05after\x12@\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReserved\"T\n" +
+	"\x1aPropertyChangeArrayWrapper\x126\n" +
+	"\x05value\x18\xe8\a \x03(\v2\x1f.nexus.v4.config.PropertyChangeR\x05value\"Q\n" +
 	"\x19ItemAssociationProjection\x124\n" +
 	"\x04base\x18d \x01(\v2 .nexus.v4.config.ItemAssociationR\x04base\";\n" +
 	"\x0eItemProjection\x12)\n" +
@@ -686,11 +1618,40 @@ const file_nexus_v4_config_config_proto_rawDesc = "" +
 	"\x14ErrorResponseWrapper\x124\n" +
 	"\x05value\x18\xe8\a \x01(\v2\x1d.nexus.v4.error.ErrorResponseR\x05value\"T\n" +
 	"\x1aItemProjectionArrayWrapper\x126\n" +
-	"\x05value\x18\xe8\a \x03(\v2\x1f.nexus.v4.config.ItemProjectionR\x05value\"\xbb\x03\n" +
+	"\x05value\x18\xe8\a \x03(\v2\x1f.nexus.v4.config.ItemProjectionR\x05value\"R\n" +
+	"\x19ItemAggregateArrayWrapper\x125\n" +
+	"\x05value\x18\xe8\a \x03(\v2\x1e.nexus.v4.config.ItemAggregateR\x05value\"L\n" +
+	"\x16ItemChangeArrayWrapper\x122\n" +
+	"\x05value\x18\xe8\a \x03(\v2\x1b.nexus.v4.config.ItemChangeR\x05value\"\xa5\x04\n" +
 	"\x14ListItemsApiResponse\x12L\n" +
 	"\x0fitem_array_data\x18\xd1\x0f \x01(\v2!.nexus.v4.config.ItemArrayWrapperH\x00R\ritemArrayData\x12X\n" +
 	"\x13error_response_data\x18\x90\x03 \x01(\v2%.nexus.v4.config.ErrorResponseWrapperH\x00R\x11errorResponseData\x12k\n" +
-	"\x1aitem_projection_array_data\x18\x91\x03 \x01(\v2+.nexus.v4.config.ItemProjectionArrayWrapperH\x00R\x17itemProjectionArrayData\x12D\n" +
+	"\x1aitem_projection_array_data\x18\x91\x03 \x01(\v2+.nexus.v4.config.ItemProjectionArrayWrapperH\x00R\x17itemProjectionArrayData\x12h\n" +
+	"\x19item_aggregate_array_data\x18\x92\x03 \x01(\v2*.nexus.v4.config.ItemAggregateArrayWrapperH\x00R\x16itemAggregateArrayData\x12D\n" +
+	"\bmetadata\x18\xe9\a \x01(\v2'.common.v1.response.ApiResponseMetadataR\bmetadata\x12@\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReservedB\x06\n" +
+	"\x04data\"\xb7\x02\n" +
+	"\x14PatchItemApiResponse\x125\n" +
+	"\titem_data\x18\xd1\x0f \x01(\v2\x15.nexus.v4.config.ItemH\x00R\bitemData\x12X\n" +
+	"\x13error_response_data\x18\x90\x03 \x01(\v2%.nexus.v4.config.ErrorResponseWrapperH\x00R\x11errorResponseData\x12D\n" +
+	"\bmetadata\x18\xe9\a \x01(\v2'.common.v1.response.ApiResponseMetadataR\bmetadata\x12@\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReservedB\x06\n" +
+	"\x04data\"\xb9\x02\n" +
+	"\x16RestoreItemApiResponse\x125\n" +
+	"\titem_data\x18\xd1\x0f \x01(\v2\x15.nexus.v4.config.ItemH\x00R\bitemData\x12X\n" +
+	"\x13error_response_data\x18\x90\x03 \x01(\v2%.nexus.v4.config.ErrorResponseWrapperH\x00R\x11errorResponseData\x12D\n" +
+	"\bmetadata\x18\xe9\a \x01(\v2'.common.v1.response.ApiResponseMetadataR\bmetadata\x12@\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReservedB\x06\n" +
+	"\x04data\"\xb5\x02\n" +
+	"\x12GetItemApiResponse\x125\n" +
+	"\titem_data\x18\xd1\x0f \x01(\v2\x15.nexus.v4.config.ItemH\x00R\bitemData\x12X\n" +
+	"\x13error_response_data\x18\x90\x03 \x01(\v2%.nexus.v4.config.ErrorResponseWrapperH\x00R\x11errorResponseData\x12D\n" +
+	"\bmetadata\x18\xe9\a \x01(\v2'.common.v1.response.ApiResponseMetadataR\bmetadata\x12@\n" +
+	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReservedB\x06\n" +
+	"\x04data\"\xe7\x02\n" +
+	"\x1aListItemHistoryApiResponse\x12_\n" +
+	"\x16item_change_array_data\x18\xd1\x0f \x01(\v2'.nexus.v4.config.ItemChangeArrayWrapperH\x00R\x13itemChangeArrayData\x12X\n" +
+	"\x13error_response_data\x18\x90\x03 \x01(\v2%.nexus.v4.config.ErrorResponseWrapperH\x00R\x11errorResponseData\x12D\n" +
 	"\bmetadata\x18\xe9\a \x01(\v2'.common.v1.response.ApiResponseMetadataR\bmetadata\x12@\n" +
 	"\t_reserved\x18\xa0\xf76 \x01(\v2!.nexus.v4.config.ObjectMapWrapperR\bReservedB\x06\n" +
 	"\x04dataB$\n" +
@@ -708,45 +1669,94 @@ func file_nexus_v4_config_config_proto_rawDescGZIP() []byte {
 	return file_nexus_v4_config_config_proto_rawDescData
 }
 
-var file_nexus_v4_config_config_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_nexus_v4_config_config_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_nexus_v4_config_config_proto_goTypes = []any{
 	(*ItemAssociationArrayWrapper)(nil),  // 0: nexus.v4.config.ItemAssociationArrayWrapper
 	(*ObjectMapWrapper)(nil),             // 1: nexus.v4.config.ObjectMapWrapper
 	(*Item)(nil),                         // 2: nexus.v4.config.Item
-	(*ItemAssociation)(nil),              // 3: nexus.v4.config.ItemAssociation
-	(*ItemAssociationProjection)(nil),    // 4: nexus.v4.config.ItemAssociationProjection
-	(*ItemProjection)(nil),               // 5: nexus.v4.config.ItemProjection
-	(*ItemArrayWrapper)(nil),             // 6: nexus.v4.config.ItemArrayWrapper
-	(*ErrorResponseWrapper)(nil),         // 7: nexus.v4.config.ErrorResponseWrapper
-	(*ItemProjectionArrayWrapper)(nil),   // 8: nexus.v4.config.ItemProjectionArrayWrapper
-	(*ListItemsApiResponse)(nil),         // 9: nexus.v4.config.ListItemsApiResponse
-	nil,                                  // 10: nexus.v4.config.ObjectMapWrapper.ValueEntry
-	(*error1.ErrorResponse)(nil),         // 11: nexus.v4.error.ErrorResponse
-	(*response.ApiResponseMetadata)(nil), // 12: common.v1.response.ApiResponseMetadata
-	(*anypb.Any)(nil),                    // 13: google.protobuf.Any
+	(*ItemAggregate)(nil),                // 3: nexus.v4.config.ItemAggregate
+	(*ItemAssociation)(nil),              // 4: nexus.v4.config.ItemAssociation
+	(*ItemChange)(nil),                   // 5: nexus.v4.config.ItemChange
+	(*PropertyChange)(nil),               // 6: nexus.v4.config.PropertyChange
+	(*PropertyChangeArrayWrapper)(nil),   // 7: nexus.v4.config.PropertyChangeArrayWrapper
+	(*ItemAssociationProjection)(nil),    // 8: nexus.v4.config.ItemAssociationProjection
+	(*ItemProjection)(nil),               // 9: nexus.v4.config.ItemProjection
+	(*ItemArrayWrapper)(nil),             // 10: nexus.v4.config.ItemArrayWrapper
+	(*ErrorResponseWrapper)(nil),         // 11: nexus.v4.config.ErrorResponseWrapper
+	(*ItemProjectionArrayWrapper)(nil),   // 12: nexus.v4.config.ItemProjectionArrayWrapper
+	(*ItemAggregateArrayWrapper)(nil),    // 13: nexus.v4.config.ItemAggregateArrayWrapper
+	(*ItemChangeArrayWrapper)(nil),       // 14: nexus.v4.config.ItemChangeArrayWrapper
+	(*ListItemsApiResponse)(nil),         // 15: nexus.v4.config.ListItemsApiResponse
+	(*PatchItemApiResponse)(nil),         // 16: nexus.v4.config.PatchItemApiResponse
+	(*RestoreItemApiResponse)(nil),       // 17: nexus.v4.config.RestoreItemApiResponse
+	(*GetItemApiResponse)(nil),           // 18: nexus.v4.config.GetItemApiResponse
+	(*ListItemHistoryApiResponse)(nil),   // 19: nexus.v4.config.ListItemHistoryApiResponse
+	nil,                                  // 20: nexus.v4.config.ObjectMapWrapper.ValueEntry
+	(*config.TenantAwareModel)(nil),      // 21: common.v1.config.TenantAwareModel
+	(*response.ApiLinkArrayWrapper)(nil), // 22: common.v1.response.ApiLinkArrayWrapper
+	(*timestamppb.Timestamp)(nil),        // 23: google.protobuf.Timestamp
+	(*config.KVPairArrayWrapper)(nil),    // 24: common.v1.config.KVPairArrayWrapper
+	(*error1.ErrorResponse)(nil),         // 25: nexus.v4.error.ErrorResponse
+	(*response.ApiResponseMetadata)(nil), // 26: common.v1.response.ApiResponseMetadata
+	(*anypb.Any)(nil),                    // 27: google.protobuf.Any
 }
 var file_nexus_v4_config_config_proto_depIdxs = []int32{
-	3,  // 0: nexus.v4.config.ItemAssociationArrayWrapper.value:type_name -> nexus.v4.config.ItemAssociation
-	10, // 1: nexus.v4.config.ObjectMapWrapper.value:type_name -> nexus.v4.config.ObjectMapWrapper.ValueEntry
+	4,  // 0: nexus.v4.config.ItemAssociationArrayWrapper.value:type_name -> nexus.v4.config.ItemAssociation
+	20, // 1: nexus.v4.config.ObjectMapWrapper.value:type_name -> nexus.v4.config.ObjectMapWrapper.ValueEntry
 	0,  // 2: nexus.v4.config.Item.associations:type_name -> nexus.v4.config.ItemAssociationArrayWrapper
-	1,  // 3: nexus.v4.config.Item._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
-	1,  // 4: nexus.v4.config.ItemAssociation._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
-	3,  // 5: nexus.v4.config.ItemAssociationProjection.base:type_name -> nexus.v4.config.ItemAssociation
-	2,  // 6: nexus.v4.config.ItemProjection.base:type_name -> nexus.v4.config.Item
-	2,  // 7: nexus.v4.config.ItemArrayWrapper.value:type_name -> nexus.v4.config.Item
-	11, // 8: nexus.v4.config.ErrorResponseWrapper.value:type_name -> nexus.v4.error.ErrorResponse
-	5,  // 9: nexus.v4.config.ItemProjectionArrayWrapper.value:type_name -> nexus.v4.config.ItemProjection
-	6,  // 10: nexus.v4.config.ListItemsApiResponse.item_array_data:type_name -> nexus.v4.config.ItemArrayWrapper
-	7,  // 11: nexus.v4.config.ListItemsApiResponse.error_response_data:type_name -> nexus.v4.config.ErrorResponseWrapper
-	8,  // 12: nexus.v4.config.ListItemsApiResponse.item_projection_array_data:type_name -> nexus.v4.config.ItemProjectionArrayWrapper
-	12, // 13: nexus.v4.config.ListItemsApiResponse.metadata:type_name -> common.v1.response.ApiResponseMetadata
-	1,  // 14: nexus.v4.config.ListItemsApiResponse._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
-	13, // 15: nexus.v4.config.ObjectMapWrapper.ValueEntry.value:type_name -> google.protobuf.Any
-	16, // [16:16] is the sub-list for method output_type
-	16, // [16:16] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	21, // 3: nexus.v4.config.Item.tenant_info:type_name -> common.v1.config.TenantAwareModel
+	22, // 4: nexus.v4.config.Item.links:type_name -> common.v1.response.ApiLinkArrayWrapper
+	23, // 5: nexus.v4.config.Item.deleted_at:type_name -> google.protobuf.Timestamp
+	1,  // 6: nexus.v4.config.Item._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
+	24, // 7: nexus.v4.config.ItemAggregate.values:type_name -> common.v1.config.KVPairArrayWrapper
+	1,  // 8: nexus.v4.config.ItemAggregate._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
+	21, // 9: nexus.v4.config.ItemAssociation.tenant_info:type_name -> common.v1.config.TenantAwareModel
+	1,  // 10: nexus.v4.config.ItemAssociation._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
+	23, // 11: nexus.v4.config.ItemChange.time:type_name -> google.protobuf.Timestamp
+	21, // 12: nexus.v4.config.ItemChange.tenant_info:type_name -> common.v1.config.TenantAwareModel
+	7,  // 13: nexus.v4.config.ItemChange.changes:type_name -> nexus.v4.config.PropertyChangeArrayWrapper
+	2,  // 14: nexus.v4.config.ItemChange.item_before:type_name -> nexus.v4.config.Item
+	2,  // 15: nexus.v4.config.ItemChange.item_after:type_name -> nexus.v4.config.Item
+	4,  // 16: nexus.v4.config.ItemChange.association_before:type_name -> nexus.v4.config.ItemAssociation
+	4,  // 17: nexus.v4.config.ItemChange.association_after:type_name -> nexus.v4.config.ItemAssociation
+	1,  // 18: nexus.v4.config.ItemChange._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
+	1,  // 19: nexus.v4.config.PropertyChange._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
+	6,  // 20: nexus.v4.config.PropertyChangeArrayWrapper.value:type_name -> nexus.v4.config.PropertyChange
+	4,  // 21: nexus.v4.config.ItemAssociationProjection.base:type_name -> nexus.v4.config.ItemAssociation
+	2,  // 22: nexus.v4.config.ItemProjection.base:type_name -> nexus.v4.config.Item
+	2,  // 23: nexus.v4.config.ItemArrayWrapper.value:type_name -> nexus.v4.config.Item
+	25, // 24: nexus.v4.config.ErrorResponseWrapper.value:type_name -> nexus.v4.error.ErrorResponse
+	9,  // 25: nexus.v4.config.ItemProjectionArrayWrapper.value:type_name -> nexus.v4.config.ItemProjection
+	3,  // 26: nexus.v4.config.ItemAggregateArrayWrapper.value:type_name -> nexus.v4.config.ItemAggregate
+	5,  // 27: nexus.v4.config.ItemChangeArrayWrapper.value:type_name -> nexus.v4.config.ItemChange
+	10, // 28: nexus.v4.config.ListItemsApiResponse.item_array_data:type_name -> nexus.v4.config.ItemArrayWrapper
+	11, // 29: nexus.v4.config.ListItemsApiResponse.error_response_data:type_name -> nexus.v4.config.ErrorResponseWrapper
+	12, // 30: nexus.v4.config.ListItemsApiResponse.item_projection_array_data:type_name -> nexus.v4.config.ItemProjectionArrayWrapper
+	13, // 31: nexus.v4.config.ListItemsApiResponse.item_aggregate_array_data:type_name -> nexus.v4.config.ItemAggregateArrayWrapper
+	26, // 32: nexus.v4.config.ListItemsApiResponse.metadata:type_name -> common.v1.response.ApiResponseMetadata
+	1,  // 33: nexus.v4.config.ListItemsApiResponse._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
+	2,  // 34: nexus.v4.config.PatchItemApiResponse.item_data:type_name -> nexus.v4.config.Item
+	11, // 35: nexus.v4.config.PatchItemApiResponse.error_response_data:type_name -> nexus.v4.config.ErrorResponseWrapper
+	26, // 36: nexus.v4.config.PatchItemApiResponse.metadata:type_name -> common.v1.response.ApiResponseMetadata
+	1,  // 37: nexus.v4.config.PatchItemApiResponse._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
+	2,  // 38: nexus.v4.config.RestoreItemApiResponse.item_data:type_name -> nexus.v4.config.Item
+	11, // 39: nexus.v4.config.RestoreItemApiResponse.error_response_data:type_name -> nexus.v4.config.ErrorResponseWrapper
+	26, // 40: nexus.v4.config.RestoreItemApiResponse.metadata:type_name -> common.v1.response.ApiResponseMetadata
+	1,  // 41: nexus.v4.config.RestoreItemApiResponse._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
+	2,  // 42: nexus.v4.config.GetItemApiResponse.item_data:type_name -> nexus.v4.config.Item
+	11, // 43: nexus.v4.config.GetItemApiResponse.error_response_data:type_name -> nexus.v4.config.ErrorResponseWrapper
+	26, // 44: nexus.v4.config.GetItemApiResponse.metadata:type_name -> common.v1.response.ApiResponseMetadata
+	1,  // 45: nexus.v4.config.GetItemApiResponse._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
+	14, // 46: nexus.v4.config.ListItemHistoryApiResponse.item_change_array_data:type_name -> nexus.v4.config.ItemChangeArrayWrapper
+	11, // 47: nexus.v4.config.ListItemHistoryApiResponse.error_response_data:type_name -> nexus.v4.config.ErrorResponseWrapper
+	26, // 48: nexus.v4.config.ListItemHistoryApiResponse.metadata:type_name -> common.v1.response.ApiResponseMetadata
+	1,  // 49: nexus.v4.config.ListItemHistoryApiResponse._reserved:type_name -> nexus.v4.config.ObjectMapWrapper
+	27, // 50: nexus.v4.config.ObjectMapWrapper.ValueEntry.value:type_name -> google.protobuf.Any
+	51, // [51:51] is the sub-list for method output_type
+	51, // [51:51] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_nexus_v4_config_config_proto_init() }
@@ -754,10 +1764,27 @@ func file_nexus_v4_config_config_proto_init() {
 	if File_nexus_v4_config_config_proto != nil {
 		return
 	}
-	file_nexus_v4_config_config_proto_msgTypes[9].OneofWrappers = []any{
+	file_nexus_v4_config_config_proto_msgTypes[15].OneofWrappers = []any{
 		(*ListItemsApiResponse_ItemArrayData)(nil),
 		(*ListItemsApiResponse_ErrorResponseData)(nil),
 		(*ListItemsApiResponse_ItemProjectionArrayData)(nil),
+		(*ListItemsApiResponse_ItemAggregateArrayData)(nil),
+	}
+	file_nexus_v4_config_config_proto_msgTypes[16].OneofWrappers = []any{
+		(*PatchItemApiResponse_ItemData)(nil),
+		(*PatchItemApiResponse_ErrorResponseData)(nil),
+	}
+	file_nexus_v4_config_config_proto_msgTypes[17].OneofWrappers = []any{
+		(*RestoreItemApiResponse_ItemData)(nil),
+		(*RestoreItemApiResponse_ErrorResponseData)(nil),
+	}
+	file_nexus_v4_config_config_proto_msgTypes[18].OneofWrappers = []any{
+		(*GetItemApiResponse_ItemData)(nil),
+		(*GetItemApiResponse_ErrorResponseData)(nil),
+	}
+	file_nexus_v4_config_config_proto_msgTypes[19].OneofWrappers = []any{
+		(*ListItemHistoryApiResponse_ItemChangeArrayData)(nil),
+		(*ListItemHistoryApiResponse_ErrorResponseData)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -765,7 +1792,7 @@ func file_nexus_v4_config_config_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_nexus_v4_config_config_proto_rawDesc), len(file_nexus_v4_config_config_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -39,7 +39,27 @@ type ListItemsArg struct {
 	// For example, filter '$filter=name eq 'karbon-ntnx-1.0' would filter the result on cluster name 'karbon-ntnx1.0', filter '$filter=startswith(name, 'C')' would filter on cluster name starting with 'C'.
 	XFilter *string `protobuf:"bytes,101,opt,name=_filter,json=Filter" json:"_filter,omitempty"`
 	// A URL query parameter that allows clients to specify the sort criteria for the returned list of objects. Resources can be sorted in ascending order using asc or descending order using desc. If asc or desc are not specified, the resources will be sorted in ascending order by default. For example, '$orderby=templateName desc' would get all templates sorted by templateName in descending order.
-	XOrderby      *string `protobuf:"bytes,102,opt,name=_orderby,json=Orderby" json:"_orderby,omitempty"`
+	XOrderby *string `protobuf:"bytes,102,opt,name=_orderby,json=Orderby" json:"_orderby,omitempty"`
+	// A URL query parameter that specifies the page number of the result set. It must be a positive integer between 0 and the maximum number of pages that are available for that resource. Any number out of this range might lead to no results.
+	XPage *int32 `protobuf:"varint,103,opt,name=_page,json=Page" json:"_page,omitempty"`
+	// A URL query parameter that specifies the total number of records returned in the result set. Must be a positive integer between 1 and 100. Any number out of this range will lead to a validation error. If the limit is not provided, a default value of 50 records will be returned in the result set.
+	XLimit *int32 `protobuf:"varint,104,opt,name=_limit,json=Limit" json:"_limit,omitempty"`
+	// A URL query parameter that allows clients to request related resources when a resource that satisfies a particular request is retrieved. Each expanded item is evaluated relative to the entity containing the property being expanded. Other query options can be applied to an expanded property by appending a semicolon-separated list of query options, enclosed in parentheses, to the property name. Permissible system query options are $filter, $select and $orderby. The only expandable property of an item is associations.
+	XExpand *string `protobuf:"bytes,105,opt,name=_expand,json=Expand" json:"_expand,omitempty"`
+	// A URL query parameter that allows clients to request a specific set of properties for each entity or complex type. Expression specified with the $select must conform to the OData V4.01 URL conventions. If a $select expression consists of a single select item that is an asterisk (i.e., *), then all properties on the matching resource will be returned. Nested properties are selected with a path, for example tenantInfo/tenantId.
+	XSelect *string `protobuf:"bytes,106,opt,name=_select,json=Select" json:"_select,omitempty"`
+	// A URL query parameter that asks for the number of items matching $filter, before paging, to be returned as totalAvailableResults in the response metadata. The count is only computed when it is true.
+	XCount *bool `protobuf:"varint,107,opt,name=_count,json=Count" json:"_count,omitempty"`
+	// A URL query parameter that searches the itemName, itemType and description of items for words, which match the words they start, and double-quoted phrases, combined with AND, OR and NOT. Expression specified with $search must conform to the OData V4.01 URL conventions. Without $orderby, matching items are returned most relevant first. Properties withheld from the caller, such as a masked description, are not searched.
+	XSearch *string `protobuf:"bytes,108,opt,name=_search,json=Search" json:"_search,omitempty"`
+	// A URL query parameter that aggregates items instead of listing them, for example $apply=groupby((itemType),aggregate($count as total)). Transformations are separated by a slash, and any filter transformations must precede a single groupby or aggregate transformation. Paths starting with associations aggregate the associations of the items. The response data is then a list of ItemAggregate rows.
+	XApply *string `protobuf:"bytes,109,opt,name=_apply,json=Apply" json:"_apply,omitempty"`
+	// A URL query parameter that resumes a listing after the last item of a previous page, as returned in the next link of its response. Unlike $page, it is not affected by items created or deleted in between. It must be used with the same $filter, $orderby and asOf as the listing it continues, and cannot be combined with $page, $search or $apply.
+	XSkiptoken *string `protobuf:"bytes,110,opt,name=_skiptoken,json=Skiptoken" json:"_skiptoken,omitempty"`
+	// A URL query parameter that lists the items as they were at the given time, an RFC 3339 date-time such as 2024-05-01T00:00:00Z. $filter, $orderby and $expand apply to the items as they were then. The time must be within the history retention window of the service, and asOf cannot be combined with $search.
+	AsOf *string `protobuf:"bytes,111,opt,name=as_of,json=asOf" json:"as_of,omitempty"`
+	// map containing headers expected in request
+	Reserved      map[string]string `protobuf:"bytes,1000,rep,name=reserved" json:"reserved,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -88,6 +108,76 @@ func (x *ListItemsArg) GetXOrderby() string {
 	return ""
 }
 
+func (x *ListItemsArg) GetXPage() int32 {
+	if x != nil && x.XPage != nil {
+		return *x.XPage
+	}
+	return 0
+}
+
+func (x *ListItemsArg) GetXLimit() int32 {
+	if x != nil && x.XLimit != nil {
+		return *x.XLimit
+	}
+	return 0
+}
+
+func (x *ListItemsArg) GetXExpand() string {
+	if x != nil && x.XExpand != nil {
+		return *x.XExpand
+	}
+	return ""
+}
+
+func (x *ListItemsArg) GetXSelect() string {
+	if x != nil && x.XSelect != nil {
+		return *x.XSelect
+	}
+	return ""
+}
+
+func (x *ListItemsArg) GetXCount() bool {
+	if x != nil && x.XCount != nil {
+		return *x.XCount
+	}
+	return false
+}
+
+func (x *ListItemsArg) GetXSearch() string {
+	if x != nil && x.XSearch != nil {
+		return *x.XSearch
+	}
+	return ""
+}
+
+func (x *ListItemsArg) GetXApply() string {
+	if x != nil && x.XApply != nil {
+		return *x.XApply
+	}
+	return ""
+}
+
+func (x *ListItemsArg) GetXSkiptoken() string {
+	if x != nil && x.XSkiptoken != nil {
+		return *x.XSkiptoken
+	}
+	return ""
+}
+
+func (x *ListItemsArg) GetAsOf() string {
+	if x != nil && x.AsOf != nil {
+		return *x.AsOf
+	}
+	return ""
+}
+
+func (x *ListItemsArg) GetReserved() map[string]string {
+	if x != nil {
+		return x.Reserved
+	}
+	return nil
+}
+
 // message containing all attributes expected in the listItems response
 type ListItemsRet struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -143,22 +233,751 @@ func (x *ListItemsRet) GetReserved() map[string]string {
 	return nil
 }
 
+// message containing all attributes expected in the countItems request
+type CountItemsArg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A URL query parameter that allows clients to filter the items counted. Expression specified with $filter must conform to the OData V4.01 URL conventions. Deleted items are excluded unless $filter refers to the isDeleted pseudo-property, as in $filter=isDeleted eq true.
+	XFilter       *string `protobuf:"bytes,101,opt,name=_filter,json=Filter" json:"_filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountItemsArg) Reset() {
+	*x = CountItemsArg{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountItemsArg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountItemsArg) ProtoMessage() {}
+
+func (x *CountItemsArg) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountItemsArg.ProtoReflect.Descriptor instead.
+func (*CountItemsArg) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CountItemsArg) GetXFilter() string {
+	if x != nil && x.XFilter != nil {
+		return *x.XFilter
+	}
+	return ""
+}
+
+// message containing all attributes expected in the countItems response
+type CountItemsRet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field containing expected response content
+	Content *int64 `protobuf:"varint,999,opt,name=content" json:"content,omitempty"`
+	// map containing headers expected in response
+	Reserved      map[string]string `protobuf:"bytes,1000,rep,name=reserved" json:"reserved,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountItemsRet) Reset() {
+	*x = CountItemsRet{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountItemsRet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountItemsRet) ProtoMessage() {}
+
+func (x *CountItemsRet) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountItemsRet.ProtoReflect.Descriptor instead.
+func (*CountItemsRet) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CountItemsRet) GetContent() int64 {
+	if x != nil && x.Content != nil {
+		return *x.Content
+	}
+	return 0
+}
+
+func (x *CountItemsRet) GetReserved() map[string]string {
+	if x != nil {
+		return x.Reserved
+	}
+	return nil
+}
+
+// message containing all attributes expected in the getItem request
+type GetItemArg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// External identifier for the item (UUID)
+	ExtId *string `protobuf:"bytes,1,opt,name=ext_id,json=extId" json:"ext_id,omitempty"`
+	// A URL query parameter that allows clients to request related resources when a resource that satisfies a particular request is retrieved. The only expandable property of an item is associations.
+	XExpand *string `protobuf:"bytes,105,opt,name=_expand,json=Expand" json:"_expand,omitempty"`
+	// A URL query parameter that gets the item as it was at the given time, an RFC 3339 date-time such as 2024-05-01T00:00:00Z. The time must be within the history retention window of the service.
+	AsOf          *string `protobuf:"bytes,111,opt,name=as_of,json=asOf" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemArg) Reset() {
+	*x = GetItemArg{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemArg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemArg) ProtoMessage() {}
+
+func (x *GetItemArg) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemArg.ProtoReflect.Descriptor instead.
+func (*GetItemArg) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetItemArg) GetExtId() string {
+	if x != nil && x.ExtId != nil {
+		return *x.ExtId
+	}
+	return ""
+}
+
+func (x *GetItemArg) GetXExpand() string {
+	if x != nil && x.XExpand != nil {
+		return *x.XExpand
+	}
+	return ""
+}
+
+func (x *GetItemArg) GetAsOf() string {
+	if x != nil && x.AsOf != nil {
+		return *x.AsOf
+	}
+	return ""
+}
+
+// message containing all attributes expected in the getItem response
+type GetItemRet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field containing expected response content
+	Content *GetItemApiResponse `protobuf:"bytes,999,opt,name=content" json:"content,omitempty"`
+	// map containing headers expected in response
+	Reserved      map[string]string `protobuf:"bytes,1000,rep,name=reserved" json:"reserved,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemRet) Reset() {
+	*x = GetItemRet{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemRet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemRet) ProtoMessage() {}
+
+func (x *GetItemRet) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemRet.ProtoReflect.Descriptor instead.
+func (*GetItemRet) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetItemRet) GetContent() *GetItemApiResponse {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *GetItemRet) GetReserved() map[string]string {
+	if x != nil {
+		return x.Reserved
+	}
+	return nil
+}
+
+// message containing all attributes expected in the patchItem request
+type PatchItemArg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// External identifier for the item (UUID)
+	ExtId *string `protobuf:"bytes,1,opt,name=ext_id,json=extId" json:"ext_id,omitempty"`
+	// Media type of the patch document, either application/merge-patch+json or application/json-patch+json
+	ContentType *string `protobuf:"bytes,2,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
+	// The JSON merge-patch or JSON-patch document
+	Body          []byte `protobuf:"bytes,3,opt,name=body" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchItemArg) Reset() {
+	*x = PatchItemArg{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchItemArg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchItemArg) ProtoMessage() {}
+
+func (x *PatchItemArg) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchItemArg.ProtoReflect.Descriptor instead.
+func (*PatchItemArg) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PatchItemArg) GetExtId() string {
+	if x != nil && x.ExtId != nil {
+		return *x.ExtId
+	}
+	return ""
+}
+
+func (x *PatchItemArg) GetContentType() string {
+	if x != nil && x.ContentType != nil {
+		return *x.ContentType
+	}
+	return ""
+}
+
+func (x *PatchItemArg) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+// message containing all attributes expected in the patchItem response
+type PatchItemRet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field containing expected response content
+	Content *PatchItemApiResponse `protobuf:"bytes,999,opt,name=content" json:"content,omitempty"`
+	// map containing headers expected in response
+	Reserved      map[string]string `protobuf:"bytes,1000,rep,name=reserved" json:"reserved,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchItemRet) Reset() {
+	*x = PatchItemRet{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchItemRet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchItemRet) ProtoMessage() {}
+
+func (x *PatchItemRet) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchItemRet.ProtoReflect.Descriptor instead.
+func (*PatchItemRet) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PatchItemRet) GetContent() *PatchItemApiResponse {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *PatchItemRet) GetReserved() map[string]string {
+	if x != nil {
+		return x.Reserved
+	}
+	return nil
+}
+
+// message containing all attributes expected in the deleteItem request
+type DeleteItemArg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// External identifier for the item (UUID)
+	ExtId         *string `protobuf:"bytes,1,opt,name=ext_id,json=extId" json:"ext_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteItemArg) Reset() {
+	*x = DeleteItemArg{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteItemArg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteItemArg) ProtoMessage() {}
+
+func (x *DeleteItemArg) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteItemArg.ProtoReflect.Descriptor instead.
+func (*DeleteItemArg) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteItemArg) GetExtId() string {
+	if x != nil && x.ExtId != nil {
+		return *x.ExtId
+	}
+	return ""
+}
+
+// message containing all attributes expected in the deleteItem response
+type DeleteItemRet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// map containing headers expected in response
+	Reserved      map[string]string `protobuf:"bytes,1000,rep,name=reserved" json:"reserved,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteItemRet) Reset() {
+	*x = DeleteItemRet{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteItemRet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteItemRet) ProtoMessage() {}
+
+func (x *DeleteItemRet) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteItemRet.ProtoReflect.Descriptor instead.
+func (*DeleteItemRet) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteItemRet) GetReserved() map[string]string {
+	if x != nil {
+		return x.Reserved
+	}
+	return nil
+}
+
+// message containing all attributes expected in the restoreItem request
+type RestoreItemArg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// External identifier for the item (UUID)
+	ExtId         *string `protobuf:"bytes,1,opt,name=ext_id,json=extId" json:"ext_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreItemArg) Reset() {
+	*x = RestoreItemArg{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreItemArg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreItemArg) ProtoMessage() {}
+
+func (x *RestoreItemArg) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreItemArg.ProtoReflect.Descriptor instead.
+func (*RestoreItemArg) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RestoreItemArg) GetExtId() string {
+	if x != nil && x.ExtId != nil {
+		return *x.ExtId
+	}
+	return ""
+}
+
+// message containing all attributes expected in the restoreItem response
+type RestoreItemRet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field containing expected response content
+	Content *RestoreItemApiResponse `protobuf:"bytes,999,opt,name=content" json:"content,omitempty"`
+	// map containing headers expected in response
+	Reserved      map[string]string `protobuf:"bytes,1000,rep,name=reserved" json:"reserved,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreItemRet) Reset() {
+	*x = RestoreItemRet{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreItemRet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreItemRet) ProtoMessage() {}
+
+func (x *RestoreItemRet) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreItemRet.ProtoReflect.Descriptor instead.
+func (*RestoreItemRet) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RestoreItemRet) GetContent() *RestoreItemApiResponse {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *RestoreItemRet) GetReserved() map[string]string {
+	if x != nil {
+		return x.Reserved
+	}
+	return nil
+}
+
+// message containing all attributes expected in the listItemHistory request
+type ListItemHistoryArg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A URL query parameter that allows clients to filter the changes listed, for example on their actor and time range as in $filter=actor eq 'admin' and time ge 2024-05-01T00:00:00Z and time lt 2024-05-02T00:00:00Z. Expression specified with $filter must conform to the OData V4.01 URL conventions.
+	XFilter *string `protobuf:"bytes,101,opt,name=_filter,json=Filter" json:"_filter,omitempty"`
+	// A URL query parameter that specifies the page number of the result set. It must be a positive integer between 0 and the maximum number of pages that are available for that resource. Any number out of this range might lead to no results.
+	XPage *int32 `protobuf:"varint,103,opt,name=_page,json=Page" json:"_page,omitempty"`
+	// A URL query parameter that specifies the total number of records returned in the result set. Must be a positive integer between 1 and 100. Any number out of this range will lead to a validation error. If the limit is not provided, a default value of 50 records will be returned in the result set.
+	XLimit        *int32 `protobuf:"varint,104,opt,name=_limit,json=Limit" json:"_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemHistoryArg) Reset() {
+	*x = ListItemHistoryArg{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemHistoryArg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemHistoryArg) ProtoMessage() {}
+
+func (x *ListItemHistoryArg) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemHistoryArg.ProtoReflect.Descriptor instead.
+func (*ListItemHistoryArg) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListItemHistoryArg) GetXFilter() string {
+	if x != nil && x.XFilter != nil {
+		return *x.XFilter
+	}
+	return ""
+}
+
+func (x *ListItemHistoryArg) GetXPage() int32 {
+	if x != nil && x.XPage != nil {
+		return *x.XPage
+	}
+	return 0
+}
+
+func (x *ListItemHistoryArg) GetXLimit() int32 {
+	if x != nil && x.XLimit != nil {
+		return *x.XLimit
+	}
+	return 0
+}
+
+// message containing all attributes expected in the listItemHistory response
+type ListItemHistoryRet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field containing expected response content
+	Content *ListItemHistoryApiResponse `protobuf:"bytes,999,opt,name=content" json:"content,omitempty"`
+	// map containing headers expected in response
+	Reserved      map[string]string `protobuf:"bytes,1000,rep,name=reserved" json:"reserved,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemHistoryRet) Reset() {
+	*x = ListItemHistoryRet{}
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemHistoryRet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemHistoryRet) ProtoMessage() {}
+
+func (x *ListItemHistoryRet) ProtoReflect() protoreflect.Message {
+	mi := &file_nexus_v4_config_item_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemHistoryRet.ProtoReflect.Descriptor instead.
+func (*ListItemHistoryRet) Descriptor() ([]byte, []int) {
+	return file_nexus_v4_config_item_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListItemHistoryRet) GetContent() *ListItemHistoryApiResponse {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ListItemHistoryRet) GetReserved() map[string]string {
+	if x != nil {
+		return x.Reserved
+	}
+	return nil
+}
+
 var File_nexus_v4_config_item_service_proto protoreflect.FileDescriptor
 
 const file_nexus_v4_config_item_service_proto_rawDesc = "" +
 	"\n" +
-	"\"nexus/v4/config/item_service.proto\x12\x0fnexus.v4.config\x1a\x1anexus/v4/api_version.proto\x1a\"nexus/v4/http_method_options.proto\x1a\x1cnexus/v4/config/config.proto\"B\n" +
+	"\"nexus/v4/config/item_service.proto\x12\x0fnexus.v4.config\x1a\x1anexus/v4/api_version.proto\x1a\"nexus/v4/http_method_options.proto\x1a\x1cnexus/v4/config/config.proto\"\xa2\x03\n" +
 	"\fListItemsArg\x12\x17\n" +
 	"\a_filter\x18e \x01(\tR\x06Filter\x12\x19\n" +
-	"\b_orderby\x18f \x01(\tR\aOrderby\"\xd7\x01\n" +
+	"\b_orderby\x18f \x01(\tR\aOrderby\x12\x13\n" +
+	"\x05_page\x18g \x01(\x05R\x04Page\x12\x15\n" +
+	"\x06_limit\x18h \x01(\x05R\x05Limit\x12\x17\n" +
+	"\a_expand\x18i \x01(\tR\x06Expand\x12\x17\n" +
+	"\a_select\x18j \x01(\tR\x06Select\x12\x15\n" +
+	"\x06_count\x18k \x01(\bR\x05Count\x12\x17\n" +
+	"\a_search\x18l \x01(\tR\x06Search\x12\x15\n" +
+	"\x06_apply\x18m \x01(\tR\x05Apply\x12\x1d\n" +
+	"\n" +
+	"_skiptoken\x18n \x01(\tR\tSkiptoken\x12\x13\n" +
+	"\x05as_of\x18o \x01(\tR\x04asOf\x12H\n" +
+	"\breserved\x18\xe8\a \x03(\v2+.nexus.v4.config.ListItemsArg.ReservedEntryR\breserved\x1a;\n" +
+	"\rReservedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd7\x01\n" +
 	"\fListItemsRet\x12@\n" +
 	"\acontent\x18\xe7\a \x01(\v2%.nexus.v4.config.ListItemsApiResponseR\acontent\x12H\n" +
 	"\breserved\x18\xe8\a \x03(\v2+.nexus.v4.config.ListItemsRet.ReservedEntryR\breserved\x1a;\n" +
 	"\rReservedEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\x80\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"(\n" +
+	"\rCountItemsArg\x12\x17\n" +
+	"\a_filter\x18e \x01(\tR\x06Filter\"\xb2\x01\n" +
+	"\rCountItemsRet\x12\x19\n" +
+	"\acontent\x18\xe7\a \x01(\x03R\acontent\x12I\n" +
+	"\breserved\x18\xe8\a \x03(\v2,.nexus.v4.config.CountItemsRet.ReservedEntryR\breserved\x1a;\n" +
+	"\rReservedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Q\n" +
+	"\n" +
+	"GetItemArg\x12\x15\n" +
+	"\x06ext_id\x18\x01 \x01(\tR\x05extId\x12\x17\n" +
+	"\a_expand\x18i \x01(\tR\x06Expand\x12\x13\n" +
+	"\x05as_of\x18o \x01(\tR\x04asOf\"\xd1\x01\n" +
+	"\n" +
+	"GetItemRet\x12>\n" +
+	"\acontent\x18\xe7\a \x01(\v2#.nexus.v4.config.GetItemApiResponseR\acontent\x12F\n" +
+	"\breserved\x18\xe8\a \x03(\v2).nexus.v4.config.GetItemRet.ReservedEntryR\breserved\x1a;\n" +
+	"\rReservedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
+	"\fPatchItemArg\x12\x15\n" +
+	"\x06ext_id\x18\x01 \x01(\tR\x05extId\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\fR\x04body\"\xd7\x01\n" +
+	"\fPatchItemRet\x12@\n" +
+	"\acontent\x18\xe7\a \x01(\v2%.nexus.v4.config.PatchItemApiResponseR\acontent\x12H\n" +
+	"\breserved\x18\xe8\a \x03(\v2+.nexus.v4.config.PatchItemRet.ReservedEntryR\breserved\x1a;\n" +
+	"\rReservedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"&\n" +
+	"\rDeleteItemArg\x12\x15\n" +
+	"\x06ext_id\x18\x01 \x01(\tR\x05extId\"\x97\x01\n" +
+	"\rDeleteItemRet\x12I\n" +
+	"\breserved\x18\xe8\a \x03(\v2,.nexus.v4.config.DeleteItemRet.ReservedEntryR\breserved\x1a;\n" +
+	"\rReservedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"'\n" +
+	"\x0eRestoreItemArg\x12\x15\n" +
+	"\x06ext_id\x18\x01 \x01(\tR\x05extId\"\xdd\x01\n" +
+	"\x0eRestoreItemRet\x12B\n" +
+	"\acontent\x18\xe7\a \x01(\v2'.nexus.v4.config.RestoreItemApiResponseR\acontent\x12J\n" +
+	"\breserved\x18\xe8\a \x03(\v2-.nexus.v4.config.RestoreItemRet.ReservedEntryR\breserved\x1a;\n" +
+	"\rReservedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Y\n" +
+	"\x12ListItemHistoryArg\x12\x17\n" +
+	"\a_filter\x18e \x01(\tR\x06Filter\x12\x13\n" +
+	"\x05_page\x18g \x01(\x05R\x04Page\x12\x15\n" +
+	"\x06_limit\x18h \x01(\x05R\x05Limit\"\xe9\x01\n" +
+	"\x12ListItemHistoryRet\x12F\n" +
+	"\acontent\x18\xe7\a \x01(\v2+.nexus.v4.config.ListItemHistoryApiResponseR\acontent\x12N\n" +
+	"\breserved\x18\xe8\a \x03(\v21.nexus.v4.config.ListItemHistoryRet.ReservedEntryR\breserved\x1a;\n" +
+	"\rReservedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xc8\x06\n" +
 	"\vItemService\x12f\n" +
-	"\tlistItems\x12\x1d.nexus.v4.config.ListItemsArg\x1a\x1d.nexus.v4.config.ListItemsRet\"\x1b\xc2>\x18*\x16/nexus/v4/config/items\x1a\t\x82}\x06\n" +
+	"\tlistItems\x12\x1d.nexus.v4.config.ListItemsArg\x1a\x1d.nexus.v4.config.ListItemsRet\"\x1b\xc2>\x18*\x16/nexus/v4/config/items\x12p\n" +
+	"\n" +
+	"countItems\x12\x1e.nexus.v4.config.CountItemsArg\x1a\x1e.nexus.v4.config.CountItemsRet\"\"\xc2>\x1f*\x1d/nexus/v4/config/items/$count\x12h\n" +
+	"\agetItem\x12\x1b.nexus.v4.config.GetItemArg\x1a\x1b.nexus.v4.config.GetItemRet\"#\xc2> *\x1e/nexus/v4/config/items/{extId}\x12n\n" +
+	"\tpatchItem\x12\x1d.nexus.v4.config.PatchItemArg\x1a\x1d.nexus.v4.config.PatchItemRet\"#\xc2> \x12\x1e/nexus/v4/config/items/{extId}\x12q\n" +
+	"\n" +
+	"deleteItem\x12\x1e.nexus.v4.config.DeleteItemArg\x1a\x1e.nexus.v4.config.DeleteItemRet\"#\xc2> \"\x1e/nexus/v4/config/items/{extId}\x12\x85\x01\n" +
+	"\vrestoreItem\x12\x1f.nexus.v4.config.RestoreItemArg\x1a\x1f.nexus.v4.config.RestoreItemRet\"4\xc2>1\n" +
+	"//nexus/v4/config/items/{extId}/$actions/restore\x12\x7f\n" +
+	"\x0flistItemHistory\x12#.nexus.v4.config.ListItemHistoryArg\x1a#.nexus.v4.config.ListItemHistoryRet\"\"\xc2>\x1f*\x1d/nexus/v4/config/item-history\x1a\t\x82}\x06\n" +
 	"\x014\x12\x011B$\n" +
 	"\x0fnexus.v4.configP\x01Z\x0fnexus/v4/config"
 
@@ -174,23 +993,69 @@ func file_nexus_v4_config_item_service_proto_rawDescGZIP() []byte {
 	return file_nexus_v4_config_item_service_proto_rawDescData
 }
 
-var file_nexus_v4_config_item_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_nexus_v4_config_item_service_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_nexus_v4_config_item_service_proto_goTypes = []any{
-	(*ListItemsArg)(nil),         // 0: nexus.v4.config.ListItemsArg
-	(*ListItemsRet)(nil),         // 1: nexus.v4.config.ListItemsRet
-	nil,                          // 2: nexus.v4.config.ListItemsRet.ReservedEntry
-	(*ListItemsApiResponse)(nil), // 3: nexus.v4.config.ListItemsApiResponse
+	(*ListItemsArg)(nil),               // 0: nexus.v4.config.ListItemsArg
+	(*ListItemsRet)(nil),               // 1: nexus.v4.config.ListItemsRet
+	(*CountItemsArg)(nil),              // 2: nexus.v4.config.CountItemsArg
+	(*CountItemsRet)(nil),              // 3: nexus.v4.config.CountItemsRet
+	(*GetItemArg)(nil),                 // 4: nexus.v4.config.GetItemArg
+	(*GetItemRet)(nil),                 // 5: nexus.v4.config.GetItemRet
+	(*PatchItemArg)(nil),               // 6: nexus.v4.config.PatchItemArg
+	(*PatchItemRet)(nil),               // 7: nexus.v4.config.PatchItemRet
+	(*DeleteItemArg)(nil),              // 8: nexus.v4.config.DeleteItemArg
+	(*DeleteItemRet)(nil),              // 9: nexus.v4.config.DeleteItemRet
+	(*RestoreItemArg)(nil),             // 10: nexus.v4.config.RestoreItemArg
+	(*RestoreItemRet)(nil),             // 11: nexus.v4.config.RestoreItemRet
+	(*ListItemHistoryArg)(nil),         // 12: nexus.v4.config.ListItemHistoryArg
+	(*ListItemHistoryRet)(nil),         // 13: nexus.v4.config.ListItemHistoryRet
+	nil,                                // 14: nexus.v4.config.ListItemsArg.ReservedEntry
+	nil,                                // 15: nexus.v4.config.ListItemsRet.ReservedEntry
+	nil,                                // 16: nexus.v4.config.CountItemsRet.ReservedEntry
+	nil,                                // 17: nexus.v4.config.GetItemRet.ReservedEntry
+	nil,                                // 18: nexus.v4.config.PatchItemRet.ReservedEntry
+	nil,                                // 19: nexus.v4.config.DeleteItemRet.ReservedEntry
+	nil,                                // 20: nexus.v4.config.RestoreItemRet.ReservedEntry
+	nil,                                // 21: nexus.v4.config.ListItemHistoryRet.ReservedEntry
+	(*ListItemsApiResponse)(nil),       // 22: nexus.v4.config.ListItemsApiResponse
+	(*GetItemApiResponse)(nil),         // 23: nexus.v4.config.GetItemApiResponse
+	(*PatchItemApiResponse)(nil),       // 24: nexus.v4.config.PatchItemApiResponse
+	(*RestoreItemApiResponse)(nil),     // 25: nexus.v4.config.RestoreItemApiResponse
+	(*ListItemHistoryApiResponse)(nil), // 26: nexus.v4.config.ListItemHistoryApiResponse
 }
 var file_nexus_v4_config_item_service_proto_depIdxs = []int32{
-	3, // 0: nexus.v4.config.ListItemsRet.content:type_name -> nexus.v4.config.ListItemsApiResponse
-	2, // 1: nexus.v4.config.ListItemsRet.reserved:type_name -> nexus.v4.config.ListItemsRet.ReservedEntry
-	0, // 2: nexus.v4.config.ItemService.listItems:input_type -> nexus.v4.config.ListItemsArg
-	1, // 3: nexus.v4.config.ItemService.listItems:output_type -> nexus.v4.config.ListItemsRet
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	14, // 0: nexus.v4.config.ListItemsArg.reserved:type_name -> nexus.v4.config.ListItemsArg.ReservedEntry
+	22, // 1: nexus.v4.config.ListItemsRet.content:type_name -> nexus.v4.config.ListItemsApiResponse
+	15, // 2: nexus.v4.config.ListItemsRet.reserved:type_name -> nexus.v4.config.ListItemsRet.ReservedEntry
+	16, // 3: nexus.v4.config.CountItemsRet.reserved:type_name -> nexus.v4.config.CountItemsRet.ReservedEntry
+	23, // 4: nexus.v4.config.GetItemRet.content:type_name -> nexus.v4.config.GetItemApiResponse
+	17, // 5: nexus.v4.config.GetItemRet.reserved:type_name -> nexus.v4.config.GetItemRet.ReservedEntry
+	24, // 6: nexus.v4.config.PatchItemRet.content:type_name -> nexus.v4.config.PatchItemApiResponse
+	18, // 7: nexus.v4.config.PatchItemRet.reserved:type_name -> nexus.v4.config.PatchItemRet.ReservedEntry
+	19, // 8: nexus.v4.config.DeleteItemRet.reserved:type_name -> nexus.v4.config.DeleteItemRet.ReservedEntry
+	25, // 9: nexus.v4.config.RestoreItemRet.content:type_name -> nexus.v4.config.RestoreItemApiResponse
+	20, // 10: nexus.v4.config.RestoreItemRet.reserved:type_name -> nexus.v4.config.RestoreItemRet.ReservedEntry
+	26, // 11: nexus.v4.config.ListItemHistoryRet.content:type_name -> nexus.v4.config.ListItemHistoryApiResponse
+	21, // 12: nexus.v4.config.ListItemHistoryRet.reserved:type_name -> nexus.v4.config.ListItemHistoryRet.ReservedEntry
+	0,  // 13: nexus.v4.config.ItemService.listItems:input_type -> nexus.v4.config.ListItemsArg
+	2,  // 14: nexus.v4.config.ItemService.countItems:input_type -> nexus.v4.config.CountItemsArg
+	4,  // 15: nexus.v4.config.ItemService.getItem:input_type -> nexus.v4.config.GetItemArg
+	6,  // 16: nexus.v4.config.ItemService.patchItem:input_type -> nexus.v4.config.PatchItemArg
+	8,  // 17: nexus.v4.config.ItemService.deleteItem:input_type -> nexus.v4.config.DeleteItemArg
+	10, // 18: nexus.v4.config.ItemService.restoreItem:input_type -> nexus.v4.config.RestoreItemArg
+	12, // 19: nexus.v4.config.ItemService.listItemHistory:input_type -> nexus.v4.config.ListItemHistoryArg
+	1,  // 20: nexus.v4.config.ItemService.listItems:output_type -> nexus.v4.config.ListItemsRet
+	3,  // 21: nexus.v4.config.ItemService.countItems:output_type -> nexus.v4.config.CountItemsRet
+	5,  // 22: nexus.v4.config.ItemService.getItem:output_type -> nexus.v4.config.GetItemRet
+	7,  // 23: nexus.v4.config.ItemService.patchItem:output_type -> nexus.v4.config.PatchItemRet
+	9,  // 24: nexus.v4.config.ItemService.deleteItem:output_type -> nexus.v4.config.DeleteItemRet
+	11, // 25: nexus.v4.config.ItemService.restoreItem:output_type -> nexus.v4.config.RestoreItemRet
+	13, // 26: nexus.v4.config.ItemService.listItemHistory:output_type -> nexus.v4.config.ListItemHistoryRet
+	20, // [20:27] is the sub-list for method output_type
+	13, // [13:20] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_nexus_v4_config_item_service_proto_init() }
@@ -205,7 +1070,7 @@ func file_nexus_v4_config_item_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_nexus_v4_config_item_service_proto_rawDesc), len(file_nexus_v4_config_item_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
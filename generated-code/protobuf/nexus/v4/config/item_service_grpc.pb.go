@@ -29,7 +29,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ItemService_ListItems_FullMethodName = "/nexus.v4.config.ItemService/listItems"
+	ItemService_ListItems_FullMethodName       = "/nexus.v4.config.ItemService/listItems"
+	ItemService_CountItems_FullMethodName      = "/nexus.v4.config.ItemService/countItems"
+	ItemService_GetItem_FullMethodName         = "/nexus.v4.config.ItemService/getItem"
+	ItemService_PatchItem_FullMethodName       = "/nexus.v4.config.ItemService/patchItem"
+	ItemService_DeleteItem_FullMethodName      = "/nexus.v4.config.ItemService/deleteItem"
+	ItemService_RestoreItem_FullMethodName     = "/nexus.v4.config.ItemService/restoreItem"
+	ItemService_ListItemHistory_FullMethodName = "/nexus.v4.config.ItemService/listItemHistory"
 )
 
 // ItemServiceClient is the client API for ItemService service.
@@ -39,8 +45,38 @@ type ItemServiceClient interface {
 	// uri: /nexus/v4/config/items
 	// http method: GET
 	// List items
-	// List all items
+	// List all items. Deleted items are excluded unless $filter refers to the isDeleted pseudo-property, as in $filter=isDeleted eq true.
 	ListItems(ctx context.Context, in *ListItemsArg, opts ...grpc.CallOption) (*ListItemsRet, error)
+	// uri: /nexus/v4/config/items/$count
+	// http method: GET
+	// Count items
+	// Count the items matching $filter without returning them. The response body is the count as a plain integer.
+	CountItems(ctx context.Context, in *CountItemsArg, opts ...grpc.CallOption) (*CountItemsRet, error)
+	// uri: /nexus/v4/config/items/{extId}
+	// http method: GET
+	// Get an item
+	// Get the item identified by its external identifier. Only items owned by the tenant of the request are visible.
+	GetItem(ctx context.Context, in *GetItemArg, opts ...grpc.CallOption) (*GetItemRet, error)
+	// uri: /nexus/v4/config/items/{extId}
+	// http method: PATCH
+	// Patch an item
+	// Partially update an item by applying a JSON merge-patch (RFC 7396) or JSON-patch (RFC 6902) document to it. Read-only properties cannot be changed and required properties cannot be removed.
+	PatchItem(ctx context.Context, in *PatchItemArg, opts ...grpc.CallOption) (*PatchItemRet, error)
+	// uri: /nexus/v4/config/items/{extId}
+	// http method: DELETE
+	// Delete an item
+	// Delete the item identified by its external identifier. The item is marked deleted and can be restored until it is purged, once the retention period of the service has elapsed. Depending on the configuration of the service, the associations of the item are deleted when it is purged, left in place as orphans, or prevent the deletion until they are deleted.
+	DeleteItem(ctx context.Context, in *DeleteItemArg, opts ...grpc.CallOption) (*DeleteItemRet, error)
+	// uri: /nexus/v4/config/items/{extId}/$actions/restore
+	// http method: POST
+	// Restore a deleted item
+	// Restore a deleted item, along with its associations, before it is purged. Deleted items are purged once the retention period of the service has elapsed. Restoring an item that is not deleted leaves it unchanged.
+	RestoreItem(ctx context.Context, in *RestoreItemArg, opts ...grpc.CallOption) (*RestoreItemRet, error)
+	// uri: /nexus/v4/config/item-history
+	// http method: GET
+	// List the change history of items
+	// List the changes made to items and their associations, oldest first. Every change records who made it and when, the request that made it and the properties it changed. Only changes to the items of the tenant of the request are visible.
+	ListItemHistory(ctx context.Context, in *ListItemHistoryArg, opts ...grpc.CallOption) (*ListItemHistoryRet, error)
 }
 
 type itemServiceClient struct {
@@ -61,6 +97,66 @@ func (c *itemServiceClient) ListItems(ctx context.Context, in *ListItemsArg, opt
 	return out, nil
 }
 
+func (c *itemServiceClient) CountItems(ctx context.Context, in *CountItemsArg, opts ...grpc.CallOption) (*CountItemsRet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountItemsRet)
+	err := c.cc.Invoke(ctx, ItemService_CountItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) GetItem(ctx context.Context, in *GetItemArg, opts ...grpc.CallOption) (*GetItemRet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetItemRet)
+	err := c.cc.Invoke(ctx, ItemService_GetItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) PatchItem(ctx context.Context, in *PatchItemArg, opts ...grpc.CallOption) (*PatchItemRet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PatchItemRet)
+	err := c.cc.Invoke(ctx, ItemService_PatchItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) DeleteItem(ctx context.Context, in *DeleteItemArg, opts ...grpc.CallOption) (*DeleteItemRet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteItemRet)
+	err := c.cc.Invoke(ctx, ItemService_DeleteItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) RestoreItem(ctx context.Context, in *RestoreItemArg, opts ...grpc.CallOption) (*RestoreItemRet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreItemRet)
+	err := c.cc.Invoke(ctx, ItemService_RestoreItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itemServiceClient) ListItemHistory(ctx context.Context, in *ListItemHistoryArg, opts ...grpc.CallOption) (*ListItemHistoryRet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListItemHistoryRet)
+	err := c.cc.Invoke(ctx, ItemService_ListItemHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ItemServiceServer is the server API for ItemService service.
 // All implementations must embed UnimplementedItemServiceServer
 // for forward compatibility.
@@ -68,8 +164,38 @@ type ItemServiceServer interface {
 	// uri: /nexus/v4/config/items
 	// http method: GET
 	// List items
-	// List all items
+	// List all items. Deleted items are excluded unless $filter refers to the isDeleted pseudo-property, as in $filter=isDeleted eq true.
 	ListItems(context.Context, *ListItemsArg) (*ListItemsRet, error)
+	// uri: /nexus/v4/config/items/$count
+	// http method: GET
+	// Count items
+	// Count the items matching $filter without returning them. The response body is the count as a plain integer.
+	CountItems(context.Context, *CountItemsArg) (*CountItemsRet, error)
+	// uri: /nexus/v4/config/items/{extId}
+	// http method: GET
+	// Get an item
+	// Get the item identified by its external identifier. Only items owned by the tenant of the request are visible.
+	GetItem(context.Context, *GetItemArg) (*GetItemRet, error)
+	// uri: /nexus/v4/config/items/{extId}
+	// http method: PATCH
+	// Patch an item
+	// Partially update an item by applying a JSON merge-patch (RFC 7396) or JSON-patch (RFC 6902) document to it. Read-only properties cannot be changed and required properties cannot be removed.
+	PatchItem(context.Context, *PatchItemArg) (*PatchItemRet, error)
+	// uri: /nexus/v4/config/items/{extId}
+	// http method: DELETE
+	// Delete an item
+	// Delete the item identified by its external identifier. The item is marked deleted and can be restored until it is purged, once the retention period of the service has elapsed. Depending on the configuration of the service, the associations of the item are deleted when it is purged, left in place as orphans, or prevent the deletion until they are deleted.
+	DeleteItem(context.Context, *DeleteItemArg) (*DeleteItemRet, error)
+	// uri: /nexus/v4/config/items/{extId}/$actions/restore
+	// http method: POST
+	// Restore a deleted item
+	// Restore a deleted item, along with its associations, before it is purged. Deleted items are purged once the retention period of the service has elapsed. Restoring an item that is not deleted leaves it unchanged.
+	RestoreItem(context.Context, *RestoreItemArg) (*RestoreItemRet, error)
+	// uri: /nexus/v4/config/item-history
+	// http method: GET
+	// List the change history of items
+	// List the changes made to items and their associations, oldest first. Every change records who made it and when, the request that made it and the properties it changed. Only changes to the items of the tenant of the request are visible.
+	ListItemHistory(context.Context, *ListItemHistoryArg) (*ListItemHistoryRet, error)
 	mustEmbedUnimplementedItemServiceServer()
 }
 
@@ -83,6 +209,24 @@ type UnimplementedItemServiceServer struct{}
 func (UnimplementedItemServiceServer) ListItems(context.Context, *ListItemsArg) (*ListItemsRet, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListItems not implemented")
 }
+func (UnimplementedItemServiceServer) CountItems(context.Context, *CountItemsArg) (*CountItemsRet, error) {
+	return nil, status.Error(codes.Unimplemented, "method CountItems not implemented")
+}
+func (UnimplementedItemServiceServer) GetItem(context.Context, *GetItemArg) (*GetItemRet, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetItem not implemented")
+}
+func (UnimplementedItemServiceServer) PatchItem(context.Context, *PatchItemArg) (*PatchItemRet, error) {
+	return nil, status.Error(codes.Unimplemented, "method PatchItem not implemented")
+}
+func (UnimplementedItemServiceServer) DeleteItem(context.Context, *DeleteItemArg) (*DeleteItemRet, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteItem not implemented")
+}
+func (UnimplementedItemServiceServer) RestoreItem(context.Context, *RestoreItemArg) (*RestoreItemRet, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreItem not implemented")
+}
+func (UnimplementedItemServiceServer) ListItemHistory(context.Context, *ListItemHistoryArg) (*ListItemHistoryRet, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListItemHistory not implemented")
+}
 func (UnimplementedItemServiceServer) mustEmbedUnimplementedItemServiceServer() {}
 func (UnimplementedItemServiceServer) testEmbeddedByValue()                     {}
 
@@ -122,6 +266,114 @@ func _ItemService_ListItems_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ItemService_CountItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountItemsArg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).CountItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_CountItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).CountItems(ctx, req.(*CountItemsArg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_GetItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemArg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).GetItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_GetItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).GetItem(ctx, req.(*GetItemArg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_PatchItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchItemArg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).PatchItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_PatchItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).PatchItem(ctx, req.(*PatchItemArg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_DeleteItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteItemArg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).DeleteItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_DeleteItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).DeleteItem(ctx, req.(*DeleteItemArg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_RestoreItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreItemArg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).RestoreItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_RestoreItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).RestoreItem(ctx, req.(*RestoreItemArg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ItemService_ListItemHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListItemHistoryArg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemServiceServer).ListItemHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemService_ListItemHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemServiceServer).ListItemHistory(ctx, req.(*ListItemHistoryArg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ItemService_ServiceDesc is the grpc.ServiceDesc for ItemService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -133,6 +385,30 @@ var ItemService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "listItems",
 			Handler:    _ItemService_ListItems_Handler,
 		},
+		{
+			MethodName: "countItems",
+			Handler:    _ItemService_CountItems_Handler,
+		},
+		{
+			MethodName: "getItem",
+			Handler:    _ItemService_GetItem_Handler,
+		},
+		{
+			MethodName: "patchItem",
+			Handler:    _ItemService_PatchItem_Handler,
+		},
+		{
+			MethodName: "deleteItem",
+			Handler:    _ItemService_DeleteItem_Handler,
+		},
+		{
+			MethodName: "restoreItem",
+			Handler:    _ItemService_RestoreItem_Handler,
+		},
+		{
+			MethodName: "listItemHistory",
+			Handler:    _ItemService_ListItemHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "nexus/v4/config/item_service.proto",
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// AuthorizationKey is the header carrying basic and bearer credentials.
+const AuthorizationKey = "authorization"
+
+// BasicAccount is an account accepted by a BasicAuthenticator.
+type BasicAccount struct {
+	Credentials *commonpb.BasicAuth
+	Roles       []string
+	TenantId    string
+}
+
+// BasicAuthenticator authenticates requests using the HTTP basic scheme
+// (RFC 7617) against a fixed set of accounts.
+type BasicAuthenticator struct {
+	accounts map[string]BasicAccount
+}
+
+// NewBasicAuthenticator returns a BasicAuthenticator accepting accounts.
+func NewBasicAuthenticator(accounts ...BasicAccount) *BasicAuthenticator {
+	a := &BasicAuthenticator{accounts: make(map[string]BasicAccount, len(accounts))}
+	for _, acc := range accounts {
+		a.accounts[acc.Credentials.GetUsername()] = acc
+	}
+	return a
+}
+
+// Authenticate implements Authenticator.
+func (a *BasicAuthenticator) Authenticate(ctx context.Context, md metadata.MD) (*Identity, error) {
+	creds, ok := ParseBasicAuth(first(md, AuthorizationKey))
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+	acc, ok := a.accounts[creds.GetUsername()]
+	if !ok || !passwordsEqual(acc.Credentials.GetPassword(), creds.GetPassword()) {
+		return nil, errors.New("invalid username or password")
+	}
+	return &Identity{
+		User:     creds.GetUsername(),
+		Roles:    acc.Roles,
+		TenantId: acc.TenantId,
+		Method:   "basic",
+	}, nil
+}
+
+// ParseBasicAuth parses the value of an Authorization header using the basic
+// scheme.
+func ParseBasicAuth(header string) (*commonpb.BasicAuth, bool) {
+	scheme, encoded, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "basic") {
+		return nil, false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, false
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return nil, false
+	}
+	return &commonpb.BasicAuth{Username: proto.String(username), Password: proto.String(password)}, true
+}
+
+// passwordsEqual compares digests so that the comparison takes the same time
+// whatever the length of the passwords.
+func passwordsEqual(want, got string) bool {
+	w := sha256.Sum256([]byte(want))
+	g := sha256.Sum256([]byte(got))
+	return subtle.ConstantTimeCompare(w[:], g[:]) == 1
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBasicAuthenticator(t *testing.T) {
+	a := NewBasicAuthenticator(BasicAccount{
+		Credentials: &commonpb.BasicAuth{Username: proto.String("alice"), Password: proto.String("s3cret:x")},
+		Roles:       []string{RoleAdmin},
+		TenantId:    "tenant-1",
+	})
+	basic := func(userinfo string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(userinfo))
+	}
+	tests := []struct {
+		name   string
+		header string
+		err    error
+		// invalid is set when the credentials are rejected, whatever
+		// the error.
+		invalid bool
+	}{
+		{name: "valid", header: basic("alice:s3cret:x")},
+		{name: "scheme case", header: "bASIC " + base64.StdEncoding.EncodeToString([]byte("alice:s3cret:x"))},
+		{name: "wrong password", header: basic("alice:s3cret"), invalid: true},
+		{name: "unknown user", header: basic("bob:s3cret:x"), invalid: true},
+		{name: "no password", header: basic("alice"), err: ErrNoCredentials},
+		{name: "not base64", header: "Basic !!!", err: ErrNoCredentials},
+		{name: "bearer", header: "Bearer abc", err: ErrNoCredentials},
+		{name: "none", err: ErrNoCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := a.Authenticate(context.Background(), metadata.Pairs(AuthorizationKey, tt.header))
+			switch {
+			case tt.invalid:
+				if err == nil || errors.Is(err, ErrNoCredentials) {
+					t.Errorf("got %v, %v, want the credentials rejected", id, err)
+				}
+			case !errors.Is(err, tt.err):
+				t.Errorf("got %v, want %v", err, tt.err)
+			case err == nil && (id.User != "alice" || !id.HasRole(RoleAdmin) || id.TenantId != "tenant-1" || id.Method != "basic"):
+				t.Errorf("got %+v, want the account of alice", id)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Headers carrying the identity of a caller authenticated by a gateway.
+const (
+	ForwardedUserKey   = "x-ntnx-user"
+	ForwardedRolesKey  = "x-ntnx-user-roles"
+	ForwardedTenantKey = "x-ntnx-user-tenant-id"
+)
+
+// ForwardedAuthenticator trusts the identity headers set by a gateway that
+// has already authenticated the caller, such as Adonis or Mercury. The
+// gateway must strip these headers from the requests it receives.
+type ForwardedAuthenticator struct {
+	// TrustedProxies lists the networks the gateway connects from. Headers
+	// received from other peers are rejected, so callers must list the
+	// networks of their gateways: when empty no peer is trusted.
+	TrustedProxies []netip.Prefix
+	// TrustAll trusts the headers of every peer, whatever TrustedProxies
+	// lists. It is only safe when the server cannot be reached other than
+	// through the gateway.
+	TrustAll bool
+}
+
+// Authenticate implements Authenticator.
+func (a *ForwardedAuthenticator) Authenticate(ctx context.Context, md metadata.MD) (*Identity, error) {
+	user := first(md, ForwardedUserKey)
+	if user == "" {
+		return nil, ErrNoCredentials
+	}
+	if !a.trusted(ctx) {
+		return nil, ErrUntrustedProxy
+	}
+	id := &Identity{User: user, TenantId: first(md, ForwardedTenantKey), Method: "forwarded"}
+	for _, role := range strings.Split(first(md, ForwardedRolesKey), ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			id.Roles = append(id.Roles, role)
+		}
+	}
+	return id, nil
+}
+
+func (a *ForwardedAuthenticator) trusted(ctx context.Context) bool {
+	if a.TrustAll {
+		return true
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return false
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range a.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
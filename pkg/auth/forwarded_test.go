@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestForwardedAuthenticator(t *testing.T) {
+	gateways := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24"), netip.MustParsePrefix("fd00::/64")}
+	from := func(addr string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: net.TCPAddrFromAddrPort(netip.MustParseAddrPort(addr))})
+	}
+	tests := []struct {
+		name string
+		a    *ForwardedAuthenticator
+		ctx  context.Context
+		err  error
+	}{
+		{name: "trusted", a: &ForwardedAuthenticator{TrustedProxies: gateways}, ctx: from("10.0.0.7:41000")},
+		{name: "trusted IPv6", a: &ForwardedAuthenticator{TrustedProxies: gateways}, ctx: from("[fd00::1]:41000")},
+		{name: "IPv4-mapped", a: &ForwardedAuthenticator{TrustedProxies: gateways}, ctx: from("[::ffff:10.0.0.7]:41000")},
+		{name: "untrusted", a: &ForwardedAuthenticator{TrustedProxies: gateways}, ctx: from("10.0.1.7:41000"), err: ErrUntrustedProxy},
+		{name: "no proxies", a: &ForwardedAuthenticator{}, ctx: from("10.0.0.7:41000"), err: ErrUntrustedProxy},
+		{name: "no peer", a: &ForwardedAuthenticator{TrustedProxies: gateways}, ctx: context.Background(), err: ErrUntrustedProxy},
+		{name: "trust all", a: &ForwardedAuthenticator{TrustAll: true}, ctx: context.Background()},
+	}
+	md := metadata.Pairs(ForwardedUserKey, "alice", ForwardedRolesKey, " admin, ,auditor", ForwardedTenantKey, "tenant-1")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := tt.a.Authenticate(tt.ctx, md)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if id.User != "alice" || !slices.Equal(id.Roles, []string{"admin", "auditor"}) || id.TenantId != "tenant-1" || id.Method != "forwarded" {
+				t.Errorf("got %+v, want alice of tenant-1 with roles admin and auditor", id)
+			}
+		})
+	}
+	// Requests without forwarded headers are left to other authenticators,
+	// whoever sends them.
+	a := &ForwardedAuthenticator{TrustedProxies: gateways}
+	if _, err := a.Authenticate(from("10.0.1.7:41000"), metadata.Pairs(ForwardedRolesKey, "admin")); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("got %v without a user, want ErrNoCredentials", err)
+	}
+}
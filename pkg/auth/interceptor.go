@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Guard authenticates every request with Authenticator and authorizes it
+// against Policy. The Identity of admitted requests is stored in their
+// context.
+type Guard struct {
+	Authenticator Authenticator
+	Policy        Policy
+}
+
+// Check authenticates the request in ctx, whose headers are md, and
+// authorizes it to call method.
+func (g *Guard) Check(ctx context.Context, method string, md metadata.MD) (*Identity, error) {
+	id, err := g.Authenticator.Authenticate(ctx, md)
+	switch {
+	case errors.Is(err, ErrNoCredentials):
+		return nil, status.Error(codes.Unauthenticated, "request carries no credentials")
+	case err != nil:
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err := g.Policy.Authorize(method, id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// UnaryServerInterceptor guards every unary call. It must run before
+// interceptors relying on the Identity, such as tenant.Resolver's.
+func (g *Guard) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		id, err := g.Check(ctx, info.FullMethod, md)
+		if err != nil {
+			return nil, err
+		}
+		return handler(NewContext(ctx, id), req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func (g *Guard) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		md, _ := metadata.FromIncomingContext(ss.Context())
+		id, err := g.Check(ss.Context(), info.FullMethod, md)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &identityStream{ServerStream: ss, ctx: NewContext(ss.Context(), id)})
+	}
+}
+
+// Middleware guards the requests served by next. method maps each request
+// to the full name of the RPC it stands for, so that REST routes share the
+// Policy of their gRPC counterparts. The request headers are also exposed
+// to next as incoming gRPC metadata.
+func (g *Guard) Middleware(next http.Handler, method func(*http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		md := make(metadata.MD, len(r.Header))
+		for k, v := range r.Header {
+			md[strings.ToLower(k)] = v
+		}
+		ctx := metadata.NewIncomingContext(r.Context(), md)
+		if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+		}
+		id, err := g.Check(ctx, method(r), md)
+		if err != nil {
+			st, _ := status.FromError(err)
+			if st.Code() == codes.Unauthenticated {
+				w.Header().Set("WWW-Authenticate", `Basic realm="nexus", Bearer`)
+				http.Error(w, st.Message(), http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, st.Message(), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(ctx, id)))
+	})
+}
+
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context { return s.ctx }
@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// JWTAuthenticator authenticates requests carrying a bearer JSON Web Token
+// (RFC 7519) signed with one of a set of local keys. Only compact JWS tokens
+// signed with HS256/384/512, RS256/384/512 or ES256/384/512 are accepted.
+//
+// The subject claim names the user. The roles and tenantId claims, when
+// present, populate the roles and tenant of the Identity.
+type JWTAuthenticator struct {
+	// Keys maps key ids to verification keys: a []byte HMAC secret, an
+	// *rsa.PublicKey or an *ecdsa.PublicKey. Tokens without a kid header
+	// are verified with the key stored under "".
+	Keys map[string]interface{}
+	// Issuer, when set, must match the iss claim.
+	Issuer string
+	// Audience, when set, must be listed in the aud claim.
+	Audience string
+	// Leeway is the clock skew tolerated when checking exp and nbf.
+	Leeway time.Duration
+	// Now returns the current time. When nil time.Now is used.
+	Now func() time.Time
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+	Roles     []string `json:"roles"`
+	TenantId  string   `json:"tenantId"`
+}
+
+// audience accepts both forms of the aud claim: a string or an array.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*a = audience{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// Authenticate implements Authenticator.
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, md metadata.MD) (*Identity, error) {
+	scheme, token, ok := strings.Cut(first(md, AuthorizationKey), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return nil, ErrNoCredentials
+	}
+	claims, err := a.verify(strings.TrimSpace(token))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bearer token: %w", err)
+	}
+	return &Identity{
+		User:     claims.Subject,
+		Roles:    claims.Roles,
+		TenantId: claims.TenantId,
+		Method:   "jwt",
+	}, nil
+}
+
+func (a *JWTAuthenticator) verify(token string) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	key, ok := a.Keys[header.Kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", header.Kid)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("claims: %w", err)
+	}
+	if err := a.checkClaims(&claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+func (a *JWTAuthenticator) checkClaims(c *jwtClaims) error {
+	now := time.Now()
+	if a.Now != nil {
+		now = a.Now()
+	}
+	if c.Subject == "" {
+		return errors.New("missing sub claim")
+	}
+	if c.ExpiresAt != nil && now.After(unixTime(*c.ExpiresAt).Add(a.Leeway)) {
+		return errors.New("token has expired")
+	}
+	if c.NotBefore != nil && now.Add(a.Leeway).Before(unixTime(*c.NotBefore)) {
+		return errors.New("token is not valid yet")
+	}
+	if a.Issuer != "" && c.Issuer != a.Issuer {
+		return fmt.Errorf("unexpected issuer %q", c.Issuer)
+	}
+	if a.Audience != "" && !slices.Contains(c.Audience, a.Audience) {
+		return fmt.Errorf("token is not intended for %q", a.Audience)
+	}
+	return nil
+}
+
+func verifySignature(alg string, key interface{}, signed string, sig []byte) error {
+	var hash crypto.Hash
+	switch alg[min(2, len(alg)):] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case []byte:
+		if !strings.HasPrefix(alg, "HS") {
+			return fmt.Errorf("algorithm %q does not match an HMAC key", alg)
+		}
+		mac := hmac.New(hash.New, k)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return errors.New("signature mismatch")
+		}
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("algorithm %q does not match an RSA key", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(k, hash, digest, sig); err != nil {
+			return errors.New("signature mismatch")
+		}
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || len(sig) != 2*size {
+			return fmt.Errorf("algorithm %q does not match an ECDSA key", alg)
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("signature mismatch")
+		}
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+	return nil
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func unixTime(secs float64) time.Time {
+	whole, frac := math.Modf(secs)
+	return time.Unix(int64(whole), int64(frac*float64(time.Second)))
+}
@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// signJWT returns the compact JWS of claims with the given header, signed
+// with key: a []byte HMAC secret, an *rsa.PrivateKey or an
+// *ecdsa.PrivateKey. The hash is taken from the alg of header.
+func signJWT(t *testing.T, header map[string]string, claims map[string]interface{}, key interface{}) string {
+	t.Helper()
+	segment := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	signed := segment(header) + "." + segment(claims)
+	hash := map[string]crypto.Hash{"256": crypto.SHA256, "384": crypto.SHA384, "512": crypto.SHA512}[header["alg"][2:]]
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+	var sig []byte
+	var err error
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(hash.New, k)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, hash, digest)
+	case *ecdsa.PrivateKey:
+		r, s, serr := ecdsa.Sign(rand.Reader, k, digest)
+		size := (k.Curve.Params().BitSize + 7) / 8
+		sig, err = make([]byte, 2*size), serr
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	secret := []byte("secret")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	a := &JWTAuthenticator{
+		Keys: map[string]interface{}{
+			"":    secret,
+			"rsa": &rsaKey.PublicKey,
+			"ec":  &ecKey.PublicKey,
+		},
+		Issuer:   "https://idp.example.com",
+		Audience: "nexus",
+		Leeway:   time.Minute,
+		Now:      func() time.Time { return now },
+	}
+	claims := func(changes map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub":      "alice",
+			"iss":      "https://idp.example.com",
+			"aud":      "nexus",
+			"exp":      now.Add(time.Hour).Unix(),
+			"roles":    []string{RoleAdmin},
+			"tenantId": "tenant-1",
+		}
+		for k, v := range changes {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+	hs256 := map[string]string{"alg": "HS256"}
+	valid := signJWT(t, hs256, claims(nil), secret)
+	parts := strings.Split(valid, ".")
+	// forged has its claims changed after they were signed.
+	changed, _ := json.Marshal(claims(map[string]interface{}{"sub": "mallory"}))
+	forged := parts[0] + "." + base64.RawURLEncoding.EncodeToString(changed) + "." + parts[2]
+	// unsigned claims to need no signature.
+	none, _ := json.Marshal(map[string]string{"alg": "none"})
+	unsigned := base64.RawURLEncoding.EncodeToString(none) + "." + parts[1] + "."
+	tests := []struct {
+		name  string
+		token string
+		// err is a part of the message of the error expected, empty if
+		// the token is valid.
+		err string
+	}{
+		{name: "HS256", token: valid},
+		{name: "HS512", token: signJWT(t, map[string]string{"alg": "HS512"}, claims(nil), secret)},
+		{name: "RS256", token: signJWT(t, map[string]string{"alg": "RS256", "kid": "rsa"}, claims(nil), rsaKey)},
+		{name: "ES256", token: signJWT(t, map[string]string{"alg": "ES256", "kid": "ec"}, claims(nil), ecKey)},
+		{name: "audience list", token: signJWT(t, hs256, claims(map[string]interface{}{"aud": []string{"other", "nexus"}}), secret)},
+		{name: "no expiry", token: signJWT(t, hs256, claims(map[string]interface{}{"exp": nil}), secret)},
+		{name: "expired within leeway", token: signJWT(t, hs256, claims(map[string]interface{}{"exp": now.Add(-30 * time.Second).Unix()}), secret)},
+		{name: "expired", token: signJWT(t, hs256, claims(map[string]interface{}{"exp": now.Add(-2 * time.Minute).Unix()}), secret), err: "expired"},
+		{name: "not valid yet", token: signJWT(t, hs256, claims(map[string]interface{}{"nbf": now.Add(2 * time.Minute).Unix()}), secret), err: "not valid yet"},
+		{name: "valid within leeway", token: signJWT(t, hs256, claims(map[string]interface{}{"nbf": now.Add(30 * time.Second).Unix()}), secret)},
+		{name: "other issuer", token: signJWT(t, hs256, claims(map[string]interface{}{"iss": "https://evil.example.com"}), secret), err: "issuer"},
+		{name: "other audience", token: signJWT(t, hs256, claims(map[string]interface{}{"aud": "other"}), secret), err: "not intended"},
+		{name: "no audience", token: signJWT(t, hs256, claims(map[string]interface{}{"aud": nil}), secret), err: "not intended"},
+		{name: "no subject", token: signJWT(t, hs256, claims(map[string]interface{}{"sub": nil}), secret), err: "sub"},
+		{name: "wrong secret", token: signJWT(t, hs256, claims(nil), []byte("guess")), err: "signature mismatch"},
+		{name: "unknown key", token: signJWT(t, map[string]string{"alg": "HS256", "kid": "other"}, claims(nil), secret), err: "unknown key"},
+		{name: "RSA key of another kid", token: signJWT(t, map[string]string{"alg": "RS256", "kid": "ec"}, claims(nil), rsaKey), err: "does not match"},
+		{name: "algorithm of another key", token: signJWT(t, map[string]string{"alg": "HS256", "kid": "rsa"}, claims(nil), secret), err: "does not match"},
+		{name: "malformed", token: "a.b", err: "malformed"},
+		{name: "bad signature encoding", token: valid + "!", err: "signature"},
+		{name: "forged claims", token: forged, err: "signature mismatch"},
+		{name: "unsigned", token: unsigned, err: "unsupported algorithm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := a.Authenticate(context.Background(), metadata.Pairs(AuthorizationKey, "Bearer "+tt.token))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("got %v, %v, want an error mentioning %q", id, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := &Identity{User: "alice", Roles: []string{RoleAdmin}, TenantId: "tenant-1", Method: "jwt"}
+			if id.User != want.User || !slices.Equal(id.Roles, want.Roles) || id.TenantId != want.TenantId || id.Method != want.Method {
+				t.Errorf("got %+v, want %+v", id, want)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorWithoutBearer(t *testing.T) {
+	a := &JWTAuthenticator{Keys: map[string]interface{}{"": []byte("secret")}}
+	for _, header := range []string{"", "Basic YWxpY2U6c2VjcmV0", "Bearer"} {
+		md := metadata.MD{}
+		if header != "" {
+			md = metadata.Pairs(AuthorizationKey, header)
+		}
+		if _, err := a.Authenticate(context.Background(), md); !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("Authorization %q: got %v, want ErrNoCredentials", header, err)
+		}
+	}
+}
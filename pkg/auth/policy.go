@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Access is the level of access an RPC requires.
+type Access int
+
+const (
+	// Deny rejects every caller. It is the access of RPCs a Policy does not
+	// list.
+	Deny Access = iota
+	// ReadOnly admits any authenticated caller, like
+	// kAllowAnyAuthenticatedUserExt does in the Adonis registration.
+	ReadOnly
+	// Admin admits callers holding RoleAdmin.
+	Admin
+)
+
+func (a Access) String() string {
+	switch a {
+	case ReadOnly:
+		return "read-only"
+	case Admin:
+		return "admin"
+	}
+	return "deny"
+}
+
+// Policy maps full gRPC method names, as in
+// "/nexus.v4.config.ItemService/listItems", to the access they require.
+type Policy map[string]Access
+
+// Merge returns a Policy holding the entries of p and others. Later entries
+// win.
+func (p Policy) Merge(others ...Policy) Policy {
+	merged := make(Policy, len(p))
+	for _, policy := range append([]Policy{p}, others...) {
+		for method, access := range policy {
+			merged[method] = access
+		}
+	}
+	return merged
+}
+
+// Authorize returns a PermissionDenied status unless id may call method.
+func (p Policy) Authorize(method string, id *Identity) error {
+	switch access := p[method]; access {
+	case ReadOnly:
+		if id != nil {
+			return nil
+		}
+	case Admin:
+		if id.HasRole(RoleAdmin) {
+			return nil
+		}
+	default:
+		return status.Errorf(codes.PermissionDenied, "%s is not allowed by the access policy", method)
+	}
+	return status.Errorf(codes.PermissionDenied, "%s requires %s access", method, p[method])
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// stubAuthenticator authenticates the users named by the x-user header,
+// granting them the role in x-role. Users named "invalid" are rejected.
+type stubAuthenticator struct{}
+
+func (stubAuthenticator) Authenticate(ctx context.Context, md metadata.MD) (*Identity, error) {
+	switch user := first(md, "x-user"); user {
+	case "":
+		return nil, ErrNoCredentials
+	case "invalid":
+		return nil, errors.New("invalid credentials")
+	default:
+		id := &Identity{User: user}
+		if role := first(md, "x-role"); role != "" {
+			id.Roles = []string{role}
+		}
+		return id, nil
+	}
+}
+
+const (
+	readMethod  = "/nexus.v4.config.ItemService/listItems"
+	writeMethod = "/nexus.v4.config.ItemService/patchItem"
+)
+
+var testPolicy = Policy{readMethod: ReadOnly, writeMethod: Admin}
+
+func TestPolicyAuthorize(t *testing.T) {
+	tests := []struct {
+		method string
+		id     *Identity
+		ok     bool
+	}{
+		{readMethod, &Identity{User: "alice"}, true},
+		{readMethod, nil, false},
+		{writeMethod, &Identity{User: "alice", Roles: []string{RoleAdmin}}, true},
+		{writeMethod, &Identity{User: "alice", Roles: []string{"auditor"}}, false},
+		{writeMethod, nil, false},
+		{"/nexus.v4.config.ItemService/unlisted", &Identity{User: "alice", Roles: []string{RoleAdmin}}, false},
+	}
+	for _, tt := range tests {
+		err := testPolicy.Authorize(tt.method, tt.id)
+		if tt.ok != (err == nil) || (err != nil && status.Code(err) != codes.PermissionDenied) {
+			t.Errorf("Authorize(%s, %+v) = %v, want allowed %v", tt.method, tt.id, err, tt.ok)
+		}
+	}
+}
+
+func TestPolicyMerge(t *testing.T) {
+	merged := testPolicy.Merge(Policy{writeMethod: ReadOnly, "/other": Admin})
+	if merged[readMethod] != ReadOnly || merged[writeMethod] != ReadOnly || merged["/other"] != Admin {
+		t.Errorf("got %v, want the later entries to win", merged)
+	}
+	if testPolicy[writeMethod] != Admin {
+		t.Error("Merge changed the policy merged into")
+	}
+}
+
+func TestGuard(t *testing.T) {
+	g := &Guard{Authenticator: stubAuthenticator{}, Policy: testPolicy}
+	tests := []struct {
+		name   string
+		method string
+		md     []string
+		code   codes.Code
+		http   int
+	}{
+		{name: "reader", method: readMethod, md: []string{"x-user", "alice"}, http: http.StatusOK},
+		{name: "admin", method: writeMethod, md: []string{"x-user", "alice", "x-role", RoleAdmin}, http: http.StatusOK},
+		{name: "not an admin", method: writeMethod, md: []string{"x-user", "alice"}, code: codes.PermissionDenied, http: http.StatusForbidden},
+		{name: "anonymous", method: readMethod, code: codes.Unauthenticated, http: http.StatusUnauthorized},
+		{name: "rejected", method: readMethod, md: []string{"x-user", "invalid"}, code: codes.Unauthenticated, http: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(tt.md...))
+			var user string
+			_, err := g.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				id, _ := FromContext(ctx)
+				user = id.User
+				return nil, nil
+			})
+			if status.Code(err) != tt.code {
+				t.Errorf("interceptor: got %v, want %v", err, tt.code)
+			}
+			if err == nil && user != "alice" {
+				t.Errorf("the handler was called for %q, want alice", user)
+			}
+
+			// The middleware holds REST requests to the same rules.
+			h := g.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if id, ok := FromContext(r.Context()); !ok || id.User != "alice" {
+					t.Errorf("the handler was called for %+v, want alice", id)
+				}
+			}), func(*http.Request) string { return tt.method })
+			r := httptest.NewRequest(http.MethodGet, "/api/nexus/v4.1/config/items", nil)
+			for i := 0; i+1 < len(tt.md); i += 2 {
+				r.Header.Set(tt.md[i], tt.md[i+1])
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.http {
+				t.Errorf("middleware: got HTTP %d, want %d", w.Code, tt.http)
+			}
+			if challenge := w.Header().Get("WWW-Authenticate"); (tt.http == http.StatusUnauthorized) != (challenge != "") {
+				t.Errorf("middleware: got WWW-Authenticate %q with HTTP %d", challenge, w.Code)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	a := Chain(&ForwardedAuthenticator{TrustAll: true}, stubAuthenticator{})
+	tests := []struct {
+		md     []string
+		user   string
+		method string
+		err    error
+	}{
+		{md: []string{ForwardedUserKey, "alice"}, user: "alice", method: "forwarded"},
+		{md: []string{"x-user", "bob"}, user: "bob"},
+		{md: nil, err: ErrNoCredentials},
+	}
+	for _, tt := range tests {
+		id, err := a.Authenticate(context.Background(), metadata.Pairs(tt.md...))
+		if !errors.Is(err, tt.err) || (err == nil && (id.User != tt.user || id.Method != tt.method)) {
+			t.Errorf("with %v got %+v, %v, want %s authenticated by %q", tt.md, id, err, tt.user, tt.method)
+		}
+	}
+	// The first authenticator finding credentials decides.
+	if _, err := Chain(stubAuthenticator{}, &ForwardedAuthenticator{TrustAll: true}).Authenticate(context.Background(),
+		metadata.Pairs("x-user", "invalid", ForwardedUserKey, "alice")); err == nil {
+		t.Error("a later authenticator admitted credentials an earlier one rejected")
+	}
+}
@@ -0,0 +1,261 @@
+package compat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/discovery"
+)
+
+// Change is a change that breaks clients of the older snapshot.
+type Change struct {
+	// Element is the full name of the changed element, such as
+	// "nexus.v4.config.Item.itemId" or "items.description" for a property
+	// of an entity set.
+	Element string
+	Message string
+}
+
+func (c Change) String() string {
+	return c.Element + ": " + c.Message
+}
+
+// Compare returns the changes from before to after that break clients of
+// before, in the order of its definitions. Additions are not reported,
+// except for new required fields.
+func Compare(before, after *Snapshot) ([]Change, error) {
+	oldFiles, err := before.files()
+	if err != nil {
+		return nil, fmt.Errorf("old descriptors: %w", err)
+	}
+	newFiles, err := after.files()
+	if err != nil {
+		return nil, fmt.Errorf("new descriptors: %w", err)
+	}
+	c := &comparison{newFiles: newFiles}
+	oldFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		if !strings.HasPrefix(fd.Path(), "google/protobuf/") {
+			c.file(fd)
+		}
+		return true
+	})
+	if before.Bindings != nil && after.Bindings != nil {
+		c.bindings(before.Bindings, after.Bindings)
+	}
+	return c.changes, nil
+}
+
+type comparison struct {
+	newFiles *protoregistry.Files
+	changes  []Change
+}
+
+func (c *comparison) report(element interface{}, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{Element: fmt.Sprint(element), Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *comparison) file(fd protoreflect.FileDescriptor) {
+	c.messages(fd.Messages())
+	c.enums(fd.Enums())
+	services := fd.Services()
+	for i := 0; i < services.Len(); i++ {
+		c.service(services.Get(i))
+	}
+}
+
+func (c *comparison) messages(messages protoreflect.MessageDescriptors) {
+	for i := 0; i < messages.Len(); i++ {
+		before := messages.Get(i)
+		if before.IsMapEntry() {
+			continue
+		}
+		d, err := c.newFiles.FindDescriptorByName(before.FullName())
+		after, ok := d.(protoreflect.MessageDescriptor)
+		if err != nil || !ok {
+			c.report(before.FullName(), "message removed")
+			continue
+		}
+		c.message(before, after)
+		c.messages(before.Messages())
+		c.enums(before.Enums())
+	}
+}
+
+func (c *comparison) message(before, after protoreflect.MessageDescriptor) {
+	fields := before.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.Get(i)
+		nf := after.Fields().ByName(f.Name())
+		if nf == nil {
+			if renamed := after.Fields().ByNumber(f.Number()); renamed != nil {
+				c.report(f.FullName(), "field %d renamed to %s", f.Number(), renamed.Name())
+			} else {
+				c.report(f.FullName(), "field removed")
+			}
+			continue
+		}
+		if f.Number() != nf.Number() {
+			c.report(f.FullName(), "field number changed from %d to %d", f.Number(), nf.Number())
+		}
+		if t, nt := typeName(f), typeName(nf); t != nt {
+			c.report(f.FullName(), "type changed from %s to %s", t, nt)
+		}
+		if f.Cardinality() != nf.Cardinality() {
+			c.report(f.FullName(), "changed from %s to %s", f.Cardinality(), nf.Cardinality())
+		}
+		if oneofName(f) != oneofName(nf) {
+			c.report(f.FullName(), "moved from oneof %q to oneof %q", oneofName(f), oneofName(nf))
+		}
+	}
+	newFields := after.Fields()
+	for i := 0; i < newFields.Len(); i++ {
+		nf := newFields.Get(i)
+		if nf.Cardinality() == protoreflect.Required && fields.ByName(nf.Name()) == nil {
+			c.report(nf.FullName(), "required field added")
+		}
+	}
+}
+
+// typeName returns the type of f without its cardinality.
+func typeName(f protoreflect.FieldDescriptor) string {
+	switch {
+	case f.IsMap():
+		return fmt.Sprintf("map<%s, %s>", typeName(f.MapKey()), typeName(f.MapValue()))
+	case f.Message() != nil:
+		return string(f.Message().FullName())
+	case f.Enum() != nil:
+		return string(f.Enum().FullName())
+	}
+	return f.Kind().String()
+}
+
+func oneofName(f protoreflect.FieldDescriptor) string {
+	if o := f.ContainingOneof(); o != nil && !o.IsSynthetic() {
+		return string(o.Name())
+	}
+	return ""
+}
+
+func (c *comparison) enums(enums protoreflect.EnumDescriptors) {
+	for i := 0; i < enums.Len(); i++ {
+		before := enums.Get(i)
+		d, err := c.newFiles.FindDescriptorByName(before.FullName())
+		after, ok := d.(protoreflect.EnumDescriptor)
+		if err != nil || !ok {
+			c.report(before.FullName(), "enum removed")
+			continue
+		}
+		values := before.Values()
+		for j := 0; j < values.Len(); j++ {
+			v := values.Get(j)
+			nv := after.Values().ByName(v.Name())
+			switch {
+			case nv == nil:
+				c.report(v.FullName(), "enum value removed")
+			case v.Number() != nv.Number():
+				c.report(v.FullName(), "enum value number changed from %d to %d", v.Number(), nv.Number())
+			}
+		}
+	}
+}
+
+func (c *comparison) service(before protoreflect.ServiceDescriptor) {
+	d, err := c.newFiles.FindDescriptorByName(before.FullName())
+	after, ok := d.(protoreflect.ServiceDescriptor)
+	if err != nil || !ok {
+		c.report(before.FullName(), "service removed")
+		return
+	}
+	oldVersion, _, _ := discovery.ServiceVersion(before)
+	newVersion, _, _ := discovery.ServiceVersion(after)
+	if oldVersion.Major != newVersion.Major {
+		c.report(before.FullName(), "major API version changed from %s to %s", oldVersion.Major, newVersion.Major)
+	}
+	methods := before.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		m := methods.Get(i)
+		nm := after.Methods().ByName(m.Name())
+		if nm == nil {
+			c.report(m.FullName(), "RPC removed")
+			continue
+		}
+		if m.Input().FullName() != nm.Input().FullName() {
+			c.report(m.FullName(), "request type changed from %s to %s", m.Input().FullName(), nm.Input().FullName())
+		}
+		if m.Output().FullName() != nm.Output().FullName() {
+			c.report(m.FullName(), "response type changed from %s to %s", m.Output().FullName(), nm.Output().FullName())
+		}
+		if m.IsStreamingClient() != nm.IsStreamingClient() || m.IsStreamingServer() != nm.IsStreamingServer() {
+			c.report(m.FullName(), "streaming changed")
+		}
+		method, path, err := discovery.MethodHTTP(m)
+		if err != nil {
+			c.report(m.FullName(), "%v", err)
+			continue
+		}
+		newMethod, newPath, err := discovery.MethodHTTP(nm)
+		if err != nil {
+			c.report(m.FullName(), "%v", err)
+			continue
+		}
+		if method != newMethod || path != newPath {
+			c.report(m.FullName(), "HTTP route changed from %s to %s", route(method, path), route(newMethod, newPath))
+		}
+	}
+}
+
+func route(method, path string) string {
+	if method == "" {
+		return "none"
+	}
+	return method + " " + path
+}
+
+func (c *comparison) bindings(before, after []*edm.EdmEntityBinding) {
+	sets := make(map[string]*edm.EdmEntityBinding, len(after))
+	for _, b := range after {
+		sets[b.EntitySet.Name] = b
+	}
+	for _, b := range before {
+		nb, ok := sets[b.EntitySet.Name]
+		if !ok {
+			c.report(b.EntitySet.Name, "entity set removed")
+			continue
+		}
+		if b.EntitySet.EntityType != nb.EntitySet.EntityType {
+			c.report(b.EntitySet.Name, "entity type changed from %s to %s", b.EntitySet.EntityType, nb.EntitySet.EntityType)
+		}
+		props := make(map[string]*edm.EdmProperty, len(nb.EntityType.Properties))
+		for _, p := range nb.EntityType.Properties {
+			props[p.Name] = p
+		}
+		for _, p := range b.EntityType.Properties {
+			element := b.EntitySet.Name + "." + p.Name
+			np, ok := props[p.Name]
+			if !ok {
+				c.report(element, "property removed")
+				continue
+			}
+			if p.Type != np.Type || p.IsCollection != np.IsCollection {
+				c.report(element, "type changed from %s to %s", edmType(p), edmType(np))
+			}
+			if p.IsFilterable && !np.IsFilterable {
+				c.report(element, "no longer filterable")
+			}
+			if p.IsSortable && !np.IsSortable {
+				c.report(element, "no longer sortable")
+			}
+		}
+	}
+}
+
+func edmType(p *edm.EdmProperty) string {
+	if p.IsCollection {
+		return "Collection(" + p.Type + ")"
+	}
+	return p.Type
+}
@@ -0,0 +1,338 @@
+package compat
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// option returns the wire encoding of an option numbered num whose value
+// is a message of the given string fields.
+func option(num protowire.Number, fields map[protowire.Number]string) []byte {
+	var msg []byte
+	for n, f := range fields {
+		msg = protowire.AppendTag(msg, n, protowire.BytesType)
+		msg = protowire.AppendString(msg, f)
+	}
+	b := protowire.AppendTag(nil, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func serviceOptions(major, minor string) *descriptorpb.ServiceOptions {
+	opts := &descriptorpb.ServiceOptions{}
+	opts.ProtoReflect().SetUnknown(option(2000, map[protowire.Number]string{1: major, 2: minor}))
+	return opts
+}
+
+// getOptions returns the options of a method routed at GET path.
+func getOptions(path string) *descriptorpb.MethodOptions {
+	opts := &descriptorpb.MethodOptions{}
+	opts.ProtoReflect().SetUnknown(option(1000, map[protowire.Number]string{5: path}))
+	return opts
+}
+
+// widgetsFile returns the descriptor of a widgets API at v1.0.
+func widgetsFile() *descriptorpb.FileDescriptorProto {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+			JsonName: proto.String(name),
+		}
+	}
+	kind := field("kind", 3, descriptorpb.FieldDescriptorProto_TYPE_ENUM)
+	kind.TypeName = proto.String(".test.v1.config.Kind")
+	circle := field("circle", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	circle.OneofIndex = proto.Int32(0)
+	square := field("square", 5, descriptorpb.FieldDescriptorProto_TYPE_STRING)
+	square.OneofIndex = proto.Int32(0)
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test/v1/config/widgets.proto"),
+		Package: proto.String("test.v1.config"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Widget"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					field("size", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+					kind,
+					circle,
+					square,
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("shape")}},
+			},
+			{Name: proto.String("Gadget")},
+		},
+		EnumType: []*descriptorpb.EnumDescriptorProto{
+			{
+				Name: proto.String("Kind"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("KIND_UNKNOWN"), Number: proto.Int32(0)},
+					{Name: proto.String("KIND_ROUND"), Number: proto.Int32(1)},
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("WidgetService"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name:       proto.String("getWidget"),
+						InputType:  proto.String(".test.v1.config.Widget"),
+						OutputType: proto.String(".test.v1.config.Widget"),
+						Options:    getOptions("/test/v1/config/widgets/{name}"),
+					},
+					{
+						Name:       proto.String("getGadget"),
+						InputType:  proto.String(".test.v1.config.Gadget"),
+						OutputType: proto.String(".test.v1.config.Gadget"),
+					},
+				},
+				Options: serviceOptions("1", "0"),
+			},
+		},
+	}
+}
+
+func snapshotOf(fd *descriptorpb.FileDescriptorProto) *Snapshot {
+	return &Snapshot{Descriptors: &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fd}}}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(fd *descriptorpb.FileDescriptorProto)
+		want   []string
+	}{
+		{"unchanged", func(fd *descriptorpb.FileDescriptorProto) {}, nil},
+		{
+			"field added",
+			func(fd *descriptorpb.FileDescriptorProto) {
+				fd.MessageType[0].Field = append(fd.MessageType[0].Field, &descriptorpb.FieldDescriptorProto{
+					Name: proto.String("color"), Number: proto.Int32(6), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				})
+			},
+			nil,
+		},
+		{
+			"required field added",
+			func(fd *descriptorpb.FileDescriptorProto) {
+				fd.MessageType[0].Field = append(fd.MessageType[0].Field, &descriptorpb.FieldDescriptorProto{
+					Name: proto.String("id"), Number: proto.Int32(6), Label: descriptorpb.FieldDescriptorProto_LABEL_REQUIRED.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				})
+			},
+			[]string{"test.v1.config.Widget.id: required field added"},
+		},
+		{
+			"field renumbered",
+			func(fd *descriptorpb.FileDescriptorProto) { fd.MessageType[0].Field[1].Number = proto.Int32(6) },
+			[]string{"test.v1.config.Widget.size: field number changed from 2 to 6"},
+		},
+		{
+			"field renamed",
+			func(fd *descriptorpb.FileDescriptorProto) { fd.MessageType[0].Field[0].Name = proto.String("title") },
+			[]string{"test.v1.config.Widget.name: field 1 renamed to title"},
+		},
+		{
+			"field removed",
+			func(fd *descriptorpb.FileDescriptorProto) { fd.MessageType[0].Field = fd.MessageType[0].Field[:4] },
+			[]string{"test.v1.config.Widget.square: field removed"},
+		},
+		{
+			"type changed",
+			func(fd *descriptorpb.FileDescriptorProto) {
+				fd.MessageType[0].Field[1].Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+			},
+			[]string{"test.v1.config.Widget.size: type changed from int32 to int64"},
+		},
+		{
+			"made repeated",
+			func(fd *descriptorpb.FileDescriptorProto) {
+				fd.MessageType[0].Field[1].Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			},
+			[]string{"test.v1.config.Widget.size: changed from optional to repeated"},
+		},
+		{
+			"moved out of a oneof",
+			func(fd *descriptorpb.FileDescriptorProto) { fd.MessageType[0].Field[4].OneofIndex = nil },
+			[]string{`test.v1.config.Widget.square: moved from oneof "shape" to oneof ""`},
+		},
+		{
+			"message removed",
+			func(fd *descriptorpb.FileDescriptorProto) {
+				fd.MessageType = fd.MessageType[:1]
+				fd.Service[0].Method = fd.Service[0].Method[:1]
+			},
+			[]string{"test.v1.config.Gadget: message removed", "test.v1.config.WidgetService.getGadget: RPC removed"},
+		},
+		{
+			"enum value removed",
+			func(fd *descriptorpb.FileDescriptorProto) { fd.EnumType[0].Value = fd.EnumType[0].Value[:1] },
+			[]string{"test.v1.config.KIND_ROUND: enum value removed"},
+		},
+		{
+			"enum value renumbered",
+			func(fd *descriptorpb.FileDescriptorProto) { fd.EnumType[0].Value[1].Number = proto.Int32(2) },
+			[]string{"test.v1.config.KIND_ROUND: enum value number changed from 1 to 2"},
+		},
+		{
+			"request type changed",
+			func(fd *descriptorpb.FileDescriptorProto) {
+				fd.Service[0].Method[0].InputType = proto.String(".test.v1.config.Gadget")
+			},
+			[]string{"test.v1.config.WidgetService.getWidget: request type changed from test.v1.config.Widget to test.v1.config.Gadget"},
+		},
+		{
+			"streaming",
+			func(fd *descriptorpb.FileDescriptorProto) { fd.Service[0].Method[1].ServerStreaming = proto.Bool(true) },
+			[]string{"test.v1.config.WidgetService.getGadget: streaming changed"},
+		},
+		{
+			"route changed",
+			func(fd *descriptorpb.FileDescriptorProto) {
+				fd.Service[0].Method[0].Options = getOptions("/test/v1/config/widget/{name}")
+				fd.Service[0].Method[1].Options = getOptions("/test/v1/config/gadgets")
+			},
+			[]string{
+				"test.v1.config.WidgetService.getWidget: HTTP route changed from GET /test/v1/config/widgets/{name} to GET /test/v1/config/widget/{name}",
+				"test.v1.config.WidgetService.getGadget: HTTP route changed from none to GET /test/v1/config/gadgets",
+			},
+		},
+		{"minor version", func(fd *descriptorpb.FileDescriptorProto) { fd.Service[0].Options = serviceOptions("1", "1") }, nil},
+		{
+			"major version",
+			func(fd *descriptorpb.FileDescriptorProto) { fd.Service[0].Options = serviceOptions("2", "0") },
+			[]string{"test.v1.config.WidgetService: major API version changed from 1 to 2"},
+		},
+		{
+			"service removed",
+			func(fd *descriptorpb.FileDescriptorProto) { fd.Service = nil },
+			[]string{"test.v1.config.WidgetService: service removed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := widgetsFile()
+			tt.change(after)
+			changes, err := Compare(snapshotOf(widgetsFile()), snapshotOf(after))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	invalid := widgetsFile()
+	invalid.MessageType[0].Field[1].Number = proto.Int32(1)
+	if _, err := Compare(snapshotOf(widgetsFile()), snapshotOf(invalid)); err == nil {
+		t.Error("comparing invalid descriptors: got no error")
+	}
+}
+
+// widgetBindings returns the bindings of a widgets entity set.
+func widgetBindings() []*edm.EdmEntityBinding {
+	return []*edm.EdmEntityBinding{
+		{
+			EntityType: &edm.EdmEntityType{Name: "widget", Properties: []*edm.EdmProperty{
+				{Name: "name", Type: string(edm.EdmString), IsFilterable: true, IsSortable: true},
+				{Name: "size", Type: string(edm.EdmInt32), IsFilterable: true},
+				{Name: "labels", Type: string(edm.EdmString), IsCollection: true},
+			}},
+			EntitySet: &edm.EdmEntitySet{Name: "widgets", EntityType: "test.v1.widget"},
+		},
+	}
+}
+
+func TestCompareBindings(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(b []*edm.EdmEntityBinding) []*edm.EdmEntityBinding
+		want   []string
+	}{
+		{"unchanged", func(b []*edm.EdmEntityBinding) []*edm.EdmEntityBinding { return b }, nil},
+		{
+			"restrictions lifted",
+			func(b []*edm.EdmEntityBinding) []*edm.EdmEntityBinding {
+				b[0].EntityType.Properties[2].IsFilterable = true
+				b[0].EntityType.Properties[1].IsSortable = true
+				return b
+			},
+			nil,
+		},
+		{
+			"restrictions added",
+			func(b []*edm.EdmEntityBinding) []*edm.EdmEntityBinding {
+				b[0].EntityType.Properties[0].IsFilterable = false
+				b[0].EntityType.Properties[0].IsSortable = false
+				return b
+			},
+			[]string{"widgets.name: no longer filterable", "widgets.name: no longer sortable"},
+		},
+		{
+			"type changed",
+			func(b []*edm.EdmEntityBinding) []*edm.EdmEntityBinding {
+				b[0].EntityType.Properties[2].IsCollection = false
+				return b
+			},
+			[]string{"widgets.labels: type changed from Collection(Edm.String) to Edm.String"},
+		},
+		{
+			"property removed",
+			func(b []*edm.EdmEntityBinding) []*edm.EdmEntityBinding {
+				b[0].EntityType.Properties = b[0].EntityType.Properties[1:]
+				return b
+			},
+			[]string{"widgets.name: property removed"},
+		},
+		{
+			"entity type changed",
+			func(b []*edm.EdmEntityBinding) []*edm.EdmEntityBinding {
+				b[0].EntitySet.EntityType = "test.v1.gadget"
+				return b
+			},
+			[]string{"widgets: entity type changed from test.v1.widget to test.v1.gadget"},
+		},
+		{"entity set removed", func(b []*edm.EdmEntityBinding) []*edm.EdmEntityBinding { return b[:0] }, []string{"widgets: entity set removed"}},
+		// Snapshots read from a bare descriptor set are only compared on
+		// their descriptors.
+		{"no bindings", func(b []*edm.EdmEntityBinding) []*edm.EdmEntityBinding { return nil }, nil},
+		{
+			"entity set renamed",
+			func(b []*edm.EdmEntityBinding) []*edm.EdmEntityBinding {
+				b[0].EntitySet.Name = "gadgets"
+				return b
+			},
+			[]string{"widgets: entity set removed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after := snapshotOf(widgetsFile()), snapshotOf(widgetsFile())
+			before.Bindings = widgetBindings()
+			after.Bindings = tt.change(widgetBindings())
+			changes, err := Compare(before, after)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
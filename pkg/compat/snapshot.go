@@ -0,0 +1,102 @@
+// Package compat detects changes to the API definitions that break existing
+// clients.
+//
+// A Snapshot captures a revision of the API: the protobuf descriptors
+// generated from the .proto files and the EDM bindings generated from
+// itemModel.yaml. Compare reports what a newer snapshot breaks in an older
+// one, such as renumbered fields, removed RPCs, changed HTTP paths or
+// properties that are no longer filterable.
+package compat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Snapshot is a revision of the API definitions.
+type Snapshot struct {
+	// Descriptors holds the files of the API and the files they import.
+	Descriptors *descriptorpb.FileDescriptorSet
+	// Bindings holds the EDM entity bindings of the API. Snapshots read
+	// from a bare descriptor set have none, and are only compared on their
+	// descriptors.
+	Bindings []*edm.EdmEntityBinding
+}
+
+// snapshotFile is the JSON form of a Snapshot. Descriptors are kept in the
+// protobuf wire format, which unlike JSON preserves the custom options of
+// the Nutanix API.
+type snapshotFile struct {
+	Descriptors []byte                  `json:"descriptors"`
+	Bindings    []*edm.EdmEntityBinding `json:"bindings"`
+}
+
+// Current returns the snapshot of files, the files they import, and
+// bindings as linked into the running binary.
+func Current(files []protoreflect.FileDescriptor, bindings []*edm.EdmEntityBinding) *Snapshot {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] || fd.IsPlaceholder() {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	for _, fd := range files {
+		add(fd)
+	}
+	return &Snapshot{Descriptors: set, Bindings: bindings}
+}
+
+// MarshalJSON encodes s in the format read by Load.
+func (s *Snapshot) MarshalJSON() ([]byte, error) {
+	descriptors, err := proto.Marshal(s.Descriptors)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(snapshotFile{Descriptors: descriptors, Bindings: s.Bindings})
+}
+
+// Load reads a snapshot written as JSON by MarshalJSON or, for files not
+// ending in .json, a binary FileDescriptorSet as written by
+// protoc --include_imports --descriptor_set_out.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f snapshotFile
+	if strings.HasSuffix(path, ".json") {
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	} else {
+		f.Descriptors = data
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(f.Descriptors, set); err != nil {
+		return nil, fmt.Errorf("%s: descriptors: %w", path, err)
+	}
+	return &Snapshot{Descriptors: set, Bindings: f.Bindings}, nil
+}
+
+// files resolves the descriptors of s. Imports missing from the set, such
+// as the files declaring custom options, are left unresolved.
+func (s *Snapshot) files() (*protoregistry.Files, error) {
+	return protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(s.Descriptors)
+}
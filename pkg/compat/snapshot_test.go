@@ -0,0 +1,101 @@
+package compat
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	edmconfig "github.com/nutanix/ntnx-api-golang-mock-pc/generated-code/edm/nexus/v4/config"
+)
+
+func currentSnapshot() *Snapshot {
+	return Current([]protoreflect.FileDescriptor{pb.File_nexus_v4_config_item_service_proto}, edmconfig.GetAllEntityBindings())
+}
+
+func TestCurrent(t *testing.T) {
+	s := currentSnapshot()
+	var paths []string
+	for _, fd := range s.Descriptors.File {
+		paths = append(paths, fd.GetName())
+	}
+	last := pb.File_nexus_v4_config_item_service_proto.Path()
+	if len(paths) < 2 || paths[len(paths)-1] != last {
+		t.Errorf("got files %v, want the imports of %s before it", paths, last)
+	}
+	seen := make(map[string]bool)
+	for _, fd := range s.Descriptors.File {
+		if seen[fd.GetName()] {
+			t.Errorf("got %s twice", fd.GetName())
+		}
+		for _, dep := range fd.GetDependency() {
+			if !seen[dep] && slices.Contains(paths, dep) {
+				t.Errorf("got %s before its import %s", fd.GetName(), dep)
+			}
+		}
+		seen[fd.GetName()] = true
+	}
+	changes, err := Compare(s, currentSnapshot())
+	if err != nil || changes != nil {
+		t.Errorf("comparing the current snapshot with itself: got %v, %v", changes, err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	s := currentSnapshot()
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonPath := filepath.Join(dir, "api-snapshot.json")
+	if err := os.WriteFile(jsonPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(loaded.Descriptors, s.Descriptors) {
+		t.Error("the descriptors loaded differ from those written")
+	}
+	if !reflect.DeepEqual(loaded.Bindings, s.Bindings) {
+		t.Error("the bindings loaded differ from those written")
+	}
+	// The custom options of the API survive the round trip.
+	if changes, err := Compare(loaded, s); err != nil || changes != nil {
+		t.Errorf("comparing the snapshot loaded: got %v, %v", changes, err)
+	}
+
+	set, err := proto.Marshal(s.Descriptors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	setPath := filepath.Join(dir, "api.pb")
+	if err := os.WriteFile(setPath, set, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = Load(setPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(loaded.Descriptors, s.Descriptors) || loaded.Bindings != nil {
+		t.Error("got a descriptor set differing from that written, or bindings")
+	}
+
+	badPath := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(badPath, []byte(`{"descriptors": "AQ=="}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{filepath.Join(dir, "missing.json"), badPath} {
+		if _, err := Load(path); err == nil {
+			t.Errorf("Load(%s): got no error", path)
+		}
+	}
+}
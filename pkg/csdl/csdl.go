@@ -0,0 +1,148 @@
+// Package csdl publishes generated EDM bindings as an OData CSDL $metadata
+// document, in the XML and the JSON representation of OData V4.01.
+//
+// Each binding contributes an entity type and an entity set. The
+// IsFilterable and IsSortable capabilities of its properties are rendered
+// as the FilterRestrictions and SortRestrictions annotations of the
+// Capabilities vocabulary on the entity set.
+package csdl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+)
+
+// Version is the OData version of the documents.
+const Version = "4.01"
+
+// ContainerName is the name of the entity container of a Document.
+const ContainerName = "Container"
+
+// DefaultKey is the key of entity types Build is given no other key for.
+const DefaultKey = "extId"
+
+// The Capabilities vocabulary and the alias its terms are qualified with.
+const (
+	capabilitiesNamespace = "Org.OData.Capabilities.V1"
+	capabilitiesAlias     = "Capabilities"
+	capabilitiesURI       = "https://oasis-tcs.github.io/odata-vocabularies/vocabularies/Org.OData.Capabilities.V1"
+)
+
+// Document is the CSDL description of a schema of entity types and the
+// entity sets of its container.
+type Document struct {
+	Namespace   string
+	EntityTypes []*EntityType
+	EntitySets  []*EntitySet
+}
+
+// EntityType describes an entity type and its structural properties.
+type EntityType struct {
+	Name       string
+	Key        []string
+	Properties []*Property
+}
+
+// Property describes a structural property. Type is the name of an Edm
+// primitive type.
+type Property struct {
+	Name       string
+	Type       string
+	Collection bool
+	Nullable   bool
+}
+
+// EntitySet describes an entity set and the properties of its entity type
+// that cannot be used in $filter and $orderby.
+type EntitySet struct {
+	Name string
+	// EntityType is the qualified name of the entity type.
+	EntityType               string
+	IncludeInServiceDocument bool
+	NonFilterable            []string
+	NonSortable              []string
+}
+
+// Build returns the Document describing bindings, which must share a
+// namespace. keys maps the names of entity types whose key is not
+// DefaultKey to the names of their key properties.
+func Build(bindings []*edm.EdmEntityBinding, keys map[string][]string) (*Document, error) {
+	doc := &Document{}
+	for _, b := range bindings {
+		namespace, _, ok := cutLast(b.EntitySet.EntityType, ".")
+		if !ok || (doc.Namespace != "" && namespace != doc.Namespace) {
+			return nil, fmt.Errorf("entity set %s: entity type %s is not in namespace %s", b.EntitySet.Name, b.EntitySet.EntityType, doc.Namespace)
+		}
+		doc.Namespace = namespace
+
+		key, ok := keys[b.EntityType.Name]
+		if !ok {
+			key = []string{DefaultKey}
+		}
+		t := &EntityType{Name: b.EntityType.Name, Key: key}
+		set := &EntitySet{
+			Name:                     b.EntitySet.Name,
+			EntityType:               b.EntitySet.EntityType,
+			IncludeInServiceDocument: b.EntitySet.IncludeInServiceDocument,
+		}
+		for _, p := range b.EntityType.Properties {
+			t.Properties = append(t.Properties, &Property{
+				Name:       p.Name,
+				Type:       p.Type,
+				Collection: p.IsCollection,
+				Nullable:   !contains(key, p.Name),
+			})
+			if !p.IsFilterable {
+				set.NonFilterable = append(set.NonFilterable, p.Name)
+			}
+			if !p.IsSortable {
+				set.NonSortable = append(set.NonSortable, p.Name)
+			}
+		}
+		for _, k := range key {
+			if t.Property(k) == nil {
+				return nil, fmt.Errorf("entity type %s has no key property %s", t.Name, k)
+			}
+		}
+		doc.EntityTypes = append(doc.EntityTypes, t)
+		doc.EntitySets = append(doc.EntitySets, set)
+	}
+	return doc, nil
+}
+
+// Property returns the property of t with the given name, or nil.
+func (t *EntityType) Property(name string) *Property {
+	for _, p := range t.Properties {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// typeName returns the CSDL type of p.
+func (p *Property) typeName() string {
+	if p.Collection {
+		return "Collection(" + p.Type + ")"
+	}
+	return p.Type
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+len(sep):], true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+package csdl
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+)
+
+// testBindings returns the bindings of a disk entity set, keyed by extId,
+// and of a tag entity set keyed by its name and value.
+func testBindings() []*edm.EdmEntityBinding {
+	return []*edm.EdmEntityBinding{
+		{
+			EntityType: &edm.EdmEntityType{Name: "disk", Properties: []*edm.EdmProperty{
+				{Name: "extId", Type: string(edm.EdmString), IsFilterable: true},
+				{Name: "size", Type: string(edm.EdmInt64), IsFilterable: true, IsSortable: true},
+				{Name: "labels", Type: string(edm.EdmString), IsCollection: true},
+			}},
+			EntitySet: &edm.EdmEntitySet{Name: "disks", EntityType: "storage.v1.disk", IncludeInServiceDocument: true},
+		},
+		{
+			EntityType: &edm.EdmEntityType{Name: "tag", Properties: []*edm.EdmProperty{
+				{Name: "name", Type: string(edm.EdmString), IsFilterable: true, IsSortable: true},
+				{Name: "value", Type: string(edm.EdmString), IsFilterable: true, IsSortable: true},
+			}},
+			EntitySet: &edm.EdmEntitySet{Name: "tags", EntityType: "storage.v1.tag"},
+		},
+	}
+}
+
+func testDocument(t *testing.T) *Document {
+	t.Helper()
+	doc, err := Build(testBindings(), map[string][]string{"tag": {"name", "value"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestBuild(t *testing.T) {
+	doc := testDocument(t)
+	if doc.Namespace != "storage.v1" {
+		t.Errorf("got namespace %q, want storage.v1", doc.Namespace)
+	}
+	disk, tag := doc.EntityTypes[0], doc.EntityTypes[1]
+	if !slices.Equal(disk.Key, []string{"extId"}) || !slices.Equal(tag.Key, []string{"name", "value"}) {
+		t.Errorf("got keys %v and %v, want [extId] and [name value]", disk.Key, tag.Key)
+	}
+	if p := disk.Property("extId"); p == nil || p.Nullable {
+		t.Errorf("got key property %+v, want it not nullable", p)
+	}
+	if p := disk.Property("labels"); p == nil || !p.Nullable || !p.Collection || p.typeName() != "Collection(Edm.String)" {
+		t.Errorf("got property %+v, want a nullable collection of strings", p)
+	}
+	if p := disk.Property("name"); p != nil {
+		t.Errorf("got property %+v of disk, want none", p)
+	}
+	disks, tags := doc.EntitySets[0], doc.EntitySets[1]
+	if !slices.Equal(disks.NonFilterable, []string{"labels"}) || !slices.Equal(disks.NonSortable, []string{"extId", "labels"}) {
+		t.Errorf("got restrictions %v and %v of disks, want [labels] and [extId labels]", disks.NonFilterable, disks.NonSortable)
+	}
+	if tags.NonFilterable != nil || tags.NonSortable != nil || tags.IncludeInServiceDocument {
+		t.Errorf("got entity set %+v, want no restrictions, left out of the service document", tags)
+	}
+
+	bindings := testBindings()
+	bindings[1].EntitySet.EntityType = "compute.v1.tag"
+	if _, err := Build(bindings, map[string][]string{"tag": {"name"}}); err == nil || err.Error() != "entity set tags: entity type compute.v1.tag is not in namespace storage.v1" {
+		t.Errorf("building bindings of two namespaces: got %v", err)
+	}
+	if _, err := Build(testBindings(), nil); err == nil || err.Error() != "entity type tag has no key property extId" {
+		t.Errorf("building an entity type without its key: got %v", err)
+	}
+}
+
+func TestXML(t *testing.T) {
+	want := `<?xml version="1.0" encoding="utf-8"?>
+<edmx:Edmx xmlns:edmx="http://docs.oasis-open.org/odata/ns/edmx" Version="4.01">
+  <edmx:Reference Uri="https://oasis-tcs.github.io/odata-vocabularies/vocabularies/Org.OData.Capabilities.V1.xml">
+    <edmx:Include Namespace="Org.OData.Capabilities.V1" Alias="Capabilities"/>
+  </edmx:Reference>
+  <edmx:DataServices>
+    <Schema xmlns="http://docs.oasis-open.org/odata/ns/edm" Namespace="storage.v1">
+      <EntityType Name="disk">
+        <Key>
+          <PropertyRef Name="extId"/>
+        </Key>
+        <Property Name="extId" Type="Edm.String" Nullable="false"/>
+        <Property Name="size" Type="Edm.Int64"/>
+        <Property Name="labels" Type="Collection(Edm.String)"/>
+      </EntityType>
+      <EntityType Name="tag">
+        <Key>
+          <PropertyRef Name="name"/>
+          <PropertyRef Name="value"/>
+        </Key>
+        <Property Name="name" Type="Edm.String" Nullable="false"/>
+        <Property Name="value" Type="Edm.String" Nullable="false"/>
+      </EntityType>
+      <EntityContainer Name="Container">
+        <EntitySet Name="disks" EntityType="storage.v1.disk">
+          <Annotation Term="Capabilities.FilterRestrictions">
+            <Record>
+              <PropertyValue Property="NonFilterableProperties">
+                <Collection>
+                  <PropertyPath>labels</PropertyPath>
+                </Collection>
+              </PropertyValue>
+            </Record>
+          </Annotation>
+          <Annotation Term="Capabilities.SortRestrictions">
+            <Record>
+              <PropertyValue Property="NonSortableProperties">
+                <Collection>
+                  <PropertyPath>extId</PropertyPath>
+                  <PropertyPath>labels</PropertyPath>
+                </Collection>
+              </PropertyValue>
+            </Record>
+          </Annotation>
+        </EntitySet>
+        <EntitySet Name="tags" EntityType="storage.v1.tag" IncludeInServiceDocument="false">
+        </EntitySet>
+      </EntityContainer>
+    </Schema>
+  </edmx:DataServices>
+</edmx:Edmx>
+`
+	got := string(testDocument(t).XML())
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	var v interface{}
+	if err := xml.Unmarshal([]byte(got), &v); err != nil {
+		t.Errorf("the document is not well-formed: %v", err)
+	}
+}
+
+func TestXMLEscaping(t *testing.T) {
+	bindings := testBindings()
+	bindings[0].EntityType.Properties[2].Name = `a<"&">b`
+	doc, err := Build(bindings, map[string][]string{"tag": {"name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(doc.XML())
+	for _, want := range []string{
+		`<Property Name="a&lt;&#34;&amp;&#34;&gt;b" Type="Collection(Edm.String)"/>`,
+		`<PropertyPath>a&lt;&#34;&amp;&#34;&gt;b</PropertyPath>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("the document does not contain %s:\n%s", want, got)
+		}
+	}
+	if err := xml.Unmarshal([]byte(got), new(interface{})); err != nil {
+		t.Errorf("the document is not well-formed: %v", err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	want := `{
+  "$Version": "4.01",
+  "$EntityContainer": "storage.v1.Container",
+  "$Reference": {
+    "https://oasis-tcs.github.io/odata-vocabularies/vocabularies/Org.OData.Capabilities.V1.json": {
+      "$Include": [
+        {
+          "$Namespace": "Org.OData.Capabilities.V1",
+          "$Alias": "Capabilities"
+        }
+      ]
+    }
+  },
+  "storage.v1": {
+    "disk": {
+      "$Kind": "EntityType",
+      "$Key": [
+        "extId"
+      ],
+      "extId": {},
+      "size": {
+        "$Type": "Edm.Int64",
+        "$Nullable": true
+      },
+      "labels": {
+        "$Collection": true,
+        "$Nullable": true
+      }
+    },
+    "tag": {
+      "$Kind": "EntityType",
+      "$Key": [
+        "name",
+        "value"
+      ],
+      "name": {},
+      "value": {}
+    },
+    "Container": {
+      "$Kind": "EntityContainer",
+      "disks": {
+        "$Collection": true,
+        "$Type": "storage.v1.disk",
+        "@Capabilities.FilterRestrictions": {
+          "NonFilterableProperties": [
+            "labels"
+          ]
+        },
+        "@Capabilities.SortRestrictions": {
+          "NonSortableProperties": [
+            "extId",
+            "labels"
+          ]
+        }
+      },
+      "tags": {
+        "$Collection": true,
+        "$Type": "storage.v1.tag",
+        "$IncludeInServiceDocument": false
+      }
+    }
+  }
+}`
+	got := string(testDocument(t).JSON())
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if !json.Valid([]byte(got)) {
+		t.Error("the document is not valid JSON")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	h := testDocument(t).Handler()
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"default", http.MethodGet, "/$metadata", "", http.StatusOK, "application/xml"},
+		{"accept json", http.MethodGet, "/$metadata", "application/xml;q=0.5, application/json;q=0.9", http.StatusOK, "application/json"},
+		{"accept xml", http.MethodGet, "/$metadata", "application/xml", http.StatusOK, "application/xml"},
+		{"format json", http.MethodGet, "/$metadata?$format=json", "application/xml", http.StatusOK, "application/json"},
+		{"format media type", http.MethodGet, "/$metadata?$format=application/json;odata.metadata=full", "", http.StatusOK, "application/json"},
+		{"format xml", http.MethodGet, "/$metadata?$format=xml", "application/json", http.StatusOK, "application/xml"},
+		{"head", http.MethodHead, "/$metadata", "", http.StatusOK, "application/xml"},
+		{"post", http.MethodPost, "/$metadata", "", http.StatusMethodNotAllowed, "text/plain; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.status || w.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("got %d %s, want %d %s", w.Code, w.Header().Get("Content-Type"), tt.status, tt.contentType)
+			}
+			if tt.status == http.StatusOK && w.Header().Get("OData-Version") != Version {
+				t.Errorf("got OData-Version %q, want %s", w.Header().Get("OData-Version"), Version)
+			}
+			if tt.status == http.StatusMethodNotAllowed && w.Header().Get("Allow") != "GET, HEAD" {
+				t.Errorf("got Allow %q, want GET, HEAD", w.Header().Get("Allow"))
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package csdl
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Handler serves the document at any path. JSON is served to requests with
+// $format=json or accepting application/json, XML otherwise.
+func (d *Document) Handler() http.Handler {
+	xmlDoc, jsonDoc := d.XML(), d.JSON()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("OData-Version", Version)
+		if wantsJSON(r) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(jsonDoc)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write(xmlDoc)
+	})
+}
+
+func wantsJSON(r *http.Request) bool {
+	if format := formatParam(r.URL.RawQuery); format != "" {
+		return format == "json" || strings.HasPrefix(format, "application/json")
+	}
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(accept, ";")
+		if strings.TrimSpace(mediaType) == "application/json" {
+			return true
+		}
+	}
+	return false
+}
+
+// formatParam returns the $format parameter of query. It is looked up by
+// hand as url.ParseQuery drops parameters holding a semicolon, as media
+// types with parameters such as application/json;odata.metadata=full do.
+func formatParam(query string) string {
+	for _, param := range strings.Split(query, "&") {
+		name, value, _ := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(name); err != nil || name != "$format" {
+			continue
+		}
+		if value, err := url.QueryUnescape(value); err == nil {
+			return value
+		}
+	}
+	return ""
+}
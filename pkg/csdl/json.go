@@ -0,0 +1,96 @@
+package csdl
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+)
+
+// JSON returns the document in the CSDL JSON representation.
+func (d *Document) JSON() []byte {
+	schema := object{}
+	for _, t := range d.EntityTypes {
+		et := object{{"$Kind", "EntityType"}, {"$Key", t.Key}}
+		for _, p := range t.Properties {
+			prop := object{}
+			if p.Collection {
+				prop = append(prop, member{"$Collection", true})
+			}
+			// Edm.String is the default type, and properties are not nullable
+			// unless stated otherwise.
+			if p.Type != string(edm.EdmString) {
+				prop = append(prop, member{"$Type", p.Type})
+			}
+			if p.Nullable {
+				prop = append(prop, member{"$Nullable", true})
+			}
+			et = append(et, member{p.Name, prop})
+		}
+		schema = append(schema, member{t.Name, et})
+	}
+	container := object{{"$Kind", "EntityContainer"}}
+	for _, s := range d.EntitySets {
+		set := object{{"$Collection", true}, {"$Type", s.EntityType}}
+		if !s.IncludeInServiceDocument {
+			set = append(set, member{"$IncludeInServiceDocument", false})
+		}
+		if len(s.NonFilterable) > 0 {
+			set = append(set, member{"@" + capabilitiesAlias + ".FilterRestrictions", object{{"NonFilterableProperties", s.NonFilterable}}})
+		}
+		if len(s.NonSortable) > 0 {
+			set = append(set, member{"@" + capabilitiesAlias + ".SortRestrictions", object{{"NonSortableProperties", s.NonSortable}}})
+		}
+		container = append(container, member{s.Name, set})
+	}
+	schema = append(schema, member{ContainerName, container})
+
+	doc := object{
+		{"$Version", Version},
+		{"$EntityContainer", d.Namespace + "." + ContainerName},
+		{"$Reference", object{
+			{capabilitiesURI + ".json", object{
+				{"$Include", []object{{{"$Namespace", capabilitiesNamespace}, {"$Alias", capabilitiesAlias}}}},
+			}},
+		}},
+		{d.Namespace, schema},
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		// Documents hold only strings, booleans and the types above.
+		panic(err)
+	}
+	return b
+}
+
+// object is a JSON object whose members keep their order, as is customary
+// in CSDL JSON where $-prefixed members come first.
+type object []member
+
+type member struct {
+	name  string
+	value interface{}
+}
+
+func (o object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, err := json.Marshal(m.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
@@ -0,0 +1,107 @@
+package csdl
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// XML returns the document in the CSDL XML representation.
+func (d *Document) XML() []byte {
+	w := &xmlWriter{}
+	w.line(0, `<?xml version="1.0" encoding="utf-8"?>`)
+	w.open(0, "edmx:Edmx", "xmlns:edmx", "http://docs.oasis-open.org/odata/ns/edmx", "Version", Version)
+	w.open(1, "edmx:Reference", "Uri", capabilitiesURI+".xml")
+	w.empty(2, "edmx:Include", "Namespace", capabilitiesNamespace, "Alias", capabilitiesAlias)
+	w.close(1, "edmx:Reference")
+	w.open(1, "edmx:DataServices")
+	w.open(2, "Schema", "xmlns", "http://docs.oasis-open.org/odata/ns/edm", "Namespace", d.Namespace)
+	for _, t := range d.EntityTypes {
+		w.open(3, "EntityType", "Name", t.Name)
+		w.open(4, "Key")
+		for _, k := range t.Key {
+			w.empty(5, "PropertyRef", "Name", k)
+		}
+		w.close(4, "Key")
+		for _, p := range t.Properties {
+			attrs := []string{"Name", p.Name, "Type", p.typeName()}
+			if !p.Nullable {
+				attrs = append(attrs, "Nullable", "false")
+			}
+			w.empty(4, "Property", attrs...)
+		}
+		w.close(3, "EntityType")
+	}
+	w.open(3, "EntityContainer", "Name", ContainerName)
+	for _, s := range d.EntitySets {
+		attrs := []string{"Name", s.Name, "EntityType", s.EntityType}
+		if !s.IncludeInServiceDocument {
+			attrs = append(attrs, "IncludeInServiceDocument", "false")
+		}
+		w.open(4, "EntitySet", attrs...)
+		w.restrictions(5, "FilterRestrictions", "NonFilterableProperties", s.NonFilterable)
+		w.restrictions(5, "SortRestrictions", "NonSortableProperties", s.NonSortable)
+		w.close(4, "EntitySet")
+	}
+	w.close(3, "EntityContainer")
+	w.close(2, "Schema")
+	w.close(1, "edmx:DataServices")
+	w.close(0, "edmx:Edmx")
+	return []byte(w.String())
+}
+
+// xmlWriter writes indented XML elements.
+type xmlWriter struct {
+	strings.Builder
+}
+
+func (w *xmlWriter) line(depth int, s string) {
+	w.WriteString(strings.Repeat("  ", depth))
+	w.WriteString(s)
+	w.WriteByte('\n')
+}
+
+// start returns the start tag of name with attrs, given as name and value
+// pairs.
+func start(name string, attrs []string) string {
+	var b strings.Builder
+	b.WriteString("<" + name)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		b.WriteString(" " + attrs[i] + `="`)
+		xml.EscapeText(&b, []byte(attrs[i+1]))
+		b.WriteString(`"`)
+	}
+	return b.String()
+}
+
+func (w *xmlWriter) open(depth int, name string, attrs ...string) {
+	w.line(depth, start(name, attrs)+">")
+}
+
+func (w *xmlWriter) empty(depth int, name string, attrs ...string) {
+	w.line(depth, start(name, attrs)+"/>")
+}
+
+func (w *xmlWriter) close(depth int, name string) {
+	w.line(depth, "</"+name+">")
+}
+
+// restrictions writes a Capabilities annotation listing the properties
+// that are not supported, if any.
+func (w *xmlWriter) restrictions(depth int, term, property string, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+	w.open(depth, "Annotation", "Term", capabilitiesAlias+"."+term)
+	w.open(depth+1, "Record")
+	w.open(depth+2, "PropertyValue", "Property", property)
+	w.open(depth+3, "Collection")
+	for _, p := range paths {
+		var b strings.Builder
+		xml.EscapeText(&b, []byte(p))
+		w.line(depth+4, "<PropertyPath>"+b.String()+"</PropertyPath>")
+	}
+	w.close(depth+3, "Collection")
+	w.close(depth+2, "PropertyValue")
+	w.close(depth+1, "Record")
+	w.close(depth, "Annotation")
+}
@@ -0,0 +1,213 @@
+package discovery
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	// Options of descriptors can only be read with the descriptor package
+	// linked in.
+	_ "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Field numbers of the ntnx_api_version service option and the
+// ntnx_api_http method option, declared in nexus/v4/api_version.proto and
+// nexus/v4/http_method_options.proto. The generated packages do not import
+// the Go package declaring them, so they are decoded from the unknown
+// fields of the descriptor options.
+const (
+	apiVersionOption protowire.Number = 2000
+	apiHTTPOption    protowire.Number = 1000
+)
+
+// Fields of nexus.v4.ApiVersion.
+const (
+	majorField               protowire.Number = 1
+	minorField               protowire.Number = 2
+	releaseTypeField         protowire.Number = 3
+	releaseTypeRevisionField protowire.Number = 4
+)
+
+// httpMethods maps the fields of nexus.v4.HttpMethodOptions to the HTTP
+// method they declare a path for.
+var httpMethods = map[protowire.Number]string{
+	1: "POST",
+	2: "PATCH",
+	3: "PUT",
+	4: "DELETE",
+	5: "GET",
+	6: "HEAD",
+	7: "OPTIONS",
+	8: "TRACE",
+}
+
+// Service describes a gRPC service of the Nutanix API and the REST routes
+// of its methods.
+type Service struct {
+	// Name is the full name of the service, such as
+	// "nexus.v4.config.ItemService".
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Module    string    `json:"module"`
+	Version   Version   `json:"apiVersion"`
+	Methods   []*Method `json:"methods"`
+}
+
+// Method describes an RPC and the HTTP route it is served at.
+type Method struct {
+	Name string `json:"name"`
+	// FullMethod is the gRPC full method name, such as
+	// "/nexus.v4.config.ItemService/listItems".
+	FullMethod string `json:"fullMethod"`
+	HTTPMethod string `json:"httpMethod,omitempty"`
+	// Path is the path declared by the ntnx_api_http option, such as
+	// "/nexus/v4/config/items".
+	Path string `json:"path,omitempty"`
+	// URL is the path the REST gateway serves the method at, such as
+	// "/api/nexus/v4.1/config/items".
+	URL string `json:"url,omitempty"`
+}
+
+// DescribeService describes the service registered in the global protobuf
+// registry under the given full name. It returns nil if the service carries
+// no ntnx_api_version option.
+func DescribeService(name string) (*Service, error) {
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("service %s: %w", name, err)
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", name)
+	}
+	version, ok, err := ServiceVersion(sd)
+	if err != nil || !ok {
+		return nil, err
+	}
+	pkg := strings.Split(string(sd.ParentFile().Package()), ".")
+	if len(pkg) != 3 {
+		return nil, fmt.Errorf("service %s: package %s is not of the form namespace.version.module", name, sd.ParentFile().Package())
+	}
+	s := &Service{
+		Name:      name,
+		Namespace: pkg[0],
+		Module:    pkg[2],
+		Version:   version,
+	}
+	// Declared paths are rooted at the major version, served ones at the
+	// full version of the service.
+	declaredRoot := "/" + s.Namespace + "/v" + s.Version.Major + "/" + s.Module
+	servedRoot := ServiceRoot(s.Namespace, s.Version, s.Module)
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		m := &Method{
+			Name:       string(md.Name()),
+			FullMethod: "/" + name + "/" + string(md.Name()),
+		}
+		if m.HTTPMethod, m.Path, err = MethodHTTP(md); err != nil {
+			return nil, err
+		}
+		if rest, ok := strings.CutPrefix(m.Path, declaredRoot); ok {
+			m.URL = servedRoot + rest
+		}
+		s.Methods = append(s.Methods, m)
+	}
+	return s, nil
+}
+
+// ServiceVersion returns the version declared by the ntnx_api_version
+// option of sd, and whether it has one.
+func ServiceVersion(sd protoreflect.ServiceDescriptor) (Version, bool, error) {
+	raw, ok := lastOption(sd.Options().ProtoReflect().GetUnknown(), apiVersionOption)
+	if !ok {
+		return Version{}, false, nil
+	}
+	fields, err := stringFields(raw)
+	if err != nil {
+		return Version{}, false, fmt.Errorf("service %s: ntnx_api_version: %w", sd.FullName(), err)
+	}
+	return Version{
+		Major:               fields[majorField],
+		Minor:               fields[minorField],
+		ReleaseType:         fields[releaseTypeField],
+		ReleaseTypeRevision: fields[releaseTypeRevisionField],
+	}, true, nil
+}
+
+// MethodHTTP returns the HTTP method and path declared by the ntnx_api_http
+// option of md. Both are empty if it has none.
+func MethodHTTP(md protoreflect.MethodDescriptor) (method, path string, err error) {
+	raw, ok := lastOption(md.Options().ProtoReflect().GetUnknown(), apiHTTPOption)
+	if !ok {
+		return "", "", nil
+	}
+	fields, err := stringFields(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("method %s: ntnx_api_http: %w", md.FullName(), err)
+	}
+	for num := protowire.Number(1); int(num) <= len(httpMethods); num++ {
+		if path, ok := fields[num]; ok {
+			return httpMethods[num], path, nil
+		}
+	}
+	return "", "", nil
+}
+
+// ServiceRoot returns the REST path of the given module at version v.
+func ServiceRoot(namespace string, v Version, module string) string {
+	return "/api/" + namespace + "/" + v.String() + "/" + module
+}
+
+// lastOption returns the value of the last occurrence of the length
+// delimited field num in raw, the wire encoding of an options message.
+func lastOption(raw []byte, num protowire.Number) ([]byte, bool) {
+	var value []byte
+	found := false
+	for len(raw) > 0 {
+		n, typ, tagLen := protowire.ConsumeTag(raw)
+		if tagLen < 0 {
+			return nil, false
+		}
+		valueLen := protowire.ConsumeFieldValue(n, typ, raw[tagLen:])
+		if valueLen < 0 {
+			return nil, false
+		}
+		if n == num && typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(raw[tagLen:])
+			found = true
+		}
+		raw = raw[tagLen+valueLen:]
+	}
+	return value, found
+}
+
+// stringFields decodes the string fields of the message encoded in raw.
+func stringFields(raw []byte) (map[protowire.Number]string, error) {
+	fields := make(map[protowire.Number]string)
+	for len(raw) > 0 {
+		n, typ, tagLen := protowire.ConsumeTag(raw)
+		if tagLen < 0 {
+			return nil, protowire.ParseError(tagLen)
+		}
+		raw = raw[tagLen:]
+		if typ != protowire.BytesType {
+			valueLen := protowire.ConsumeFieldValue(n, typ, raw)
+			if valueLen < 0 {
+				return nil, protowire.ParseError(valueLen)
+			}
+			raw = raw[valueLen:]
+			continue
+		}
+		v, valueLen := protowire.ConsumeBytes(raw)
+		if valueLen < 0 {
+			return nil, protowire.ParseError(valueLen)
+		}
+		fields[n] = string(v)
+		raw = raw[valueLen:]
+	}
+	return fields, nil
+}
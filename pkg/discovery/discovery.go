@@ -0,0 +1,185 @@
+// Package discovery describes what a deployment of the Nutanix API exposes:
+// its gRPC services with their API versions and REST routes, and the entity
+// sets of its modules. It serves the description as a JSON discovery
+// document and, for each module, as an OData service document.
+//
+// Services are enumerated from the ServiceDescs registered with a gRPC
+// server. Their versions and routes are read from the ntnx_api_version and
+// ntnx_api_http options of their protobuf descriptors, and the entity sets
+// of modules from their generated EDM bindings.
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+	"google.golang.org/grpc"
+)
+
+// Path is the REST path of the discovery document.
+const Path = "/api/$discovery"
+
+// Module names a module of the API and the EDM bindings of its entity sets,
+// as returned by the GetAllEntityBindings function generated for it.
+type Module struct {
+	Namespace string
+	Name      string
+	Bindings  []*edm.EdmEntityBinding
+}
+
+// Document is the discovery document of a deployment.
+type Document struct {
+	Services []*Service        `json:"services"`
+	Modules  []*ModuleDocument `json:"modules"`
+}
+
+// ModuleDocument describes a module at the version of its services.
+type ModuleDocument struct {
+	Namespace   string       `json:"namespace"`
+	Module      string       `json:"module"`
+	Version     string       `json:"version"`
+	ServiceRoot string       `json:"serviceRoot"`
+	Metadata    string       `json:"metadata"`
+	EntitySets  []*EntitySet `json:"entitySets"`
+}
+
+// EntitySet describes an entity set of a module.
+type EntitySet struct {
+	Name string `json:"name"`
+	// EntityType is the qualified name of the entity type.
+	EntityType string `json:"entityType"`
+	URL        string `json:"url"`
+	// IncludeInServiceDocument reports whether the entity set is listed by
+	// the service document of its module.
+	IncludeInServiceDocument bool `json:"includeInServiceDocument"`
+}
+
+// ServiceInfoProvider is implemented by *grpc.Server.
+type ServiceInfoProvider interface {
+	GetServiceInfo() map[string]grpc.ServiceInfo
+}
+
+// New returns the discovery document of the services registered with srv
+// and of modules. Services without an ntnx_api_version option, such as
+// server reflection, are left out. Every module must have a service, whose
+// version it is described at.
+func New(srv ServiceInfoProvider, modules ...Module) (*Document, error) {
+	names := make([]string, 0, len(srv.GetServiceInfo()))
+	for name := range srv.GetServiceInfo() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	doc := &Document{Services: []*Service{}, Modules: []*ModuleDocument{}}
+	for _, name := range names {
+		s, err := DescribeService(name)
+		if err != nil {
+			return nil, err
+		}
+		if s != nil {
+			doc.Services = append(doc.Services, s)
+		}
+	}
+	for _, m := range modules {
+		md, err := doc.describeModule(m)
+		if err != nil {
+			return nil, err
+		}
+		doc.Modules = append(doc.Modules, md)
+	}
+	return doc, nil
+}
+
+func (d *Document) describeModule(m Module) (*ModuleDocument, error) {
+	var version *Version
+	for _, s := range d.Services {
+		if s.Namespace != m.Namespace || s.Module != m.Name {
+			continue
+		}
+		if version != nil && *version != s.Version {
+			return nil, fmt.Errorf("module %s.%s: services are at versions %s and %s", m.Namespace, m.Name, version, s.Version)
+		}
+		version = &s.Version
+	}
+	if version == nil {
+		return nil, fmt.Errorf("module %s.%s has no registered service", m.Namespace, m.Name)
+	}
+	root := ServiceRoot(m.Namespace, *version, m.Name)
+	md := &ModuleDocument{
+		Namespace:   m.Namespace,
+		Module:      m.Name,
+		Version:     version.String(),
+		ServiceRoot: root,
+		Metadata:    root + "/$metadata",
+		EntitySets:  []*EntitySet{},
+	}
+	for _, b := range m.Bindings {
+		md.EntitySets = append(md.EntitySets, &EntitySet{
+			Name:                     b.EntitySet.Name,
+			EntityType:               b.EntitySet.EntityType,
+			URL:                      root + "/" + b.EntitySet.Name,
+			IncludeInServiceDocument: b.EntitySet.IncludeInServiceDocument,
+		})
+	}
+	return md, nil
+}
+
+// Module returns the description of the given module, or nil.
+func (d *Document) Module(namespace, name string) *ModuleDocument {
+	for _, m := range d.Modules {
+		if m.Namespace == namespace && m.Module == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Handler serves the discovery document.
+func (d *Document) Handler() http.Handler {
+	return jsonHandler(d)
+}
+
+// serviceDocument is the JSON format of an OData service document.
+type serviceDocument struct {
+	Context string                `json:"@odata.context"`
+	Value   []serviceDocumentItem `json:"value"`
+}
+
+type serviceDocumentItem struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	URL  string `json:"url"`
+}
+
+// ServiceDocumentHandler serves the OData service document of m, listing
+// the entity sets to include in it with URLs relative to its service root.
+func (m *ModuleDocument) ServiceDocumentHandler() http.Handler {
+	doc := serviceDocument{Context: m.Metadata, Value: []serviceDocumentItem{}}
+	for _, s := range m.EntitySets {
+		if s.IncludeInServiceDocument {
+			doc.Value = append(doc.Value, serviceDocumentItem{Name: s.Name, Kind: "EntitySet", URL: s.Name})
+		}
+	}
+	return jsonHandler(doc)
+}
+
+// jsonHandler serves v encoded as JSON to GET and HEAD requests.
+func jsonHandler(v interface{}) http.Handler {
+	body, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		// Documents hold only strings, booleans and slices of them.
+		panic(err)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+}
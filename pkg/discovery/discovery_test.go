@@ -0,0 +1,221 @@
+package discovery
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// services is a ServiceInfoProvider registering the named services.
+type services []string
+
+func (s services) GetServiceInfo() map[string]grpc.ServiceInfo {
+	info := make(map[string]grpc.ServiceInfo)
+	for _, name := range s {
+		info[name] = grpc.ServiceInfo{}
+	}
+	return info
+}
+
+// configModule returns the config module with an items entity set and an
+// associations entity set left out of its service document.
+func configModule() Module {
+	return Module{
+		Namespace: "nexus",
+		Name:      "config",
+		Bindings: []*edm.EdmEntityBinding{
+			{EntitySet: &edm.EdmEntitySet{Name: "items", EntityType: "entities.item", IncludeInServiceDocument: true}},
+			{EntitySet: &edm.EdmEntitySet{Name: "itemassociationSet", EntityType: "entities.itemassociation"}},
+		},
+	}
+}
+
+func testDocument(t *testing.T) *Document {
+	t.Helper()
+	doc, err := New(services{healthpb.Health_ServiceDesc.ServiceName, pb.ItemService_ServiceDesc.ServiceName}, configModule())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestDescribeService(t *testing.T) {
+	s, err := DescribeService(pb.ItemService_ServiceDesc.ServiceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Namespace != "nexus" || s.Module != "config" || s.Version != (Version{Major: "4", Minor: "1"}) {
+		t.Errorf("got service %s.%s at %s, want nexus.config at v4.1", s.Namespace, s.Module, s.Version)
+	}
+	if len(s.Methods) != len(pb.ItemService_ServiceDesc.Methods) {
+		t.Errorf("got %d methods, want %d", len(s.Methods), len(pb.ItemService_ServiceDesc.Methods))
+	}
+	methods := make(map[string]Method)
+	for _, m := range s.Methods {
+		methods[m.Name] = *m
+	}
+	tests := []Method{
+		{
+			Name:       "listItems",
+			FullMethod: pb.ItemService_ListItems_FullMethodName,
+			HTTPMethod: "GET",
+			Path:       "/nexus/v4/config/items",
+			URL:        "/api/nexus/v4.1/config/items",
+		},
+		{
+			Name:       "patchItem",
+			FullMethod: pb.ItemService_PatchItem_FullMethodName,
+			HTTPMethod: "PATCH",
+			Path:       "/nexus/v4/config/items/{extId}",
+			URL:        "/api/nexus/v4.1/config/items/{extId}",
+		},
+		{
+			Name:       "restoreItem",
+			FullMethod: pb.ItemService_RestoreItem_FullMethodName,
+			HTTPMethod: "POST",
+			Path:       "/nexus/v4/config/items/{extId}/$actions/restore",
+			URL:        "/api/nexus/v4.1/config/items/{extId}/$actions/restore",
+		},
+	}
+	for _, want := range tests {
+		if got := methods[want.Name]; got != want {
+			t.Errorf("got method %+v, want %+v", got, want)
+		}
+	}
+
+	if s, err := DescribeService(healthpb.Health_ServiceDesc.ServiceName); s != nil || err != nil {
+		t.Errorf("describing a service without ntnx_api_version: got %v, %v, want neither", s, err)
+	}
+	for _, name := range []string{"nexus.v4.config.Item", "nexus.v4.config.NoSuchService"} {
+		if _, err := DescribeService(name); err == nil {
+			t.Errorf("describing %s: got no error", name)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	doc := testDocument(t)
+	if len(doc.Services) != 1 || doc.Services[0].Name != pb.ItemService_ServiceDesc.ServiceName {
+		t.Errorf("got %d services, want only ItemService", len(doc.Services))
+	}
+	want := &ModuleDocument{
+		Namespace:   "nexus",
+		Module:      "config",
+		Version:     "v4.1",
+		ServiceRoot: "/api/nexus/v4.1/config",
+		Metadata:    "/api/nexus/v4.1/config/$metadata",
+		EntitySets: []*EntitySet{
+			{Name: "items", EntityType: "entities.item", URL: "/api/nexus/v4.1/config/items", IncludeInServiceDocument: true},
+			{Name: "itemassociationSet", EntityType: "entities.itemassociation", URL: "/api/nexus/v4.1/config/itemassociationSet"},
+		},
+	}
+	if got := doc.Module("nexus", "config"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got module %+v, want %+v", got, want)
+	}
+	if got := doc.Module("nexus", "stats"); got != nil {
+		t.Errorf("got module %+v, want none", got)
+	}
+
+	other := configModule()
+	other.Name = "stats"
+	if _, err := New(services{pb.ItemService_ServiceDesc.ServiceName}, other); err == nil || err.Error() != "module nexus.stats has no registered service" {
+		t.Errorf("describing a module without services: got %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	doc := testDocument(t)
+	h := doc.Handler()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("GET %s = %d %s", Path, w.Code, w.Header().Get("Content-Type"))
+	}
+	var got Document
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&got, doc) {
+		t.Errorf("got document %s, want the document served", w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, Path, nil))
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "GET, HEAD" {
+		t.Errorf("POST %s = %d, Allow %q, want %d, GET, HEAD", Path, w.Code, w.Header().Get("Allow"), http.StatusMethodNotAllowed)
+	}
+}
+
+func TestServiceDocumentHandler(t *testing.T) {
+	m := testDocument(t).Module("nexus", "config")
+	w := httptest.NewRecorder()
+	m.ServiceDocumentHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, m.ServiceRoot, nil))
+	var got serviceDocument
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := serviceDocument{
+		Context: "/api/nexus/v4.1/config/$metadata",
+		Value:   []serviceDocumentItem{{Name: "items", Kind: "EntitySet", URL: "items"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got service document %+v, want %+v", got, want)
+	}
+}
+
+func TestLastOption(t *testing.T) {
+	field := func(num protowire.Number, value string) []byte {
+		b := protowire.AppendTag(nil, num, protowire.BytesType)
+		return protowire.AppendString(b, value)
+	}
+	varint := protowire.AppendVarint(protowire.AppendTag(nil, apiVersionOption, protowire.VarintType), 7)
+	cat := func(parts ...[]byte) []byte {
+		var b []byte
+		for _, p := range parts {
+			b = append(b, p...)
+		}
+		return b
+	}
+	tests := []struct {
+		name  string
+		raw   []byte
+		want  string
+		found bool
+	}{
+		{"absent", field(1, "a"), "", false},
+		{"present", cat(field(1, "a"), field(apiVersionOption, "b")), "b", true},
+		{"repeated", cat(field(apiVersionOption, "b"), field(apiVersionOption, "c")), "c", true},
+		{"not length delimited", varint, "", false},
+		{"truncated", field(apiVersionOption, "b")[:3], "", false},
+	}
+	for _, tt := range tests {
+		got, found := lastOption(tt.raw, apiVersionOption)
+		if string(got) != tt.want || found != tt.found {
+			t.Errorf("%s: got %q, %t, want %q, %t", tt.name, got, found, tt.want, tt.found)
+		}
+	}
+}
+
+func TestStringFields(t *testing.T) {
+	raw := protowire.AppendString(protowire.AppendTag(nil, majorField, protowire.BytesType), "4")
+	raw = protowire.AppendVarint(protowire.AppendTag(raw, 9, protowire.VarintType), 1)
+	raw = protowire.AppendString(protowire.AppendTag(raw, minorField, protowire.BytesType), "1")
+	fields, err := stringFields(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[protowire.Number]string{majorField: "4", minorField: "1"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("got %v, want %v", fields, want)
+	}
+	if _, err := stringFields(raw[:len(raw)-1]); err == nil {
+		t.Error("decoding a truncated message: got no error")
+	}
+}
@@ -0,0 +1,80 @@
+package discovery
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Version is the API version of a service, as declared by its
+// ntnx_api_version option.
+type Version struct {
+	Major               string `json:"major"`
+	Minor               string `json:"minor"`
+	ReleaseType         string `json:"releaseType,omitempty"`
+	ReleaseTypeRevision string `json:"releaseTypeRevision,omitempty"`
+}
+
+// versionPattern matches versions as they appear in REST paths.
+var versionPattern = regexp.MustCompile(`^v(\d+)\.(\d+)(?:\.([a-z]+)(\d+))?$`)
+
+// ParseVersion parses a version as it appears in REST paths, such as "v4.1"
+// or "v4.2.b1".
+func ParseVersion(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("%q is not an API version", s)
+	}
+	return Version{Major: m[1], Minor: m[2], ReleaseType: m[3], ReleaseTypeRevision: m[4]}, nil
+}
+
+// String returns the version as it appears in REST paths, such as "v4.1"
+// or "v4.2.b1".
+func (v Version) String() string {
+	s := "v" + v.Major + "." + v.Minor
+	if v.ReleaseType != "" {
+		s += "." + v.ReleaseType + v.ReleaseTypeRevision
+	}
+	return s
+}
+
+// FormatVersion returns the version as carried by the "$fv" reserved
+// property of DTOs, such as "v4.r1". It omits the release type.
+func (v Version) FormatVersion() string {
+	return "v" + v.Major + ".r" + v.Minor
+}
+
+// Compare returns -1, 0 or 1 as v is older than, the same as or newer than
+// w. A pre-release, such as v4.2.b1, is older than its release v4.2 and
+// newer than v4.1.
+func (v Version) Compare(w Version) int {
+	if c := compareNumbers(v.Major, w.Major); c != 0 {
+		return c
+	}
+	if c := compareNumbers(v.Minor, w.Minor); c != 0 {
+		return c
+	}
+	switch {
+	case v.ReleaseType == w.ReleaseType:
+		return compareNumbers(v.ReleaseTypeRevision, w.ReleaseTypeRevision)
+	case v.ReleaseType == "":
+		return 1
+	case w.ReleaseType == "":
+		return -1
+	case v.ReleaseType < w.ReleaseType:
+		return -1
+	}
+	return 1
+}
+
+func compareNumbers(a, b string) int {
+	x, _ := strconv.Atoi(a)
+	y, _ := strconv.Atoi(b)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,61 @@
+package discovery
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+		fv   string
+	}{
+		{"v4.0", Version{Major: "4", Minor: "0"}, "v4.r0"},
+		{"v4.1", Version{Major: "4", Minor: "1"}, "v4.r1"},
+		{"v4.2.b1", Version{Major: "4", Minor: "2", ReleaseType: "b", ReleaseTypeRevision: "1"}, "v4.r2"},
+		{"v10.12.alpha3", Version{Major: "10", Minor: "12", ReleaseType: "alpha", ReleaseTypeRevision: "3"}, "v10.r12"},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseVersion(%q) = %+v, %v, want %+v", tt.in, got, err, tt.want)
+			continue
+		}
+		if got.String() != tt.in {
+			t.Errorf("got %s, want %s", got, tt.in)
+		}
+		if got.FormatVersion() != tt.fv {
+			t.Errorf("got format version %s of %s, want %s", got.FormatVersion(), tt.in, tt.fv)
+		}
+	}
+	for _, in := range []string{"", "4.1", "v4", "v4.", "v4.1.b", "v4.1.1", "v4.1.B1", "config"} {
+		if _, err := ParseVersion(in); err == nil {
+			t.Errorf("ParseVersion(%q): got no error", in)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		v, w string
+		want int
+	}{
+		{"v4.1", "v4.1", 0},
+		{"v4.0", "v4.1", -1},
+		{"v4.10", "v4.9", 1},
+		{"v5.0", "v4.9", 1},
+		{"v4.2.b1", "v4.2", -1},
+		{"v4.2.b1", "v4.1", 1},
+		{"v4.2.b1", "v4.2.b2", -1},
+		{"v4.2.b10", "v4.2.b9", 1},
+		{"v4.2.a3", "v4.2.b1", -1},
+	}
+	for _, tt := range tests {
+		v, _ := ParseVersion(tt.v)
+		w, _ := ParseVersion(tt.w)
+		if got := v.Compare(w); got != tt.want {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tt.v, tt.w, got, tt.want)
+		}
+		if got := w.Compare(v); got != -tt.want {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tt.w, tt.v, got, -tt.want)
+		}
+	}
+}
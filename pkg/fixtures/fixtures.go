@@ -0,0 +1,341 @@
+// Package fixtures seeds stores with datasets declared in YAML or JSON: nexus
+// items with their associations, and the cats of the mock services.
+//
+// A dataset lists specs, each generating one or more entities whose
+// properties are Templates. Pseudo-random values are drawn from a generator
+// seeded by the dataset, so that building a dataset always yields the same
+// entities and tests loading it get reproducible data. Built-in datasets
+// are embedded from the datasets directory:
+//
+//	idf   110 items with a vm and a host association each, as the
+//	      setup_nexus_idf.py and create_associations.py scripts seed IDF
+//	cats  the cats served by a freshly started mock-cat-server
+package fixtures
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	mockpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/mock/v4/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/item"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/mappers"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// DefaultTenant owns the items of datasets that name no tenant.
+const DefaultTenant = "default"
+
+//go:embed datasets
+var builtins embed.FS
+
+// Dataset declares the entities a fixture seeds.
+type Dataset struct {
+	// Seed seeds the pseudo-random values of the dataset.
+	Seed uint64 `json:"seed"`
+	// Tenant owns the items of the dataset, unless their spec names
+	// another. It defaults to DefaultTenant.
+	Tenant string     `json:"tenant"`
+	Items  []ItemSpec `json:"items"`
+	Cats   []CatSpec  `json:"cats"`
+}
+
+// ItemSpec generates nexus items. Their extIds default to {uuid}; itemIds
+// are assigned by the store they are loaded into.
+type ItemSpec struct {
+	// Repeat is the number of items generated, 1 by default.
+	Repeat       int               `json:"repeat"`
+	Tenant       string            `json:"tenant"`
+	ExtId        Template          `json:"extId"`
+	ItemName     Template          `json:"itemName"`
+	ItemType     Template          `json:"itemType"`
+	Description  Template          `json:"description"`
+	Associations []AssociationSpec `json:"associations"`
+}
+
+// AssociationSpec generates associations of every item of the ItemSpec
+// it belongs to. Their entityIds default to {uuid}.
+type AssociationSpec struct {
+	// Repeat is the number of associations generated per item, 1 by
+	// default.
+	Repeat     int      `json:"repeat"`
+	EntityType Template `json:"entityType"`
+	EntityId   Template `json:"entityId"`
+	Count      Template `json:"count"`
+}
+
+// CatSpec generates mock cats. Their itemIds count from 1 across the
+// dataset unless given.
+type CatSpec struct {
+	// Repeat is the number of cats generated, 1 by default.
+	Repeat        int      `json:"repeat"`
+	ItemId        Template `json:"itemId"`
+	ItemName      Template `json:"itemName"`
+	ItemType      Template `json:"itemType"`
+	Description   Template `json:"description"`
+	ItemImageFile Template `json:"itemImageFile"`
+	Loitemion     struct {
+		City    Template `json:"city"`
+		Zip     Template `json:"zip"`
+		Country struct {
+			State Template `json:"state"`
+		} `json:"country"`
+	} `json:"loitemion"`
+}
+
+// Entities are the entities generated from a dataset.
+type Entities struct {
+	// Items are listed in the order of their specs, each followed in
+	// Associations by its own.
+	Items        []*pb.Item
+	Associations []*pb.ItemAssociation
+	Cats         []*mockpb.Item
+}
+
+// Parse decodes a dataset from data, in YAML unless name, the file it was
+// read from, ends in .json. Unknown properties are rejected.
+func Parse(name string, data []byte) (*Dataset, error) {
+	if !strings.EqualFold(filepath.Ext(name), ".json") {
+		v, err := decodeYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if data, err = json.Marshal(v); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var d Dataset
+	if err := dec.Decode(&d); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &d, nil
+}
+
+// ReadFile reads the dataset in the named file.
+func ReadFile(name string) (*Dataset, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(name, data)
+}
+
+// Builtin returns the built-in dataset with the given name.
+func Builtin(name string) (*Dataset, error) {
+	file := path.Join("datasets", name+".yaml")
+	data, err := builtins.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("no built-in dataset %q", name)
+	}
+	return Parse(file, data)
+}
+
+// MustBuild returns the entities of the built-in dataset with the given
+// name, which is known to be valid. It panics if there is no such dataset.
+func MustBuild(name string) *Entities {
+	d, err := Builtin(name)
+	if err != nil {
+		panic(err)
+	}
+	e, err := d.Build()
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Build generates the entities of d. Items are checked against the Item
+// schema.
+func (d *Dataset) Build() (*Entities, error) {
+	rng := rand.New(rand.NewPCG(d.Seed, 0))
+	e := &Entities{}
+	for i, spec := range d.Items {
+		if err := e.addItems(d, spec, rng); err != nil {
+			return nil, fmt.Errorf("items[%d]: %w", i, err)
+		}
+	}
+	for i, spec := range d.Cats {
+		if err := e.addCats(spec, rng); err != nil {
+			return nil, fmt.Errorf("cats[%d]: %w", i, err)
+		}
+	}
+	return e, nil
+}
+
+func repeat(n int) int {
+	if n == 0 {
+		return 1
+	}
+	return n
+}
+
+// expander expands the templates of the n-th entity of a spec, keeping the
+// first error.
+type expander struct {
+	n   int
+	rng *rand.Rand
+	err error
+}
+
+// string returns the expansion of t, or nil if t is empty.
+func (x *expander) string(t, def Template) *string {
+	if t == "" {
+		t = def
+	}
+	if t == "" || x.err != nil {
+		return nil
+	}
+	s, err := t.expand(x.n, x.rng)
+	if err != nil {
+		x.err = err
+		return nil
+	}
+	return proto.String(s)
+}
+
+// int32 returns the expansion of t as an integer, or nil if t is empty.
+func (x *expander) int32(name string, t Template) *int32 {
+	s := x.string(t, "")
+	if s == nil {
+		return nil
+	}
+	v, err := strconv.ParseInt(*s, 10, 32)
+	if err != nil {
+		x.err = fmt.Errorf("%s: %q is not an int32", name, *s)
+		return nil
+	}
+	return proto.Int32(int32(v))
+}
+
+func (e *Entities) addItems(d *Dataset, spec ItemSpec, rng *rand.Rand) error {
+	tenantId := spec.Tenant
+	if tenantId == "" {
+		tenantId = d.Tenant
+	}
+	if tenantId == "" {
+		tenantId = DefaultTenant
+	}
+	for n := 1; n <= repeat(spec.Repeat); n++ {
+		x := &expander{n: n, rng: rng}
+		it := &pb.Item{
+			ExtId:       x.string(spec.ExtId, "{uuid}"),
+			ItemName:    x.string(spec.ItemName, ""),
+			ItemType:    x.string(spec.ItemType, ""),
+			Description: x.string(spec.Description, ""),
+			TenantInfo:  &commonpb.TenantAwareModel{TenantId: proto.String(tenantId)},
+		}
+		if x.err != nil {
+			return x.err
+		}
+		if err := item.Validate(mappers.ItemToDto(it)); err != nil {
+			return err
+		}
+		e.Items = append(e.Items, it)
+		for i, a := range spec.Associations {
+			if err := e.addAssociations(it, a, rng); err != nil {
+				return fmt.Errorf("associations[%d]: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Entities) addAssociations(it *pb.Item, spec AssociationSpec, rng *rand.Rand) error {
+	for n := 1; n <= repeat(spec.Repeat); n++ {
+		x := &expander{n: n, rng: rng}
+		a := &pb.ItemAssociation{
+			ItemId:     proto.String(it.GetExtId()),
+			EntityType: x.string(spec.EntityType, ""),
+			EntityId:   x.string(spec.EntityId, "{uuid}"),
+			Count:      x.int32("count", spec.Count),
+			TenantInfo: proto.Clone(it.TenantInfo).(*commonpb.TenantAwareModel),
+		}
+		if x.err != nil {
+			return x.err
+		}
+		if a.EntityType == nil {
+			return errors.New("entityType is required")
+		}
+		e.Associations = append(e.Associations, a)
+	}
+	return nil
+}
+
+func (e *Entities) addCats(spec CatSpec, rng *rand.Rand) error {
+	for n := 1; n <= repeat(spec.Repeat); n++ {
+		x := &expander{n: n, rng: rng}
+		cat := &mockpb.Item{
+			ItemId:        x.int32("itemId", spec.ItemId),
+			ItemName:      x.string(spec.ItemName, ""),
+			ItemType:      x.string(spec.ItemType, ""),
+			Description:   x.string(spec.Description, ""),
+			ItemImageFile: x.string(spec.ItemImageFile, ""),
+		}
+		loc := spec.Loitemion
+		if loc.City != "" || loc.Zip != "" || loc.Country.State != "" {
+			cat.Loitemion = &mockpb.Loitemion{City: x.string(loc.City, ""), Zip: x.string(loc.Zip, "")}
+			if loc.Country.State != "" {
+				cat.Loitemion.Country = &mockpb.Country{State: x.string(loc.Country.State, "")}
+			}
+		}
+		if x.err != nil {
+			return x.err
+		}
+		if cat.ItemName == nil || cat.ItemType == nil {
+			return errors.New("itemName and itemType are required")
+		}
+		if cat.ItemId == nil {
+			cat.ItemId = proto.Int32(int32(len(e.Cats) + 1))
+		}
+		e.Cats = append(e.Cats, cat)
+	}
+	return nil
+}
+
+// Load writes the items and associations of e to s in a single
+// transaction, replacing those with the same extIds. Cats are served by
+// mockserver from memory and are not loaded.
+func Load(s *store.ItemStore, e *Entities) error {
+	return s.Txn(tenant.Scope{AllTenants: true}, func(tx *store.Txn) error {
+		for _, it := range e.Items {
+			if _, err := tx.Put(it); err != nil {
+				return err
+			}
+		}
+		for _, a := range e.Associations {
+			if _, err := tx.PutAssociation(a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// NewStore returns a memory ItemStore holding the items and associations of
+// d, for tests.
+func NewStore(d *Dataset) (*store.ItemStore, error) {
+	e, err := d.Build()
+	if err != nil {
+		return nil, err
+	}
+	s := store.NewItemStore()
+	if err := Load(s, e); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,278 @@
+package fixtures
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+const testYAML = `
+# Two disks of tenant t and a vm of tenant u.
+seed: 3
+tenant: t
+items:
+  - repeat: 2
+    itemName: disk-{n}
+    itemType: disk
+    associations:
+      - repeat: 2
+        entityType: host
+        entityId: host-{n}
+        count: 4
+  - tenant: u
+    extId: 4c6a1b4e-0000-4000-8000-000000000001
+    itemName: vm
+    itemType: vm
+    description: "{pick a b}"
+cats:
+  - itemName: Tom
+    itemType: Tabby
+    loitemion:
+      city: Pune
+      zip: 411001
+  - itemId: 9
+    itemName: Kit
+    itemType: Siamese
+    loitemion:
+      country:
+        state: Goa
+`
+
+const testJSON = `{
+	"seed": 3,
+	"tenant": "t",
+	"items": [
+		{"repeat": 2, "itemName": "disk-{n}", "itemType": "disk", "associations": [
+			{"repeat": 2, "entityType": "host", "entityId": "host-{n}", "count": 4}
+		]},
+		{"tenant": "u", "extId": "4c6a1b4e-0000-4000-8000-000000000001", "itemName": "vm", "itemType": "vm", "description": "{pick a b}"}
+	],
+	"cats": [
+		{"itemName": "Tom", "itemType": "Tabby", "loitemion": {"city": "Pune", "zip": 411001}},
+		{"itemId": 9, "itemName": "Kit", "itemType": "Siamese", "loitemion": {"country": {"state": "Goa"}}}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	fromYAML, err := Parse("test.yaml", []byte(testYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON, err := Parse("test.JSON", []byte(testJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Errorf("got %+v from YAML and %+v from JSON", fromYAML, fromJSON)
+	}
+	if fromYAML.Cats[0].Loitemion.Zip != "411001" {
+		t.Errorf("got zip %q, want the text of the number", fromYAML.Cats[0].Loitemion.Zip)
+	}
+
+	tests := []struct {
+		name, data, want string
+	}{
+		{"bad.yaml", "items:\n  - colour: red\n", `bad.yaml: json: unknown field "colour"`},
+		{"bad.json", `{"items": [{"colour": "red"}]}`, `bad.json: json: unknown field "colour"`},
+		{"bad.yaml", "seed: 1\nseed: 2\n", `bad.yaml: line 2: duplicate key "seed"`},
+		{"bad.yml", "items:\n  - itemName: [a]\n", "bad.yml: template must be a string, number or boolean, not [\"a\"]"},
+	}
+	for _, tt := range tests {
+		if _, err := Parse(tt.name, []byte(tt.data)); err == nil || err.Error() != tt.want {
+			t.Errorf("Parse(%s, %q): got %v, want %s", tt.name, tt.data, err, tt.want)
+		}
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "test.json")
+	if err := os.WriteFile(name, []byte(testJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, err := ReadFile(name)
+	if err != nil || d.Seed != 3 || len(d.Items) != 2 {
+		t.Errorf("ReadFile = %+v, %v, want the dataset written", d, err)
+	}
+	if _, err := ReadFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("reading a missing file: got no error")
+	}
+}
+
+func TestBuild(t *testing.T) {
+	d, err := Parse("test.yaml", []byte(testYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := d.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var items []string
+	for _, it := range e.Items {
+		items = append(items, fmt.Sprintf("%s/%s", it.GetTenantInfo().GetTenantId(), it.GetItemName()))
+		if it.ItemId != nil {
+			t.Errorf("item %s has itemId %d, want none", it.GetItemName(), it.GetItemId())
+		}
+	}
+	if want := []string{"t/disk-1", "t/disk-2", "u/vm"}; !slices.Equal(items, want) {
+		t.Errorf("got items %v, want %v", items, want)
+	}
+	if e.Items[0].GetExtId() == e.Items[1].GetExtId() {
+		t.Errorf("got extId %s twice", e.Items[0].GetExtId())
+	}
+	if vm := e.Items[2]; vm.GetExtId() != "4c6a1b4e-0000-4000-8000-000000000001" || vm.GetDescription() != "a" && vm.GetDescription() != "b" {
+		t.Errorf("got vm %v, want the extId given and a description picked", vm)
+	}
+	var assocs []string
+	for _, a := range e.Associations {
+		assocs = append(assocs, fmt.Sprintf("%s %s/%s %d %s", a.GetItemId(), a.GetEntityType(), a.GetEntityId(), a.GetCount(), a.GetTenantInfo().GetTenantId()))
+	}
+	disk1, disk2 := e.Items[0].GetExtId(), e.Items[1].GetExtId()
+	want := []string{
+		disk1 + " host/host-1 4 t", disk1 + " host/host-2 4 t",
+		disk2 + " host/host-1 4 t", disk2 + " host/host-2 4 t",
+	}
+	if !slices.Equal(assocs, want) {
+		t.Errorf("got associations %v, want %v", assocs, want)
+	}
+	var cats []string
+	for _, c := range e.Cats {
+		cats = append(cats, fmt.Sprintf("%d %s %v", c.GetItemId(), c.GetItemName(), c.GetLoitemion()))
+	}
+	if len(cats) != 2 || e.Cats[0].GetItemId() != 1 || e.Cats[0].GetLoitemion().GetCity() != "Pune" || e.Cats[0].GetLoitemion().Country != nil ||
+		e.Cats[1].GetItemId() != 9 || e.Cats[1].GetLoitemion().GetCountry().GetState() != "Goa" || e.Cats[1].GetLoitemion().City != nil {
+		t.Errorf("got cats %v", cats)
+	}
+
+	// Building again yields the same entities.
+	again, err := d.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range e.Items {
+		if !proto.Equal(e.Items[i], again.Items[i]) {
+			t.Errorf("got %v, then %v", e.Items[i], again.Items[i])
+		}
+	}
+
+	// Tenants default to DefaultTenant.
+	d.Tenant = ""
+	if e, err = d.Build(); err != nil || e.Items[0].GetTenantInfo().GetTenantId() != DefaultTenant {
+		t.Errorf("got %v, %v, want the items of %s", e, err, DefaultTenant)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"items:\n  - itemType: disk\n", "items[0]: item validation failed: itemName: is required"},
+		{"items:\n  - itemName: '{n'\n    itemType: disk\n", `items[0]: template "{n": unclosed {`},
+		{"items:\n  - itemName: a\n    itemType: b\n  - itemName: a\n    itemType: b\n    associations:\n      - count: 1\n", "items[1]: associations[0]: entityType is required"},
+		{"items:\n  - itemName: a\n    itemType: b\n    associations:\n      - entityType: vm\n        count: many\n", `items[0]: associations[0]: count: "many" is not an int32`},
+		{"cats:\n  - itemName: Tom\n", "cats[0]: itemName and itemType are required"},
+		{"cats:\n  - itemName: Tom\n    itemType: Tabby\n    itemId: 1.5\n", `cats[0]: itemId: "1.5" is not an int32`},
+	}
+	for _, tt := range tests {
+		d, err := Parse("bad.yaml", []byte(tt.data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := d.Build(); err == nil || err.Error() != tt.want {
+			t.Errorf("building %q: got %v, want %s", tt.data, err, tt.want)
+		}
+	}
+}
+
+func TestBuiltin(t *testing.T) {
+	idf := MustBuild("idf")
+	if len(idf.Items) != 110 || len(idf.Associations) != 220 {
+		t.Fatalf("got %d items and %d associations, want 110 and 220", len(idf.Items), len(idf.Associations))
+	}
+	last := idf.Items[109]
+	if last.GetItemName() != "test item 110" || last.GetItemType() != "TYPE1" || last.GetDescription() != "test item description 110" {
+		t.Errorf("got last item %v", last)
+	}
+	for i, a := range idf.Associations[:2] {
+		if a.GetItemId() != idf.Items[0].GetExtId() || a.GetEntityType() != []string{"vm", "host"}[i] || a.GetCount() != []int32{5, 10}[i] {
+			t.Errorf("got association %v of the first item", a)
+		}
+	}
+
+	cats := MustBuild("cats").Cats
+	if len(cats) != 8 || cats[0].GetItemName() != "Whiskers" || cats[7].GetItemId() != 8 {
+		t.Errorf("got %d cats starting with %v, want 8 from Whiskers", len(cats), cats[0])
+	}
+
+	if _, err := Builtin("dogs"); err == nil || err.Error() != `no built-in dataset "dogs"` {
+		t.Errorf("Builtin(dogs): got %v", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustBuild(dogs) did not panic")
+		}
+	}()
+	MustBuild("dogs")
+}
+
+func TestLoad(t *testing.T) {
+	d, err := Builtin("idf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewStore(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	scope := tenant.Scope{TenantId: DefaultTenant}
+	count := func() int {
+		t.Helper()
+		n, err := s.Count(scope, store.Query{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	if n := count(); n != 110 {
+		t.Errorf("got %d items, want 110", n)
+	}
+	e := MustBuild("idf")
+	assocs, err := s.ListAssociations(scope, e.Items[0].GetExtId())
+	if err != nil || len(assocs) != 2 {
+		t.Errorf("got associations %v, %v of the first item, want 2", assocs, err)
+	}
+
+	// Loading a dataset again replaces its items.
+	e.Items[0].ItemName = proto.String("renamed")
+	if err := Load(s, e); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(); n != 110 {
+		t.Errorf("got %d items after loading again, want 110", n)
+	}
+	if it, err := s.Get(scope, e.Items[0].GetExtId()); err != nil || it.GetItemName() != "renamed" {
+		t.Errorf("got %v, %v, want the renamed item", it, err)
+	}
+
+	// Nothing is loaded if an association references no item.
+	e.Items = []*pb.Item{e.Items[0]}
+	e.Associations[1].ItemId = proto.String("4c6a1b4e-0000-4000-8000-000000000001")
+	e.Items[0].ItemName = proto.String("again")
+	if err := Load(s, e); err == nil {
+		t.Error("loading an association of no item: got no error")
+	}
+	if it, _ := s.Get(scope, e.Items[0].GetExtId()); it.GetItemName() != "renamed" {
+		t.Error("the store changed on a failed load")
+	}
+}
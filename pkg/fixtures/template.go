@@ -0,0 +1,100 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Template is a property value of a dataset. Text in braces is replaced
+// when entities are generated:
+//
+//	{n}            the number of the entity within its spec, from 1
+//	{uuid}         a pseudo-random UUID
+//	{int LO HI}    a pseudo-random integer between LO and HI inclusive
+//	{pick A B ...} one of the words A, B, ... picked pseudo-randomly
+//
+// In datasets, numbers and booleans are accepted as templates of their
+// text.
+type Template string
+
+func (t *Template) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = Template(s)
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch v.(type) {
+	case float64, bool:
+		*t = Template(b)
+		return nil
+	}
+	return fmt.Errorf("template must be a string, number or boolean, not %s", b)
+}
+
+// expand returns the text of t for the n-th entity of its spec, drawing
+// pseudo-random values from rng.
+func (t Template) expand(n int, rng *rand.Rand) (string, error) {
+	s := string(t)
+	var b strings.Builder
+	for {
+		open := strings.IndexByte(s, '{')
+		if open < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+		end := strings.IndexByte(s[open:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("template %q: unclosed {", t)
+		}
+		b.WriteString(s[:open])
+		v, err := expandField(strings.Fields(s[open+1:open+end]), n, rng)
+		if err != nil {
+			return "", fmt.Errorf("template %q: %w", t, err)
+		}
+		b.WriteString(v)
+		s = s[open+end+1:]
+	}
+}
+
+func expandField(field []string, n int, rng *rand.Rand) (string, error) {
+	if len(field) == 0 {
+		return "", fmt.Errorf("empty {}")
+	}
+	switch name, args := field[0], field[1:]; {
+	case name == "n" && len(args) == 0:
+		return strconv.Itoa(n), nil
+	case name == "uuid" && len(args) == 0:
+		return newUUID(rng), nil
+	case name == "int" && len(args) == 2:
+		lo, err1 := strconv.ParseInt(args[0], 10, 64)
+		hi, err2 := strconv.ParseInt(args[1], 10, 64)
+		if err1 != nil || err2 != nil || lo > hi {
+			return "", fmt.Errorf("{int %s %s}: bounds must be integers LO <= HI", args[0], args[1])
+		}
+		return strconv.FormatInt(lo+rng.Int64N(hi-lo+1), 10), nil
+	case name == "pick" && len(args) > 0:
+		return args[rng.IntN(len(args))], nil
+	}
+	return "", fmt.Errorf("unknown field {%s}", strings.Join(field, " "))
+}
+
+// newUUID returns a version 4 UUID made of pseudo-random bits from rng.
+func newUUID(rng *rand.Rand) string {
+	var b [16]byte
+	for i := 0; i < len(b); i += 8 {
+		v := rng.Uint64()
+		for j := 0; j < 8; j++ {
+			b[i+j] = byte(v >> (8 * j))
+		}
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,112 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestTemplateExpand(t *testing.T) {
+	tests := []struct {
+		t    Template
+		want string
+	}{
+		{"", ""},
+		{"plain", "plain"},
+		{"item {n}", "item 7"},
+		{"{n}-{n}", "7-7"},
+		{"{ n }", "7"},
+		{"{int 3 3}", "3"},
+		{"{pick one}", "one"},
+	}
+	for _, tt := range tests {
+		got, err := tt.t.expand(7, rand.New(rand.NewPCG(1, 0)))
+		if err != nil || got != tt.want {
+			t.Errorf("expand(%q) = %q, %v, want %q", tt.t, got, err, tt.want)
+		}
+	}
+}
+
+func TestTemplateRandom(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 0))
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	seen := make(map[string]bool)
+	drawn := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		s, err := Template("{int -2 2}|{pick a b}|{uuid}").expand(1, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := regexp.MustCompile(`^(-?\d)\|([ab])\|(.*)$`).FindStringSubmatch(s)
+		if m == nil {
+			t.Fatalf("got %q, want an integer, a word and a UUID", s)
+		}
+		if n, _ := strconv.Atoi(m[1]); n < -2 || n > 2 {
+			t.Errorf("got %d, want an integer between -2 and 2", n)
+		}
+		if !uuid.MatchString(m[3]) || seen[m[3]] {
+			t.Errorf("got %q, want a new version 4 UUID", m[3])
+		}
+		seen[m[3]] = true
+		drawn[m[1]], drawn[m[2]] = true, true
+	}
+	for _, v := range []string{"-2", "-1", "0", "1", "2", "a", "b"} {
+		if !drawn[v] {
+			t.Errorf("never drew %s", v)
+		}
+	}
+
+	// The same seed draws the same values.
+	a, _ := Template("{uuid}").expand(1, rand.New(rand.NewPCG(9, 0)))
+	b, _ := Template("{uuid}").expand(1, rand.New(rand.NewPCG(9, 0)))
+	if a != b {
+		t.Errorf("got %s and %s from the same seed", a, b)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	tests := []struct {
+		t    Template
+		want string
+	}{
+		{"a {n", `template "a {n": unclosed {`},
+		{"{}", `template "{}": empty {}`},
+		{"{n 1}", `template "{n 1}": unknown field {n 1}`},
+		{"{date}", `template "{date}": unknown field {date}`},
+		{"{pick}", `template "{pick}": unknown field {pick}`},
+		{"{int 1}", `template "{int 1}": unknown field {int 1}`},
+		{"{int 2 1}", `template "{int 2 1}": {int 2 1}: bounds must be integers LO <= HI`},
+		{"{int a 1}", `template "{int a 1}": {int a 1}: bounds must be integers LO <= HI`},
+	}
+	for _, tt := range tests {
+		if _, err := tt.t.expand(1, rand.New(rand.NewPCG(1, 0))); err == nil || err.Error() != tt.want {
+			t.Errorf("expand(%q): got %v, want %s", tt.t, err, tt.want)
+		}
+	}
+}
+
+func TestTemplateUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want Template
+	}{
+		{`"x {n}"`, "x {n}"},
+		{`5`, "5"},
+		{`2.50`, "2.50"},
+		{`true`, "true"},
+	}
+	for _, tt := range tests {
+		var got Template
+		if err := json.Unmarshal([]byte(tt.json), &got); err != nil || got != tt.want {
+			t.Errorf("unmarshaling %s: got %q, %v, want %q", tt.json, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{`[1]`, `{"a": 1}`} {
+		var got Template
+		if err := json.Unmarshal([]byte(bad), &got); err == nil {
+			t.Errorf("unmarshaling %s: got %q, want an error", bad, got)
+		}
+	}
+}
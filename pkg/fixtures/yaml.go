@@ -0,0 +1,289 @@
+package fixtures
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlLine is a line of a YAML document holding content, without its
+// indentation and comment.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+// yamlParser decodes the subset of YAML datasets are written in: block
+// mappings and sequences, single-line plain, single- and double-quoted
+// scalars, flow sequences of scalars and comments. Anchors, tags, block
+// scalars, flow mappings and multi-document streams are rejected or not
+// recognized.
+type yamlParser struct {
+	lines []yamlLine
+	i     int
+}
+
+// decodeYAML decodes a YAML document into the values encoding/json decodes
+// JSON into: maps, slices, strings, float64, bool and nil.
+func decodeYAML(data []byte) (interface{}, error) {
+	p := &yamlParser{}
+	for n, raw := range strings.Split(string(data), "\n") {
+		raw = strings.TrimRight(raw, "\r")
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("line %d: tabs cannot indent YAML", n+1)
+		}
+		text = strings.TrimSpace(stripComment(text))
+		if text == "" || n == 0 && text == "---" {
+			continue
+		}
+		p.lines = append(p.lines, yamlLine{num: n + 1, indent: len(raw) - len(strings.TrimLeft(raw, " ")), text: text})
+	}
+	if len(p.lines) == 0 {
+		return nil, nil
+	}
+	v, err := p.node(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.i < len(p.lines) {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return v, nil
+}
+
+// stripComment removes the comment ending text, if any. A comment starts
+// with a # at the start of text or after a space, outside quotes.
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' '):
+			return text[:i]
+		}
+	}
+	return text
+}
+
+func (p *yamlParser) errorf(format string, args ...interface{}) error {
+	line := p.lines[len(p.lines)-1].num
+	if p.i < len(p.lines) {
+		line = p.lines[p.i].num
+	}
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// node decodes the block node starting at the current line, indented by
+// indent.
+func (p *yamlParser) node(indent int) (interface{}, error) {
+	l := p.lines[p.i]
+	switch {
+	case isSequenceEntry(l.text):
+		return p.sequence(indent)
+	case keyEnd(l.text) >= 0:
+		return p.mapping(indent)
+	}
+	v, err := scalar(l.text)
+	if err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	p.i++
+	return v, nil
+}
+
+func isSequenceEntry(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// keyEnd returns the index of the colon ending the key of a mapping entry
+// in text, or -1 if text is not one.
+func keyEnd(text string) int {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case i == 0 && (c == '"' || c == '\''):
+			quote = c
+		case c == '[' || c == '{':
+			if i == 0 {
+				return -1
+			}
+		case c == ':' && (i+1 == len(text) || text[i+1] == ' '):
+			return i
+		}
+	}
+	return -1
+}
+
+// sequence decodes the entries of a block sequence indented by indent.
+func (p *yamlParser) sequence(indent int) (interface{}, error) {
+	list := []interface{}{}
+	for p.i < len(p.lines) && p.lines[p.i].indent == indent && isSequenceEntry(p.lines[p.i].text) {
+		l := &p.lines[p.i]
+		rest := strings.TrimLeft(l.text[1:], " ")
+		var v interface{}
+		var err error
+		if rest == "" {
+			v, err = p.nested(indent)
+		} else {
+			// The entry continues as a node indented by the column of its
+			// content.
+			l.indent += len(l.text) - len(rest)
+			l.text = rest
+			v, err = p.node(l.indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+// mapping decodes the entries of a block mapping indented by indent.
+func (p *yamlParser) mapping(indent int) (interface{}, error) {
+	m := make(map[string]interface{})
+	for p.i < len(p.lines) && p.lines[p.i].indent == indent && !isSequenceEntry(p.lines[p.i].text) {
+		l := p.lines[p.i]
+		end := keyEnd(l.text)
+		if end < 0 {
+			return nil, p.errorf("expected a mapping entry")
+		}
+		k, err := scalar(strings.TrimSpace(l.text[:end]))
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		key, ok := k.(string)
+		if !ok {
+			key = fmt.Sprint(k)
+		}
+		if _, dup := m[key]; dup {
+			return nil, p.errorf("duplicate key %q", key)
+		}
+		var v interface{}
+		if rest := strings.TrimSpace(l.text[end+1:]); rest != "" {
+			if v, err = scalar(rest); err != nil {
+				return nil, p.errorf("%v", err)
+			}
+			p.i++
+		} else if p.i+1 < len(p.lines) && p.lines[p.i+1].indent == indent && isSequenceEntry(p.lines[p.i+1].text) {
+			// A sequence may be indented as much as the key it is the
+			// value of.
+			p.i++
+			if v, err = p.sequence(indent); err != nil {
+				return nil, err
+			}
+		} else if v, err = p.nested(indent); err != nil {
+			return nil, err
+		}
+		m[key] = v
+	}
+	return m, nil
+}
+
+// nested decodes the node following the current line if it is indented by
+// more than indent, or returns nil.
+func (p *yamlParser) nested(indent int) (interface{}, error) {
+	p.i++
+	if p.i == len(p.lines) || p.lines[p.i].indent <= indent {
+		return nil, nil
+	}
+	return p.node(p.lines[p.i].indent)
+}
+
+// scalar decodes a single-line scalar or flow sequence of scalars.
+func scalar(text string) (interface{}, error) {
+	switch {
+	case text == "":
+		return nil, nil
+	case strings.HasPrefix(text, `"`):
+		s, err := strconv.Unquote(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid double-quoted scalar %s", text)
+		}
+		return s, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("invalid single-quoted scalar %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case strings.HasPrefix(text, "["):
+		return flowSequence(text)
+	case text == "{}":
+		return map[string]interface{}{}, nil
+	case strings.HasPrefix(text, "{"):
+		return nil, fmt.Errorf("flow mappings are not supported; quote scalars starting with {")
+	case strings.ContainsAny(text[:1], "&*!|>%@`"):
+		return nil, fmt.Errorf("unsupported YAML syntax %s", text)
+	}
+	switch text {
+	case "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return float64(n), nil
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil && !strings.ContainsAny(text, "xXnN_") {
+		return f, nil
+	}
+	return text, nil
+}
+
+// flowSequence decodes a flow sequence of scalars such as [a, "b", 3].
+func flowSequence(text string) (interface{}, error) {
+	if !strings.HasSuffix(text, "]") {
+		return nil, fmt.Errorf("unclosed flow sequence %s", text)
+	}
+	list := []interface{}{}
+	body := strings.TrimSpace(text[1 : len(text)-1])
+	if body == "" {
+		return list, nil
+	}
+	var quote byte
+	start := 0
+	for i := 0; i <= len(body); i++ {
+		if i < len(body) {
+			switch c := body[i]; {
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				} else if c == '\\' && quote == '"' {
+					i++
+				}
+				continue
+			case c == '"' || c == '\'':
+				quote = c
+				continue
+			case c == '[' || c == '{':
+				return nil, fmt.Errorf("nested flow collections are not supported in %s", text)
+			case c != ',':
+				continue
+			}
+		}
+		v, err := scalar(strings.TrimSpace(body[start:i]))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+		start = i + 1
+	}
+	return list, nil
+}
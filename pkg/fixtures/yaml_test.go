@@ -0,0 +1,88 @@
+package fixtures
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want interface{}
+	}{
+		{"empty", "# nothing\n\n", nil},
+		{"scalar", "hello world", "hello world"},
+		{
+			name: "mapping",
+			yaml: "---\na: 1\nb: 2.5\nc: true\nd: ~\ne: text # comment\nf: a#b\n",
+			want: map[string]interface{}{"a": 1.0, "b": 2.5, "c": true, "d": nil, "e": "text", "f": "a#b"},
+		},
+		{
+			name: "quoted",
+			yaml: `a: "x: #y\t"` + "\nb: 'it''s'\n'c d': \"\"\n",
+			want: map[string]interface{}{"a": "x: #y\t", "b": "it's", "c d": ""},
+		},
+		{
+			name: "not numbers",
+			yaml: "a: 0x10\nb: NaN\nc: 1_000\nd: '7'\n",
+			want: map[string]interface{}{"a": "0x10", "b": "NaN", "c": "1_000", "d": "7"},
+		},
+		{
+			name: "nested",
+			yaml: "a:\n  b:\n    c: 1\n  d: {}\n",
+			want: map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": 1.0}, "d": map[string]interface{}{}}},
+		},
+		{
+			name: "sequences",
+			yaml: "a:\n  - 1\n  - x\nb:\n- c: 1\n  d: 2\n-\n  - e\nf: [1, 'g, h', \"i\", ]\ng: []\n",
+			want: map[string]interface{}{
+				"a": []interface{}{1.0, "x"},
+				"b": []interface{}{map[string]interface{}{"c": 1.0, "d": 2.0}, []interface{}{"e"}},
+				"f": []interface{}{1.0, "g, h", "i", nil},
+				"g": []interface{}{},
+			},
+		},
+		{
+			name: "null values",
+			yaml: "a:\nb:\n",
+			want: map[string]interface{}{"a": nil, "b": nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeYAML([]byte(tt.yaml))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeYAMLErrors(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want string
+	}{
+		{"a: 1\n\tb: 2\n", "line 2: tabs cannot indent YAML"},
+		{"a: 1\na: 2\n", `line 2: duplicate key "a"`},
+		{"a:\n    b: 1\n  c: 2\n", "line 3: unexpected indentation"},
+		{"a: 1\n- b\n", "line 2: unexpected indentation"},
+		{"a: 1\nb\n", "line 2: expected a mapping entry"},
+		{"a: {b: 1}\n", "line 1: flow mappings are not supported; quote scalars starting with {"},
+		{"a: &x 1\nb: 2\n", "line 1: unsupported YAML syntax &x 1"},
+		{"a: |\n  text\n", "line 1: unsupported YAML syntax |"},
+		{"a: \"x\n", `line 1: invalid double-quoted scalar "x`},
+		{"a: 'x\n", "line 1: invalid single-quoted scalar 'x"},
+		{"a: [1, [2]]\n", "line 1: nested flow collections are not supported in [1, [2]]"},
+		{"a: [1, 2\n", "line 1: unclosed flow sequence [1, 2"},
+	}
+	for _, tt := range tests {
+		if _, err := decodeYAML([]byte(tt.yaml)); err == nil || err.Error() != tt.want {
+			t.Errorf("decodeYAML(%q): got %v, want %s", tt.yaml, err, tt.want)
+		}
+	}
+}
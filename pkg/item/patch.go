@@ -0,0 +1,479 @@
+package item
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+
+	dto "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/nexus/v4/config"
+)
+
+// Media types accepted by Patch.
+const (
+	MergePatchMediaType = "application/merge-patch+json"
+	JSONPatchMediaType  = "application/json-patch+json"
+)
+
+var (
+	// ErrUnsupportedMediaType is returned when the patch document is neither
+	// a merge-patch nor a JSON-patch.
+	ErrUnsupportedMediaType = errors.New("unsupported patch media type")
+	// ErrTestFailed is returned when a JSON-patch "test" operation does not
+	// match the current item.
+	ErrTestFailed = errors.New("json-patch test operation failed")
+)
+
+// PatchError reports a malformed patch document or an operation that cannot
+// be applied to the item.
+type PatchError struct {
+	Op     string
+	Path   string
+	Reason string
+	Err    error
+}
+
+func (e *PatchError) Error() string {
+	if e.Op == "" {
+		return "invalid patch document: " + e.Reason
+	}
+	return fmt.Sprintf("cannot apply %q operation at %q: %s", e.Op, e.Path, e.Reason)
+}
+
+func (e *PatchError) Unwrap() error { return e.Err }
+
+// Patch applies body to item according to mediaType and returns the patched
+// copy. item itself is left untouched. The result is re-validated against the
+// Item schema, and any change to a read-only property is rejected with a
+// *ValidationError.
+func Patch(item *dto.Item, mediaType string, body []byte) (*dto.Item, error) {
+	mt, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, mediaType)
+	}
+	switch mt {
+	case MergePatchMediaType:
+		return MergePatch(item, body)
+	case JSONPatchMediaType:
+		return JSONPatch(item, body)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, mt)
+}
+
+// MergePatch applies an RFC 7396 JSON merge-patch document to item.
+func MergePatch(item *dto.Item, body []byte) (*dto.Item, error) {
+	var patch interface{}
+	if err := decode(body, &patch); err != nil {
+		return nil, &PatchError{Reason: err.Error(), Err: err}
+	}
+	if _, ok := patch.(map[string]interface{}); !ok {
+		return nil, &PatchError{Reason: "merge-patch document must be a JSON object"}
+	}
+	return apply(item, func(doc map[string]interface{}) (interface{}, error) {
+		return mergePatch(doc, patch), nil
+	})
+}
+
+// JSONPatch applies an RFC 6902 JSON-patch document to item. Operations are
+// applied in order and the patch is atomic: if any operation fails the item
+// is not changed.
+func JSONPatch(item *dto.Item, body []byte) (*dto.Item, error) {
+	var ops []jsonPatchOp
+	if err := decode(body, &ops); err != nil {
+		return nil, &PatchError{Reason: err.Error(), Err: err}
+	}
+	return apply(item, func(doc map[string]interface{}) (interface{}, error) {
+		var target interface{} = doc
+		for _, op := range ops {
+			var err error
+			if target, err = op.apply(target); err != nil {
+				return nil, err
+			}
+		}
+		return target, nil
+	})
+}
+
+// apply round-trips item through its DTO JSON form, hands the document to fn
+// and converts the result back, enforcing the Item schema on the way out.
+func apply(item *dto.Item, fn func(map[string]interface{}) (interface{}, error)) (*dto.Item, error) {
+	original, err := toDocument(item)
+	if err != nil {
+		return nil, err
+	}
+	working, err := toDocument(item)
+	if err != nil {
+		return nil, err
+	}
+	result, err := fn(working)
+	if err != nil {
+		return nil, err
+	}
+	patched, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, &PatchError{Reason: "patch must leave the item a JSON object"}
+	}
+
+	verr := &ValidationError{}
+	checkDocument(verr, original, patched)
+	if len(verr.Violations) > 0 {
+		return nil, verr
+	}
+
+	b, err := json.Marshal(patched)
+	if err != nil {
+		return nil, err
+	}
+	out := dto.NewItem()
+	if err := json.Unmarshal(b, out); err != nil {
+		return nil, &PatchError{Reason: err.Error(), Err: err}
+	}
+	if err := Validate(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// checkDocument rejects patches that touch read-only or metadata properties
+// or introduce properties the Item schema does not define.
+func checkDocument(verr *ValidationError, original, patched map[string]interface{}) {
+	known := make(map[string]bool)
+	for _, name := range writableProperties {
+		known[name] = true
+	}
+	for _, name := range readOnlyProperties {
+		known[name] = true
+		if !reflect.DeepEqual(original[name], patched[name]) {
+			verr.add(name, "is read-only")
+		}
+	}
+	for name, value := range patched {
+		switch {
+		case strings.HasPrefix(name, "$"):
+			if !reflect.DeepEqual(original[name], value) {
+				verr.add(name, "is read-only")
+			}
+		case !known[name]:
+			verr.add(name, "is not a property of Item")
+		}
+	}
+	for name := range original {
+		if _, ok := patched[name]; !ok && strings.HasPrefix(name, "$") {
+			verr.add(name, "is read-only")
+		}
+	}
+}
+
+func toDocument(item *dto.Item) (map[string]interface{}, error) {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := decode(b, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// decode unmarshals b keeping numbers as json.Number so that integer
+// properties such as itemId survive the round trip unchanged.
+func decode(b []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{})
+	}
+	for name, value := range p {
+		if value == nil {
+			delete(t, name)
+			continue
+		}
+		t[name] = mergePatch(t[name], value)
+	}
+	return t
+}
+
+type jsonPatchOp struct {
+	Op    string           `json:"op"`
+	Path  *string          `json:"path"`
+	From  *string          `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+func (o jsonPatchOp) fail(reason string, err error) error {
+	path := ""
+	if o.Path != nil {
+		path = *o.Path
+	}
+	return &PatchError{Op: o.Op, Path: path, Reason: reason, Err: err}
+}
+
+func (o jsonPatchOp) value() (interface{}, error) {
+	if o.Value == nil {
+		return nil, o.fail(`missing "value"`, nil)
+	}
+	var v interface{}
+	if err := decode(*o.Value, &v); err != nil {
+		return nil, o.fail(err.Error(), err)
+	}
+	return v, nil
+}
+
+func (o jsonPatchOp) apply(doc interface{}) (interface{}, error) {
+	if o.Path == nil {
+		return nil, o.fail(`missing "path"`, nil)
+	}
+	path, err := parsePointer(*o.Path)
+	if err != nil {
+		return nil, o.fail(err.Error(), err)
+	}
+	switch o.Op {
+	case "add":
+		v, err := o.value()
+		if err != nil {
+			return nil, err
+		}
+		return o.wrap(add(doc, path, v))
+	case "remove":
+		doc, _, err := remove(doc, path)
+		return o.wrap(doc, err)
+	case "replace":
+		v, err := o.value()
+		if err != nil {
+			return nil, err
+		}
+		if doc, _, err = remove(doc, path); err != nil {
+			return nil, o.fail(err.Error(), err)
+		}
+		return o.wrap(add(doc, path, v))
+	case "move", "copy":
+		if o.From == nil {
+			return nil, o.fail(`missing "from"`, nil)
+		}
+		from, err := parsePointer(*o.From)
+		if err != nil {
+			return nil, o.fail(err.Error(), err)
+		}
+		var v interface{}
+		if o.Op == "move" {
+			if isPrefix(from, path) && len(from) < len(path) {
+				return nil, o.fail("cannot move a value into one of its children", nil)
+			}
+			doc, v, err = remove(doc, from)
+		} else {
+			v, err = get(doc, from)
+			v = deepCopy(v)
+		}
+		if err != nil {
+			return nil, o.fail(err.Error(), err)
+		}
+		return o.wrap(add(doc, path, v))
+	case "test":
+		want, err := o.value()
+		if err != nil {
+			return nil, err
+		}
+		got, err := get(doc, path)
+		if err != nil {
+			return nil, o.fail(err.Error(), err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			return nil, o.fail("value does not match", ErrTestFailed)
+		}
+		return doc, nil
+	}
+	return nil, o.fail("unknown operation", nil)
+}
+
+func (o jsonPatchOp) wrap(doc interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return nil, o.fail(err.Error(), err)
+	}
+	return doc, nil
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into unescaped reference
+// tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("json pointer %q must start with '/'", p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	limit := length - 1
+	if allowEnd {
+		limit = length
+	}
+	if i > limit {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+	return i, nil
+}
+
+func get(doc interface{}, path []string) (interface{}, error) {
+	cur := doc
+	for _, token := range path {
+		switch c := cur.(type) {
+		case map[string]interface{}:
+			v, ok := c[token]
+			if !ok {
+				return nil, fmt.Errorf("path %q does not exist", token)
+			}
+			cur = v
+		case []interface{}:
+			i, err := arrayIndex(token, len(c), false)
+			if err != nil {
+				return nil, err
+			}
+			cur = c[i]
+		default:
+			return nil, fmt.Errorf("path %q does not exist", token)
+		}
+	}
+	return cur, nil
+}
+
+// add sets path to value and returns the (possibly new) root.
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[last] = value
+		return doc, nil
+	case []interface{}:
+		i, err := arrayIndex(last, len(p), true)
+		if err != nil {
+			return nil, err
+		}
+		p = append(p, nil)
+		copy(p[i+1:], p[i:])
+		p[i] = value
+		return set(doc, path[:len(path)-1], p)
+	}
+	return nil, fmt.Errorf("cannot add to a scalar value")
+}
+
+// set replaces the existing value at path by value and returns the
+// (possibly new) root. Unlike add, it never inserts into an array, so that
+// arrays grown or shrunk in place can be written back to their parent.
+func set(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[last] = value
+		return doc, nil
+	case []interface{}:
+		i, err := arrayIndex(last, len(p), false)
+		if err != nil {
+			return nil, err
+		}
+		p[i] = value
+		return doc, nil
+	}
+	return nil, fmt.Errorf("path %q does not exist", last)
+}
+
+// remove deletes path and returns the new root and the removed value.
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		v, ok := p[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("path %q does not exist", last)
+		}
+		delete(p, last)
+		return doc, v, nil
+	case []interface{}:
+		i, err := arrayIndex(last, len(p), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		v := p[i]
+		p = append(p[:i:i], p[i+1:]...)
+		doc, err = set(doc, path[:len(path)-1], p)
+		return doc, v, err
+	}
+	return nil, nil, fmt.Errorf("path %q does not exist", last)
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[k] = deepCopy(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(t))
+		for i, e := range t {
+			s[i] = deepCopy(e)
+		}
+		return s
+	}
+	return v
+}
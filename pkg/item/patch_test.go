@@ -0,0 +1,246 @@
+package item
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	dto "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/nexus/v4/config"
+)
+
+// applyOps applies the JSON-patch document ops to the JSON document doc.
+func applyOps(t *testing.T, doc, ops string) (interface{}, error) {
+	t.Helper()
+	var target interface{}
+	if err := decode([]byte(doc), &target); err != nil {
+		t.Fatal(err)
+	}
+	var list []jsonPatchOp
+	if err := decode([]byte(ops), &list); err != nil {
+		t.Fatal(err)
+	}
+	for _, op := range list {
+		var err error
+		if target, err = op.apply(target); err != nil {
+			return nil, err
+		}
+	}
+	return target, nil
+}
+
+func TestJSONPatchOps(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		ops  string
+		want string // empty when the patch fails
+	}{
+		{"add property", `{"a":1}`, `[{"op":"add","path":"/b","value":2}]`, `{"a":1,"b":2}`},
+		{"add replaces property", `{"a":1}`, `[{"op":"add","path":"/a","value":2}]`, `{"a":2}`},
+		{"add into array", `{"a":[1,2]}`, `[{"op":"add","path":"/a/1","value":9}]`, `{"a":[1,9,2]}`},
+		{"add at array end", `{"a":[1,2]}`, `[{"op":"add","path":"/a/-","value":9}]`, `{"a":[1,2,9]}`},
+		{"add into nested array", `{"a":[[1,2]]}`, `[{"op":"add","path":"/a/0/1","value":9}]`, `{"a":[[1,9,2]]}`},
+		{"add into deeply nested array", `{"a":[[[1]],[[2]]]}`, `[{"op":"add","path":"/a/1/0/-","value":3}]`, `{"a":[[[1]],[[2,3]]]}`},
+		{"add past array end", `{"a":[1]}`, `[{"op":"add","path":"/a/2","value":9}]`, ``},
+		{"add to missing parent", `{}`, `[{"op":"add","path":"/a/b","value":1}]`, ``},
+		{"add without value", `{}`, `[{"op":"add","path":"/a"}]`, ``},
+		{"remove property", `{"a":1,"b":2}`, `[{"op":"remove","path":"/a"}]`, `{"b":2}`},
+		{"remove from array", `{"a":[1,2,3]}`, `[{"op":"remove","path":"/a/1"}]`, `{"a":[1,3]}`},
+		{"remove from nested array", `{"a":[[1,2],[3]]}`, `[{"op":"remove","path":"/a/0/0"}]`, `{"a":[[2],[3]]}`},
+		{"remove missing property", `{"a":1}`, `[{"op":"remove","path":"/b"}]`, ``},
+		{"remove array end", `{"a":[1]}`, `[{"op":"remove","path":"/a/-"}]`, ``},
+		{"replace property", `{"a":1}`, `[{"op":"replace","path":"/a","value":"x"}]`, `{"a":"x"}`},
+		{"replace in nested array", `{"a":[[1,2]]}`, `[{"op":"replace","path":"/a/0/1","value":9}]`, `{"a":[[1,9]]}`},
+		{"replace missing property", `{}`, `[{"op":"replace","path":"/a","value":1}]`, ``},
+		{"move property", `{"a":{"b":1}}`, `[{"op":"move","from":"/a/b","path":"/c"}]`, `{"a":{},"c":1}`},
+		{"move between nested arrays", `{"a":[[1,2],[3]]}`, `[{"op":"move","from":"/a/0/0","path":"/a/1/-"}]`, `{"a":[[2],[3,1]]}`},
+		{"move into child", `{"a":{"b":1}}`, `[{"op":"move","from":"/a","path":"/a/c"}]`, ``},
+		{"move without from", `{"a":1}`, `[{"op":"move","path":"/b"}]`, ``},
+		{"copy property", `{"a":[1]}`, `[{"op":"copy","from":"/a","path":"/b"},{"op":"add","path":"/b/-","value":2}]`, `{"a":[1],"b":[1,2]}`},
+		{"escaped tokens", `{"a/b":1,"c~d":2}`, `[{"op":"remove","path":"/a~1b"},{"op":"remove","path":"/c~0d"}]`, `{}`},
+		{"test matches", `{"a":[1,{"b":"c"}]}`, `[{"op":"test","path":"/a","value":[1,{"b":"c"}]}]`, `{"a":[1,{"b":"c"}]}`},
+		{"test mismatches", `{"a":1}`, `[{"op":"test","path":"/a","value":2}]`, ``},
+		{"failure after success", `{"a":1}`, `[{"op":"add","path":"/b","value":2},{"op":"remove","path":"/c"}]`, ``},
+		{"unknown operation", `{"a":1}`, `[{"op":"increment","path":"/a"}]`, ``},
+		{"pointer without slash", `{"a":1}`, `[{"op":"remove","path":"a"}]`, ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyOps(t, tt.doc, tt.ops)
+			if tt.want == "" {
+				var perr *PatchError
+				if !errors.As(err, &perr) {
+					t.Fatalf("patch succeeded with %v or failed with %v, want a *PatchError", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want interface{}
+			if err := decode([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				b, _ := json.Marshal(got)
+				t.Errorf("got %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
+
+func testItem() *dto.Item {
+	item := dto.NewItem()
+	extId, name, typ, description := "4c6a1b4e-0000-4000-8000-000000000001", "disk", "storage", "a disk"
+	itemId := 7
+	item.ExtId = &extId
+	item.ItemId = &itemId
+	item.ItemName = &name
+	item.ItemType = &typ
+	item.Description = &description
+	return item
+}
+
+func TestPatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		mediaType string
+		body      string
+		check     func(t *testing.T, item *dto.Item)
+		// wantErr is the kind of error expected, nil on success.
+		wantErr interface{}
+	}{
+		{
+			name:      "merge-patch sets a property",
+			mediaType: MergePatchMediaType,
+			body:      `{"itemName":"volume"}`,
+			check: func(t *testing.T, item *dto.Item) {
+				if *item.ItemName != "volume" || *item.ItemType != "storage" || *item.ItemId != 7 {
+					t.Errorf("got itemName %q, itemType %q and itemId %d", *item.ItemName, *item.ItemType, *item.ItemId)
+				}
+			},
+		},
+		{
+			name:      "merge-patch removes a property",
+			mediaType: MergePatchMediaType,
+			body:      `{"description":null}`,
+			check: func(t *testing.T, item *dto.Item) {
+				if item.Description != nil {
+					t.Errorf("description is %q, want none", *item.Description)
+				}
+			},
+		},
+		{
+			name:      "merge-patch with parameters",
+			mediaType: MergePatchMediaType + "; charset=utf-8",
+			body:      `{"itemType":"network"}`,
+			check: func(t *testing.T, item *dto.Item) {
+				if *item.ItemType != "network" {
+					t.Errorf("itemType is %q, want network", *item.ItemType)
+				}
+			},
+		},
+		{
+			name:      "json-patch",
+			mediaType: JSONPatchMediaType,
+			body:      `[{"op":"test","path":"/itemName","value":"disk"},{"op":"replace","path":"/itemName","value":"volume"}]`,
+			check: func(t *testing.T, item *dto.Item) {
+				if *item.ItemName != "volume" {
+					t.Errorf("itemName is %q, want volume", *item.ItemName)
+				}
+			},
+		},
+		{
+			name:      "json-patch test failure",
+			mediaType: JSONPatchMediaType,
+			body:      `[{"op":"test","path":"/itemName","value":"other"}]`,
+			wantErr:   ErrTestFailed,
+		},
+		{
+			name:      "read-only property",
+			mediaType: MergePatchMediaType,
+			body:      `{"itemId":8}`,
+			wantErr:   new(*ValidationError),
+		},
+		{
+			name:      "removed read-only property",
+			mediaType: JSONPatchMediaType,
+			body:      `[{"op":"remove","path":"/extId"}]`,
+			wantErr:   new(*ValidationError),
+		},
+		{
+			name:      "unknown property",
+			mediaType: MergePatchMediaType,
+			body:      `{"color":"red"}`,
+			wantErr:   new(*ValidationError),
+		},
+		{
+			name:      "required property removed",
+			mediaType: MergePatchMediaType,
+			body:      `{"itemName":null}`,
+			wantErr:   new(*ValidationError),
+		},
+		{
+			name:      "name too long",
+			mediaType: MergePatchMediaType,
+			body:      `{"itemName":"` + strings.Repeat("x", itemNameMaxLength+1) + `"}`,
+			wantErr:   new(*ValidationError),
+		},
+		{
+			name:      "merge-patch not an object",
+			mediaType: MergePatchMediaType,
+			body:      `["itemName"]`,
+			wantErr:   new(*PatchError),
+		},
+		{
+			name:      "malformed json-patch",
+			mediaType: JSONPatchMediaType,
+			body:      `{"op":"add"}`,
+			wantErr:   new(*PatchError),
+		},
+		{
+			name:      "json-patch replacing the document",
+			mediaType: JSONPatchMediaType,
+			body:      `[{"op":"replace","path":"","value":[]}]`,
+			wantErr:   new(*PatchError),
+		},
+		{
+			name:      "unsupported media type",
+			mediaType: "application/json",
+			body:      `{"itemName":"volume"}`,
+			wantErr:   ErrUnsupportedMediaType,
+		},
+		{
+			name:      "malformed media type",
+			mediaType: "merge patch",
+			body:      `{"itemName":"volume"}`,
+			wantErr:   ErrUnsupportedMediaType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := testItem()
+			got, err := Patch(item, tt.mediaType, []byte(tt.body))
+			if !reflect.DeepEqual(item, testItem()) {
+				t.Error("Patch modified the item it was given")
+			}
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatal(err)
+				}
+				tt.check(t, got)
+			case error:
+				if !errors.Is(err, want) {
+					t.Errorf("got %v, want %v", err, want)
+				}
+			default:
+				if !errors.As(err, want) {
+					t.Errorf("got %v, want a %T", err, reflect.ValueOf(want).Elem().Interface())
+				}
+			}
+		})
+	}
+}
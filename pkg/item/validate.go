@@ -0,0 +1,89 @@
+// Package item holds the server-side rules for nexus.v4.config.Item that are
+// not expressed by the generated DTOs: schema validation and partial updates.
+//
+// The constraints below mirror the Item schema in itemModel.yaml, which the
+// generated code does not carry; TestSchema fails when they drift apart.
+package item
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	dto "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/nexus/v4/config"
+)
+
+const (
+	itemNameMinLength = 1
+	itemNameMaxLength = 100
+	itemTypeMinLength = 1
+	itemTypeMaxLength = 50
+	associationsMax   = 100
+)
+
+// readOnlyProperties are the Item properties marked readOnly in
+// itemModel.yaml. They are assigned by the server and cannot be written by
+// clients.
+var readOnlyProperties = []string{"itemId", "extId", "associations", "tenantInfo", "links", "deletedAt"}
+
+// writableProperties are the Item properties a client may set.
+var writableProperties = []string{"itemName", "itemType", "description"}
+
+// Violation describes a single schema constraint that an item does not
+// satisfy.
+type Violation struct {
+	// AttributePath is the JSON property the violation refers to.
+	AttributePath string
+	Message       string
+}
+
+// ValidationError is returned when an item does not conform to the Item
+// schema. It carries every violation found, not just the first.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.AttributePath+": "+v.Message)
+	}
+	return "item validation failed: " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) add(path, format string, args ...interface{}) {
+	e.Violations = append(e.Violations, Violation{AttributePath: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (e *ValidationError) orNil() error {
+	if len(e.Violations) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Validate checks item against the required and length constraints of the
+// Item schema.
+func Validate(item *dto.Item) error {
+	verr := &ValidationError{}
+	checkString(verr, "itemName", item.ItemName, itemNameMinLength, itemNameMaxLength)
+	checkString(verr, "itemType", item.ItemType, itemTypeMinLength, itemTypeMaxLength)
+	if len(item.Associations) > associationsMax {
+		verr.add("associations", "must contain at most %d elements", associationsMax)
+	}
+	return verr.orNil()
+}
+
+func checkString(verr *ValidationError, path string, value *string, min, max int) {
+	if value == nil {
+		verr.add(path, "is required")
+		return
+	}
+	n := utf8.RuneCountInString(*value)
+	if n < min {
+		verr.add(path, "must be at least %d characters long", min)
+	}
+	if n > max {
+		verr.add(path, "must be at most %d characters long", max)
+	}
+}
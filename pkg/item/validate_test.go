@@ -0,0 +1,172 @@
+package item
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	dto "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/nexus/v4/config"
+)
+
+const itemModelPath = "../../golang-nexus-api-definitions/defs/namespaces/nexus/versioned/v4/modules/config/released/models/itemModel.yaml"
+
+// schemaProperty holds the scalar keywords of a property of a schema in
+// itemModel.yaml, such as readOnly and maxLength, nested ones included.
+type schemaProperty map[string]string
+
+// readItemSchema returns the properties and the required properties of the
+// Item schema in itemModel.yaml. It only understands the block mappings and
+// sequences the file is written in.
+func readItemSchema(t *testing.T) (map[string]schemaProperty, []string) {
+	t.Helper()
+	data, err := os.ReadFile(itemModelPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties := make(map[string]schemaProperty)
+	var required []string
+	var section string
+	var current schemaProperty
+	inItem := false
+	for _, line := range strings.Split(string(data), "\n") {
+		text := strings.TrimSpace(line)
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indent == 4:
+			inItem = text == "Item:"
+			continue
+		case !inItem || indent < 6:
+			continue
+		case indent == 6:
+			section = strings.TrimSuffix(text, ":")
+			continue
+		}
+		switch {
+		case section == "required" && indent == 8:
+			required = append(required, strings.TrimSpace(strings.TrimPrefix(text, "-")))
+		case section == "properties" && indent == 8:
+			current = make(schemaProperty)
+			properties[strings.TrimSuffix(text, ":")] = current
+		case section == "properties":
+			if key, value, ok := strings.Cut(text, ":"); ok && value != "" {
+				current[key] = strings.Trim(strings.TrimSpace(value), `"`)
+			}
+		}
+	}
+	if len(properties) == 0 {
+		t.Fatalf("%s defines no Item properties", itemModelPath)
+	}
+	return properties, required
+}
+
+// TestSchema checks the constraints Validate and Patch enforce against
+// the Item schema they mirror.
+func TestSchema(t *testing.T) {
+	properties, required := readItemSchema(t)
+	limit := func(property, keyword string) int {
+		t.Helper()
+		n, err := strconv.Atoi(properties[property][keyword])
+		if err != nil {
+			t.Fatalf("%s of %s: %v", keyword, property, err)
+		}
+		return n
+	}
+	limits := []struct {
+		property, keyword string
+		value             int
+	}{
+		{"itemName", "minLength", itemNameMinLength},
+		{"itemName", "maxLength", itemNameMaxLength},
+		{"itemType", "minLength", itemTypeMinLength},
+		{"itemType", "maxLength", itemTypeMaxLength},
+		{"associations", "maxItems", associationsMax},
+	}
+	for _, l := range limits {
+		if want := limit(l.property, l.keyword); l.value != want {
+			t.Errorf("%s of %s is %d, itemModel.yaml says %d", l.keyword, l.property, l.value, want)
+		}
+	}
+	// Validate requires exactly the properties it checks the length of.
+	if want := []string{"itemName", "itemType"}; !slices.Equal(required, want) {
+		t.Errorf("itemModel.yaml requires %v, Validate requires %v", required, want)
+	}
+	var readOnly, writable []string
+	for name, p := range properties {
+		if p["readOnly"] == "true" {
+			readOnly = append(readOnly, name)
+		} else {
+			writable = append(writable, name)
+		}
+	}
+	sorted := func(names []string) []string {
+		return slices.Sorted(slices.Values(names))
+	}
+	if got, want := sorted(readOnlyProperties), sorted(readOnly); !slices.Equal(got, want) {
+		t.Errorf("readOnlyProperties = %v, itemModel.yaml marks %v read-only", got, want)
+	}
+	if got, want := sorted(writableProperties), sorted(writable); !slices.Equal(got, want) {
+		t.Errorf("writableProperties = %v, itemModel.yaml leaves %v writable", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name string
+		item *dto.Item
+		// want lists the attribute paths of the violations expected.
+		want []string
+	}{
+		{
+			name: "valid",
+			item: &dto.Item{ItemName: str("disk"), ItemType: str("storage")},
+		},
+		{
+			name: "longest",
+			item: &dto.Item{ItemName: str(strings.Repeat("é", itemNameMaxLength)), ItemType: str(strings.Repeat("t", itemTypeMaxLength))},
+		},
+		{
+			name: "missing",
+			item: &dto.Item{},
+			want: []string{"itemName", "itemType"},
+		},
+		{
+			name: "empty",
+			item: &dto.Item{ItemName: str(""), ItemType: str("storage")},
+			want: []string{"itemName"},
+		},
+		{
+			name: "too long",
+			item: &dto.Item{ItemName: str("disk"), ItemType: str(strings.Repeat("t", itemTypeMaxLength+1))},
+			want: []string{"itemType"},
+		},
+		{
+			name: "too many associations",
+			item: &dto.Item{ItemName: str("disk"), ItemType: str("storage"), Associations: make([]dto.ItemAssociation, associationsMax+1)},
+			want: []string{"associations"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.item)
+			var paths []string
+			var verr *ValidationError
+			if errors.As(err, &verr) {
+				for _, v := range verr.Violations {
+					paths = append(paths, v.AttributePath)
+				}
+			} else if err != nil {
+				t.Fatalf("got %v, want a *ValidationError", err)
+			}
+			if !slices.Equal(paths, tt.want) {
+				t.Errorf("got violations of %v, want %v", paths, tt.want)
+			}
+		})
+	}
+}
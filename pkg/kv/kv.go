@@ -0,0 +1,381 @@
+// Package kv is an embedded, ordered key-value database kept in a single
+// append-only file.
+//
+// Every write appends a record to the file, and an in-memory directory of
+// the sorted live keys locates the latest record of each. Records carry a
+// CRC-32C and are written in batches whose last record is flagged as the
+// end of the batch, so that a batch torn by a crash is discarded when the
+// database is reopened. Records superseded by later writes are reclaimed
+// by Compact, which runs automatically once they outweigh the live ones.
+package kv
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Record flags.
+const (
+	flagDelete   byte = 1 << iota // the record deletes its key
+	flagBatchEnd                  // the record is the last of its batch
+)
+
+// headerSize is the size of the fixed part of a record: its CRC-32C, flags,
+// key size and value size.
+const headerSize = 4 + 1 + 4 + 4
+
+// compactMinGarbage is the number of bytes of superseded records below
+// which a database is never compacted automatically.
+const compactMinGarbage = 4 << 20
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrClosed is returned by the operations of a closed DB.
+var ErrClosed = errors.New("kv: database closed")
+
+// DB is a key-value database. It is safe for concurrent use.
+type DB struct {
+	mu   sync.RWMutex
+	path string
+	f    *os.File
+	// keys holds the live keys in order, and index the location of their
+	// values in f.
+	keys  []string
+	index map[string]location
+	// size is the size of f, and garbage the number of its bytes taken by
+	// superseded records.
+	size    int64
+	garbage int64
+	// compactErr is the error of the last automatic compaction, which is
+	// retried on every batch until it succeeds.
+	compactErr error
+}
+
+// location is the location of a record and its value within the file.
+type location struct {
+	offset int64 // of the record
+	size   int64 // of the record
+	value  int64 // offset of the value
+	length int   // of the value
+}
+
+// Op is a write of a Batch.
+type Op struct {
+	Key string
+	// Value is the new value of Key, unless Delete is set.
+	Value  []byte
+	Delete bool
+}
+
+// Open opens the database in the file at path, creating it if needed.
+func Open(path string) (*DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	db := &DB{path: path, f: f, index: make(map[string]location)}
+	if err := db.load(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("kv: %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// load reads the records of the file into the index, truncating the file
+// after the last complete batch.
+func (db *DB) load() error {
+	info, err := db.f.Stat()
+	if err != nil {
+		return err
+	}
+	r := bufio.NewReader(io.NewSectionReader(db.f, 0, 1<<62))
+	var offset int64
+	type pending struct {
+		key string
+		loc location
+		del bool
+	}
+	var batch []pending
+	for {
+		flags, key, value, size, err := readRecord(r, info.Size()-offset)
+		if err != nil {
+			if err != io.EOF && !errors.Is(err, errTorn) {
+				return err
+			}
+			break
+		}
+		batch = append(batch, pending{
+			key: key,
+			loc: location{offset: offset, size: size, value: offset + size - int64(len(value)), length: len(value)},
+			del: flags&flagDelete != 0,
+		})
+		offset += size
+		if flags&flagBatchEnd == 0 {
+			continue
+		}
+		for _, p := range batch {
+			db.set(p.key, p.loc, p.del)
+		}
+		db.size = offset
+		batch = batch[:0]
+	}
+	return db.f.Truncate(db.size)
+}
+
+var errTorn = errors.New("torn record")
+
+// readRecord reads a record from r, returning its total size. Records
+// claiming to be larger than the remaining bytes of the file are torn, so
+// that a corrupted header never makes it allocate more than the file holds.
+func readRecord(r *bufio.Reader, remaining int64) (flags byte, key string, value []byte, size int64, err error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.EOF {
+			return 0, "", nil, 0, io.EOF
+		}
+		return 0, "", nil, 0, errTorn
+	}
+	keySize := binary.LittleEndian.Uint32(header[5:])
+	valueSize := binary.LittleEndian.Uint32(header[9:])
+	if int64(keySize)+int64(valueSize) > remaining-headerSize {
+		return 0, "", nil, 0, errTorn
+	}
+	body := make([]byte, int(keySize)+int(valueSize))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, "", nil, 0, errTorn
+	}
+	crc := crc32.Update(crc32.Checksum(header[4:], crcTable), crcTable, body)
+	if crc != binary.LittleEndian.Uint32(header[:4]) {
+		return 0, "", nil, 0, errTorn
+	}
+	return header[4], string(body[:keySize]), body[keySize:], int64(headerSize + len(body)), nil
+}
+
+// appendRecord appends the record of a write to b.
+func appendRecord(b []byte, flags byte, key string, value []byte) []byte {
+	start := len(b)
+	b = append(b, 0, 0, 0, 0, flags)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(key)))
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(value)))
+	b = append(b, key...)
+	b = append(b, value...)
+	binary.LittleEndian.PutUint32(b[start:], crc32.Checksum(b[start+4:], crcTable))
+	return b
+}
+
+// set records loc as the location of the latest record of key. The caller
+// must hold db.mu for writing.
+func (db *DB) set(key string, loc location, del bool) {
+	if old, ok := db.index[key]; ok {
+		db.garbage += old.size
+	} else if !del {
+		i, _ := slices.BinarySearch(db.keys, key)
+		db.keys = slices.Insert(db.keys, i, key)
+	}
+	if !del {
+		db.index[key] = loc
+		return
+	}
+	// The deletion record itself is garbage once the key is gone.
+	db.garbage += loc.size
+	if _, ok := db.index[key]; ok {
+		delete(db.index, key)
+		i, _ := slices.BinarySearch(db.keys, key)
+		db.keys = slices.Delete(db.keys, i, i+1)
+	}
+}
+
+// Get returns the value of key, or nil and false if it has none.
+func (db *DB) Get(key string) ([]byte, bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	if db.f == nil {
+		return nil, false, ErrClosed
+	}
+	loc, ok := db.index[key]
+	if !ok {
+		return nil, false, nil
+	}
+	value, err := db.read(loc)
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (db *DB) read(loc location) ([]byte, error) {
+	value := make([]byte, loc.length)
+	if _, err := db.f.ReadAt(value, loc.value); err != nil {
+		return nil, fmt.Errorf("kv: %s: %w", db.path, err)
+	}
+	return value, nil
+}
+
+// Scan calls fn with every key starting with prefix and its value, in key
+// order, until fn returns false. fn must not write to db.
+func (db *DB) Scan(prefix string, fn func(key string, value []byte) bool) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	if db.f == nil {
+		return ErrClosed
+	}
+	i, _ := slices.BinarySearch(db.keys, prefix)
+	for _, key := range db.keys[i:] {
+		if !strings.HasPrefix(key, prefix) {
+			break
+		}
+		value, err := db.read(db.index[key])
+		if err != nil {
+			return err
+		}
+		if !fn(key, value) {
+			break
+		}
+	}
+	return nil
+}
+
+// Batch applies ops atomically and durably: once it returns, all of them
+// survive a crash, and if it fails none of them is visible, even after a
+// crash. A failure of the compaction the batch triggers does not fail it.
+func (db *DB) Batch(ops []Op) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.f == nil {
+		return ErrClosed
+	}
+	var buf []byte
+	locs := make([]location, len(ops))
+	for i, op := range ops {
+		var flags byte
+		if op.Delete {
+			flags |= flagDelete
+		}
+		if i == len(ops)-1 {
+			flags |= flagBatchEnd
+		}
+		offset := int64(len(buf))
+		buf = appendRecord(buf, flags, op.Key, op.Value)
+		size := int64(len(buf)) - offset
+		locs[i] = location{
+			offset: db.size + offset,
+			size:   size,
+			value:  db.size + offset + size - int64(len(op.Value)),
+			length: len(op.Value),
+		}
+	}
+	if _, err := db.f.WriteAt(buf, db.size); err != nil {
+		// Drop whatever part of the batch made it to the file.
+		db.f.Truncate(db.size)
+		return err
+	}
+	if err := db.f.Sync(); err != nil {
+		db.f.Truncate(db.size)
+		return err
+	}
+	db.size += int64(len(buf))
+	for i, op := range ops {
+		db.set(op.Key, locs[i], op.Delete)
+	}
+	if db.garbage >= compactMinGarbage && db.garbage > db.size/2 {
+		// The garbage is left for a later batch to reclaim.
+		db.compactErr = db.compact()
+	}
+	return nil
+}
+
+// Compact rewrites the file with only the live records.
+func (db *DB) Compact() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.f == nil {
+		return ErrClosed
+	}
+	return db.compact()
+}
+
+func (db *DB) compact() error {
+	tmp := db.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	index := make(map[string]location, len(db.keys))
+	w := bufio.NewWriter(f)
+	var size int64
+	var buf []byte
+	for i, key := range db.keys {
+		value, err := db.read(db.index[key])
+		if err != nil {
+			f.Close()
+			return err
+		}
+		// The whole file is a single batch, which the rename below makes
+		// visible at once.
+		var flags byte
+		if i == len(db.keys)-1 {
+			flags = flagBatchEnd
+		}
+		buf = appendRecord(buf[:0], flags, key, value)
+		if _, err := w.Write(buf); err != nil {
+			f.Close()
+			return err
+		}
+		index[key] = location{
+			offset: size,
+			size:   int64(len(buf)),
+			value:  size + int64(len(buf)-len(value)),
+			length: len(value),
+		}
+		size += int64(len(buf))
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := os.Rename(tmp, db.path); err != nil {
+		f.Close()
+		return err
+	}
+	if dir, err := os.Open(filepath.Dir(db.path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	db.f.Close()
+	db.f = f
+	db.index = index
+	db.size = size
+	db.garbage = 0
+	return nil
+}
+
+// Close closes the database.
+func (db *DB) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.f == nil {
+		return ErrClosed
+	}
+	err := db.f.Close()
+	db.f = nil
+	return err
+}
@@ -0,0 +1,297 @@
+package kv
+
+import (
+	"encoding/binary"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// contents returns the live keys of db and their values.
+func contents(t *testing.T, db *DB) map[string]string {
+	t.Helper()
+	got := make(map[string]string)
+	if err := db.Scan("", func(key string, value []byte) bool {
+		got[key] = string(value)
+		return true
+	}); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	return got
+}
+
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.Size()
+}
+
+func TestRecovery(t *testing.T) {
+	committed := []Op{{Key: "a", Value: []byte("1")}, {Key: "b", Value: []byte("2")}}
+	last := []Op{{Key: "a", Value: []byte("10")}, {Key: "c", Value: []byte("3")}, {Key: "b", Delete: true}}
+	tests := []struct {
+		name string
+		// damage alters the file at path, in which the last batch takes
+		// the bytes from start on.
+		damage func(t *testing.T, path string, start int64)
+		want   map[string]string
+	}{
+		{
+			name:   "intact",
+			damage: func(*testing.T, string, int64) {},
+			want:   map[string]string{"a": "10", "c": "3"},
+		},
+		{
+			name: "torn in the middle of a record",
+			damage: func(t *testing.T, path string, start int64) {
+				if err := os.Truncate(path, start+headerSize+1); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name: "torn before the end of the batch",
+			damage: func(t *testing.T, path string, start int64) {
+				// Only the first two records of the batch survive whole.
+				if err := os.Truncate(path, fileSize(t, path)-headerSize-1); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name: "corrupted record",
+			damage: func(t *testing.T, path string, start int64) {
+				f, err := os.OpenFile(path, os.O_RDWR, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer f.Close()
+				if _, err := f.WriteAt([]byte{'x'}, start+headerSize); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name: "header claiming more than the file holds",
+			damage: func(t *testing.T, path string, start int64) {
+				var header [headerSize]byte
+				binary.LittleEndian.PutUint32(header[5:], 1<<31)
+				binary.LittleEndian.PutUint32(header[9:], 1<<31)
+				f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer f.Close()
+				if _, err := f.Write(header[:]); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: map[string]string{"a": "10", "c": "3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "db")
+			db, err := Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Batch(committed); err != nil {
+				t.Fatal(err)
+			}
+			start := db.size
+			if err := db.Batch(last); err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Close(); err != nil {
+				t.Fatal(err)
+			}
+			tt.damage(t, path, start)
+
+			db, err = Open(path)
+			if err != nil {
+				t.Fatalf("reopening: %v", err)
+			}
+			if got := contents(t, db); !maps.Equal(got, tt.want) {
+				t.Errorf("after reopening got %v, want %v", got, tt.want)
+			}
+			// The discarded tail must not hide the writes that follow.
+			if err := db.Batch([]Op{{Key: "d", Value: []byte("4")}}); err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Close(); err != nil {
+				t.Fatal(err)
+			}
+			db, err = Open(path)
+			if err != nil {
+				t.Fatalf("reopening after writing: %v", err)
+			}
+			defer db.Close()
+			want := maps.Clone(tt.want)
+			want["d"] = "4"
+			if got := contents(t, db); !maps.Equal(got, want) {
+				t.Errorf("after writing got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCompact(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  [][]Op
+		want map[string]string
+	}{
+		{
+			name: "empty",
+			want: map[string]string{},
+		},
+		{
+			name: "overwritten keys",
+			ops: [][]Op{
+				{{Key: "a", Value: []byte("1")}, {Key: "b", Value: []byte("2")}},
+				{{Key: "a", Value: []byte("3")}},
+				{{Key: "a", Value: []byte("4")}},
+			},
+			want: map[string]string{"a": "4", "b": "2"},
+		},
+		{
+			name: "deleted keys",
+			ops: [][]Op{
+				{{Key: "a", Value: []byte("1")}, {Key: "b", Value: []byte("2")}},
+				{{Key: "a", Delete: true}},
+				{{Key: "c", Delete: true}},
+			},
+			want: map[string]string{"b": "2"},
+		},
+		{
+			name: "everything deleted",
+			ops: [][]Op{
+				{{Key: "a", Value: []byte("1")}},
+				{{Key: "a", Delete: true}},
+			},
+			want: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "db")
+			db, err := Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, ops := range tt.ops {
+				if err := db.Batch(ops); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := db.Compact(); err != nil {
+				t.Fatalf("Compact: %v", err)
+			}
+			var live int64
+			for key, value := range tt.want {
+				live += int64(headerSize + len(key) + len(value))
+			}
+			if size := fileSize(t, path); size != live || db.size != live || db.garbage != 0 {
+				t.Errorf("after compacting the file holds %d bytes, size %d and garbage %d, want %d bytes of live records", size, db.size, db.garbage, live)
+			}
+			if got := contents(t, db); !maps.Equal(got, tt.want) {
+				t.Errorf("after compacting got %v, want %v", got, tt.want)
+			}
+			if err := db.Close(); err != nil {
+				t.Fatal(err)
+			}
+			db, err = Open(path)
+			if err != nil {
+				t.Fatalf("reopening: %v", err)
+			}
+			defer db.Close()
+			if got := contents(t, db); !maps.Equal(got, tt.want) {
+				t.Errorf("after reopening got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutomaticCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+	db, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	value := make([]byte, 64<<10)
+	var written int64
+	for i := 0; written < 2*compactMinGarbage; i++ {
+		if err := db.Batch([]Op{{Key: "k", Value: value}, {Key: "n", Value: []byte(strconv.Itoa(i))}}); err != nil {
+			t.Fatal(err)
+		}
+		written += int64(len(value))
+	}
+	// Garbage builds up again after every compaction, up to
+	// compactMinGarbage and the records superseding it.
+	if size := fileSize(t, path); size > compactMinGarbage+int64(2*len(value)) {
+		t.Errorf("superseded records were not reclaimed: the file holds %d bytes", size)
+	}
+	if v, ok, err := db.Get("k"); err != nil || !ok || len(v) != len(value) {
+		t.Errorf("Get(k) = %d bytes, %v, %v after compacting", len(v), ok, err)
+	}
+}
+
+func TestFailedCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+	db, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// A directory in the way of its temporary file makes compact fail.
+	if err := os.Mkdir(path+".tmp", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	value := make([]byte, 64<<10)
+	var i int
+	for ; db.compactErr == nil; i++ {
+		if i > 2*compactMinGarbage/len(value) {
+			t.Fatal("no compaction was attempted")
+		}
+		if err := db.Batch([]Op{{Key: "k", Value: value}, {Key: "n", Value: []byte(strconv.Itoa(i))}}); err != nil {
+			t.Fatalf("Batch failed with its compaction: %v", err)
+		}
+	}
+	want := map[string]string{"k": string(value), "n": strconv.Itoa(i - 1)}
+	if got := contents(t, db); !maps.Equal(got, want) {
+		t.Errorf("after the failed compaction got n = %q, want %q", got["n"], want["n"])
+	}
+	garbage := db.garbage
+	if err := os.Remove(path + ".tmp"); err != nil {
+		t.Fatal(err)
+	}
+	// The next batch retries the compaction.
+	if err := db.Batch([]Op{{Key: "n", Value: []byte("last")}}); err != nil {
+		t.Fatal(err)
+	}
+	if db.compactErr != nil || db.garbage >= garbage {
+		t.Errorf("after retrying garbage is %d bytes, was %d, with error %v", db.garbage, garbage, db.compactErr)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	db, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopening: %v", err)
+	}
+	defer db.Close()
+	want["n"] = "last"
+	if got := contents(t, db); !maps.Equal(got, want) {
+		t.Errorf("after reopening got n = %q, want %q", got["n"], want["n"])
+	}
+}
@@ -0,0 +1,177 @@
+// Package mappers converts between the JSON DTOs generated under
+// generated-code/dto and the protobuf messages served over gRPC.
+package mappers
+
+import (
+	"time"
+
+	commondto "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/common/v1/config"
+	dto "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/nexus/v4/config"
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ItemToDto converts a protobuf Item to its DTO form.
+func ItemToDto(in *pb.Item) *dto.Item {
+	if in == nil {
+		return nil
+	}
+	out := dto.NewItem()
+	if in.ItemId != nil {
+		id := int(in.GetItemId())
+		out.ItemId = &id
+	}
+	out.ItemName = copyString(in.ItemName)
+	out.ItemType = copyString(in.ItemType)
+	out.Description = copyString(in.Description)
+	out.ExtId = copyString(in.ExtId)
+	if in.Associations != nil {
+		out.Associations = make([]dto.ItemAssociation, 0, len(in.GetAssociations().GetValue()))
+		for _, a := range in.GetAssociations().GetValue() {
+			out.Associations = append(out.Associations, *ItemAssociationToDto(a))
+		}
+	}
+	out.Links = ApiLinksToDto(in.Links)
+	out.TenantInfo = TenantInfoToDto(in.TenantInfo)
+	out.DeletedAt = timeToDto(in.DeletedAt)
+	return out
+}
+
+// ItemFromDto converts a DTO Item to its protobuf form.
+func ItemFromDto(in *dto.Item) *pb.Item {
+	if in == nil {
+		return nil
+	}
+	out := &pb.Item{
+		ItemName:    copyString(in.ItemName),
+		ItemType:    copyString(in.ItemType),
+		Description: copyString(in.Description),
+		ExtId:       copyString(in.ExtId),
+	}
+	if in.ItemId != nil {
+		out.ItemId = proto.Int32(int32(*in.ItemId))
+	}
+	if in.Associations != nil {
+		out.Associations = &pb.ItemAssociationArrayWrapper{}
+		for i := range in.Associations {
+			out.Associations.Value = append(out.Associations.Value, ItemAssociationFromDto(&in.Associations[i]))
+		}
+	}
+	out.Links = ApiLinksFromDto(in.Links)
+	out.TenantInfo = TenantInfoFromDto(in.TenantInfo)
+	out.DeletedAt = timeFromDto(in.DeletedAt)
+	return out
+}
+
+// ItemAssociationToDto converts a protobuf ItemAssociation to its DTO form.
+func ItemAssociationToDto(in *pb.ItemAssociation) *dto.ItemAssociation {
+	if in == nil {
+		return nil
+	}
+	out := dto.NewItemAssociation()
+	out.ItemId = copyString(in.ItemId)
+	out.EntityType = copyString(in.EntityType)
+	out.EntityId = copyString(in.EntityId)
+	if in.Count != nil {
+		count := int(in.GetCount())
+		out.Count = &count
+	}
+	out.TenantInfo = TenantInfoToDto(in.TenantInfo)
+	return out
+}
+
+// ItemAssociationFromDto converts a DTO ItemAssociation to its protobuf form.
+func ItemAssociationFromDto(in *dto.ItemAssociation) *pb.ItemAssociation {
+	if in == nil {
+		return nil
+	}
+	out := &pb.ItemAssociation{
+		ItemId:     copyString(in.ItemId),
+		EntityType: copyString(in.EntityType),
+		EntityId:   copyString(in.EntityId),
+	}
+	if in.Count != nil {
+		out.Count = proto.Int32(int32(*in.Count))
+	}
+	out.TenantInfo = TenantInfoFromDto(in.TenantInfo)
+	return out
+}
+
+// ItemChangeToDto converts a protobuf ItemChange to its DTO form. Changes
+// are only recorded by the server, so there is no conversion back.
+func ItemChangeToDto(in *pb.ItemChange) *dto.ItemChange {
+	if in == nil {
+		return nil
+	}
+	out := dto.NewItemChange()
+	if in.Sequence != nil {
+		seq := in.GetSequence()
+		out.Sequence = &seq
+	}
+	out.Time = timeToDto(in.Time)
+	out.Actor = copyString(in.Actor)
+	out.RequestId = copyString(in.RequestId)
+	out.Operation = copyString(in.Operation)
+	out.ItemExtId = copyString(in.ItemExtId)
+	out.EntityType = copyString(in.EntityType)
+	out.EntityId = copyString(in.EntityId)
+	out.TenantInfo = TenantInfoToDto(in.TenantInfo)
+	if in.Changes != nil {
+		out.Changes = make([]dto.PropertyChange, 0, len(in.GetChanges().GetValue()))
+		for _, c := range in.GetChanges().GetValue() {
+			pc := dto.NewPropertyChange()
+			pc.Path = copyString(c.Path)
+			pc.Before = copyString(c.Before)
+			pc.After = copyString(c.After)
+			out.Changes = append(out.Changes, *pc)
+		}
+	}
+	out.ItemBefore = ItemToDto(in.ItemBefore)
+	out.ItemAfter = ItemToDto(in.ItemAfter)
+	out.AssociationBefore = ItemAssociationToDto(in.AssociationBefore)
+	out.AssociationAfter = ItemAssociationToDto(in.AssociationAfter)
+	return out
+}
+
+// TenantInfoToDto converts a protobuf TenantAwareModel to its DTO form.
+func TenantInfoToDto(in *commonpb.TenantAwareModel) *commondto.TenantAwareModel {
+	if in == nil {
+		return nil
+	}
+	out := commondto.NewTenantAwareModel()
+	out.TenantId = copyString(in.TenantId)
+	return out
+}
+
+// TenantInfoFromDto converts a DTO TenantAwareModel to its protobuf form.
+func TenantInfoFromDto(in *commondto.TenantAwareModel) *commonpb.TenantAwareModel {
+	if in == nil {
+		return nil
+	}
+	return &commonpb.TenantAwareModel{TenantId: copyString(in.TenantId)}
+}
+
+func timeToDto(in *timestamppb.Timestamp) *time.Time {
+	if in == nil {
+		return nil
+	}
+	t := in.AsTime()
+	return &t
+}
+
+func timeFromDto(in *time.Time) *timestamppb.Timestamp {
+	if in == nil {
+		return nil
+	}
+	return timestamppb.New(*in)
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
@@ -0,0 +1,65 @@
+package mappers
+
+import (
+	"net/url"
+
+	import2 "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/common/v1/response"
+	responsepb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/response"
+	"google.golang.org/protobuf/proto"
+)
+
+// Relations of the HATEOAS links attached to items and list responses.
+const (
+	RelSelf         = "self"
+	RelAssociations = "associations"
+	RelNext         = "next"
+	RelPrev         = "prev"
+)
+
+// ItemLinks returns the links of the item with the given extId in the
+// collection served at collectionURL: a self link to the item and a related
+// link to the item with its associations expanded.
+func ItemLinks(collectionURL, extId string) *responsepb.ApiLinkArrayWrapper {
+	self := collectionURL + "/" + url.PathEscape(extId)
+	return &responsepb.ApiLinkArrayWrapper{
+		Value: []*responsepb.ApiLink{
+			NewApiLink(RelSelf, self),
+			NewApiLink(RelAssociations, self+"?$expand=associations"),
+		},
+	}
+}
+
+// NewApiLink returns a link to href with relation rel.
+func NewApiLink(rel, href string) *responsepb.ApiLink {
+	return &responsepb.ApiLink{Rel: proto.String(rel), Href: proto.String(href)}
+}
+
+// ApiLinksToDto converts protobuf ApiLinks to their DTO form.
+func ApiLinksToDto(in *responsepb.ApiLinkArrayWrapper) []import2.ApiLink {
+	if in == nil {
+		return nil
+	}
+	out := make([]import2.ApiLink, 0, len(in.GetValue()))
+	for _, l := range in.GetValue() {
+		link := import2.NewApiLink()
+		link.Rel = copyString(l.Rel)
+		link.Href = copyString(l.Href)
+		out = append(out, *link)
+	}
+	return out
+}
+
+// ApiLinksFromDto converts DTO ApiLinks to their protobuf form.
+func ApiLinksFromDto(in []import2.ApiLink) *responsepb.ApiLinkArrayWrapper {
+	if in == nil {
+		return nil
+	}
+	out := &responsepb.ApiLinkArrayWrapper{}
+	for i := range in {
+		out.Value = append(out.Value, &responsepb.ApiLink{
+			Rel:  copyString(in[i].Rel),
+			Href: copyString(in[i].Href),
+		})
+	}
+	return out
+}
@@ -0,0 +1,13 @@
+package mockserver
+
+import (
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/mock/v4/config"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/fixtures"
+)
+
+// SeedItems returns the cats served by a freshly started mock service, as
+// declared by the built-in cats dataset of package fixtures.
+func SeedItems() []*pb.Item {
+	return fixtures.MustBuild("cats").Cats
+}
@@ -0,0 +1,49 @@
+package mockserver
+
+import (
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+)
+
+// countryType describes mock.v4.config.Country.
+var countryType = &odata.ComplexType{
+	Name: "mock.v4.config.Country",
+	Properties: []*odata.Property{
+		primitive("state", edm.EdmString),
+	},
+}
+
+// loitemionType describes mock.v4.config.Loitemion.
+var loitemionType = &odata.ComplexType{
+	Name: "mock.v4.config.Loitemion",
+	Properties: []*odata.Property{
+		complexProperty("country", countryType),
+		primitive("city", edm.EdmString),
+		primitive("zip", edm.EdmString),
+	},
+}
+
+// ItemEntityType describes mock.v4.config.Item to the query options of
+// listItems. Every primitive property, nested ones included, is filterable
+// and sortable.
+var ItemEntityType = &odata.EntityType{
+	Name:      "item",
+	EntitySet: "items",
+	Properties: []*odata.Property{
+		primitive("itemId", edm.EdmInt32),
+		primitive("itemName", edm.EdmString),
+		primitive("itemType", edm.EdmString),
+		primitive("description", edm.EdmString),
+		primitive("itemImageFile", edm.EdmString),
+		complexProperty("loitemion", loitemionType),
+	},
+}
+
+func primitive(name string, kind edm.EdmPrimitiveTypeKind) *odata.Property {
+	return &odata.Property{Name: name, Type: string(kind), IsFilterable: true, IsSortable: true}
+}
+
+func complexProperty(name string, t *odata.ComplexType) *odata.Property {
+	return &odata.Property{Name: name, Type: t.Name, ComplexType: t}
+}
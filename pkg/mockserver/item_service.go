@@ -0,0 +1,150 @@
+// Package mockserver implements the mock.v4.config services, serving a fixed
+// set of cats from memory.
+package mockserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	responsepb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/response"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/mock/v4/config"
+	errorpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/mock/v4/error"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+)
+
+// itemsPath is the REST collection path of mock.v4.config.ItemService.
+const itemsPath = "/api/mock/v4.1/config/items"
+
+// ItemService implements mock.v4.config.ItemService.
+type ItemService struct {
+	pb.UnimplementedItemServiceServer
+
+	mu    sync.RWMutex
+	items []*pb.Item
+}
+
+// NewItemService returns an ItemService serving items.
+func NewItemService(items []*pb.Item) *ItemService {
+	return &ItemService{items: items}
+}
+
+// ListItems returns the items matching the $filter of arg, sorted by its
+// $orderby and reduced to the properties of its $select.
+func (s *ItemService) ListItems(ctx context.Context, arg *pb.ListItemsArg) (*pb.ListItemsRet, error) {
+	q, err := parseQuery(arg)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	items := make([]*pb.Item, 0, len(s.items))
+	for _, it := range s.items {
+		if q.filter != nil {
+			ok, err := odata.Match(q.filter, it.ProtoReflect())
+			if err != nil {
+				s.mu.RUnlock()
+				return nil, queryError("$filter", err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		items = append(items, proto.Clone(it).(*pb.Item))
+	}
+	s.mu.RUnlock()
+	if err := odata.Sort(items, q.orderby); err != nil {
+		return nil, queryError("$orderby", err)
+	}
+	for _, it := range items {
+		odata.Select(it.ProtoReflect(), q.selected)
+	}
+	return &pb.ListItemsRet{
+		Content: &pb.ListItemsApiResponse{
+			Data: &pb.ListItemsApiResponse_ItemArrayData{
+				ItemArrayData: &pb.ItemArrayWrapper{Value: items},
+			},
+			Metadata: &responsepb.ApiResponseMetadata{
+				TotalAvailableResults: proto.Int32(int32(len(items))),
+			},
+		},
+	}, nil
+}
+
+// query holds the parsed query options of a listItems request.
+type query struct {
+	filter   odata.Expr
+	orderby  []odata.OrderItem
+	selected []*odata.Path
+}
+
+// parseQuery parses the query options of arg and checks them against
+// ItemEntityType.
+func parseQuery(arg *pb.ListItemsArg) (query, error) {
+	var q query
+	var err error
+	if arg.XFilter != nil {
+		if q.filter, err = odata.ParseFilter(arg.GetXFilter()); err == nil {
+			err = odata.CheckFilter(q.filter, ItemEntityType)
+		}
+		if err != nil {
+			return q, queryError("$filter", err)
+		}
+	}
+	if arg.XOrderby != nil {
+		if q.orderby, err = odata.ParseOrderBy(arg.GetXOrderby()); err == nil {
+			err = odata.CheckOrderBy(q.orderby, ItemEntityType)
+		}
+		if err != nil {
+			return q, queryError("$orderby", err)
+		}
+	}
+	if arg.XSelect != nil {
+		if q.selected, err = odata.ParseSelect(arg.GetXSelect()); err == nil {
+			err = odata.CheckSelect(q.selected, ItemEntityType)
+		}
+		if err != nil {
+			return q, queryError("$select", err)
+		}
+	}
+	return q, nil
+}
+
+// queryError reports an invalid query option as a schema validation error
+// of the query.
+func queryError(param string, err error) error {
+	var oerr *odata.Error
+	if !errors.As(err, &oerr) {
+		return status.Error(codes.Internal, err.Error())
+	}
+	resp := &errorpb.ErrorResponse{
+		Error: &errorpb.ErrorResponse_SchemaValidationErrorError{
+			SchemaValidationErrorError: &errorpb.SchemaValidationErrorWrapper{
+				Value: &errorpb.SchemaValidationError{
+					Timestamp:  timestamppb.Now(),
+					StatusCode: proto.Int32(http.StatusBadRequest),
+					Error:      proto.String(http.StatusText(http.StatusBadRequest)),
+					Path:       proto.String(itemsPath),
+					ValidationErrorMessages: &errorpb.SchemaValidationErrorMessageArrayWrapper{
+						Value: []*errorpb.SchemaValidationErrorMessage{{
+							Loitemion:     proto.String("query"),
+							Message:       proto.String(oerr.Error()),
+							AttributePath: proto.String(param),
+						}},
+					},
+				},
+			},
+		},
+	}
+	msg := param + ": " + oerr.Error()
+	st, detailErr := status.New(codes.InvalidArgument, msg).WithDetails(resp)
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return st.Err()
+}
@@ -0,0 +1,116 @@
+package mockserver
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/mock/v4/config"
+	errorpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/mock/v4/error"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// listNames lists the seeded cats with arg and returns their names.
+func listNames(t *testing.T, arg *pb.ListItemsArg) []string {
+	t.Helper()
+	ret, err := NewItemService(SeedItems()).ListItems(context.Background(), arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, it := range ret.GetContent().GetItemArrayData().GetValue() {
+		names = append(names, it.GetItemName())
+	}
+	if n := ret.GetContent().GetMetadata().GetTotalAvailableResults(); int(n) != len(names) {
+		t.Errorf("reported %d results, want %d", n, len(names))
+	}
+	return names
+}
+
+func TestListItemsFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"itemName eq 'Luna'", []string{"Luna"}},
+		{"itemId le 3", []string{"Whiskers", "Simba", "Luna"}},
+		{"loitemion/city eq 'Pune'", []string{"Whiskers", "Nala"}},
+		{"loitemion/country/state eq 'Karnataka' and itemId gt 2", []string{"Tiger"}},
+		{"loitemion/country/state in ('California', 'Washington')", []string{"Luna", "Chai"}},
+		{"startswith(loitemion/zip, '4') and not (loitemion/city eq 'Mumbai')", []string{"Whiskers", "Nala"}},
+		{"loitemion/country/state eq 'Texas'", nil},
+	}
+	for _, tt := range tests {
+		if got := listNames(t, &pb.ListItemsArg{XFilter: proto.String(tt.filter)}); !slices.Equal(got, tt.want) {
+			t.Errorf("$filter=%s: got %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestListItemsOrderBy(t *testing.T) {
+	tests := []struct {
+		orderby string
+		want    []string
+	}{
+		{"itemName", []string{"Chai", "Luna", "Milo", "Nala", "Oliver", "Simba", "Tiger", "Whiskers"}},
+		{"loitemion/country/state,itemName desc", []string{"Luna", "Tiger", "Simba", "Whiskers", "Oliver", "Nala", "Milo", "Chai"}},
+		{"loitemion/city desc,itemId", []string{"Chai", "Luna", "Whiskers", "Nala", "Tiger", "Oliver", "Milo", "Simba"}},
+	}
+	for _, tt := range tests {
+		if got := listNames(t, &pb.ListItemsArg{XOrderby: proto.String(tt.orderby)}); !slices.Equal(got, tt.want) {
+			t.Errorf("$orderby=%s: got %v, want %v", tt.orderby, got, tt.want)
+		}
+	}
+}
+
+func TestListItemsSelect(t *testing.T) {
+	ret, err := NewItemService(SeedItems()).ListItems(context.Background(), &pb.ListItemsArg{
+		XFilter: proto.String("itemName eq 'Whiskers'"),
+		XSelect: proto.String("itemName,loitemion/city"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &pb.Item{ItemName: proto.String("Whiskers"), Loitemion: &pb.Loitemion{City: proto.String("Pune")}}
+	if got := ret.GetContent().GetItemArrayData().GetValue(); len(got) != 1 || !proto.Equal(got[0], want) {
+		t.Errorf("got %v, want [%v]", got, want)
+	}
+	// The served cats keep their other properties.
+	if got := listNames(t, &pb.ListItemsArg{XFilter: proto.String("loitemion/zip ne null and itemName eq 'Whiskers'")}); len(got) != 1 {
+		t.Errorf("$select changed the served cats: got %v", got)
+	}
+}
+
+func TestListItemsInvalidQuery(t *testing.T) {
+	tests := []struct {
+		arg   *pb.ListItemsArg
+		param string
+	}{
+		{&pb.ListItemsArg{XFilter: proto.String("itemName eq")}, "$filter"},
+		{&pb.ListItemsArg{XFilter: proto.String("loitemion eq 'Pune'")}, "$filter"},
+		{&pb.ListItemsArg{XFilter: proto.String("loitemion/planet eq 'Earth'")}, "$filter"},
+		{&pb.ListItemsArg{XFilter: proto.String("itemName eq 1")}, "$filter"},
+		{&pb.ListItemsArg{XOrderby: proto.String("loitemion")}, "$orderby"},
+		{&pb.ListItemsArg{XOrderby: proto.String("itemName sideways")}, "$orderby"},
+		{&pb.ListItemsArg{XSelect: proto.String("loitemion/planet")}, "$select"},
+	}
+	for _, tt := range tests {
+		_, err := NewItemService(SeedItems()).ListItems(context.Background(), tt.arg)
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument {
+			t.Errorf("%v: got %v, want InvalidArgument", tt.arg, err)
+			continue
+		}
+		var msgs []*errorpb.SchemaValidationErrorMessage
+		for _, d := range st.Details() {
+			if resp, ok := d.(*errorpb.ErrorResponse); ok {
+				msgs = resp.GetSchemaValidationErrorError().GetValue().GetValidationErrorMessages().GetValue()
+			}
+		}
+		if len(msgs) != 1 || msgs[0].GetAttributePath() != tt.param || msgs[0].GetLoitemion() != "query" {
+			t.Errorf("%v: got validation messages %v, want one about the %s query parameter", tt.arg, msgs, tt.param)
+		}
+	}
+}
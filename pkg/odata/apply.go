@@ -0,0 +1,523 @@
+package odata
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// countAggregate is the virtual property aggregated to count the members of
+// each group.
+const countAggregate = "$count"
+
+// aggregateMethods lists the aggregation methods of the with clause and
+// whether they only apply to numbers.
+var aggregateMethods = map[string]bool{
+	"sum":           true,
+	"average":       true,
+	"min":           false,
+	"max":           false,
+	"countdistinct": false,
+}
+
+// numericTypes are the Edm primitive types sum and average apply to.
+var numericTypes = map[string]bool{
+	"Edm.Byte":    true,
+	"Edm.SByte":   true,
+	"Edm.Int16":   true,
+	"Edm.Int32":   true,
+	"Edm.Int64":   true,
+	"Edm.Single":  true,
+	"Edm.Double":  true,
+	"Edm.Decimal": true,
+}
+
+// Apply is a parsed $apply expression. Its transformations are restricted
+// to filters followed by at most one groupby or aggregate, which covers
+// rollups such as groupby((itemType),aggregate($count as total)).
+type Apply struct {
+	// Filters restrict the entities before they are grouped.
+	Filters []Expr
+	// GroupBy lists the grouping properties. Without them the aggregates
+	// are computed over all entities.
+	GroupBy []*Path
+	// Aggregates lists the values computed for each group.
+	Aggregates []Aggregate
+}
+
+// Aggregate is one of the comma separated terms of aggregate(), either
+// "path with method as alias" or "$count as alias".
+type Aggregate struct {
+	// Path is the aggregated property. It is nil for $count.
+	Path   *Path
+	Method string
+	Alias  string
+}
+
+func (a Aggregate) String() string {
+	if a.Path == nil {
+		return countAggregate + " as " + a.Alias
+	}
+	return a.Path.String() + " with " + a.Method + " as " + a.Alias
+}
+
+// Row is a row of the result of an Apply, holding the values of the
+// columns named by Columns.
+type Row []interface{}
+
+// Columns returns the names of the columns of the rows of a: the paths of
+// the grouping properties followed by the aliases of the aggregates.
+func (a *Apply) Columns() []string {
+	cols := make([]string, 0, len(a.GroupBy)+len(a.Aggregates))
+	for _, p := range a.GroupBy {
+		cols = append(cols, p.String())
+	}
+	for _, agg := range a.Aggregates {
+		cols = append(cols, agg.Alias)
+	}
+	return cols
+}
+
+// References reports whether a refers to the top-level property name.
+func (a *Apply) References(name string) bool {
+	if anyReferences(a.Filters, name) {
+		return true
+	}
+	for _, p := range a.GroupBy {
+		if References(p, name) {
+			return true
+		}
+	}
+	for _, agg := range a.Aggregates {
+		if agg.Path != nil && References(agg.Path, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseApply parses an $apply expression: transformations separated by
+// "/", any filter(expr) transformations first, then optionally
+// groupby((path,...)[,aggregate(...)]) or aggregate(...).
+func ParseApply(s string) (*Apply, error) {
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	a := &Apply{}
+	for {
+		t := p.next()
+		if t.kind != tokIdent {
+			return nil, errorf(t.pos, "expected a transformation")
+		}
+		if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		switch t.text {
+		case "filter":
+			e, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			a.Filters = append(a.Filters, e)
+		case "groupby":
+			if err := p.parseGroupBy(a); err != nil {
+				return nil, err
+			}
+			if err := p.expectLastTransformation(t); err != nil {
+				return nil, err
+			}
+			return a, nil
+		case "aggregate":
+			if a.Aggregates, err = p.parseAggregates(); err != nil {
+				return nil, err
+			}
+			if err := p.expectLastTransformation(t); err != nil {
+				return nil, err
+			}
+			return a, nil
+		default:
+			return nil, errorf(t.pos, "unsupported transformation %s", t.text)
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind == tokEOF {
+			return a, nil
+		} else if t.kind != tokPunct || t.text != "/" {
+			return nil, errorf(t.pos, "expected \"/\"")
+		}
+	}
+}
+
+// expectLastTransformation reports an error unless the transformation t,
+// which the parser is past, ends the expression.
+func (p *parser) expectLastTransformation(t token) error {
+	if p.isPunct("/") {
+		return errorf(p.peek().pos, "no transformation can follow %s", t.text)
+	}
+	return p.expectEOF()
+}
+
+// parseGroupBy parses the arguments of groupby, after its opening
+// parenthesis, up to its closing one.
+func (p *parser) parseGroupBy(a *Apply) error {
+	if err := p.expectPunct("("); err != nil {
+		return err
+	}
+	for {
+		t := p.next()
+		if t.kind != tokIdent {
+			return errorf(t.pos, "expected a property name")
+		}
+		path, err := p.parsePlainPath(t)
+		if err != nil {
+			return err
+		}
+		a.GroupBy = append(a.GroupBy, path)
+		if p.isPunct(",") {
+			p.next()
+			continue
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return err
+		}
+		break
+	}
+	if p.isPunct(",") {
+		p.next()
+		if t := p.next(); t.kind != tokIdent || t.text != "aggregate" {
+			return errorf(t.pos, "expected aggregate")
+		}
+		if err := p.expectPunct("("); err != nil {
+			return err
+		}
+		var err error
+		if a.Aggregates, err = p.parseAggregates(); err != nil {
+			return err
+		}
+	}
+	return p.expectPunct(")")
+}
+
+// parseAggregates parses the arguments of aggregate, after its opening
+// parenthesis, up to its closing one.
+func (p *parser) parseAggregates() ([]Aggregate, error) {
+	var aggs []Aggregate
+	for {
+		t := p.next()
+		if t.kind != tokIdent {
+			return nil, errorf(t.pos, "expected a property name or %s", countAggregate)
+		}
+		var agg Aggregate
+		if t.text != countAggregate {
+			path, err := p.parsePlainPath(t)
+			if err != nil {
+				return nil, err
+			}
+			agg.Path = path
+			if err := p.expectKeyword("with"); err != nil {
+				return nil, err
+			}
+			m := p.next()
+			if _, ok := aggregateMethods[m.text]; m.kind != tokIdent || !ok {
+				return nil, errorf(m.pos, "unsupported aggregation method %q", m.text)
+			}
+			agg.Method = m.text
+		}
+		if err := p.expectKeyword("as"); err != nil {
+			return nil, err
+		}
+		alias := p.next()
+		if alias.kind != tokIdent || strings.HasPrefix(alias.text, "$") {
+			return nil, errorf(alias.pos, "expected an alias")
+		}
+		agg.Alias = alias.text
+		aggs = append(aggs, agg)
+		if p.isPunct(",") {
+			p.next()
+			continue
+		}
+		return aggs, p.expectPunct(")")
+	}
+}
+
+func (p *parser) expectKeyword(word string) error {
+	if t := p.next(); t.kind != tokIdent || t.text != word {
+		return errorf(t.pos, "expected %s", word)
+	}
+	return nil
+}
+
+func (p *parser) expectEOF() error {
+	if t := p.peek(); t.kind != tokEOF {
+		return errorf(t.pos, "unexpected %q", t.text)
+	}
+	return nil
+}
+
+// CheckApply reports whether a only filters on filterable properties of t
+// and only groups and aggregates filterable primitive properties. Paths
+// may lead into one collection of complex values, such as
+// associations/entityType, in which case its members are grouped.
+func CheckApply(a *Apply, t *EntityType) error {
+	for _, e := range a.Filters {
+		if err := CheckFilter(e, t); err != nil {
+			return err
+		}
+	}
+	var collection string
+	seen := make(map[string]bool)
+	check := func(p *Path) (*Property, error) {
+		props, segments := t.Properties, p.Segments
+		if found, err := lookup(props, segments[:1]); err == nil && found[0].IsCollection && found[0].ComplexType != nil {
+			if collection != "" && collection != segments[0] {
+				return nil, &Error{Pos: -1, Msg: "cannot aggregate both " + collection + " and " + segments[0]}
+			}
+			collection = segments[0]
+			props, segments = found[0].ComplexType.Properties, segments[1:]
+			if len(segments) == 0 {
+				return nil, &Error{Pos: -1, Msg: "property " + p.String() + " is a collection"}
+			}
+		}
+		found, err := lookup(props, segments)
+		if err != nil {
+			return nil, err
+		}
+		find := func([]string) ([]*Property, error) { return found, nil }
+		if err := checkPrimitive(find, p, "filterable", func(p *Property) bool { return p.IsFilterable }); err != nil {
+			return nil, err
+		}
+		return found[len(found)-1], nil
+	}
+	for _, p := range a.GroupBy {
+		if seen[p.String()] {
+			return &Error{Pos: -1, Msg: "property " + p.String() + " is grouped twice"}
+		}
+		seen[p.String()] = true
+		if _, err := check(p); err != nil {
+			return err
+		}
+	}
+	for _, agg := range a.Aggregates {
+		if seen[agg.Alias] {
+			return &Error{Pos: -1, Msg: "alias " + agg.Alias + " is already used"}
+		}
+		seen[agg.Alias] = true
+		if agg.Path == nil {
+			continue
+		}
+		prop, err := check(agg.Path)
+		if err != nil {
+			return err
+		}
+		if aggregateMethods[agg.Method] && !numericTypes[prop.Type] {
+			return &Error{Pos: -1, Msg: agg.Method + " requires a numeric property, not " + agg.Path.String()}
+		}
+	}
+	return nil
+}
+
+// Evaluate applies a to msgs and returns the resulting rows, in the order
+// their groups first occur in msgs. When the grouped or aggregated paths
+// lead into a collection, each member of the collection counts as one
+// entity, and entities without members are left out.
+func (a *Apply) Evaluate(msgs []protoreflect.Message) ([]Row, error) {
+	var records []*env
+	for _, m := range msgs {
+		en := &env{root: m}
+		ok := true
+		for _, e := range a.Filters {
+			v, err := eval(e, en)
+			if err != nil {
+				return nil, err
+			}
+			if b, _ := v.(bool); !b {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		members, name, err := a.members(m)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			records = append(records, en)
+			continue
+		}
+		for _, member := range members {
+			records = append(records, &env{root: m, vars: map[string]protoreflect.Message{name: member}})
+		}
+	}
+
+	type group struct {
+		key     Row
+		records []*env
+	}
+	var groups []*group
+	index := make(map[string]*group)
+	for _, en := range records {
+		key := make(Row, len(a.GroupBy))
+		for i, p := range a.GroupBy {
+			v, err := evalPath(p, en)
+			if err != nil {
+				return nil, err
+			}
+			key[i] = v
+		}
+		id := fmt.Sprintf("%#v", key)
+		g, ok := index[id]
+		if !ok {
+			g = &group{key: key}
+			index[id] = g
+			groups = append(groups, g)
+		}
+		g.records = append(g.records, en)
+	}
+	if len(a.GroupBy) == 0 && len(groups) == 0 {
+		groups = append(groups, &group{})
+	}
+
+	rows := make([]Row, 0, len(groups))
+	for _, g := range groups {
+		row := append(Row{}, g.key...)
+		for _, agg := range a.Aggregates {
+			v, err := aggregate(agg, g.records)
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, v)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// members returns the members of the collection the paths of a lead into,
+// and its name, or "" when they lead into none.
+func (a *Apply) members(m protoreflect.Message) ([]protoreflect.Message, string, error) {
+	paths := append([]*Path{}, a.GroupBy...)
+	for _, agg := range a.Aggregates {
+		if agg.Path != nil {
+			paths = append(paths, agg.Path)
+		}
+	}
+	for _, p := range paths {
+		if len(p.Segments) < 2 {
+			continue
+		}
+		fields, err := resolve(m.Descriptor(), p.Segments[:1], true)
+		if err != nil {
+			continue
+		}
+		fd := fields[0]
+		if !m.Has(fd) {
+			return nil, p.Segments[0], nil
+		}
+		list := m.Get(fd)
+		if value := listField(fd); value != fd {
+			list = list.Message().Get(value)
+		}
+		members := make([]protoreflect.Message, list.List().Len())
+		for i := range members {
+			members[i] = list.List().Get(i).Message()
+		}
+		return members, p.Segments[0], nil
+	}
+	return nil, "", nil
+}
+
+func aggregate(agg Aggregate, records []*env) (interface{}, error) {
+	if agg.Path == nil {
+		return int64(len(records)), nil
+	}
+	var values []interface{}
+	for _, en := range records {
+		v, err := evalPath(agg.Path, en)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			values = append(values, v)
+		}
+	}
+	switch agg.Method {
+	case "countdistinct":
+		distinct := make(map[interface{}]bool)
+		for _, v := range values {
+			distinct[v] = true
+		}
+		return int64(len(distinct)), nil
+	case "min", "max":
+		if len(values) == 0 {
+			return nil, nil
+		}
+		sort.SliceStable(values, func(i, j int) bool { return compareNullable(values[i], values[j]) < 0 })
+		if agg.Method == "min" {
+			return values[0], nil
+		}
+		return values[len(values)-1], nil
+	}
+	var isum int64
+	var fsum float64
+	floats := false
+	for _, v := range values {
+		switch v := v.(type) {
+		case int64:
+			isum += v
+			fsum += float64(v)
+		case float64:
+			fsum += v
+			floats = true
+		default:
+			return nil, &Error{Pos: -1, Msg: agg.Method + " requires a numeric property, not " + agg.Path.String()}
+		}
+	}
+	if agg.Method == "average" {
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return fsum / float64(len(values)), nil
+	}
+	if floats {
+		return fsum, nil
+	}
+	return isum, nil
+}
+
+// SortRows sorts rows, whose columns are named by cols, by order, keeping
+// the original order of rows that compare equal. Each term of order must
+// name a column.
+func SortRows(rows []Row, cols []string, order []OrderItem) error {
+	idx := make([]int, len(order))
+	for i, o := range order {
+		idx[i] = -1
+		for j, c := range cols {
+			if c == o.Path.String() {
+				idx[i] = j
+			}
+		}
+		if idx[i] < 0 {
+			return &Error{Pos: -1, Msg: "property " + o.Path.String() + " is not a column of $apply"}
+		}
+	}
+	sort.SliceStable(rows, func(a, b int) bool {
+		for i, o := range order {
+			c := compareNullable(rows[a][idx[i]], rows[b][idx[i]])
+			if o.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return nil
+}
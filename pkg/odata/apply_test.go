@@ -0,0 +1,234 @@
+package odata
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// applyString formats a in the syntax of $apply, parenthesising the
+// expressions of its filters as String does.
+func applyString(a *Apply) string {
+	var parts []string
+	for _, e := range a.Filters {
+		parts = append(parts, "filter("+e.String()+")")
+	}
+	var aggs []string
+	for _, agg := range a.Aggregates {
+		aggs = append(aggs, agg.String())
+	}
+	aggregate := "aggregate(" + strings.Join(aggs, ",") + ")"
+	switch {
+	case a.GroupBy != nil:
+		var paths []string
+		for _, p := range a.GroupBy {
+			paths = append(paths, p.String())
+		}
+		groupby := "groupby((" + strings.Join(paths, ",") + ")"
+		if a.Aggregates != nil {
+			groupby += "," + aggregate
+		}
+		parts = append(parts, groupby+")")
+	case a.Aggregates != nil:
+		parts = append(parts, aggregate)
+	}
+	return strings.Join(parts, "/")
+}
+
+func TestParseApply(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"groupby((itemType))", "groupby((itemType))"},
+		{"groupby((itemType),aggregate($count as total))", "groupby((itemType),aggregate($count as total))"},
+		{
+			"groupby( (itemType, tenantInfo/tenantId) , aggregate(itemId with max as last, $count as n) )",
+			"groupby((itemType,tenantInfo/tenantId),aggregate(itemId with max as last,$count as n))",
+		},
+		{"aggregate(itemId with sum as s,itemId with average as a)", "aggregate(itemId with sum as s,itemId with average as a)"},
+		{"filter(itemId gt 1)", "filter((itemId gt 1))"},
+		{
+			"filter(itemId gt 1)/filter(associations/any())/groupby((associations/entityType),aggregate(associations/count with sum as total))",
+			"filter((itemId gt 1))/filter(associations/any())/groupby((associations/entityType),aggregate(associations/count with sum as total))",
+		},
+		{"aggregate(itemName with countdistinct as names, itemName with min as first)", "aggregate(itemName with countdistinct as names,itemName with min as first)"},
+	}
+	for _, tt := range tests {
+		a, err := ParseApply(tt.in)
+		if err != nil {
+			t.Errorf("ParseApply(%q): %v", tt.in, err)
+			continue
+		}
+		if got := applyString(a); got != tt.want {
+			t.Errorf("ParseApply(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseApplyErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "expected a transformation at position 0"},
+		{"groupby", `expected "(" at position 7`},
+		{"orderby((itemName))", "unsupported transformation orderby at position 0"},
+		{"filter(itemId gt 1", `expected ")" at position 18`},
+		{"filter(itemId gt 1) filter(itemId lt 3)", `expected "/" at position 20`},
+		{"filter(itemId gt 1)/", "expected a transformation at position 20"},
+		{"groupby(itemType)", `expected "(" at position 8`},
+		{"groupby(())", "expected a property name at position 9"},
+		{"groupby((itemType),$count as n)", "expected aggregate at position 19"},
+		{"groupby((itemType))/filter(itemId gt 1)", "no transformation can follow groupby at position 19"},
+		{"aggregate($count as n)/groupby((itemType))", "no transformation can follow aggregate at position 22"},
+		{"aggregate($count as n) x", `unexpected "x" at position 23`},
+		{"aggregate($count)", "expected as at position 16"},
+		{"aggregate(itemId as n)", "expected with at position 17"},
+		{"aggregate(itemId with median as n)", `unsupported aggregation method "median" at position 22`},
+		{"aggregate($count as $n)", "expected an alias at position 20"},
+		{"aggregate(1 with sum as n)", "expected a property name or $count at position 10"},
+		{"groupby((associations/any()))", "lambda operators are only allowed in $filter at position 9"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseApply(tt.in); errorString(err) != tt.want {
+			t.Errorf("ParseApply(%q): got %v, want %s", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestCheckApply(t *testing.T) {
+	tests := []struct {
+		apply string
+		want  string
+	}{
+		{apply: "groupby((itemType),aggregate($count as total))"},
+		{apply: "filter(itemId gt 1)/groupby((tenantInfo/tenantId,itemType),aggregate(itemId with sum as s))"},
+		{apply: "groupby((associations/entityType),aggregate(associations/count with average as a,$count as n))"},
+		{apply: "groupby((itemType),aggregate(associations/count with max as most))"},
+		{"filter(description eq 'a')/aggregate($count as n)", "property description is not filterable"},
+		{"groupby((description))", "property description is not filterable"},
+		{"groupby((tenantInfo))", "property tenantInfo is not a primitive property"},
+		{"groupby((associations))", "property associations is a collection"},
+		{"groupby((associations/entityId))", "property associations/entityId is not filterable"},
+		{"groupby((itemType,itemType))", "property itemType is grouped twice"},
+		{"groupby((itemType),aggregate($count as itemType))", "alias itemType is already used"},
+		{"aggregate($count as n,itemId with max as n)", "alias n is already used"},
+		{"aggregate(itemName with sum as s)", "sum requires a numeric property, not itemName"},
+		{"aggregate(associations/entityType with average as a)", "average requires a numeric property, not associations/entityType"},
+		{"aggregate(noSuchProperty with max as m)", "unknown property noSuchProperty"},
+	}
+	for _, tt := range tests {
+		a, err := ParseApply(tt.apply)
+		if err != nil {
+			t.Errorf("ParseApply(%q): %v", tt.apply, err)
+			continue
+		}
+		if got := errorString(CheckApply(a, lambdaType)); got != tt.want {
+			t.Errorf("CheckApply(%q) = %q, want %q", tt.apply, got, tt.want)
+		}
+	}
+}
+
+// applyItems returns the items $apply is evaluated over in the tests.
+func applyItems() []protoreflect.Message {
+	newItem := func(id int32, itemType string, counts ...int32) *pb.Item {
+		it := &pb.Item{ItemId: proto.Int32(id), ItemName: proto.String(fmt.Sprintf("%s-%d", itemType, id))}
+		if itemType != "" {
+			it.ItemType = proto.String(itemType)
+		}
+		if counts != nil {
+			it.Associations = &pb.ItemAssociationArrayWrapper{}
+		}
+		for i, n := range counts {
+			entityType := "vm"
+			if i%2 == 1 {
+				entityType = "host"
+			}
+			it.Associations.Value = append(it.Associations.Value, &pb.ItemAssociation{EntityType: proto.String(entityType), Count: proto.Int32(n)})
+		}
+		return it
+	}
+	items := []*pb.Item{
+		newItem(1, "disk", 1, 2),
+		newItem(2, "vm"),
+		newItem(3, "disk", 4),
+		newItem(4, ""),
+		newItem(5, "disk", 8, 16, 32),
+	}
+	msgs := make([]protoreflect.Message, len(items))
+	for i, it := range items {
+		msgs[i] = it.ProtoReflect()
+	}
+	return msgs
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		apply string
+		// want formats the rows expected with %v.
+		want string
+	}{
+		{"groupby((itemType),aggregate($count as total))", "[[disk 3] [vm 1] [<nil> 1]]"},
+		{"groupby((itemType))", "[[disk] [vm] [<nil>]]"},
+		{"filter(itemId gt 1)/filter(itemId lt 5)/groupby((itemType),aggregate($count as total))", "[[vm 1] [disk 1] [<nil> 1]]"},
+		{"aggregate($count as n,itemId with sum as s,itemId with average as a,itemId with min as lo,itemId with max as hi)", "[[5 15 3 1 5]]"},
+		{"aggregate(itemType with countdistinct as types,itemType with max as last)", "[[2 vm]]"},
+		{"filter(itemId gt 5)/aggregate($count as n,itemId with sum as s,itemId with average as a,itemId with max as hi)", "[[0 0 <nil> <nil>]]"},
+		{"filter(itemId gt 5)/groupby((itemType),aggregate($count as n))", "[]"},
+		// Paths into associations count each association as an entity,
+		// leaving out items without any.
+		{"groupby((associations/entityType),aggregate(associations/count with sum as total,$count as n))", "[[vm 45 4] [host 18 2]]"},
+		{"groupby((itemType),aggregate(associations/count with max as most))", "[[disk 32]]"},
+		{"filter(associations/any(a: a/count gt 10))/groupby((itemId),aggregate(associations/count with average as a))", "[[5 18.666666666666668]]"},
+	}
+	for _, tt := range tests {
+		a, err := ParseApply(tt.apply)
+		if err == nil {
+			err = CheckApply(a, lambdaType)
+		}
+		if err != nil {
+			t.Errorf("$apply=%s: %v", tt.apply, err)
+			continue
+		}
+		rows, err := a.Evaluate(applyItems())
+		if got := fmt.Sprint(rows); err != nil || got != tt.want {
+			t.Errorf("$apply=%s: got %s, %v, want %s", tt.apply, got, err, tt.want)
+		}
+	}
+}
+
+func TestSortRows(t *testing.T) {
+	cols := []string{"itemType", "total"}
+	rows := []Row{{"disk", int64(3)}, {"vm", int64(1)}, {nil, int64(1)}, {"host", int64(3)}}
+	tests := []struct {
+		orderby string
+		want    string
+	}{
+		{"total", "[[vm 1] [<nil> 1] [disk 3] [host 3]]"},
+		{"total desc,itemType", "[[disk 3] [host 3] [<nil> 1] [vm 1]]"},
+		{"itemType desc", "[[vm 1] [host 3] [disk 3] [<nil> 1]]"},
+	}
+	for _, tt := range tests {
+		order, err := ParseOrderBy(tt.orderby)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sorted := append([]Row{}, rows...)
+		if err := SortRows(sorted, cols, order); err != nil {
+			t.Errorf("$orderby=%s: %v", tt.orderby, err)
+			continue
+		}
+		if got := fmt.Sprint(sorted); got != tt.want {
+			t.Errorf("$orderby=%s: got %s, want %s", tt.orderby, got, tt.want)
+		}
+	}
+	order, _ := ParseOrderBy("itemName")
+	if err := SortRows(rows, cols, order); errorString(err) != "property itemName is not a column of $apply" {
+		t.Errorf("sorting by a property that is not a column: got %v", err)
+	}
+}
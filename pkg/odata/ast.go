@@ -0,0 +1,252 @@
+// Package odata parses OData V4.01 system query options and evaluates them
+// against protobuf messages.
+//
+// Property paths name fields by their JSON names, the names used by the
+// REST API, with "/" separating the properties of nested objects as in
+// loitemion/country/state.
+package odata
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expr is a node of a parsed $filter expression.
+type Expr interface {
+	fmt.Stringer
+	expr()
+}
+
+// Path references a property, possibly nested.
+type Path struct {
+	Segments []string
+}
+
+// Literal is a constant: a string, int64, float64, bool, time.Time or nil
+// for null.
+type Literal struct {
+	Value interface{}
+}
+
+// Binary applies a logical (and, or) or comparison (eq, ne, gt, ge, lt, le)
+// operator to two operands.
+type Binary struct {
+	Op    string
+	Left  Expr
+	Right Expr
+}
+
+// Not negates its operand.
+type Not struct {
+	X Expr
+}
+
+// In tests whether its operand equals one of a list of values.
+type In struct {
+	X    Expr
+	List []Expr
+}
+
+// Lambda applies the any or all operator to a collection-valued property.
+// Within Predicate, paths starting with Var refer to the members of the
+// collection. A nil Predicate only occurs with any() and tests whether the
+// collection is not empty.
+type Lambda struct {
+	Collection *Path
+	Op         string
+	Var        string
+	Predicate  Expr
+}
+
+// Call invokes a built-in function such as contains or tolower.
+type Call struct {
+	Name string
+	Args []Expr
+}
+
+func (*Path) expr()    {}
+func (*Literal) expr() {}
+func (*Binary) expr()  {}
+func (*Not) expr()     {}
+func (*In) expr()      {}
+func (*Lambda) expr()  {}
+func (*Call) expr()    {}
+
+func (p *Path) String() string { return strings.Join(p.Segments, "/") }
+
+func (l *Literal) String() string {
+	switch v := l.Value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (b *Binary) String() string {
+	return "(" + b.Left.String() + " " + b.Op + " " + b.Right.String() + ")"
+}
+
+func (n *Not) String() string { return "not " + n.X.String() }
+
+func (in *In) String() string { return in.X.String() + " in (" + joinExprs(in.List) + ")" }
+
+func (l *Lambda) String() string {
+	if l.Predicate == nil {
+		return l.Collection.String() + "/" + l.Op + "()"
+	}
+	return l.Collection.String() + "/" + l.Op + "(" + l.Var + ":" + l.Predicate.String() + ")"
+}
+
+func (c *Call) String() string { return c.Name + "(" + joinExprs(c.Args) + ")" }
+
+func joinExprs(exprs []Expr) string {
+	parts := make([]string, len(exprs))
+	for i, e := range exprs {
+		parts[i] = e.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// Error reports an invalid query option.
+type Error struct {
+	// Pos is the byte offset in the query option at which the error was
+	// detected, or -1 when it does not apply to a position.
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	if e.Pos < 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+func errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// References reports whether e refers to the top-level property name, or
+// to the properties nested in it.
+func References(e Expr, name string) bool {
+	switch e := e.(type) {
+	case *Path:
+		return e.Segments[0] == name
+	case *Binary:
+		return References(e.Left, name) || References(e.Right, name)
+	case *Not:
+		return References(e.X, name)
+	case *In:
+		return References(e.X, name) || anyReferences(e.List, name)
+	case *Lambda:
+		if References(e.Collection, name) {
+			return true
+		}
+		// Paths starting with the lambda variable refer to members of the
+		// collection, not to a property of the same name.
+		return e.Predicate != nil && e.Var != name && References(e.Predicate, name)
+	case *Call:
+		return anyReferences(e.Args, name)
+	}
+	return false
+}
+
+// Paths calls fn with the segments of every property path e refers to.
+// Paths starting with the variable of a lambda are resolved against its
+// collection, so that they name the property of the members they refer to.
+func Paths(e Expr, fn func(segments []string)) {
+	exprPaths(e, nil, fn)
+}
+
+func exprPaths(e Expr, vars map[string][]string, fn func([]string)) {
+	switch e := e.(type) {
+	case *Path:
+		if prefix, ok := vars[e.Segments[0]]; ok {
+			fn(append(slices.Clip(prefix), e.Segments[1:]...))
+			return
+		}
+		fn(e.Segments)
+	case *Binary:
+		exprPaths(e.Left, vars, fn)
+		exprPaths(e.Right, vars, fn)
+	case *Not:
+		exprPaths(e.X, vars, fn)
+	case *In:
+		exprPaths(e.X, vars, fn)
+		for _, x := range e.List {
+			exprPaths(x, vars, fn)
+		}
+	case *Lambda:
+		var collection []string
+		exprPaths(e.Collection, vars, func(segments []string) {
+			collection = segments
+			fn(segments)
+		})
+		if e.Predicate != nil {
+			inner := maps.Clone(vars)
+			if inner == nil {
+				inner = make(map[string][]string)
+			}
+			inner[e.Var] = collection
+			exprPaths(e.Predicate, inner, fn)
+		}
+	case *Call:
+		for _, x := range e.Args {
+			exprPaths(x, vars, fn)
+		}
+	}
+}
+
+// Substitute returns a copy of e in which the references to the top-level
+// primitive property name are replaced by with; e itself is not modified.
+// Properties that are computed rather than stored are evaluated by
+// substituting their value for them.
+func Substitute(e Expr, name string, with Expr) Expr {
+	switch e := e.(type) {
+	case *Path:
+		if len(e.Segments) == 1 && e.Segments[0] == name {
+			return with
+		}
+	case *Binary:
+		return &Binary{Op: e.Op, Left: Substitute(e.Left, name, with), Right: Substitute(e.Right, name, with)}
+	case *Not:
+		return &Not{X: Substitute(e.X, name, with)}
+	case *In:
+		return &In{X: Substitute(e.X, name, with), List: substituteAll(e.List, name, with)}
+	case *Lambda:
+		if e.Predicate != nil && e.Var != name {
+			return &Lambda{Collection: e.Collection, Op: e.Op, Var: e.Var, Predicate: Substitute(e.Predicate, name, with)}
+		}
+	case *Call:
+		return &Call{Name: e.Name, Args: substituteAll(e.Args, name, with)}
+	}
+	return e
+}
+
+func substituteAll(exprs []Expr, name string, with Expr) []Expr {
+	out := make([]Expr, len(exprs))
+	for i, e := range exprs {
+		out[i] = Substitute(e, name, with)
+	}
+	return out
+}
+
+func anyReferences(exprs []Expr, name string) bool {
+	for _, e := range exprs {
+		if References(e, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,474 @@
+package odata
+
+import (
+	"cmp"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// functions maps the supported built-in functions to their arity.
+var functions = map[string]int{
+	"contains":   2,
+	"startswith": 2,
+	"endswith":   2,
+	"tolower":    1,
+	"toupper":    1,
+	"trim":       1,
+	"length":     1,
+}
+
+// CheckFilter reports whether e only references filterable properties of t
+// and only calls supported functions, so that a bad expression is rejected
+// even when there is nothing to filter.
+func CheckFilter(e Expr, t *EntityType) error {
+	return checkFilter(e, t.Properties, nil)
+}
+
+// checkFilter checks e against the properties of the entity, props, and the
+// properties of the members of the collections bound to lambda variables.
+func checkFilter(e Expr, props []*Property, vars map[string][]*Property) error {
+	switch e := e.(type) {
+	case *Path:
+		return checkPrimitive(lookupVar(e, props, vars), e, "filterable", func(p *Property) bool { return p.IsFilterable })
+	case *Literal:
+		return nil
+	case *Binary:
+		if err := checkFilter(e.Left, props, vars); err != nil {
+			return err
+		}
+		return checkFilter(e.Right, props, vars)
+	case *Not:
+		return checkFilter(e.X, props, vars)
+	case *In:
+		for _, x := range append([]Expr{e.X}, e.List...) {
+			if err := checkFilter(x, props, vars); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *Lambda:
+		found, err := lookupVar(e.Collection, props, vars)(e.Collection.Segments)
+		if err != nil {
+			return err
+		}
+		coll := found[len(found)-1]
+		if !coll.IsCollection || coll.ComplexType == nil {
+			return &Error{Pos: -1, Msg: "property " + e.Collection.String() + " is not a collection of complex values"}
+		}
+		if e.Predicate == nil {
+			return nil
+		}
+		inner := make(map[string][]*Property, len(vars)+1)
+		for k, v := range vars {
+			inner[k] = v
+		}
+		inner[e.Var] = coll.ComplexType.Properties
+		return checkFilter(e.Predicate, props, inner)
+	case *Call:
+		arity, ok := functions[e.Name]
+		if !ok {
+			return &Error{Pos: -1, Msg: "unsupported function " + e.Name}
+		}
+		if len(e.Args) != arity {
+			return errorf(-1, "%s takes %d arguments", e.Name, arity)
+		}
+		for _, a := range e.Args {
+			if err := checkFilter(a, props, vars); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return &Error{Pos: -1, Msg: "unsupported expression " + e.String()}
+}
+
+// lookupVar returns a function looking up paths relative to the lambda
+// variable p starts with, if any, or else to props.
+func lookupVar(p *Path, props []*Property, vars map[string][]*Property) func([]string) ([]*Property, error) {
+	if varProps, ok := vars[p.Segments[0]]; ok && len(p.Segments) > 1 {
+		return func(segments []string) ([]*Property, error) { return lookup(varProps, segments[1:]) }
+	}
+	return func(segments []string) ([]*Property, error) { return lookup(props, segments) }
+}
+
+// checkPrimitive reports whether p names a primitive property that
+// satisfies allowed, looking it up with find.
+func checkPrimitive(find func([]string) ([]*Property, error), p *Path, what string, allowed func(*Property) bool) error {
+	props, err := find(p.Segments)
+	if err != nil {
+		return err
+	}
+	leaf := props[len(props)-1]
+	switch {
+	case leaf.IsCollection:
+		return &Error{Pos: -1, Msg: "property " + p.String() + " is a collection"}
+	case leaf.ComplexType != nil:
+		return &Error{Pos: -1, Msg: "property " + p.String() + " is not a primitive property"}
+	case !allowed(leaf):
+		return &Error{Pos: -1, Msg: "property " + p.String() + " is not " + what}
+	}
+	return nil
+}
+
+// timestampName is the message type of DateTimeOffset properties, which
+// are primitive although they are messages.
+const timestampName protoreflect.FullName = "google.protobuf.Timestamp"
+
+// isPrimitive reports whether fd holds primitive values.
+func isPrimitive(fd protoreflect.FieldDescriptor) bool {
+	return fd.Kind() != protoreflect.MessageKind || fd.Message().FullName() == timestampName
+}
+
+// resolve returns the fields the segments of a path name, starting from
+// messages described by md. When collection is false the last field must be
+// single valued and primitive; when it is true the last field must be a
+// collection of messages.
+func resolve(md protoreflect.MessageDescriptor, segments []string, collection bool) ([]protoreflect.FieldDescriptor, error) {
+	fields := make([]protoreflect.FieldDescriptor, 0, len(segments))
+	for i, name := range segments {
+		fd := md.Fields().ByJSONName(name)
+		if fd == nil {
+			return nil, &Error{Pos: -1, Msg: "unknown property " + strings.Join(segments[:i+1], "/")}
+		}
+		fields = append(fields, fd)
+		path := strings.Join(segments[:i+1], "/")
+		last := i == len(segments)-1
+		if last && collection {
+			if listField(fd) == nil {
+				return nil, &Error{Pos: -1, Msg: "property " + path + " is not a collection of complex values"}
+			}
+			break
+		}
+		if fd.IsList() || fd.IsMap() {
+			return nil, &Error{Pos: -1, Msg: "property " + path + " is a collection"}
+		}
+		switch {
+		case !isPrimitive(fd) && last:
+			return nil, &Error{Pos: -1, Msg: "property " + path + " is not a primitive property"}
+		case isPrimitive(fd) && !last:
+			return nil, &Error{Pos: -1, Msg: "property " + path + " has no nested properties"}
+		case !last:
+			md = fd.Message()
+		}
+	}
+	return fields, nil
+}
+
+// listField returns the repeated message field holding the members of the
+// collection fd: fd itself, or the value field of the array wrapper message
+// the API uses for collections. It returns nil if fd is not a collection of
+// messages.
+func listField(fd protoreflect.FieldDescriptor) protoreflect.FieldDescriptor {
+	if fd.Kind() != protoreflect.MessageKind || fd.IsMap() {
+		return nil
+	}
+	if fd.IsList() {
+		return fd
+	}
+	if value := fd.Message().Fields().ByName("value"); value != nil && value.IsList() && value.Kind() == protoreflect.MessageKind {
+		return value
+	}
+	return nil
+}
+
+// env is the context an expression is evaluated in: the entity and the
+// collection members bound to lambda variables.
+type env struct {
+	root protoreflect.Message
+	vars map[string]protoreflect.Message
+}
+
+// target returns the message p is relative to and the segments of p that
+// remain to be resolved in it.
+func (en *env) target(p *Path) (protoreflect.Message, []string) {
+	if m, ok := en.vars[p.Segments[0]]; ok && len(p.Segments) > 1 {
+		return m, p.Segments[1:]
+	}
+	return en.root, p.Segments
+}
+
+// Match reports whether m satisfies e. Properties that are not set, and the
+// properties nested in them, are null.
+func Match(e Expr, m protoreflect.Message) (bool, error) {
+	v, err := eval(e, &env{root: m})
+	if err != nil {
+		return false, err
+	}
+	b, _ := v.(bool)
+	return b, nil
+}
+
+func eval(e Expr, en *env) (interface{}, error) {
+	switch e := e.(type) {
+	case *Literal:
+		return e.Value, nil
+	case *Path:
+		return evalPath(e, en)
+	case *Not:
+		x, err := eval(e.X, en)
+		if err != nil {
+			return nil, err
+		}
+		b, ok := x.(bool)
+		if !ok {
+			return nil, nil
+		}
+		return !b, nil
+	case *Binary:
+		return evalBinary(e, en)
+	case *In:
+		x, err := eval(e.X, en)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range e.List {
+			v, err := eval(item, en)
+			if err != nil {
+				return nil, err
+			}
+			if c, ok := compare(x, v); ok && c == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	case *Lambda:
+		return evalLambda(e, en)
+	case *Call:
+		return evalCall(e, en)
+	}
+	return nil, &Error{Pos: -1, Msg: "unsupported expression " + e.String()}
+}
+
+// Value returns the value of the primitive property p of m in the
+// representation of literals, or nil if it is null.
+func Value(p *Path, m protoreflect.Message) (interface{}, error) {
+	return evalPath(p, &env{root: m})
+}
+
+func evalPath(p *Path, en *env) (interface{}, error) {
+	m, segments := en.target(p)
+	fields, err := resolve(m.Descriptor(), segments, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, fd := range fields[:len(fields)-1] {
+		if !m.Has(fd) {
+			return nil, nil
+		}
+		m = m.Get(fd).Message()
+	}
+	fd := fields[len(fields)-1]
+	if !m.Has(fd) {
+		return nil, nil
+	}
+	return scalar(fd, m.Get(fd)), nil
+}
+
+// evalLambda applies any or all to the members of the collection of l. An
+// unset collection is empty.
+func evalLambda(l *Lambda, en *env) (interface{}, error) {
+	m, segments := en.target(l.Collection)
+	fields, err := resolve(m.Descriptor(), segments, true)
+	if err != nil {
+		return nil, err
+	}
+	var members protoreflect.List
+	for _, fd := range fields {
+		if !m.Has(fd) {
+			break
+		}
+		if fd == fields[len(fields)-1] {
+			if list := listField(fd); list == fd {
+				members = m.Get(fd).List()
+			} else {
+				members = m.Get(fd).Message().Get(list).List()
+			}
+			break
+		}
+		m = m.Get(fd).Message()
+	}
+	n := 0
+	if members != nil {
+		n = members.Len()
+	}
+	if l.Predicate == nil {
+		return n > 0, nil
+	}
+	inner := &env{root: en.root, vars: make(map[string]protoreflect.Message, len(en.vars)+1)}
+	for k, v := range en.vars {
+		inner.vars[k] = v
+	}
+	for i := 0; i < n; i++ {
+		inner.vars[l.Var] = members.Get(i).Message()
+		v, err := eval(l.Predicate, inner)
+		if err != nil {
+			return nil, err
+		}
+		b, _ := v.(bool)
+		if l.Op == "any" && b {
+			return true, nil
+		}
+		if l.Op == "all" && !b {
+			return false, nil
+		}
+	}
+	return l.Op == "all", nil
+}
+
+// scalar converts a primitive field value to the representation of
+// literals.
+func scalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.StringKind:
+		return v.String()
+	case protoreflect.BytesKind:
+		return string(v.Bytes())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int64(v.Enum())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int64(v.Uint())
+	case protoreflect.MessageKind:
+		m := v.Message()
+		fields := m.Descriptor().Fields()
+		return time.Unix(m.Get(fields.ByName("seconds")).Int(), m.Get(fields.ByName("nanos")).Int()).UTC()
+	default:
+		return v.Int()
+	}
+}
+
+func evalBinary(b *Binary, en *env) (interface{}, error) {
+	left, err := eval(b.Left, en)
+	if err != nil {
+		return nil, err
+	}
+	switch b.Op {
+	case "and", "or":
+		l, _ := left.(bool)
+		if b.Op == "and" && !l || b.Op == "or" && l {
+			return l, nil
+		}
+		right, err := eval(b.Right, en)
+		if err != nil {
+			return nil, err
+		}
+		r, _ := right.(bool)
+		return r, nil
+	}
+	right, err := eval(b.Right, en)
+	if err != nil {
+		return nil, err
+	}
+	if left == nil || right == nil {
+		switch b.Op {
+		case "eq":
+			return left == nil && right == nil, nil
+		case "ne":
+			return left != nil || right != nil, nil
+		}
+		return false, nil
+	}
+	c, ok := compare(left, right)
+	if !ok {
+		return nil, &Error{Pos: -1, Msg: "cannot compare " + b.Left.String() + " with " + b.Right.String()}
+	}
+	switch b.Op {
+	case "eq":
+		return c == 0, nil
+	case "ne":
+		return c != 0, nil
+	case "gt":
+		return c > 0, nil
+	case "ge":
+		return c >= 0, nil
+	case "lt":
+		return c < 0, nil
+	case "le":
+		return c <= 0, nil
+	}
+	return nil, &Error{Pos: -1, Msg: "unsupported operator " + b.Op}
+}
+
+// compare orders two non-null values of the same type. Integers and
+// floating point numbers compare with each other.
+func compare(a, b interface{}) (int, bool) {
+	switch a := a.(type) {
+	case time.Time:
+		b, ok := b.(time.Time)
+		return a.Compare(b), ok
+	case string:
+		b, ok := b.(string)
+		return strings.Compare(a, b), ok
+	case bool:
+		b, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		if a == b {
+			return 0, true
+		}
+		if !a {
+			return -1, true
+		}
+		return 1, true
+	case int64:
+		if b, ok := b.(int64); ok {
+			return cmp.Compare(a, b), true
+		}
+		return compare(float64(a), b)
+	case float64:
+		switch b := b.(type) {
+		case float64:
+			return cmp.Compare(a, b), true
+		case int64:
+			return cmp.Compare(a, float64(b)), true
+		}
+	}
+	return 0, false
+}
+
+func evalCall(c *Call, en *env) (interface{}, error) {
+	if arity, ok := functions[c.Name]; !ok || len(c.Args) != arity {
+		return nil, &Error{Pos: -1, Msg: "unsupported call " + c.String()}
+	}
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		v, err := eval(a, en)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, nil
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, &Error{Pos: -1, Msg: c.Name + " expects string arguments"}
+		}
+		args[i] = s
+	}
+	switch c.Name {
+	case "contains":
+		return strings.Contains(args[0], args[1]), nil
+	case "startswith":
+		return strings.HasPrefix(args[0], args[1]), nil
+	case "endswith":
+		return strings.HasSuffix(args[0], args[1]), nil
+	case "tolower":
+		return strings.ToLower(args[0]), nil
+	case "toupper":
+		return strings.ToUpper(args[0]), nil
+	case "trim":
+		return strings.TrimSpace(args[0]), nil
+	case "length":
+		return int64(len([]rune(args[0]))), nil
+	}
+	return nil, &Error{Pos: -1, Msg: "unsupported function " + c.Name}
+}
@@ -0,0 +1,190 @@
+package odata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testType describes pb.Item as the tests query it.
+var testType = &EntityType{
+	Name:      "item",
+	EntitySet: "items",
+	Properties: []*Property{
+		{Name: "itemId", Type: string(edm.EdmInt32), IsFilterable: true, IsSortable: true},
+		{Name: "itemName", Type: string(edm.EdmString), IsFilterable: true, IsSortable: true},
+		{Name: "itemType", Type: string(edm.EdmString), IsFilterable: true, IsSortable: true},
+		{Name: "description", Type: string(edm.EdmString)},
+		{Name: "tenantInfo", Type: "common.v1.config.TenantAwareModel", ComplexType: &ComplexType{
+			Name: "common.v1.config.TenantAwareModel",
+			Properties: []*Property{
+				{Name: "tenantId", Type: string(edm.EdmString), IsFilterable: true, IsSortable: true},
+			},
+		}},
+	},
+}
+
+// testItem returns the item the expressions of the tests are matched
+// against. Its description is not set.
+func testItem() *pb.Item {
+	return &pb.Item{
+		ExtId:      proto.String("4c6a1b4e-0000-4000-8000-000000000001"),
+		ItemId:     proto.Int32(7),
+		ItemName:   proto.String("  Disk-1 "),
+		ItemType:   proto.String("disk"),
+		TenantInfo: &commonpb.TenantAwareModel{TenantId: proto.String("tenant-1")},
+		DeletedAt:  timestamppb.New(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)),
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"itemType eq 'disk'", true},
+		{"itemType eq 'Disk'", false},
+		{"itemType ne 'vm'", true},
+		{"itemType gt 'dis'", true},
+		{"itemType lt 'dis'", false},
+		{"itemId eq 7", true},
+		{"itemId ge 7 and itemId le 7", true},
+		{"itemId gt 7", false},
+		{"itemId lt 7.5", true},
+		{"itemId gt 6.99", true},
+		{"itemId eq 7.0", true},
+		{"itemId in (1, 7)", true},
+		{"itemType in ('vm', 'host')", false},
+		{"itemType in ()", false},
+		{"not itemId eq 7", false},
+		{"not (itemId eq 1 or itemType eq 'vm')", true},
+		{"itemId eq 1 or itemType eq 'disk'", true},
+		{"itemId eq 7 and itemType eq 'vm'", false},
+		{"deletedAt eq 2024-05-01T14:00:00+02:00", true},
+		{"deletedAt lt 2024-05-01T12:00:00.001Z", true},
+		{"deletedAt gt 2024-05-01T12:00:00Z", false},
+		{"tenantInfo/tenantId eq 'tenant-1'", true},
+		{"startswith(tenantInfo/tenantId, 'tenant') and itemId eq 7", true},
+
+		// Unset properties are null.
+		{"description eq null", true},
+		{"description ne null", false},
+		{"itemName ne null", true},
+		{"description eq 'x'", false},
+		{"description ne 'x'", true},
+		{"description gt 'x' or description le 'x'", false},
+		{"not (description eq 'x')", true},
+		{"contains(description, 'x')", false},
+		{"not contains(description, 'x')", false},
+
+		{"contains(itemName, 'sk-')", true},
+		{"contains(itemName, 'SK')", false},
+		{"startswith(trim(itemName), 'Disk')", true},
+		{"startswith(itemName, 'Disk')", false},
+		{"endswith(trim(itemName), '-1')", true},
+		{"tolower(itemName) eq '  disk-1 '", true},
+		{"toupper(itemType) eq 'DISK'", true},
+		{"length(itemName) eq 9", true},
+		{"length(trim(itemName)) eq 6", true},
+		{"contains(itemName, itemType)", false},
+		{"contains(tolower(itemName), itemType)", true},
+	}
+	for _, tt := range tests {
+		e, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", tt.filter, err)
+			continue
+		}
+		got, err := Match(e, testItem().ProtoReflect())
+		if err != nil || got != tt.want {
+			t.Errorf("Match(%q) = %v, %v, want %v", tt.filter, got, err, tt.want)
+		}
+	}
+	// The properties nested in unset properties are null too.
+	it := testItem()
+	it.TenantInfo = nil
+	for filter, want := range map[string]bool{"tenantInfo/tenantId eq null": true, "tenantInfo/tenantId eq 'tenant-1'": false} {
+		e, _ := ParseFilter(filter)
+		if got, err := Match(e, it.ProtoReflect()); err != nil || got != want {
+			t.Errorf("Match(%q) without tenantInfo = %v, %v, want %v", filter, got, err, want)
+		}
+	}
+}
+
+func TestMatchErrors(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"itemName eq 1", "cannot compare itemName with 1"},
+		{"itemId eq '7'", "cannot compare itemId with '7'"},
+		{"deletedAt gt 1", "cannot compare deletedAt with 1"},
+		{"noSuchProperty eq 1", "unknown property noSuchProperty"},
+		{"length(itemId) eq 1", "length expects string arguments"},
+		{"reverse(itemName) eq 'x'", "unsupported call reverse(itemName)"},
+		{"itemId eq 1 or itemName eq 1", "cannot compare itemName with 1"},
+	}
+	for _, tt := range tests {
+		e, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", tt.filter, err)
+			continue
+		}
+		if _, err := Match(e, testItem().ProtoReflect()); err == nil || err.Error() != tt.want {
+			t.Errorf("Match(%q): got %v, want %s", tt.filter, err, tt.want)
+		}
+	}
+	// The right operand of and and or is not evaluated when the left one
+	// decides.
+	for _, filter := range []string{"itemId eq 1 and itemName eq 1", "itemId eq 7 or itemName eq 1"} {
+		e, _ := ParseFilter(filter)
+		if _, err := Match(e, testItem().ProtoReflect()); err != nil {
+			t.Errorf("Match(%q): %v", filter, err)
+		}
+	}
+}
+
+func TestCheckFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		// want is the error expected, empty if the filter is valid.
+		want string
+	}{
+		{filter: "itemName eq 'a' and itemId in (1, 2)"},
+		{filter: "contains(tolower(itemName), 'a')"},
+		{"description eq 'a'", "property description is not filterable"},
+		{"not (itemId eq 1 or description eq 'a')", "property description is not filterable"},
+		{"itemType in ('a', description)", "property description is not filterable"},
+		{"noSuchProperty eq 1", "unknown property noSuchProperty"},
+		{"itemName/first eq 'a'", "property itemName has no nested properties"},
+		{"reverse(itemName) eq 'a'", "unsupported function reverse"},
+		{"contains(itemName) eq 'a'", "contains takes 2 arguments"},
+		{"length(description) eq 1", "property description is not filterable"},
+		{filter: "tenantInfo/tenantId eq 'a'"},
+		{"tenantInfo eq 'a'", "property tenantInfo is not a primitive property"},
+		{"tenantInfo/name eq 'a'", "unknown property tenantInfo/name"},
+	}
+	for _, tt := range tests {
+		e, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", tt.filter, err)
+			continue
+		}
+		err = CheckFilter(e, testType)
+		if got := errorString(err); got != tt.want {
+			t.Errorf("CheckFilter(%q) = %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
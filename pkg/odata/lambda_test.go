@@ -0,0 +1,301 @@
+package odata
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+)
+
+// lambdaType is testType with the associations of items, stored in a table
+// of their own.
+var lambdaType = &EntityType{
+	Name:      testType.Name,
+	EntitySet: testType.EntitySet,
+	Table:     "items",
+	Properties: append(slices.Clip(testType.Properties), &Property{
+		Name:         "associations",
+		Type:         "nexus.v4.config.ItemAssociation",
+		IsCollection: true,
+		ComplexType: &ComplexType{
+			Name: "nexus.v4.config.ItemAssociation",
+			Properties: []*Property{
+				{Name: "entityType", Type: string(edm.EdmString), IsFilterable: true, MappedName: "entity_type"},
+				{Name: "entityId", Type: string(edm.EdmString)},
+				{Name: "count", Type: string(edm.EdmInt32), IsFilterable: true},
+			},
+		},
+		Join: &Join{Table: "item_associations", ForeignKey: "item_id", Key: "extId"},
+	}),
+}
+
+// associatedItem returns testItem associated with 2 VMs, one of them
+// counted 5 times, and a host.
+func associatedItem() *pb.Item {
+	it := testItem()
+	assoc := func(entityType, entityId string, count int32) *pb.ItemAssociation {
+		return &pb.ItemAssociation{EntityType: proto.String(entityType), EntityId: proto.String(entityId), Count: proto.Int32(count)}
+	}
+	it.Associations = &pb.ItemAssociationArrayWrapper{Value: []*pb.ItemAssociation{
+		assoc("vm", "vm-1", 1),
+		assoc("vm", "vm-2", 5),
+		assoc("host", "host-1", 2),
+	}}
+	return it
+}
+
+func TestParseLambda(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"associations/any()", "associations/any()"},
+		{"associations/any(a: a/count gt 3)", "associations/any(a:(a/count gt 3))"},
+		{"associations/all(a: a/entityType eq 'vm' and a/count gt 3)", "associations/all(a:((a/entityType eq 'vm') and (a/count gt 3)))"},
+		{"not associations/any() or itemId eq 1", "(not associations/any() or (itemId eq 1))"},
+		{"links/value/any(l: l/rel eq 'self')", "links/value/any(l:(l/rel eq 'self'))"},
+		{"associations/any(a: a/entityType eq 'vm' and associations/all(b: b/count le a/count))", "associations/any(a:((a/entityType eq 'vm') and associations/all(b:(b/count le a/count))))"},
+		// Without parentheses, any and all are property names.
+		{"associations/any eq 1", "(associations/any eq 1)"},
+	}
+	for _, tt := range tests {
+		e, err := ParseFilter(tt.in)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", tt.in, err)
+			continue
+		}
+		if got := e.String(); got != tt.want {
+			t.Errorf("ParseFilter(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+		if again, err := ParseFilter(e.String()); err != nil || again.String() != e.String() {
+			t.Errorf("ParseFilter(%q) = %v, %v, want %s", e.String(), again, err, e)
+		}
+	}
+}
+
+func TestParseLambdaErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"associations/all()", "all requires a lambda expression at position 13"},
+		{"associations/any('a': 'a' eq 'a')", "expected a lambda variable at position 17"},
+		{"associations/any(a a/count gt 1)", `expected ":" at position 19`},
+		{"associations/any(a: a/count gt 1", `expected ")" at position 32`},
+		{"associations/any(a:)", `unexpected ")" at position 19`},
+	}
+	for _, tt := range tests {
+		if _, err := ParseFilter(tt.in); errorString(err) != tt.want {
+			t.Errorf("ParseFilter(%q): got %v, want %s", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestMatchLambda(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"associations/any()", true},
+		{"associations/any(a: a/entityType eq 'vm' and a/count gt 3)", true},
+		{"associations/any(a: a/entityType eq 'host' and a/count gt 3)", false},
+		{"associations/all(a: a/count ge 1)", true},
+		{"associations/all(a: a/entityType eq 'vm')", false},
+		{"not associations/all(a: a/entityType eq 'vm')", true},
+		{"associations/any(a: a/entityType eq itemType)", false},
+		{"associations/any(a: a/count gt itemId or a/entityId eq 'host-1')", true},
+		// Nested lambdas see the variables of the enclosing ones.
+		{"associations/any(a: associations/all(b: b/count le a/count))", true},
+		{"associations/all(a: associations/any(b: b/count gt a/count))", false},
+	}
+	for _, tt := range tests {
+		e, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", tt.filter, err)
+			continue
+		}
+		got, err := Match(e, associatedItem().ProtoReflect())
+		if err != nil || got != tt.want {
+			t.Errorf("Match(%q) = %v, %v, want %v", tt.filter, got, err, tt.want)
+		}
+	}
+	// Items without associations have an empty collection, for which any
+	// is false and all is true.
+	for filter, want := range map[string]bool{
+		"associations/any()":                            false,
+		"associations/any(a: a/count gt 0)":             false,
+		"associations/all(a: a/entityType eq 'vm')":     true,
+		"not associations/any(a: a/entityType eq 'vm')": true,
+	} {
+		e, _ := ParseFilter(filter)
+		if got, err := Match(e, testItem().ProtoReflect()); err != nil || got != want {
+			t.Errorf("Match(%q) without associations = %v, %v, want %v", filter, got, err, want)
+		}
+	}
+}
+
+func TestCheckFilterLambda(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{filter: "associations/any()"},
+		{filter: "associations/any(a: a/entityType eq 'vm' and a/count gt 3)"},
+		{filter: "associations/all(a: a/count gt itemId)"},
+		{filter: "associations/any(a: associations/all(b: b/count le a/count))"},
+		{"associations/any(a: a/entityId eq 'vm-1')", "property a/entityId is not filterable"},
+		{"associations/any(a: a/size gt 1)", "unknown property size"},
+		{"associations/any(a: a eq 'vm')", "unknown property a"},
+		{"associations/any(a: description eq 'x')", "property description is not filterable"},
+		{"associations eq 1", "property associations is a collection"},
+		{"associations/entityType eq 'vm'", "property associations has no nested properties"},
+		{"tenantInfo/any(t: t/tenantId eq 'a')", "property tenantInfo is not a collection of complex values"},
+		{"itemName/any()", "property itemName is not a collection of complex values"},
+	}
+	for _, tt := range tests {
+		e, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", tt.filter, err)
+			continue
+		}
+		if got := errorString(CheckFilter(e, lambdaType)); got != tt.want {
+			t.Errorf("CheckFilter(%q) = %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestPaths(t *testing.T) {
+	e, err := ParseFilter("itemId eq 1 and associations/any(a: a/count gt itemId and associations/all(b: b/entityType eq a/entityType))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	Paths(e, func(segments []string) { got = append(got, strings.Join(segments, "/")) })
+	want := []string{
+		"itemId",
+		"associations",
+		"associations/count",
+		"itemId",
+		"associations",
+		"associations/entityType",
+		"associations/entityType",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Paths = %v, want %v", got, want)
+	}
+}
+
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		filter string
+		name   string
+		want   bool
+	}{
+		{"itemId eq 1", "itemId", true},
+		{"itemId eq 1", "itemName", false},
+		{"tenantInfo/tenantId eq 'a'", "tenantInfo", true},
+		{"contains(itemName, 'a') or itemType in ('a', itemName)", "itemName", true},
+		{"not itemType in ('a', 'b')", "itemType", true},
+		{"associations/any()", "associations", true},
+		{"associations/any(a: a/count gt 1)", "count", false},
+		// The lambda variable hides a property of the same name.
+		{"associations/any(itemId: itemId/count gt 1)", "itemId", false},
+		{"associations/any(a: a/count gt itemId)", "itemId", true},
+	}
+	for _, tt := range tests {
+		e, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := References(e, tt.name); got != tt.want {
+			t.Errorf("References(%q, %s) = %v, want %v", tt.filter, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"isDeleted eq true", "(false eq true)"},
+		{"not isDeleted and itemId eq 1", "(not false and (itemId eq 1))"},
+		{"isDeleted in (true) or contains(itemName, 'a')", "(false in (true) or contains(itemName,'a'))"},
+		{"associations/any(a: a/count gt 1 and isDeleted)", "associations/any(a:((a/count gt 1) and false))"},
+		// Only top-level properties are substituted.
+		{"tenantInfo/isDeleted eq true", "(tenantInfo/isDeleted eq true)"},
+		{"associations/any(isDeleted: isDeleted/count gt 1)", "associations/any(isDeleted:(isDeleted/count gt 1))"},
+	}
+	for _, tt := range tests {
+		e, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before := e.String()
+		if got := Substitute(e, "isDeleted", &Literal{Value: false}).String(); got != tt.want {
+			t.Errorf("Substitute(%q) = %s, want %s", tt.filter, got, tt.want)
+		}
+		if e.String() != before {
+			t.Errorf("Substitute(%q) modified the expression to %s", tt.filter, e)
+		}
+	}
+}
+
+func TestSQL(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+		args   []interface{}
+	}{
+		{"itemId eq 1", "t0.itemId = ?", []interface{}{int64(1)}},
+		{"itemName eq null or itemType ne null", "(t0.itemName IS NULL OR t0.itemType IS NOT NULL)", nil},
+		{"not (itemId gt 1 and itemId le 5)", "NOT ((t0.itemId > ? AND t0.itemId <= ?))", []interface{}{int64(1), int64(5)}},
+		{"itemType in ('vm', 'disk')", "t0.itemType IN (?, ?)", []interface{}{"vm", "disk"}},
+		{"itemType in ()", "1 = 0", nil},
+		{"contains(tolower(itemName), '50%_a')", `LOWER(t0.itemName) LIKE ? ESCAPE '\'`, []interface{}{`%50\%\_a%`}},
+		{"startswith(itemName, 'a') and endswith(itemName, 'z')", `(t0.itemName LIKE ? ESCAPE '\' AND t0.itemName LIKE ? ESCAPE '\')`, []interface{}{"a%", "%z"}},
+		{"associations/any()", "EXISTS (SELECT 1 FROM item_associations t1 WHERE t1.item_id = t0.extId)", nil},
+		{
+			"associations/any(a: a/entityType eq 'vm' and a/count gt 3)",
+			"EXISTS (SELECT 1 FROM item_associations t1 WHERE t1.item_id = t0.extId AND ((t1.entity_type = ? AND t1.count > ?)))",
+			[]interface{}{"vm", int64(3)},
+		},
+		{
+			"associations/all(a: a/count gt itemId)",
+			"NOT EXISTS (SELECT 1 FROM item_associations t1 WHERE t1.item_id = t0.extId AND NOT (t1.count > t0.itemId))",
+			nil,
+		},
+		{
+			"associations/any(a: associations/all(b: b/count le a/count))",
+			"EXISTS (SELECT 1 FROM item_associations t1 WHERE t1.item_id = t0.extId AND (NOT EXISTS (SELECT 1 FROM item_associations t2 WHERE t2.item_id = t0.extId AND NOT (t2.count <= t1.count))))",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		e, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, args, err := SQL(e, lambdaType)
+		if err != nil || got != tt.want || !slices.Equal(args, tt.args) {
+			t.Errorf("SQL(%q) = %s, %v, %v, want %s, %v", tt.filter, got, args, err, tt.want, tt.args)
+		}
+	}
+	for filter, want := range map[string]string{
+		"tenantInfo/tenantId eq 'a'":   "property tenantInfo/tenantId cannot be translated to SQL",
+		"contains(itemName, itemType)": "contains can only be translated to SQL with a string literal pattern",
+		"reverse(itemName) eq 'a'":     "unsupported call reverse(itemName)",
+		"tenantInfo/any(t: t eq 'a')":  "property tenantInfo cannot be translated to SQL",
+	} {
+		e, err := ParseFilter(filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := SQL(e, lambdaType); errorString(err) != want {
+			t.Errorf("SQL(%q): got %v, want %s", filter, err, want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package odata
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+)
+
+// Property describes a property of an entity or complex type.
+type Property struct {
+	Name string
+	// Type is the name of an Edm primitive type, such as Edm.String, or the
+	// qualified name of ComplexType.
+	Type         string
+	IsCollection bool
+	IsFilterable bool
+	IsSortable   bool
+	// MappedName is the backend column of the property, if any.
+	MappedName string
+	// ComplexType describes the nested properties of a complex-typed
+	// property. It is nil for primitive properties.
+	ComplexType *ComplexType
+	// Join describes how the members of a collection stored in a table of
+	// their own relate to the entity holding them. It is nil for properties
+	// stored with the entity.
+	Join *Join
+}
+
+// Join relates the rows of Table to the entity they belong to: the column
+// ForeignKey of Table holds the value of the column Key of the entity.
+type Join struct {
+	Table      string
+	ForeignKey string
+	Key        string
+}
+
+// ComplexType is a structured type without a key, whose values are nested
+// in the entities that hold them.
+type ComplexType struct {
+	// Name is the qualified name of the type, such as
+	// mock.v4.config.Loitemion.
+	Name       string
+	Properties []*Property
+}
+
+// EntityType describes the entities of an entity set and the query options
+// their properties support.
+type EntityType struct {
+	Name      string
+	EntitySet string
+	// Table is the backend table the entities are stored in, if any.
+	Table      string
+	Properties []*Property
+}
+
+// Lookup returns the properties named by the segments of a path, starting
+// from the properties of t.
+func (t *EntityType) Lookup(segments []string) ([]*Property, error) {
+	return lookup(t.Properties, segments)
+}
+
+func lookup(props []*Property, segments []string) ([]*Property, error) {
+	found := make([]*Property, 0, len(segments))
+	for i, name := range segments {
+		var p *Property
+		for _, candidate := range props {
+			if candidate.Name == name {
+				p = candidate
+				break
+			}
+		}
+		if p == nil {
+			return nil, &Error{Pos: -1, Msg: "unknown property " + strings.Join(segments[:i+1], "/")}
+		}
+		found = append(found, p)
+		if i < len(segments)-1 {
+			if p.ComplexType == nil || p.IsCollection {
+				return nil, &Error{Pos: -1, Msg: "property " + strings.Join(segments[:i+1], "/") + " has no nested properties"}
+			}
+			props = p.ComplexType.Properties
+		}
+	}
+	return found, nil
+}
+
+// FilterablePaths returns the paths of the primitive properties of t,
+// nested ones included, that can be used in $filter, in lexical order.
+func (t *EntityType) FilterablePaths() []string {
+	return paths(t.Properties, "", func(p *Property) bool { return p.IsFilterable })
+}
+
+// SortablePaths returns the paths of the primitive properties of t, nested
+// ones included, that can be used in $orderby, in lexical order.
+func (t *EntityType) SortablePaths() []string {
+	return paths(t.Properties, "", func(p *Property) bool { return p.IsSortable })
+}
+
+func paths(props []*Property, prefix string, include func(*Property) bool) []string {
+	var out []string
+	for _, p := range props {
+		switch {
+		case p.ComplexType != nil && !p.IsCollection:
+			out = append(out, paths(p.ComplexType.Properties, prefix+p.Name+"/", include)...)
+		case include(p):
+			out = append(out, prefix+p.Name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// EntityTypeFromBinding returns the EntityType described by a generated EDM
+// binding. Bindings only describe primitive properties; complex-typed
+// properties can be appended to the result.
+func EntityTypeFromBinding(b *edm.EdmEntityBinding) *EntityType {
+	t := &EntityType{Name: b.EntityType.Name, EntitySet: b.EntitySet.Name, Table: b.EntitySet.TableName}
+	for _, p := range b.EntityType.Properties {
+		t.Properties = append(t.Properties, &Property{
+			Name:         p.Name,
+			Type:         p.Type,
+			IsCollection: p.IsCollection,
+			IsFilterable: p.IsFilterable,
+			IsSortable:   p.IsSortable,
+			MappedName:   p.MappedName,
+		})
+	}
+	return t
+}
+
+// column returns the backend column of p.
+func (p *Property) column() string {
+	if p.MappedName != "" {
+		return p.MappedName
+	}
+	return p.Name
+}
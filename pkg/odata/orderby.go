@@ -0,0 +1,127 @@
+package odata
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// OrderItem is one of the comma separated terms of $orderby.
+type OrderItem struct {
+	Path *Path
+	Desc bool
+}
+
+func (o OrderItem) String() string {
+	if o.Desc {
+		return o.Path.String() + " desc"
+	}
+	return o.Path.String() + " asc"
+}
+
+// ParseOrderBy parses an $orderby expression such as
+// "loitemion/country/state asc,itemName desc".
+func ParseOrderBy(s string) ([]OrderItem, error) {
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	var items []OrderItem
+	for {
+		t := p.next()
+		if t.kind != tokIdent {
+			return nil, errorf(t.pos, "expected a property name")
+		}
+		path, err := p.parsePlainPath(t)
+		if err != nil {
+			return nil, err
+		}
+		item := OrderItem{Path: path}
+		switch {
+		case p.isKeyword("asc"):
+			p.next()
+		case p.isKeyword("desc"):
+			p.next()
+			item.Desc = true
+		}
+		items = append(items, item)
+		if t := p.next(); t.kind == tokEOF {
+			return items, nil
+		} else if t.kind != tokPunct || t.text != "," {
+			return nil, errorf(t.pos, "expected \",\"")
+		}
+	}
+}
+
+// CheckOrderBy reports whether every term of order names a sortable
+// primitive property of t.
+func CheckOrderBy(order []OrderItem, t *EntityType) error {
+	for _, o := range order {
+		if err := checkPrimitive(t.Lookup, o.Path, "sortable", func(p *Property) bool { return p.IsSortable }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sort sorts msgs by order, keeping the original order of messages that
+// compare equal. Null values sort before all others.
+func Sort[M proto.Message](msgs []M, order []OrderItem) error {
+	if len(order) == 0 {
+		return nil
+	}
+	keys := make([][]interface{}, len(msgs))
+	for i, m := range msgs {
+		keys[i] = make([]interface{}, len(order))
+		for j, o := range order {
+			v, err := evalPath(o.Path, &env{root: m.ProtoReflect()})
+			if err != nil {
+				return err
+			}
+			keys[i][j] = v
+		}
+	}
+	idx := make([]int, len(msgs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		for j, o := range order {
+			c := compareNullable(keys[idx[a]][j], keys[idx[b]][j])
+			if o.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	sorted := make([]M, len(msgs))
+	for i, k := range idx {
+		sorted[i] = msgs[k]
+	}
+	copy(msgs, sorted)
+	return nil
+}
+
+// Compare orders two values in the representation of literals as $orderby
+// does: null before all other values. Values that cannot be compared with
+// each other compare equal.
+func Compare(a, b interface{}) int {
+	return compareNullable(a, b)
+}
+
+func compareNullable(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	c, _ := compare(a, b)
+	return c
+}
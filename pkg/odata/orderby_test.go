@@ -0,0 +1,121 @@
+package odata
+
+import (
+	"slices"
+	"testing"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+		// err is the error expected, empty if in is valid.
+		err string
+	}{
+		{in: "itemName", want: []string{"itemName asc"}},
+		{in: "itemName desc", want: []string{"itemName desc"}},
+		{in: "tenantInfo/tenantId asc,itemId desc", want: []string{"tenantInfo/tenantId asc", "itemId desc"}},
+		{in: " itemType , itemName desc ", want: []string{"itemType asc", "itemName desc"}},
+		{in: "", err: "expected a property name at position 0"},
+		{in: "itemName,", err: "expected a property name at position 9"},
+		{in: "itemName up", err: `expected "," at position 9`},
+		{in: "itemName desc asc", err: `expected "," at position 14`},
+		{in: "'itemName'", err: "expected a property name at position 0"},
+		{in: "associations/any(a: a/entityType eq 'vm')", err: "lambda operators are only allowed in $filter at position 0"},
+	}
+	for _, tt := range tests {
+		order, err := ParseOrderBy(tt.in)
+		if got := errorString(err); got != tt.err {
+			t.Errorf("ParseOrderBy(%q): got error %q, want %q", tt.in, got, tt.err)
+			continue
+		}
+		var got []string
+		for _, o := range order {
+			got = append(got, o.String())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ParseOrderBy(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCheckOrderBy(t *testing.T) {
+	tests := []struct {
+		in  string
+		err string
+	}{
+		{in: "itemName desc,itemId"},
+		{in: "tenantInfo/tenantId"},
+		{"description", "property description is not sortable"},
+		{"tenantInfo", "property tenantInfo is not a primitive property"},
+		{"itemName/first", "property itemName has no nested properties"},
+		{"itemName,noSuchProperty", "unknown property noSuchProperty"},
+	}
+	for _, tt := range tests {
+		order, err := ParseOrderBy(tt.in)
+		if err != nil {
+			t.Errorf("ParseOrderBy(%q): %v", tt.in, err)
+			continue
+		}
+		if got := errorString(CheckOrderBy(order, testType)); got != tt.err {
+			t.Errorf("CheckOrderBy(%q) = %q, want %q", tt.in, got, tt.err)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	item := func(id int32, name, tenantId string) *pb.Item {
+		it := &pb.Item{ItemId: proto.Int32(id)}
+		if name != "" {
+			it.ItemName = proto.String(name)
+		}
+		if tenantId != "" {
+			it.TenantInfo = &commonpb.TenantAwareModel{TenantId: proto.String(tenantId)}
+		}
+		return it
+	}
+	items := []*pb.Item{
+		item(1, "b", "t2"),
+		item(2, "a", "t1"),
+		item(3, "", "t2"),
+		item(4, "b", ""),
+		item(5, "a", "t2"),
+	}
+	tests := []struct {
+		orderby string
+		// want lists the itemIds in the order expected.
+		want []int32
+	}{
+		// Null values sort first; equal ones keep their order.
+		{"itemName", []int32{3, 2, 5, 1, 4}},
+		{"itemName desc", []int32{1, 4, 2, 5, 3}},
+		{"tenantInfo/tenantId", []int32{4, 2, 1, 3, 5}},
+		{"tenantInfo/tenantId desc,itemName", []int32{3, 5, 1, 2, 4}},
+		{"itemName,itemId desc", []int32{3, 5, 2, 4, 1}},
+	}
+	for _, tt := range tests {
+		order, err := ParseOrderBy(tt.orderby)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sorted := slices.Clone(items)
+		if err := Sort(sorted, order); err != nil {
+			t.Fatal(err)
+		}
+		var got []int32
+		for _, it := range sorted {
+			got = append(got, it.GetItemId())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("sorting by %s: got %v, want %v", tt.orderby, got, tt.want)
+		}
+	}
+	order, _ := ParseOrderBy("noSuchProperty")
+	if err := Sort(slices.Clone(items), order); err == nil {
+		t.Error("sorting by an unknown property succeeded")
+	}
+}
@@ -0,0 +1,344 @@
+package odata
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokDateTime
+	tokPunct
+)
+
+// dateTimePattern matches the DateTimeOffset literals of OData at the start
+// of a string, such as 2024-05-01T12:00:00Z or 2024-05-01T14:00:00.5+02:00.
+var dateTimePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func lex(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '\'':
+			start := i
+			var b strings.Builder
+			for i++; ; i++ {
+				if i >= len(s) {
+					return nil, errorf(start, "unterminated string literal")
+				}
+				if s[i] == '\'' {
+					if i+1 < len(s) && s[i+1] == '\'' {
+						b.WriteByte('\'')
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				b.WriteByte(s[i])
+			}
+			toks = append(toks, token{kind: tokString, text: b.String(), pos: start})
+		case unicode.IsDigit(c) && dateTimePattern.MatchString(s[i:]):
+			text := dateTimePattern.FindString(s[i:])
+			toks = append(toks, token{kind: tokDateTime, text: text, pos: i})
+			i += len(text)
+		case unicode.IsDigit(c) || (c == '-' && i+1 < len(s) && unicode.IsDigit(rune(s[i+1]))):
+			start := i
+			for i++; i < len(s) && strings.ContainsRune("0123456789.eE", rune(s[i])); i++ {
+			}
+			toks = append(toks, token{kind: tokNumber, text: s[start:i], pos: start})
+		case unicode.IsLetter(c) || c == '_' || c == '$':
+			start := i
+			for i++; i < len(s) && (unicode.IsLetter(rune(s[i])) || unicode.IsDigit(rune(s[i])) || s[i] == '_'); i++ {
+			}
+			toks = append(toks, token{kind: tokIdent, text: s[start:i], pos: start})
+		case strings.ContainsRune("()/,:", c):
+			toks = append(toks, token{kind: tokPunct, text: string(c), pos: i})
+			i++
+		default:
+			return nil, errorf(i, "unexpected character %q", c)
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(s)}), nil
+}
+
+var comparisonOps = map[string]bool{"eq": true, "ne": true, "gt": true, "ge": true, "lt": true, "le": true}
+
+type parser struct {
+	toks []token
+	pos  int
+}
+
+// ParseFilter parses a $filter expression.
+func ParseFilter(s string) (Expr, error) {
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, errorf(t.pos, "unexpected %q", t.text)
+	}
+	return e, nil
+}
+
+func (p *parser) peek() token { return p.toks[p.pos] }
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isKeyword(word string) bool {
+	t := p.peek()
+	return t.kind == tokIdent && t.text == word
+}
+
+func (p *parser) isPunct(c string) bool {
+	t := p.peek()
+	return t.kind == tokPunct && t.text == c
+}
+
+func (p *parser) expectPunct(c string) error {
+	if t := p.next(); t.kind != tokPunct || t.text != c {
+		return errorf(t.pos, "expected %q", c)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.isKeyword("not") {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{X: x}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	switch {
+	case t.kind == tokIdent && comparisonOps[t.text]:
+		p.next()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return &Binary{Op: t.text, Left: left, Right: right}, nil
+	case t.kind == tokIdent && t.text == "in":
+		p.next()
+		if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &In{X: left, List: list}, nil
+	}
+	return left, nil
+}
+
+// parseList parses a comma separated list of operands up to the closing
+// parenthesis, which the caller has opened.
+func (p *parser) parseList() ([]Expr, error) {
+	var list []Expr
+	if p.isPunct(")") {
+		p.next()
+		return list, nil
+	}
+	for {
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+		if p.isPunct(",") {
+			p.next()
+			continue
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokString:
+		return &Literal{Value: t.text}, nil
+	case tokNumber:
+		if n, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return &Literal{Value: n}, nil
+		}
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, errorf(t.pos, "invalid number %q", t.text)
+		}
+		return &Literal{Value: f}, nil
+	case tokDateTime:
+		v, err := time.Parse(time.RFC3339Nano, t.text)
+		if err != nil {
+			return nil, errorf(t.pos, "invalid date-time %q", t.text)
+		}
+		return &Literal{Value: v}, nil
+	case tokPunct:
+		if t.text != "(" {
+			break
+		}
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return &Literal{Value: true}, nil
+		case "false":
+			return &Literal{Value: false}, nil
+		case "null":
+			return &Literal{Value: nil}, nil
+		}
+		if p.isPunct("(") {
+			p.next()
+			args, err := p.parseList()
+			if err != nil {
+				return nil, err
+			}
+			return &Call{Name: t.text, Args: args}, nil
+		}
+		return p.parsePath(t)
+	case tokEOF:
+		return nil, errorf(t.pos, "unexpected end of expression")
+	}
+	return nil, errorf(t.pos, "unexpected %q", t.text)
+}
+
+func (p *parser) parsePath(first token) (Expr, error) {
+	path := &Path{Segments: []string{first.text}}
+	for p.isPunct("/") {
+		p.next()
+		t := p.next()
+		if t.kind != tokIdent {
+			return nil, errorf(t.pos, "expected a property name")
+		}
+		if (t.text == "any" || t.text == "all") && p.isPunct("(") {
+			p.next()
+			return p.parseLambda(path, t)
+		}
+		path.Segments = append(path.Segments, t.text)
+	}
+	return path, nil
+}
+
+// parsePlainPath parses a property path that must not end in a lambda
+// operator.
+func (p *parser) parsePlainPath(first token) (*Path, error) {
+	e, err := p.parsePath(first)
+	if err != nil {
+		return nil, err
+	}
+	path, ok := e.(*Path)
+	if !ok {
+		return nil, errorf(first.pos, "lambda operators are only allowed in $filter")
+	}
+	return path, nil
+}
+
+// parseLambda parses the body of an any or all operator applied to
+// collection, after the opening parenthesis.
+func (p *parser) parseLambda(collection *Path, op token) (Expr, error) {
+	l := &Lambda{Collection: collection, Op: op.text}
+	if p.isPunct(")") {
+		p.next()
+		if l.Op == "all" {
+			return nil, errorf(op.pos, "all requires a lambda expression")
+		}
+		return l, nil
+	}
+	v := p.next()
+	if v.kind != tokIdent {
+		return nil, errorf(v.pos, "expected a lambda variable")
+	}
+	if err := p.expectPunct(":"); err != nil {
+		return nil, err
+	}
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	l.Var, l.Predicate = v.text, pred
+	return l, nil
+}
@@ -0,0 +1,81 @@
+package odata
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		in string
+		// want is the String of the expression parsed, which parenthesises
+		// every binary operation.
+		want string
+	}{
+		{"itemName eq 'disk'", "(itemName eq 'disk')"},
+		{"itemId ne 1", "(itemId ne 1)"},
+		{"itemId gt -3", "(itemId gt -3)"},
+		{"itemId ge 1.5", "(itemId ge 1.5)"},
+		{"itemId lt 1e3", "(itemId lt 1000)"},
+		{"itemName le 'O''Brien'", "(itemName le 'O''Brien')"},
+		{"itemName eq ''", "(itemName eq '')"},
+		{"description eq null", "(description eq null)"},
+		{"flag eq true or flag eq false", "((flag eq true) or (flag eq false))"},
+		{"deletedAt lt 2024-05-01T14:00:00.5+02:00", "(deletedAt lt 2024-05-01T14:00:00.5+02:00)"},
+		{"deletedAt ge 2024-05-01T12:00:00Z", "(deletedAt ge 2024-05-01T12:00:00Z)"},
+		{"a eq 1 or b eq 2 and c eq 3", "((a eq 1) or ((b eq 2) and (c eq 3)))"},
+		{"a eq 1 and b eq 2 or c eq 3", "(((a eq 1) and (b eq 2)) or (c eq 3))"},
+		{"(a eq 1 or b eq 2) and c eq 3", "(((a eq 1) or (b eq 2)) and (c eq 3))"},
+		{"a eq 1 and b eq 2 and c eq 3", "(((a eq 1) and (b eq 2)) and (c eq 3))"},
+		{"not a eq 1 and b eq 2", "(not (a eq 1) and (b eq 2))"},
+		{"not not flag", "not not flag"},
+		{"itemType in ('vm', 'disk')", "itemType in ('vm','disk')"},
+		{"itemType in ()", "itemType in ()"},
+		{"contains(tolower(itemName), 'ab')", "contains(tolower(itemName),'ab')"},
+		{"length(trim(itemName)) gt 3", "(length(trim(itemName)) gt 3)"},
+		{"  itemName\teq 'a b'  ", "(itemName eq 'a b')"},
+	}
+	for _, tt := range tests {
+		e, err := ParseFilter(tt.in)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", tt.in, err)
+			continue
+		}
+		if got := e.String(); got != tt.want {
+			t.Errorf("ParseFilter(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+		// The String of an expression parses back to the same expression.
+		again, err := ParseFilter(e.String())
+		if err != nil || again.String() != e.String() {
+			t.Errorf("ParseFilter(%q) = %v, %v, want %s", e.String(), again, err, e)
+		}
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "unexpected end of expression at position 0"},
+		{"itemName eq", "unexpected end of expression at position 11"},
+		{"itemName eq 'disk", "unterminated string literal at position 12"},
+		{"itemName eq 'a' 'b'", `unexpected "b" at position 16`},
+		{"(itemId eq 1", `expected ")" at position 12`},
+		{"itemId eq 1)", `unexpected ")" at position 11`},
+		{"itemId # 1", `unexpected character '#' at position 7`},
+		{"itemId eq 1.2.3", `invalid number "1.2.3" at position 10`},
+		{"itemType in ('a' 'b')", `expected ")" at position 17`},
+		{"contains(itemName, 'a'", `expected ")" at position 22`},
+		{"itemName eq ,", `unexpected "," at position 12`},
+		{"tenantInfo/'a' eq 'a'", "expected a property name at position 11"},
+		{"itemId eq 1 and", "unexpected end of expression at position 15"},
+	}
+	for _, tt := range tests {
+		_, err := ParseFilter(tt.in)
+		var oerr *Error
+		if !errors.As(err, &oerr) || err.Error() != tt.want {
+			t.Errorf("ParseFilter(%q): got %v, want %s", tt.in, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package odata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SearchExpr is a node of a parsed $search expression.
+type SearchExpr interface {
+	fmt.Stringer
+	searchExpr()
+}
+
+// SearchTerm is a search word or, when Phrase is set, a double-quoted
+// phrase whose words must occur next to each other.
+type SearchTerm struct {
+	Text   string
+	Phrase bool
+}
+
+// SearchBinary combines two search expressions with AND or OR.
+type SearchBinary struct {
+	Op    string
+	Left  SearchExpr
+	Right SearchExpr
+}
+
+// SearchNot matches what its operand does not.
+type SearchNot struct {
+	X SearchExpr
+}
+
+func (*SearchTerm) searchExpr()   {}
+func (*SearchBinary) searchExpr() {}
+func (*SearchNot) searchExpr()    {}
+
+func (t *SearchTerm) String() string {
+	if t.Phrase {
+		return strconv.Quote(t.Text)
+	}
+	return t.Text
+}
+
+func (b *SearchBinary) String() string {
+	return "(" + b.Left.String() + " " + b.Op + " " + b.Right.String() + ")"
+}
+
+func (n *SearchNot) String() string { return "NOT " + n.X.String() }
+
+// ParseSearch parses a $search expression: words and double-quoted phrases
+// combined with AND, OR and NOT, where AND binds tighter than OR and
+// adjacent terms are implicitly combined with AND. Within a phrase \" and \\
+// escape a quote and a backslash.
+func ParseSearch(s string) (SearchExpr, error) {
+	toks, err := lexSearch(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	e, err := p.parseSearchOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, errorf(t.pos, "unexpected %q", t.text)
+	}
+	return e, nil
+}
+
+// lexSearch splits a $search expression into words, as tokIdent, phrases,
+// as tokString, and parentheses.
+func lexSearch(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(' || c == ')':
+			toks = append(toks, token{kind: tokPunct, text: string(c), pos: i})
+			i++
+		case c == '"':
+			start := i
+			var b strings.Builder
+			for i++; ; i++ {
+				if i >= len(s) {
+					return nil, errorf(start, "unterminated phrase")
+				}
+				if s[i] == '\\' && i+1 < len(s) && (s[i+1] == '"' || s[i+1] == '\\') {
+					i++
+				} else if s[i] == '"' {
+					i++
+					break
+				}
+				b.WriteByte(s[i])
+			}
+			toks = append(toks, token{kind: tokString, text: b.String(), pos: start})
+		default:
+			start := i
+			for i < len(s) && !unicode.IsSpace(rune(s[i])) && !strings.ContainsRune(`()"`, rune(s[i])) {
+				i++
+			}
+			toks = append(toks, token{kind: tokIdent, text: s[start:i], pos: start})
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(s)}), nil
+}
+
+func (p *parser) parseSearchOr() (SearchExpr, error) {
+	left, err := p.parseSearchAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("OR") {
+		p.next()
+		right, err := p.parseSearchAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &SearchBinary{Op: "OR", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseSearchAnd() (SearchExpr, error) {
+	left, err := p.parseSearchUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if p.isKeyword("AND") {
+			p.next()
+		} else if t := p.peek(); t.kind == tokEOF || p.isPunct(")") || p.isKeyword("OR") {
+			return left, nil
+		}
+		right, err := p.parseSearchUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &SearchBinary{Op: "AND", Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseSearchUnary() (SearchExpr, error) {
+	if p.isKeyword("NOT") {
+		p.next()
+		x, err := p.parseSearchUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &SearchNot{X: x}, nil
+	}
+	t := p.next()
+	switch {
+	case t.kind == tokIdent && (t.text == "AND" || t.text == "OR"):
+		return nil, errorf(t.pos, "unexpected %q", t.text)
+	case t.kind == tokIdent:
+		return &SearchTerm{Text: t.text}, nil
+	case t.kind == tokString:
+		return &SearchTerm{Text: t.text, Phrase: true}, nil
+	case t.kind == tokPunct && t.text == "(":
+		e, err := p.parseSearchOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case t.kind == tokEOF:
+		return nil, errorf(t.pos, "unexpected end of expression")
+	}
+	return nil, errorf(t.pos, "unexpected %q", t.text)
+}
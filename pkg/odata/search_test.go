@@ -0,0 +1,58 @@
+package odata
+
+import "testing"
+
+func TestParseSearch(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"disk", "disk"},
+		{"virtual disk", "(virtual AND disk)"},
+		{"virtual AND disk", "(virtual AND disk)"},
+		{"vm OR disk host", "(vm OR (disk AND host))"},
+		{"(vm OR disk) host", "((vm OR disk) AND host)"},
+		{"NOT vm", "NOT vm"},
+		{"NOT NOT vm", "NOT NOT vm"},
+		{"disk NOT (vm OR host)", "(disk AND NOT (vm OR host))"},
+		{`"virtual disk" OR vm`, `("virtual disk" OR vm)`},
+		{`"say \"hi\" \\ \n"`, `"say \"hi\" \\ \\n"`},
+		{"and or not", "((and AND or) AND not)"},
+		{"disk-1 o'brien", "(disk-1 AND o'brien)"},
+		{`disk"vm"`, `(disk AND "vm")`},
+	}
+	for _, tt := range tests {
+		e, err := ParseSearch(tt.in)
+		if err != nil {
+			t.Errorf("ParseSearch(%q): %v", tt.in, err)
+			continue
+		}
+		if got := e.String(); got != tt.want {
+			t.Errorf("ParseSearch(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseSearchErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "unexpected end of expression at position 0"},
+		{"   ", "unexpected end of expression at position 3"},
+		{"disk AND", "unexpected end of expression at position 8"},
+		{"OR disk", `unexpected "OR" at position 0`},
+		{"disk AND OR vm", `unexpected "OR" at position 9`},
+		{"NOT", "unexpected end of expression at position 3"},
+		{"(disk", `expected ")" at position 5`},
+		{"disk)", `unexpected ")" at position 4`},
+		{"()", `unexpected ")" at position 1`},
+		{`"virtual disk`, "unterminated phrase at position 0"},
+		{`vm "disk\"`, "unterminated phrase at position 3"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseSearch(tt.in); errorString(err) != tt.want {
+			t.Errorf("ParseSearch(%q): got %v, want %s", tt.in, err, tt.want)
+		}
+	}
+}
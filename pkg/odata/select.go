@@ -0,0 +1,95 @@
+package odata
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ParseSelect parses a $select expression, a comma separated list of
+// property paths. It returns nil for "*", which selects every property.
+func ParseSelect(s string) ([]*Path, error) {
+	if strings.TrimSpace(s) == "*" {
+		return nil, nil
+	}
+	toks, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	var paths []*Path
+	for {
+		t := p.next()
+		if t.kind != tokIdent {
+			return nil, errorf(t.pos, "expected a property name")
+		}
+		path, err := p.parsePlainPath(t)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+		if t := p.next(); t.kind == tokEOF {
+			return paths, nil
+		} else if t.kind != tokPunct || t.text != "," {
+			return nil, errorf(t.pos, "expected \",\"")
+		}
+	}
+}
+
+// CheckSelect reports whether every path names a property of t. Unlike in
+// $filter and $orderby, complex-typed properties can be selected whole.
+func CheckSelect(paths []*Path, t *EntityType) error {
+	for _, p := range paths {
+		if _, err := t.Lookup(p.Segments); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selection is the tree of selected properties, keyed by JSON name. A nil
+// subtree selects the whole property.
+type selection map[string]selection
+
+// Select clears the properties of m that paths do not select. Selecting a
+// nested property keeps its ancestors but none of their other properties.
+// A nil paths selects everything.
+func Select(m protoreflect.Message, paths []*Path) {
+	if paths == nil {
+		return
+	}
+	root := selection{}
+	for _, p := range paths {
+		root.add(p.Segments)
+	}
+	root.apply(m)
+}
+
+func (s selection) add(segments []string) {
+	sub, seen := s[segments[0]]
+	if len(segments) == 1 {
+		s[segments[0]] = nil
+		return
+	}
+	if seen && sub == nil {
+		return
+	}
+	if sub == nil {
+		sub = selection{}
+		s[segments[0]] = sub
+	}
+	sub.add(segments[1:])
+}
+
+func (s selection) apply(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		sub, ok := s[fd.JSONName()]
+		switch {
+		case !ok:
+			m.Clear(fd)
+		case sub != nil && fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap():
+			sub.apply(m.Mutable(fd).Message())
+		}
+		return true
+	})
+}
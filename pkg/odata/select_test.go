@@ -0,0 +1,95 @@
+package odata
+
+import (
+	"slices"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		selects string
+		// want changes the test item as the selection should leave it.
+		want func() *pb.Item
+	}{
+		{"*", testItem},
+		{"itemName", func() *pb.Item {
+			return &pb.Item{ItemName: testItem().ItemName}
+		}},
+		{"itemName, itemId", func() *pb.Item {
+			return &pb.Item{ItemName: testItem().ItemName, ItemId: testItem().ItemId}
+		}},
+		{"tenantInfo", func() *pb.Item {
+			return &pb.Item{TenantInfo: testItem().TenantInfo}
+		}},
+		{"tenantInfo/tenantId,itemType", func() *pb.Item {
+			return &pb.Item{TenantInfo: testItem().TenantInfo, ItemType: testItem().ItemType}
+		}},
+		// Selecting a property whole covers its nested properties.
+		{"tenantInfo/tenantId,tenantInfo", func() *pb.Item {
+			return &pb.Item{TenantInfo: testItem().TenantInfo}
+		}},
+		{"description", func() *pb.Item { return &pb.Item{} }},
+	}
+	for _, tt := range tests {
+		paths, err := ParseSelect(tt.selects)
+		if err == nil {
+			err = CheckSelect(paths, testType)
+		}
+		if err != nil {
+			t.Errorf("$select=%s: %v", tt.selects, err)
+			continue
+		}
+		it := testItem()
+		Select(it.ProtoReflect(), paths)
+		if want := tt.want(); !proto.Equal(it, want) {
+			t.Errorf("$select=%s: got %v, want %v", tt.selects, it, want)
+		}
+	}
+}
+
+func TestParseSelectErrors(t *testing.T) {
+	tests := []struct {
+		selects string
+		err     string
+	}{
+		{"", "expected a property name at position 0"},
+		{"itemName,", "expected a property name at position 9"},
+		{"itemName itemId", `expected "," at position 9`},
+		{"itemName,*", "unexpected character '*' at position 9"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseSelect(tt.selects); errorString(err) != tt.err {
+			t.Errorf("ParseSelect(%q): got %v, want %s", tt.selects, err, tt.err)
+		}
+	}
+	for selects, want := range map[string]string{
+		"noSuchProperty":      "unknown property noSuchProperty",
+		"tenantInfo/name":     "unknown property tenantInfo/name",
+		"itemName/first":      "property itemName has no nested properties",
+		"itemName,tenantInfo": "",
+	} {
+		paths, err := ParseSelect(selects)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := errorString(CheckSelect(paths, testType)); got != want {
+			t.Errorf("CheckSelect(%q) = %q, want %q", selects, got, want)
+		}
+	}
+}
+
+func TestEntityTypePaths(t *testing.T) {
+	if got, want := testType.FilterablePaths(), []string{"itemId", "itemName", "itemType", "tenantInfo/tenantId"}; !slices.Equal(got, want) {
+		t.Errorf("FilterablePaths = %v, want %v", got, want)
+	}
+	if got, want := testType.SortablePaths(), []string{"itemId", "itemName", "itemType", "tenantInfo/tenantId"}; !slices.Equal(got, want) {
+		t.Errorf("SortablePaths = %v, want %v", got, want)
+	}
+	props, err := testType.Lookup([]string{"tenantInfo", "tenantId"})
+	if err != nil || len(props) != 2 || props[0].Name != "tenantInfo" || props[1].Name != "tenantId" {
+		t.Errorf("Lookup(tenantInfo/tenantId) = %v, %v", props, err)
+	}
+}
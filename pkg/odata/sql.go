@@ -0,0 +1,221 @@
+package odata
+
+import (
+	"strconv"
+	"strings"
+)
+
+// sqlOps maps comparison operators to SQL.
+var sqlOps = map[string]string{"eq": "=", "ne": "<>", "gt": ">", "ge": ">=", "lt": "<", "le": "<="}
+
+// sqlFuncs maps the functions that translate to a single SQL function.
+var sqlFuncs = map[string]string{"tolower": "LOWER", "toupper": "UPPER", "trim": "TRIM", "length": "LENGTH"}
+
+// likeEscaper escapes the LIKE wildcards of a pattern literal.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SQL translates e, checked against t, to the condition of a WHERE clause
+// selecting from the table of t under the alias t0. Literals are replaced by
+// ? placeholders whose values are returned in order. Lambda operators over
+// collections stored in a table of their own become EXISTS subqueries
+// joining that table, so that
+//
+//	associations/any(a: a/count gt 3)
+//
+// becomes
+//
+//	EXISTS (SELECT 1 FROM item_associations t1 WHERE t1.item_id = t0.extId AND (t1.count > ?))
+//
+// Properties nested in complex values have no column of their own and
+// cannot be translated.
+func SQL(e Expr, t *EntityType) (string, []interface{}, error) {
+	tr := &sqlTranslator{}
+	cond, err := tr.translate(e, &sqlScope{alias: "t0", props: t.Properties})
+	if err != nil {
+		return "", nil, err
+	}
+	return cond, tr.args, nil
+}
+
+type sqlTranslator struct {
+	args    []interface{}
+	aliases int
+}
+
+// sqlScope is the table an expression is translated against and the tables
+// joined for the lambda variables in scope.
+type sqlScope struct {
+	alias string
+	props []*Property
+	vars  map[string]*sqlScope
+}
+
+func (tr *sqlTranslator) arg(v interface{}) string {
+	tr.args = append(tr.args, v)
+	return "?"
+}
+
+func (tr *sqlTranslator) translate(e Expr, sc *sqlScope) (string, error) {
+	switch e := e.(type) {
+	case *Literal:
+		if e.Value == nil {
+			return "NULL", nil
+		}
+		return tr.arg(e.Value), nil
+	case *Path:
+		return tr.column(e, sc)
+	case *Not:
+		x, err := tr.translate(e.X, sc)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + x + ")", nil
+	case *Binary:
+		return tr.binary(e, sc)
+	case *In:
+		x, err := tr.translate(e.X, sc)
+		if err != nil {
+			return "", err
+		}
+		if len(e.List) == 0 {
+			return "1 = 0", nil
+		}
+		list := make([]string, len(e.List))
+		for i, item := range e.List {
+			if list[i], err = tr.translate(item, sc); err != nil {
+				return "", err
+			}
+		}
+		return x + " IN (" + strings.Join(list, ", ") + ")", nil
+	case *Lambda:
+		return tr.lambda(e, sc)
+	case *Call:
+		return tr.call(e, sc)
+	}
+	return "", &Error{Pos: -1, Msg: "unsupported expression " + e.String()}
+}
+
+// column returns the qualified column of the property p names.
+func (tr *sqlTranslator) column(p *Path, sc *sqlScope) (string, error) {
+	alias, props, segments := sc.alias, sc.props, p.Segments
+	if v, ok := sc.vars[segments[0]]; ok && len(segments) > 1 {
+		alias, props, segments = v.alias, v.props, segments[1:]
+	}
+	found, err := lookup(props, segments)
+	if err != nil {
+		return "", err
+	}
+	if len(found) > 1 {
+		return "", &Error{Pos: -1, Msg: "property " + p.String() + " cannot be translated to SQL"}
+	}
+	return alias + "." + found[0].column(), nil
+}
+
+func (tr *sqlTranslator) binary(b *Binary, sc *sqlScope) (string, error) {
+	if op, ok := sqlOps[b.Op]; ok && (b.Op == "eq" || b.Op == "ne") {
+		x, null := b.Left, isNull(b.Right)
+		if !null && isNull(b.Left) {
+			x, null = b.Right, true
+		}
+		if null {
+			s, err := tr.translate(x, sc)
+			if err != nil {
+				return "", err
+			}
+			if op == "=" {
+				return s + " IS NULL", nil
+			}
+			return s + " IS NOT NULL", nil
+		}
+	}
+	left, err := tr.translate(b.Left, sc)
+	if err != nil {
+		return "", err
+	}
+	right, err := tr.translate(b.Right, sc)
+	if err != nil {
+		return "", err
+	}
+	switch b.Op {
+	case "and", "or":
+		return "(" + left + " " + strings.ToUpper(b.Op) + " " + right + ")", nil
+	}
+	op, ok := sqlOps[b.Op]
+	if !ok {
+		return "", &Error{Pos: -1, Msg: "unsupported operator " + b.Op}
+	}
+	return left + " " + op + " " + right, nil
+}
+
+func isNull(e Expr) bool {
+	l, ok := e.(*Literal)
+	return ok && l.Value == nil
+}
+
+// lambda translates any and all to EXISTS subqueries: all holds when no
+// member fails the predicate.
+func (tr *sqlTranslator) lambda(l *Lambda, sc *sqlScope) (string, error) {
+	alias, props, segments := sc.alias, sc.props, l.Collection.Segments
+	if v, ok := sc.vars[segments[0]]; ok && len(segments) > 1 {
+		alias, props, segments = v.alias, v.props, segments[1:]
+	}
+	found, err := lookup(props, segments)
+	if err != nil {
+		return "", err
+	}
+	coll := found[len(found)-1]
+	if len(found) > 1 || coll.Join == nil || coll.ComplexType == nil {
+		return "", &Error{Pos: -1, Msg: "property " + l.Collection.String() + " cannot be translated to SQL"}
+	}
+	tr.aliases++
+	inner := &sqlScope{alias: "t" + strconv.Itoa(tr.aliases), props: coll.ComplexType.Properties}
+	sub := "SELECT 1 FROM " + coll.Join.Table + " " + inner.alias +
+		" WHERE " + inner.alias + "." + coll.Join.ForeignKey + " = " + alias + "." + coll.Join.Key
+	if l.Predicate == nil {
+		return "EXISTS (" + sub + ")", nil
+	}
+	vars := make(map[string]*sqlScope, len(sc.vars)+1)
+	for k, v := range sc.vars {
+		vars[k] = v
+	}
+	vars[l.Var] = inner
+	pred, err := tr.translate(l.Predicate, &sqlScope{alias: sc.alias, props: sc.props, vars: vars})
+	if err != nil {
+		return "", err
+	}
+	if l.Op == "all" {
+		return "NOT EXISTS (" + sub + " AND NOT (" + pred + "))", nil
+	}
+	return "EXISTS (" + sub + " AND (" + pred + "))", nil
+}
+
+func (tr *sqlTranslator) call(c *Call, sc *sqlScope) (string, error) {
+	if arity, ok := functions[c.Name]; !ok || len(c.Args) != arity {
+		return "", &Error{Pos: -1, Msg: "unsupported call " + c.String()}
+	}
+	x, err := tr.translate(c.Args[0], sc)
+	if err != nil {
+		return "", err
+	}
+	if fn, ok := sqlFuncs[c.Name]; ok {
+		return fn + "(" + x + ")", nil
+	}
+	lit, _ := c.Args[1].(*Literal)
+	if lit == nil {
+		lit = &Literal{}
+	}
+	pattern, ok := lit.Value.(string)
+	if !ok {
+		return "", &Error{Pos: -1, Msg: c.Name + " can only be translated to SQL with a string literal pattern"}
+	}
+	pattern = likeEscaper.Replace(pattern)
+	switch c.Name {
+	case "contains":
+		pattern = "%" + pattern + "%"
+	case "startswith":
+		pattern += "%"
+	case "endswith":
+		pattern = "%" + pattern
+	}
+	return x + " LIKE " + tr.arg(pattern) + ` ESCAPE '\'`, nil
+}
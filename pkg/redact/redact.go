@@ -0,0 +1,113 @@
+// Package redact withholds fields of API responses from callers lacking the
+// roles to see them.
+//
+// A Policy lists Rules naming a property by its JSON path, such as
+// "description" or "associations", and the roles allowed to read it. For
+// other callers the property is either hidden, removing it from the
+// response, or masked, replacing a string value by MaskedValue. Callers of
+// Apply report every redaction in the response metadata with a Message of
+// severity REDACTED so that clients know data was withheld.
+package redact
+
+import (
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/auth"
+)
+
+// MaskedValue replaces the value of masked string properties.
+const MaskedValue = "********"
+
+// Action is what happens to a property a caller may not read.
+type Action int
+
+const (
+	// Hide clears the property.
+	Hide Action = iota
+	// Mask replaces a string property by MaskedValue. Properties of other
+	// kinds are hidden.
+	Mask
+)
+
+func (a Action) String() string {
+	if a == Mask {
+		return "masked"
+	}
+	return "hidden"
+}
+
+// Rule restricts a single property.
+type Rule struct {
+	// Path is the JSON name of the property, with "/" separating the
+	// properties of nested objects, as in $select.
+	Path string
+	// Action applies to callers holding none of Roles.
+	Action Action
+	// Roles lists the roles allowed to read the property.
+	Roles []string
+}
+
+// Policy is the set of Rules applying to a message type.
+type Policy []Rule
+
+// Redaction records a property withheld from a caller.
+type Redaction struct {
+	Path   string
+	Action Action
+}
+
+// Apply redacts msg in place for the caller id, which may be nil for
+// unauthenticated callers, and returns the redactions made. Properties that
+// are not set are left alone and not reported.
+func (p Policy) Apply(msg proto.Message, id *auth.Identity) []Redaction {
+	var done []Redaction
+	for _, rule := range p {
+		if allowed(rule, id) {
+			continue
+		}
+		if redactPath(msg.ProtoReflect(), strings.Split(rule.Path, "/"), rule.Action) {
+			done = append(done, Redaction{Path: rule.Path, Action: rule.Action})
+		}
+	}
+	return done
+}
+
+// Withheld returns the paths of the properties p hides or masks from the
+// caller id, which may be nil for unauthenticated callers, in the order of
+// its rules.
+func (p Policy) Withheld(id *auth.Identity) []string {
+	var paths []string
+	for _, rule := range p {
+		if !allowed(rule, id) {
+			paths = append(paths, rule.Path)
+		}
+	}
+	return paths
+}
+
+func allowed(rule Rule, id *auth.Identity) bool {
+	return slices.ContainsFunc(rule.Roles, id.HasRole)
+}
+
+func redactPath(m protoreflect.Message, path []string, action Action) bool {
+	fd := m.Descriptor().Fields().ByJSONName(path[0])
+	if fd == nil || !m.Has(fd) {
+		return false
+	}
+	if len(path) > 1 {
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			return false
+		}
+		return redactPath(m.Mutable(fd).Message(), path[1:], action)
+	}
+	if action == Mask && fd.Kind() == protoreflect.StringKind && fd.Cardinality() != protoreflect.Repeated {
+		m.Set(fd, protoreflect.ValueOfString(MaskedValue))
+		return true
+	}
+	m.Clear(fd)
+	return true
+}
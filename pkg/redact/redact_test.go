@@ -0,0 +1,130 @@
+package redact
+
+import (
+	"slices"
+	"testing"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/auth"
+)
+
+func testItem() *pb.Item {
+	return &pb.Item{
+		ExtId:       proto.String("4c6a1b4e-0000-4000-8000-000000000001"),
+		ItemId:      proto.Int32(7),
+		ItemName:    proto.String("disk"),
+		Description: proto.String("secret plans"),
+		TenantInfo:  &commonpb.TenantAwareModel{TenantId: proto.String("tenant-1")},
+		Associations: &pb.ItemAssociationArrayWrapper{Value: []*pb.ItemAssociation{
+			{EntityType: proto.String("vm"), EntityId: proto.String("vm-1")},
+		}},
+	}
+}
+
+func TestApply(t *testing.T) {
+	policy := Policy{
+		{Path: "description", Action: Mask, Roles: []string{auth.RoleAdmin}},
+		{Path: "associations", Action: Hide, Roles: []string{auth.RoleAdmin}},
+		{Path: "tenantInfo/tenantId", Action: Mask, Roles: []string{auth.RoleAdmin, "auditor"}},
+		// Only strings can be masked; other properties are hidden.
+		{Path: "itemId", Action: Mask, Roles: []string{auth.RoleAdmin}},
+		// Paths through lists are not followed.
+		{Path: "associations/entityId", Action: Hide, Roles: []string{"nobody"}},
+		{Path: "noSuchProperty", Action: Hide},
+	}
+	masked := func(it *pb.Item) {
+		it.Description = proto.String(MaskedValue)
+		it.Associations = nil
+		it.ItemId = nil
+	}
+	tests := []struct {
+		name string
+		id   *auth.Identity
+		want []Redaction
+		// redact changes the item as the caller should receive it.
+		redact func(*pb.Item)
+	}{
+		{
+			name:   "admin",
+			id:     &auth.Identity{User: "admin", Roles: []string{auth.RoleAdmin}},
+			redact: func(*pb.Item) {},
+		},
+		{
+			name:   "auditor",
+			id:     &auth.Identity{User: "auditor", Roles: []string{"auditor"}},
+			want:   []Redaction{{"description", Mask}, {"associations", Hide}, {"itemId", Mask}},
+			redact: masked,
+		},
+		{
+			name: "viewer",
+			id:   &auth.Identity{User: "viewer"},
+			want: []Redaction{{"description", Mask}, {"associations", Hide}, {"tenantInfo/tenantId", Mask}, {"itemId", Mask}},
+			redact: func(it *pb.Item) {
+				masked(it)
+				it.TenantInfo.TenantId = proto.String(MaskedValue)
+			},
+		},
+		{
+			name: "anonymous",
+			want: []Redaction{{"description", Mask}, {"associations", Hide}, {"tenantInfo/tenantId", Mask}, {"itemId", Mask}},
+			redact: func(it *pb.Item) {
+				masked(it)
+				it.TenantInfo.TenantId = proto.String(MaskedValue)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := testItem()
+			got := policy.Apply(it, tt.id)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got redactions %v, want %v", got, tt.want)
+			}
+			want := testItem()
+			tt.redact(want)
+			if !proto.Equal(it, want) {
+				t.Errorf("got %v, want %v", it, want)
+			}
+		})
+	}
+}
+
+// TestApplyUnset checks that properties that are not set are not reported
+// as withheld.
+func TestApplyUnset(t *testing.T) {
+	policy := Policy{
+		{Path: "description", Action: Mask, Roles: []string{auth.RoleAdmin}},
+		{Path: "tenantInfo/tenantId", Action: Hide, Roles: []string{auth.RoleAdmin}},
+	}
+	it := &pb.Item{ItemName: proto.String("disk")}
+	if got := policy.Apply(it, nil); len(got) != 0 {
+		t.Errorf("got redactions %v of an item without the properties", got)
+	}
+	if it.TenantInfo != nil {
+		t.Error("Apply set the parent of an unset property")
+	}
+}
+
+func TestWithheld(t *testing.T) {
+	policy := Policy{
+		{Path: "description", Action: Mask, Roles: []string{auth.RoleAdmin, "auditor"}},
+		{Path: "associations", Action: Hide, Roles: []string{auth.RoleAdmin}},
+	}
+	tests := []struct {
+		id   *auth.Identity
+		want []string
+	}{
+		{&auth.Identity{Roles: []string{auth.RoleAdmin}}, nil},
+		{&auth.Identity{Roles: []string{"auditor"}}, []string{"associations"}},
+		{&auth.Identity{}, []string{"description", "associations"}},
+		{nil, []string{"description", "associations"}},
+	}
+	for _, tt := range tests {
+		if got := policy.Withheld(tt.id); !slices.Equal(got, tt.want) {
+			t.Errorf("Withheld(%+v) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+// Package search answers $search queries over items from an in-memory
+// inverted index of their itemName, itemType and description.
+//
+// Text is split into lower-cased words of letters and digits. A search word
+// matches every indexed word it is a prefix of, so that "vir" finds
+// "virtual", while the words of a phrase must match whole and in order.
+// Matching items are scored by TF-IDF, with prefix matches weighted by the
+// fraction of the indexed word they cover. Properties withheld from a caller
+// can be left out of a search, so that it neither matches nor scores their
+// words.
+package search
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// fieldGap separates the positions of words of different properties so that
+// phrases never span two properties.
+const fieldGap = 1
+
+// Fields lists the JSON names of the properties of items that are indexed,
+// in the order their words are numbered.
+var Fields = []string{"itemName", "itemType", "description"}
+
+// Index is an inverted index of the searchable properties of items, keyed by
+// extId. It is safe for concurrent use.
+type Index struct {
+	mu sync.RWMutex
+	// postings maps each indexed word to the positions it occurs at in each
+	// item.
+	postings map[string]map[string][]int
+	// docs maps each indexed item to the words it contains.
+	docs map[string]doc
+	// words holds the keys of postings in order, for prefix lookups.
+	words []string
+}
+
+// doc is what the index holds of an item.
+type doc struct {
+	words []string
+	// ends holds, for each of Fields, the position following its last
+	// word.
+	ends []int
+}
+
+// NewIndex returns an empty Index.
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[string][]int),
+		docs:     make(map[string]doc),
+	}
+}
+
+// Tokenize splits s into lower-cased words of letters and digits.
+func Tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Put indexes item, replacing what was indexed for its extId.
+func (x *Index) Put(item *pb.Item) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(item.GetExtId())
+	var d doc
+	pos := 0
+	for _, text := range []string{item.GetItemName(), item.GetItemType(), item.GetDescription()} {
+		for _, w := range Tokenize(text) {
+			docs, ok := x.postings[w]
+			if !ok {
+				docs = make(map[string][]int)
+				x.postings[w] = docs
+				i := sort.SearchStrings(x.words, w)
+				x.words = slices.Insert(x.words, i, w)
+			}
+			if docs[item.GetExtId()] == nil {
+				d.words = append(d.words, w)
+			}
+			docs[item.GetExtId()] = append(docs[item.GetExtId()], pos)
+			pos++
+		}
+		d.ends = append(d.ends, pos)
+		pos += fieldGap
+	}
+	x.docs[item.GetExtId()] = d
+}
+
+// Delete removes the item with the given extId from the index.
+func (x *Index) Delete(extId string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(extId)
+}
+
+// remove drops extId from the postings. The caller must hold x.mu.
+func (x *Index) remove(extId string) {
+	for _, w := range x.docs[extId].words {
+		delete(x.postings[w], extId)
+		if len(x.postings[w]) == 0 {
+			delete(x.postings, w)
+			i := sort.SearchStrings(x.words, w)
+			x.words = slices.Delete(x.words, i, i+1)
+		}
+	}
+	delete(x.docs, extId)
+}
+
+// Search returns the relevance score of every indexed item matching e,
+// keyed by extId. Items matched only through NOT score 0. The words of the
+// properties named by hidden, among Fields, are ignored as if they were not
+// indexed.
+func (x *Index) Search(e odata.SearchExpr, hidden ...string) map[string]float64 {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return view{x: x, hidden: hidden}.search(e)
+}
+
+// view is the index as seen by a search ignoring the words of the hidden
+// properties. The caller must hold x.mu.
+type view struct {
+	x      *Index
+	hidden []string
+}
+
+func (v view) search(e odata.SearchExpr) map[string]float64 {
+	switch e := e.(type) {
+	case *odata.SearchTerm:
+		words := Tokenize(e.Text)
+		if len(words) == 1 && !e.Phrase {
+			return v.prefix(words[0])
+		}
+		return v.phrase(words)
+	case *odata.SearchBinary:
+		left, right := v.search(e.Left), v.search(e.Right)
+		if e.Op == "OR" {
+			for id, score := range right {
+				left[id] += score
+			}
+			return left
+		}
+		for id, score := range left {
+			if r, ok := right[id]; ok {
+				left[id] = score + r
+			} else {
+				delete(left, id)
+			}
+		}
+		return left
+	case *odata.SearchNot:
+		excluded := v.search(e.X)
+		scores := make(map[string]float64)
+		for id := range v.x.docs {
+			if _, ok := excluded[id]; !ok {
+				scores[id] = 0
+			}
+		}
+		return scores
+	}
+	return map[string]float64{}
+}
+
+// prefix scores the items containing a word starting with p.
+func (v view) prefix(p string) map[string]float64 {
+	scores := make(map[string]float64)
+	x := v.x
+	for i := sort.SearchStrings(x.words, p); i < len(x.words) && strings.HasPrefix(x.words[i], p); i++ {
+		w := x.words[i]
+		weight := v.idf(w) * float64(len(p)) / float64(len(w))
+		for id := range x.postings[w] {
+			if n := len(v.positions(w, id)); n > 0 {
+				scores[id] += float64(n) * weight
+			}
+		}
+	}
+	return scores
+}
+
+// phrase scores the items containing words in sequence. An empty phrase
+// matches nothing.
+func (v view) phrase(words []string) map[string]float64 {
+	scores := make(map[string]float64)
+	if len(words) == 0 {
+		return scores
+	}
+	x := v.x
+	for id := range x.postings[words[0]] {
+		n := 0
+		// Phrases never span two properties, so only where they start
+		// tells whether they are visible.
+	next:
+		for _, start := range v.positions(words[0], id) {
+			for i, w := range words[1:] {
+				if !contains(x.postings[w][id], start+i+1) {
+					continue next
+				}
+			}
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		for _, w := range words {
+			scores[id] += float64(n) * v.idf(w)
+		}
+	}
+	return scores
+}
+
+// idf is the inverse document frequency of w among the visible words.
+func (v view) idf(w string) float64 {
+	n := len(v.x.postings[w])
+	if len(v.hidden) > 0 {
+		n = 0
+		for id := range v.x.postings[w] {
+			if len(v.positions(w, id)) > 0 {
+				n++
+			}
+		}
+	}
+	return math.Log(1 + float64(len(v.x.docs))/float64(max(n, 1)))
+}
+
+// positions returns the positions of w in the item with the given extId
+// that belong to properties which are not hidden.
+func (v view) positions(w, extId string) []int {
+	all := v.x.postings[w][extId]
+	if len(v.hidden) == 0 {
+		return all
+	}
+	var visible []int
+	for _, pos := range all {
+		if f := v.x.docs[extId].field(pos); f >= 0 && !slices.Contains(v.hidden, Fields[f]) {
+			visible = append(visible, pos)
+		}
+	}
+	return visible
+}
+
+// field returns the index in Fields of the property holding the word at
+// pos, or -1 if there is none.
+func (d doc) field(pos int) int {
+	start := 0
+	for i, end := range d.ends {
+		if pos >= start && pos < end {
+			return i
+		}
+		start = end + fieldGap
+	}
+	return -1
+}
+
+func contains(sorted []int, v int) bool {
+	i := sort.SearchInts(sorted, v)
+	return i < len(sorted) && sorted[i] == v
+}
+
+// Follow indexes the items of every tenant in s and keeps x up to date
+// with them until the returned function is called. Changes reach the index
+// asynchronously, shortly after they are applied to s. An error is returned
+// if the items cannot be listed; should relisting them fail later on, it is
+// retried every relistInterval.
+func (x *Index) Follow(s *store.ItemStore) (stop func(), err error) {
+	scope := tenant.Scope{AllTenants: true}
+	events, cancel := s.Watch(scope)
+	items, err := s.List(scope, store.Query{})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	x.reset(items)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case e, ok := <-events:
+				if !ok {
+					// The watch was dropped for falling behind; list again.
+					events, cancel = s.Watch(scope)
+					items, err := s.List(scope, store.Query{})
+					if err != nil {
+						cancel()
+						events = closedEvents
+						select {
+						case <-time.After(relistInterval):
+						case <-done:
+							return
+						}
+						continue
+					}
+					x.reset(items)
+					continue
+				}
+				if e.Item != nil {
+					x.apply(e)
+				}
+			case <-done:
+				cancel()
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }, nil
+}
+
+// relistInterval is how long Follow waits before relisting items after a
+// failure.
+const relistInterval = time.Second
+
+// closedEvents is a closed channel of events, from which Follow receives to
+// relist items.
+var closedEvents = func() chan store.Event {
+	c := make(chan store.Event)
+	close(c)
+	return c
+}()
+
+func (x *Index) reset(items []*pb.Item) {
+	x.mu.Lock()
+	x.postings = make(map[string]map[string][]int)
+	x.docs = make(map[string]doc)
+	x.words = nil
+	x.mu.Unlock()
+	for _, it := range items {
+		x.Put(it)
+	}
+}
+
+func (x *Index) apply(e store.Event) {
+	if e.Item == nil {
+		return
+	}
+	// Items restored from snapshots may be put already deleted.
+	if e.Type == store.Deleted || e.Item.DeletedAt != nil {
+		x.Delete(e.Item.GetExtId())
+		return
+	}
+	x.Put(e.Item)
+}
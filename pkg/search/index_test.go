@@ -0,0 +1,240 @@
+package search
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// item returns an item with the given extId and searchable properties.
+func item(extId, name, itemType, description string) *pb.Item {
+	return &pb.Item{
+		ExtId:       proto.String(extId),
+		ItemName:    proto.String(name),
+		ItemType:    proto.String(itemType),
+		Description: proto.String(description),
+	}
+}
+
+// testIndex returns an index of a few items keyed by the extIds 1 to 4.
+func testIndex() *Index {
+	x := NewIndex()
+	x.Put(item("1", "Virtual disk", "disk", "The boot disk of the web VM"))
+	x.Put(item("2", "web-vm", "vm", "Serves the web site"))
+	x.Put(item("3", "Host 1", "host", "Runs virtual machines"))
+	x.Put(item("4", "backup", "disk", "Virtual tape library disk disk disk"))
+	return x
+}
+
+// search returns the extIds of the items of x matching s, by decreasing
+// score and then extId.
+func search(t *testing.T, x *Index, s string, hidden ...string) []string {
+	t.Helper()
+	e, err := odata.ParseSearch(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scores := x.Search(e, hidden...)
+	ids := slices.Sorted(maps.Keys(scores))
+	sort.SliceStable(ids, func(i, j int) bool { return scores[ids[i]] > scores[ids[j]] })
+	return ids
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"Virtual Disk", []string{"virtual", "disk"}},
+		{"web-vm_01, (backup)", []string{"web", "vm", "01", "backup"}},
+		{"Çafé  naïve", []string{"çafé", "naïve"}},
+	}
+	for _, tt := range tests {
+		if got := Tokenize(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("Tokenize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	x := testIndex()
+	tests := []struct {
+		search string
+		want   []string
+	}{
+		{"web", []string{"2", "1"}},
+		{"WEB", []string{"2", "1"}},
+		{"vir", []string{"1", "3", "4"}},
+		{"disk", []string{"4", "1"}},
+		{"nothing", nil},
+		{"virtual disk", []string{"4", "1"}},
+		// host is rarer than vm, so matches of host weigh more.
+		{"vm OR host", []string{"3", "2", "1"}},
+		{"disk NOT boot", []string{"4"}},
+		{"NOT disk", []string{"2", "3"}},
+		{`"virtual disk"`, []string{"1"}},
+		{`"disk virtual"`, nil},
+		{`"web vm"`, []string{"1", "2"}},
+		{`"virt disk"`, nil},
+		{`"web-vm"`, []string{"1", "2"}},
+		{`""`, nil},
+		// Phrases do not span properties: item 2 is named web-vm of the
+		// type vm, and item 3 is the host 1 of the type host.
+		{`"vm vm"`, nil},
+		{`"1 host"`, nil},
+		{"(vm OR host) machines", []string{"3"}},
+	}
+	for _, tt := range tests {
+		if got := search(t, x, tt.search); !slices.Equal(got, tt.want) {
+			t.Errorf("$search=%s: got %v, want %v", tt.search, got, tt.want)
+		}
+	}
+}
+
+func TestSearchHidden(t *testing.T) {
+	x := testIndex()
+	tests := []struct {
+		search string
+		hidden []string
+		want   []string
+	}{
+		{"web", []string{"description"}, []string{"2"}},
+		{"vir", []string{"description"}, []string{"1"}},
+		{"tape", []string{"description"}, nil},
+		{"NOT tape", []string{"description"}, []string{"1", "2", "3", "4"}},
+		{`"web vm"`, []string{"description"}, []string{"2"}},
+		{"disk", []string{"itemType", "description"}, []string{"1"}},
+		{"disk", []string{"itemName", "itemType", "description"}, nil},
+		{"backup", []string{"associations"}, []string{"4"}},
+	}
+	for _, tt := range tests {
+		if got := search(t, x, tt.search, tt.hidden...); !slices.Equal(got, tt.want) {
+			t.Errorf("$search=%s without %v: got %v, want %v", tt.search, tt.hidden, got, tt.want)
+		}
+	}
+}
+
+func TestIndexUpdates(t *testing.T) {
+	x := testIndex()
+	x.Put(item("1", "Scratch", "volume", ""))
+	if got, want := search(t, x, "virtual"), []string{"3", "4"}; !slices.Equal(got, want) {
+		t.Errorf("after replacing item 1: got %v, want %v", got, want)
+	}
+	if got, want := search(t, x, "scratch"), []string{"1"}; !slices.Equal(got, want) {
+		t.Errorf("after replacing item 1: got %v, want %v", got, want)
+	}
+	x.Delete("4")
+	x.Delete("5")
+	if got, want := search(t, x, "virtual OR tape"), []string{"3"}; !slices.Equal(got, want) {
+		t.Errorf("after deleting item 4: got %v, want %v", got, want)
+	}
+	if got, want := search(t, x, "NOT scratch"), []string{"2", "3"}; !slices.Equal(got, want) {
+		t.Errorf("after deleting item 4: got %v, want %v", got, want)
+	}
+	// Words no longer indexed are no longer matched by prefix.
+	if got := search(t, x, "ta"); got != nil {
+		t.Errorf("after deleting item 4: got %v, want none", got)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	s := store.NewItemStore()
+	defer s.Close()
+	a := tenant.Scope{TenantId: "a"}
+	first, err := s.Create(a, &pb.Item{ItemName: proto.String("first disk")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := NewIndex()
+	stop, err := x.Follow(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+	if got, want := search(t, x, "first"), []string{first.GetExtId()}; !slices.Equal(got, want) {
+		t.Errorf("items indexed by Follow: got %v, want %v", got, want)
+	}
+	second, err := s.Create(tenant.Scope{TenantId: "b"}, &pb.Item{ItemName: proto.String("second disk")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Update(a, first.GetExtId(), func(it *pb.Item) (*pb.Item, error) {
+		it.ItemName = proto.String("renamed")
+		return it, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, func() bool { return slices.Equal(search(t, x, "disk"), []string{second.GetExtId()}) })
+	if err := s.Delete(tenant.Scope{TenantId: "b"}, second.GetExtId()); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, func() bool { return search(t, x, "second") == nil })
+	stop()
+	stop()
+}
+
+func TestFollowDeleted(t *testing.T) {
+	s := store.NewItemStore()
+	defer s.Close()
+	a := tenant.Scope{TenantId: "a"}
+	deleted, err := s.Create(a, &pb.Item{ItemName: proto.String("deleted disk")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(a, deleted.GetExtId()); err != nil {
+		t.Fatal(err)
+	}
+	x := NewIndex()
+	stop, err := x.Follow(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+	if got := search(t, x, "disk"); got != nil {
+		t.Errorf("got %v, want deleted items left out", got)
+	}
+	if _, err := s.Restore(a, deleted.GetExtId()); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, func() bool { return slices.Equal(search(t, x, "disk"), []string{deleted.GetExtId()}) })
+
+	// Items put already deleted, as snapshots restore them, are left out.
+	err = s.Txn(a, func(tx *store.Txn) error {
+		it, err := tx.Get(deleted.GetExtId())
+		if err != nil {
+			return err
+		}
+		it.ItemName = proto.String("tape")
+		it.DeletedAt = timestamppb.Now()
+		_, err = tx.Put(it)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, func() bool { return search(t, x, "disk") == nil })
+	if got := search(t, x, "tape"); got != nil {
+		t.Errorf("got %v, want the item put deleted left out", got)
+	}
+}
+
+// eventually fails t unless cond holds within a second.
+func eventually(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within a second")
+		}
+	}
+}
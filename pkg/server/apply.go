@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// aggregate evaluates the $apply of q over items and returns its rows
+// sorted by the $orderby of q. Items are redacted for the caller of the
+// request in ctx before they are aggregated, so that rows never reveal
+// what the caller may not read, and the redactions are returned as
+// REDACTED messages.
+func (s *ItemService) aggregate(ctx context.Context, scope tenant.Scope, q listQuery, items []*pb.Item) ([]*pb.ItemAggregate, *commonpb.MessageArrayWrapper, error) {
+	if q.applied.References(expandAssociations) {
+		for _, it := range items {
+			assocs, err := s.store.ListAssociations(scope, it.GetExtId())
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(assocs) > 0 {
+				it.Associations = &pb.ItemAssociationArrayWrapper{Value: assocs}
+			}
+		}
+	}
+	// Only the properties $apply refers to are kept, so that redactions
+	// of other properties are not reported.
+	used := []*odata.Path{}
+	for _, p := range ItemEntityType.Properties {
+		if q.applied.References(p.Name) {
+			used = append(used, &odata.Path{Segments: []string{p.Name}})
+		}
+	}
+	reflected := make([]protoreflect.Message, len(items))
+	for i, it := range items {
+		reflected[i] = it.ProtoReflect()
+		odata.Select(reflected[i], used)
+	}
+	msgs := s.redactItems(ctx, items...)
+	rows, err := q.applied.Evaluate(reflected)
+	if err != nil {
+		return nil, nil, odataError("$apply", err)
+	}
+	cols := q.applied.Columns()
+	if err := odata.SortRows(rows, cols, q.order); err != nil {
+		return nil, nil, odataError("$orderby", err)
+	}
+	out := make([]*pb.ItemAggregate, len(rows))
+	for i, row := range rows {
+		values := make([]*commonpb.KVPair, len(cols))
+		for j, col := range cols {
+			values[j] = kvPair(col, row[j])
+		}
+		out[i] = &pb.ItemAggregate{Values: &commonpb.KVPairArrayWrapper{Value: values}}
+	}
+	return out, msgs, nil
+}
+
+// kvPair returns a KVPair holding v. KVPair has no floating point variant,
+// so averages, and integers beyond the range of its integer variant, are
+// held as strings. Null values leave the value of the pair unset.
+func kvPair(name string, v interface{}) *commonpb.KVPair {
+	kv := &commonpb.KVPair{Name: proto.String(name)}
+	switch v := v.(type) {
+	case string:
+		kv.Value = &commonpb.KVPair_StringValue{StringValue: &commonpb.StringWrapper{Value: proto.String(v)}}
+	case bool:
+		kv.Value = &commonpb.KVPair_BooleanValue{BooleanValue: &commonpb.BooleanWrapper{Value: proto.Bool(v)}}
+	case int64:
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			kv.Value = &commonpb.KVPair_StringValue{StringValue: &commonpb.StringWrapper{Value: proto.String(strconv.FormatInt(v, 10))}}
+			break
+		}
+		kv.Value = &commonpb.KVPair_IntegerValue{IntegerValue: &commonpb.IntegerWrapper{Value: proto.Int32(int32(v))}}
+	case float64:
+		kv.Value = &commonpb.KVPair_StringValue{StringValue: &commonpb.StringWrapper{Value: proto.String(strconv.FormatFloat(v, 'g', -1, 64))}}
+	}
+	return kv
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/auth"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// rows formats the values of aggregates as name=value pairs, one string
+// per row.
+func rows(aggregates []*pb.ItemAggregate) []string {
+	var out []string
+	for _, agg := range aggregates {
+		var values []string
+		for _, kv := range agg.GetValues().GetValue() {
+			var v interface{}
+			switch {
+			case kv.GetStringValue() != nil:
+				v = kv.GetStringValue().GetValue()
+			case kv.GetIntegerValue() != nil:
+				v = kv.GetIntegerValue().GetValue()
+			case kv.GetBooleanValue() != nil:
+				v = kv.GetBooleanValue().GetValue()
+			}
+			values = append(values, fmt.Sprintf("%s=%v", kv.GetName(), v))
+		}
+		out = append(out, strings.Join(values, " "))
+	}
+	return out
+}
+
+func TestListItemsApply(t *testing.T) {
+	s, st := newTestService(t)
+	s.Redaction = ItemRedaction
+	scope := tenant.Scope{TenantId: testTenant}
+	items := createItems(t, st, "disk-1", "disk-2", "disk-3")
+	if _, err := st.Create(scope, &pb.Item{ItemName: proto.String("vm-1"), ItemType: proto.String("vm")}); err != nil {
+		t.Fatal(err)
+	}
+	for i, entityType := range []string{"vm", "vm", "host"} {
+		if _, err := st.PutAssociation(scope, &pb.ItemAssociation{
+			ItemId:     items[i%2].ExtId,
+			EntityType: proto.String(entityType),
+			EntityId:   proto.String(fmt.Sprint(entityType, i)),
+			Count:      proto.Int32(int32(i + 1)),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name     string
+		id       *auth.Identity
+		arg      *pb.ListItemsArg
+		want     []string
+		total    int32
+		messages int
+	}{
+		{
+			name: "groupby",
+			id:   admin,
+			arg:  &pb.ListItemsArg{XApply: proto.String("groupby((itemType),aggregate($count as total))")},
+			want: []string{"itemType=disk total=3", "itemType=vm total=1"},
+		},
+		{
+			name: "ordered",
+			id:   admin,
+			arg:  &pb.ListItemsArg{XApply: proto.String("groupby((itemType),aggregate($count as total))"), XOrderby: proto.String("total,itemType desc")},
+			want: []string{"itemType=vm total=1", "itemType=disk total=3"},
+		},
+		{
+			name:  "paged and counted",
+			id:    admin,
+			arg:   &pb.ListItemsArg{XApply: proto.String("groupby((itemName))"), XOrderby: proto.String("itemName desc"), XLimit: proto.Int32(2), XPage: proto.Int32(1), XCount: proto.Bool(true)},
+			want:  []string{"itemName=disk-2", "itemName=disk-1"},
+			total: 4,
+		},
+		{
+			name: "filtered",
+			id:   admin,
+			arg:  &pb.ListItemsArg{XFilter: proto.String("itemName ne 'disk-1'"), XApply: proto.String("filter(itemType eq 'disk')/aggregate($count as n)")},
+			want: []string{"n=2"},
+		},
+		{
+			name: "associations",
+			id:   admin,
+			arg:  &pb.ListItemsArg{XApply: proto.String("groupby((associations/entityType),aggregate(associations/count with sum as total,associations/count with average as mean))")},
+			want: []string{"associations/entityType=vm total=3 mean=1.5", "associations/entityType=host total=3 mean=3"},
+		},
+		{
+			name: "of no items",
+			id:   admin,
+			arg:  &pb.ListItemsArg{XApply: proto.String("filter(itemType eq 'host')/aggregate($count as n,itemId with max as last)")},
+			want: []string{"n=0 last=<nil>"},
+		},
+		// Viewers may not read associations, so none are aggregated.
+		{
+			name:     "withheld from the caller",
+			id:       viewer,
+			arg:      &pb.ListItemsArg{XApply: proto.String("groupby((associations/entityType),aggregate($count as n))")},
+			messages: 1,
+		},
+		{
+			name: "not withheld from the caller",
+			id:   viewer,
+			arg:  &pb.ListItemsArg{XApply: proto.String("aggregate(itemType with countdistinct as types)")},
+			want: []string{"types=2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ret, err := s.ListItems(requestContext(tt.id), tt.arg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rows(ret.GetContent().GetItemAggregateArrayData().GetValue()); !slices.Equal(got, tt.want) {
+				t.Errorf("got rows %q, want %q", got, tt.want)
+			}
+			md := ret.GetContent().GetMetadata()
+			if md.GetTotalAvailableResults() != tt.total {
+				t.Errorf("got a total of %d, want %d", md.GetTotalAvailableResults(), tt.total)
+			}
+			if n := len(md.GetMessages().GetValue()); n != tt.messages {
+				t.Errorf("got %d messages, want %d", n, tt.messages)
+			}
+		})
+	}
+	// Aggregating leaves the items in the store as they were.
+	if assocs, err := st.ListAssociations(scope, items[0].GetExtId()); err != nil || len(assocs) != 2 {
+		t.Errorf("ListAssociations = %v, %v, want the 2 associations of disk-1", assocs, err)
+	}
+}
+
+func TestListItemsApplyInvalid(t *testing.T) {
+	s, _ := newTestService(t)
+	for _, arg := range []*pb.ListItemsArg{
+		{XApply: proto.String("groupby((itemType)")},
+		{XApply: proto.String("groupby((description))")},
+		{XApply: proto.String("groupby((itemType))"), XSelect: proto.String("itemType")},
+		{XApply: proto.String("groupby((itemType))"), XExpand: proto.String(expandAssociations)},
+		{XApply: proto.String("groupby((itemType))"), XOrderby: proto.String("itemName")},
+		{XApply: proto.String("groupby((itemType))"), XSkiptoken: proto.String("token")},
+	} {
+		if _, err := s.ListItems(requestContext(admin), arg); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v: got %v, want InvalidArgument", arg, err)
+		}
+	}
+}
+
+func TestKVPair(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		want *commonpb.KVPair
+	}{
+		{"disk", &commonpb.KVPair{Name: proto.String("c"), Value: &commonpb.KVPair_StringValue{StringValue: &commonpb.StringWrapper{Value: proto.String("disk")}}}},
+		{true, &commonpb.KVPair{Name: proto.String("c"), Value: &commonpb.KVPair_BooleanValue{BooleanValue: &commonpb.BooleanWrapper{Value: proto.Bool(true)}}}},
+		{int64(-3), &commonpb.KVPair{Name: proto.String("c"), Value: &commonpb.KVPair_IntegerValue{IntegerValue: &commonpb.IntegerWrapper{Value: proto.Int32(-3)}}}},
+		{int64(math.MaxInt32) + 1, &commonpb.KVPair{Name: proto.String("c"), Value: &commonpb.KVPair_StringValue{StringValue: &commonpb.StringWrapper{Value: proto.String("2147483648")}}}},
+		{2.5, &commonpb.KVPair{Name: proto.String("c"), Value: &commonpb.KVPair_StringValue{StringValue: &commonpb.StringWrapper{Value: proto.String("2.5")}}}},
+		{nil, &commonpb.KVPair{Name: proto.String("c")}},
+	}
+	for _, tt := range tests {
+		if got := kvPair("c", tt.v); !proto.Equal(got, tt.want) {
+			t.Errorf("kvPair(%#v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+func TestListItemsAsOf(t *testing.T) {
+	s, st := newTestService(t)
+	items := createItems(t, st, "disk-1", "disk-2")
+	if _, err := st.Create(tenant.Scope{TenantId: "tenant-2"}, &pb.Item{ItemName: proto.String("other")}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	then := time.Now().UTC().Format(time.RFC3339Nano)
+	time.Sleep(time.Millisecond)
+	ctx := requestContext(admin)
+	_, err := s.PatchItem(ctx, &pb.PatchItemArg{
+		ExtId:       items[0].ExtId,
+		ContentType: proto.String("application/merge-patch+json"),
+		Body:        []byte(`{"itemName": "renamed"}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DeleteItem(ctx, &pb.DeleteItemArg{ExtId: items[1].ExtId}); err != nil {
+		t.Fatal(err)
+	}
+	created := createItems(t, st, "disk-3")[0]
+
+	tests := []struct {
+		name string
+		arg  *pb.ListItemsArg
+		want []string
+	}{
+		{"now", &pb.ListItemsArg{}, []string{"disk-3", "renamed"}},
+		{"then", &pb.ListItemsArg{AsOf: proto.String(then)}, []string{"disk-1", "disk-2"}},
+		{"then filtered", &pb.ListItemsArg{AsOf: proto.String(then), XFilter: proto.String("itemName eq 'disk-2'")}, []string{"disk-2"}},
+		{"then ordered", &pb.ListItemsArg{AsOf: proto.String(then), XOrderby: proto.String("itemName desc")}, []string{"disk-2", "disk-1"}},
+		{"before anything", &pb.ListItemsArg{AsOf: proto.String("2024-05-01T12:00:00Z")}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.arg.XOrderby == nil {
+				tt.arg.XOrderby = proto.String("itemName")
+			}
+			if got, _ := listNames(t, s, admin, tt.arg); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The pages of a listing as of a time link to each other as of that
+	// time.
+	arg := &pb.ListItemsArg{AsOf: proto.String(then), XOrderby: proto.String("itemName"), XLimit: proto.Int32(1)}
+	got, l := listNames(t, s, admin, arg)
+	next, err := url.Parse(l["next"])
+	if err != nil || next.Query().Get("asOf") != then {
+		t.Fatalf("got next link %s, want one as of %s", l["next"], then)
+	}
+	if !slices.Equal(got, []string{"disk-1"}) {
+		t.Errorf("got first page %v, want [disk-1]", got)
+	}
+	arg.XSkiptoken = proto.String(next.Query().Get("$skiptoken"))
+	if got, _ := listNames(t, s, admin, arg); !slices.Equal(got, []string{"disk-2"}) {
+		t.Errorf("got second page %v, want [disk-2]", got)
+	}
+
+	get := func(extId *string, asOf string) (*pb.Item, error) {
+		arg := &pb.GetItemArg{ExtId: extId, XExpand: proto.String("associations")}
+		if asOf != "" {
+			arg.AsOf = proto.String(asOf)
+		}
+		ret, err := s.GetItem(ctx, arg)
+		return ret.GetContent().GetItemData(), err
+	}
+	if it, err := get(items[1].ExtId, then); err != nil || it.GetItemName() != "disk-2" || it.DeletedAt != nil {
+		t.Errorf("getting the deleted item as of before its deletion: got %v, %v", it, err)
+	}
+	if it, err := get(items[0].ExtId, then); err != nil || it.GetItemName() != "disk-1" {
+		t.Errorf("getting the renamed item as of before its renaming: got %v, %v", it, err)
+	}
+	if _, err := get(items[1].ExtId, ""); status.Code(err) != codes.NotFound {
+		t.Errorf("getting the deleted item now: got %v, want NotFound", err)
+	}
+	if _, err := get(created.ExtId, then); status.Code(err) != codes.NotFound {
+		t.Errorf("getting an item as of before its creation: got %v, want NotFound", err)
+	}
+
+	st.SetHistoryRetention(time.Minute)
+	hourAgo := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	errs := []struct {
+		name string
+		arg  *pb.ListItemsArg
+		want string
+	}{
+		{"not a time", &pb.ListItemsArg{AsOf: proto.String("yesterday")}, "must be an RFC 3339 date-time"},
+		{"search", &pb.ListItemsArg{AsOf: proto.String(then), XSearch: proto.String("disk")}, "cannot be combined with $search"},
+		{"beyond the retention", &pb.ListItemsArg{AsOf: proto.String(hourAgo)}, "the history of items is only kept for 1m0s"},
+	}
+	for _, tt := range errs {
+		_, err := s.ListItems(ctx, tt.arg)
+		if st := status.Convert(err); st.Code() != codes.InvalidArgument || !strings.Contains(st.Message(), tt.want) {
+			t.Errorf("%s: got %v, want InvalidArgument: %s", tt.name, err, tt.want)
+		}
+	}
+	if _, err := get(items[0].ExtId, hourAgo); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("getting an item beyond the retention: got %v, want InvalidArgument", err)
+	}
+}
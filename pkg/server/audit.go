@@ -0,0 +1,141 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/mappers"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// AuditPath is the REST path of the audit stream of the config module,
+// which exports the change history of items.
+const AuditPath = ServiceRootPath + "/$audit"
+
+// AuditMethod stands for reads of the audit stream in ItemServicePolicy. It
+// is not an RPC of ItemService.
+const AuditMethod = "/nexus.v4.config.ItemService/$audit"
+
+// auditContentType is the media type of the audit stream: JSON Lines, one
+// change per line.
+const auditContentType = "application/x-ndjson"
+
+// Reads of the audit stream: changes are read from the store in batches of
+// auditBatch, and checked for every auditPollInterval when followed.
+const (
+	auditBatch        = 1000
+	auditPollInterval = time.Second
+)
+
+// AuditHandler serves the audit stream, for mounting at AuditPath behind
+// auth.Guard.Middleware. It writes the changes made to the items visible to
+// the tenant resolver resolves for the request, and to their associations,
+// as JSON Lines of ItemChange, oldest first. The query parameter after
+// resumes the stream after the change with that sequence, $filter selects
+// changes as it does for listItemHistory, and follow=true keeps the stream
+// open to write changes as they are made, until the client goes away.
+func AuditHandler(s *store.ItemStore, resolver *tenant.Resolver) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		scope, err := resolver.Resolve(r.Context())
+		if err != nil {
+			st, _ := status.FromError(err)
+			http.Error(w, st.Message(), httpStatusOf(st.Code()))
+			return
+		}
+		params := r.URL.Query()
+		q := store.HistoryQuery{Limit: auditBatch}
+		if v := params.Get("after"); v != "" {
+			if q.After, err = strconv.ParseInt(v, 10, 64); err != nil || q.After < 0 {
+				http.Error(w, "after: must be the sequence of a change", http.StatusBadRequest)
+				return
+			}
+		}
+		if q.Filter, err = parseChangeFilter(params.Get("$filter")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var follow bool
+		if v := params.Get("follow"); v != "" {
+			if follow, err = strconv.ParseBool(v); err != nil {
+				http.Error(w, "follow: must be true or false", http.StatusBadRequest)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", auditContentType)
+		enc := json.NewEncoder(w)
+		flusher, _ := w.(http.Flusher)
+		started := false
+		for {
+			changes, err := s.History(scope, q)
+			if err != nil {
+				// Past the headers the stream can only be cut short.
+				if !started {
+					writeAuditError(w, err)
+				}
+				return
+			}
+			started = true
+			for _, c := range changes {
+				if err := enc.Encode(mappers.ItemChangeToDto(c)); err != nil {
+					return
+				}
+				q.After = c.GetSequence()
+			}
+			if len(changes) == auditBatch {
+				continue
+			}
+			if !follow {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(auditPollInterval):
+			}
+		}
+	})
+}
+
+// writeAuditError reports err, raised reading the history of items before
+// anything was written, as the response.
+func writeAuditError(w http.ResponseWriter, err error) {
+	w.Header().Del("Content-Type")
+	var qerr *store.QueryError
+	if errors.As(err, &qerr) {
+		http.Error(w, storeError(err).Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// httpStatusOf returns the HTTP status of the errors of gRPC code c that
+// carry no ErrorResponse, such as those raised resolving the scope of a
+// request.
+func httpStatusOf(c codes.Code) int {
+	switch c {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
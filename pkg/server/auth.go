@@ -0,0 +1,66 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/auth"
+)
+
+// ItemServicePolicy declares the access each ItemService method requires:
+// reads are open to any authenticated caller, changes and their history
+// need an administrator.
+var ItemServicePolicy = auth.Policy{
+	pb.ItemService_ListItems_FullMethodName:   auth.ReadOnly,
+	pb.ItemService_CountItems_FullMethodName:  auth.ReadOnly,
+	pb.ItemService_GetItem_FullMethodName:     auth.ReadOnly,
+	pb.ItemService_PatchItem_FullMethodName:   auth.Admin,
+	pb.ItemService_DeleteItem_FullMethodName:  auth.Admin,
+	pb.ItemService_RestoreItem_FullMethodName: auth.Admin,
+
+	pb.ItemService_ListItemHistory_FullMethodName: auth.Admin,
+
+	MetadataMethod: auth.ReadOnly,
+	AuditMethod:    auth.Admin,
+}
+
+// ItemServiceMethod returns the full name of the ItemService method serving
+// the REST request r, for use with auth.Guard.Middleware. It returns "" for
+// requests outside the items collection and their history, except for
+// MetadataMethod for reads of the service and $metadata documents and
+// AuditMethod for reads of the audit stream.
+func ItemServiceMethod(r *http.Request) string {
+	if (r.URL.Path == ServiceRootPath || r.URL.Path == MetadataPath) && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+		return MetadataMethod
+	}
+	if r.Method == http.MethodGet {
+		switch strings.TrimSuffix(r.URL.Path, "/") {
+		case historyPath:
+			return pb.ItemService_ListItemHistory_FullMethodName
+		case AuditPath:
+			return AuditMethod
+		}
+	}
+	rest, ok := strings.CutPrefix(r.URL.Path, itemsPath)
+	if !ok {
+		return ""
+	}
+	rest = strings.Trim(rest, "/")
+	switch {
+	case rest == "" && r.Method == http.MethodGet:
+		return pb.ItemService_ListItems_FullMethodName
+	case rest == "$count" && r.Method == http.MethodGet:
+		return pb.ItemService_CountItems_FullMethodName
+	case rest != "" && !strings.Contains(rest, "/") && r.Method == http.MethodGet:
+		return pb.ItemService_GetItem_FullMethodName
+	case rest != "" && !strings.Contains(rest, "/") && r.Method == http.MethodPatch:
+		return pb.ItemService_PatchItem_FullMethodName
+	case rest != "" && !strings.Contains(rest, "/") && r.Method == http.MethodDelete:
+		return pb.ItemService_DeleteItem_FullMethodName
+	case strings.HasSuffix(rest, "/$actions/restore") && strings.Count(rest, "/") == 2 && r.Method == http.MethodPost:
+		return pb.ItemService_RestoreItem_FullMethodName
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/auth"
+)
+
+func TestItemServiceMethod(t *testing.T) {
+	const item = itemsPath + "/4c6a1b4e-0000-4000-8000-000000000001"
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		{http.MethodGet, itemsPath, pb.ItemService_ListItems_FullMethodName},
+		{http.MethodGet, itemsPath + "/", pb.ItemService_ListItems_FullMethodName},
+		{http.MethodGet, itemsPath + "/$count", pb.ItemService_CountItems_FullMethodName},
+		{http.MethodGet, item, pb.ItemService_GetItem_FullMethodName},
+		{http.MethodPatch, item, pb.ItemService_PatchItem_FullMethodName},
+		{http.MethodDelete, item, pb.ItemService_DeleteItem_FullMethodName},
+		{http.MethodPost, item + "/$actions/restore", pb.ItemService_RestoreItem_FullMethodName},
+		{http.MethodGet, historyPath, pb.ItemService_ListItemHistory_FullMethodName},
+		{http.MethodGet, AuditPath, AuditMethod},
+		{http.MethodGet, ServiceRootPath, MetadataMethod},
+		{http.MethodHead, MetadataPath, MetadataMethod},
+		{http.MethodPost, itemsPath, ""},
+		{http.MethodPut, item, ""},
+		{http.MethodGet, item + "/associations", ""},
+		{http.MethodGet, item + "/$actions/restore", ""},
+		{http.MethodPost, MetadataPath, ""},
+		{http.MethodGet, "/api/nexus/v4.1/other", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, "http://pc"+tt.path, nil)
+		if got := ItemServiceMethod(r); got != tt.want {
+			t.Errorf("%s %s: got %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+// TestItemServicePolicy checks that every RPC of ItemService is reachable,
+// and that only reads are open to callers without the admin role.
+func TestItemServicePolicy(t *testing.T) {
+	reads := map[string]bool{
+		pb.ItemService_ListItems_FullMethodName:  true,
+		pb.ItemService_CountItems_FullMethodName: true,
+		pb.ItemService_GetItem_FullMethodName:    true,
+		MetadataMethod:                           true,
+	}
+	var methods []string
+	for _, m := range pb.ItemService_ServiceDesc.Methods {
+		methods = append(methods, "/"+pb.ItemService_ServiceDesc.ServiceName+"/"+m.MethodName)
+	}
+	for _, m := range pb.ItemService_ServiceDesc.Streams {
+		methods = append(methods, "/"+pb.ItemService_ServiceDesc.ServiceName+"/"+m.StreamName)
+	}
+	for _, method := range append(methods, MetadataMethod, AuditMethod) {
+		want := auth.Admin
+		if reads[method] {
+			want = auth.ReadOnly
+		}
+		if got := ItemServicePolicy[method]; got != want {
+			t.Errorf("%s requires %v access, want %v", method, got, want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+func TestCount(t *testing.T) {
+	s, st := newTestService(t)
+	createItems(t, st, "disk-1", "disk-2", "disk-3", "vm-1", "vm-2")
+	if _, err := st.Create(tenant.Scope{TenantId: "tenant-2"}, &pb.Item{ItemName: proto.String("disk-4")}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := requestContext(admin)
+	tests := []struct {
+		name string
+		arg  *pb.ListItemsArg
+		// total is the count expected, -1 if none is.
+		total int32
+		page  int
+	}{
+		{"no count", &pb.ListItemsArg{XLimit: proto.Int32(2)}, -1, 2},
+		{"count", &pb.ListItemsArg{XCount: proto.Bool(true)}, 5, 5},
+		{"count of a page", &pb.ListItemsArg{XCount: proto.Bool(true), XLimit: proto.Int32(2), XPage: proto.Int32(1)}, 5, 2},
+		{"count past the last page", &pb.ListItemsArg{XCount: proto.Bool(true), XLimit: proto.Int32(2), XPage: proto.Int32(5)}, 5, 0},
+		{"filtered count", &pb.ListItemsArg{XCount: proto.Bool(true), XFilter: proto.String("startswith(itemName, 'disk')"), XLimit: proto.Int32(1)}, 3, 1},
+		{"sorted count", &pb.ListItemsArg{XCount: proto.Bool(true), XOrderby: proto.String("itemName desc"), XLimit: proto.Int32(1)}, 5, 1},
+		{"count of nothing", &pb.ListItemsArg{XCount: proto.Bool(true), XFilter: proto.String("itemName eq 'none'")}, 0, 0},
+		{"count false", &pb.ListItemsArg{XCount: proto.Bool(false)}, -1, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ret, err := s.ListItems(ctx, tt.arg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			md := ret.GetContent().GetMetadata()
+			switch {
+			case tt.total < 0 && md.TotalAvailableResults != nil:
+				t.Errorf("got a total of %d, want none", md.GetTotalAvailableResults())
+			case tt.total >= 0 && (md.TotalAvailableResults == nil || md.GetTotalAvailableResults() != tt.total):
+				t.Errorf("got a total of %v, want %d", md.TotalAvailableResults, tt.total)
+			}
+			if n := len(ret.GetContent().GetItemArrayData().GetValue()); n != tt.page {
+				t.Errorf("got %d items, want %d", n, tt.page)
+			}
+		})
+	}
+}
+
+func TestCountItems(t *testing.T) {
+	s, st := newTestService(t)
+	createItems(t, st, "disk-1", "disk-2", "vm-1")
+	if _, err := st.Create(tenant.Scope{TenantId: "tenant-2"}, &pb.Item{ItemName: proto.String("disk-3")}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := requestContext(admin)
+	tests := []struct {
+		filter string
+		want   int64
+	}{
+		{"", 3},
+		{"startswith(itemName, 'disk')", 2},
+		{"associations/any()", 0},
+		{"itemName eq 'disk-3'", 0},
+	}
+	for _, tt := range tests {
+		arg := &pb.CountItemsArg{}
+		if tt.filter != "" {
+			arg.XFilter = proto.String(tt.filter)
+		}
+		ret, err := s.CountItems(ctx, arg)
+		if err != nil || ret.Content == nil || ret.GetContent() != tt.want {
+			t.Errorf("CountItems($filter=%s) = %v, %v, want %d", tt.filter, ret.GetContent(), err, tt.want)
+		}
+	}
+	for _, filter := range []string{"itemName eq", "description eq 'a'", "noSuchProperty eq 1"} {
+		if _, err := s.CountItems(ctx, &pb.CountItemsArg{XFilter: proto.String(filter)}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CountItems($filter=%s): got %v, want InvalidArgument", filter, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"slices"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+func TestDeleteItemPolicy(t *testing.T) {
+	s, st := newTestService(t)
+	items := createItems(t, st, "disk-1", "disk-2")
+	if _, err := st.PutAssociation(tenant.Scope{TenantId: testTenant}, &pb.ItemAssociation{
+		ItemId:     items[0].ExtId,
+		EntityType: proto.String("vm"),
+		EntityId:   proto.String("vm-1"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	st.SetDeletePolicy(store.Restrict)
+	ctx := requestContext(admin)
+
+	_, err := s.DeleteItem(ctx, &pb.DeleteItemArg{ExtId: items[0].ExtId})
+	want := "item " + items[0].GetExtId() + " cannot be deleted while it has 1 associations (vm vm-1); delete them first"
+	if st := status.Convert(err); st.Code() != codes.FailedPrecondition || st.Message() != want {
+		t.Errorf("deleting an associated item: got %v, want FailedPrecondition: %s", err, want)
+	}
+	if _, err := s.GetItem(ctx, &pb.GetItemArg{ExtId: items[0].ExtId}); err != nil {
+		t.Errorf("getting the item after refusing to delete it: %v", err)
+	}
+	if _, err := s.DeleteItem(ctx, &pb.DeleteItemArg{ExtId: items[1].ExtId}); err != nil {
+		t.Errorf("deleting an item without associations: %v", err)
+	}
+	if _, err := s.DeleteItem(ctx, &pb.DeleteItemArg{ExtId: items[1].ExtId}); status.Code(err) != codes.NotFound {
+		t.Errorf("deleting the item again: got %v, want NotFound", err)
+	}
+}
+
+func TestDeleteAndRestoreItem(t *testing.T) {
+	s, st := newTestService(t)
+	items := createItems(t, st, "disk-1", "disk-2", "disk-3")
+	if _, err := st.PutAssociation(tenant.Scope{TenantId: testTenant}, &pb.ItemAssociation{
+		ItemId:     items[1].ExtId,
+		EntityType: proto.String("vm"),
+		EntityId:   proto.String("vm-1"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := requestContext(admin)
+	if _, err := s.DeleteItem(ctx, &pb.DeleteItemArg{ExtId: items[1].ExtId}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetItem(ctx, &pb.GetItemArg{ExtId: items[1].ExtId}); status.Code(err) != codes.NotFound {
+		t.Errorf("getting the deleted item: got %v, want NotFound", err)
+	}
+	if _, err := s.PatchItem(ctx, &pb.PatchItemArg{ExtId: items[1].ExtId, Body: []byte(`[{"op": "replace", "path": "/itemName", "value": "x"}]`)}); status.Code(err) != codes.NotFound {
+		t.Errorf("patching the deleted item: got %v, want NotFound", err)
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"", []string{"disk-1", "disk-3"}},
+		{"isDeleted", []string{"disk-2"}},
+		{"isDeleted eq false", []string{"disk-1", "disk-3"}},
+		{"isDeleted or itemName eq 'disk-3'", []string{"disk-2", "disk-3"}},
+		{"associations/any(a: a/entityType eq 'vm')", nil},
+		{"associations/any(a: a/entityType eq 'vm') and isDeleted", []string{"disk-2"}},
+	}
+	for _, tt := range tests {
+		arg := &pb.ListItemsArg{}
+		if tt.filter != "" {
+			arg.XFilter = proto.String(tt.filter)
+		}
+		if got, _ := listNames(t, s, admin, arg); !slices.Equal(got, tt.want) {
+			t.Errorf("$filter=%s: got %v, want %v", tt.filter, got, tt.want)
+		}
+		ret, err := s.CountItems(ctx, &pb.CountItemsArg{XFilter: arg.XFilter})
+		if err != nil || ret.GetContent() != int64(len(tt.want)) {
+			t.Errorf("CountItems($filter=%s) = %d, %v, want %d", tt.filter, ret.GetContent(), err, len(tt.want))
+		}
+	}
+	ret, err := s.ListItems(ctx, &pb.ListItemsArg{XFilter: proto.String("isDeleted")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted := ret.GetContent().GetItemArrayData().GetValue(); len(deleted) != 1 || deleted[0].DeletedAt == nil {
+		t.Errorf("got %v, want the deleted item with its deletedAt", deleted)
+	}
+	if _, err := s.ListItems(ctx, &pb.ListItemsArg{XOrderby: proto.String("isDeleted")}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ordering by isDeleted: got %v, want InvalidArgument", err)
+	}
+
+	restored, err := s.RestoreItem(requestContext(admin, forwardedHostKey, "pc.example.com"), &pb.RestoreItemArg{ExtId: items[1].ExtId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := restored.GetContent().GetItemData()
+	if it.GetItemName() != "disk-2" || it.DeletedAt != nil {
+		t.Errorf("got %v, want the item undeleted", it)
+	}
+	if self := links(it.GetLinks())["self"]; self != "https://pc.example.com"+itemsPath+"/"+it.GetExtId() {
+		t.Errorf("got self link %s", self)
+	}
+	if got, _ := listNames(t, s, admin, &pb.ListItemsArg{}); !slices.Equal(got, []string{"disk-1", "disk-2", "disk-3"}) {
+		t.Errorf("got %v after restoring, want every item", got)
+	}
+	got, err := s.GetItem(ctx, &pb.GetItemArg{ExtId: items[1].ExtId, XExpand: proto.String("associations")})
+	if err != nil || len(got.GetContent().GetItemData().GetAssociations().GetValue()) != 1 {
+		t.Errorf("got %v, %v, want the item restored with its association", got, err)
+	}
+	if _, err := s.RestoreItem(ctx, &pb.RestoreItemArg{ExtId: proto.String("4c6a1b4e-0000-4000-8000-000000000001")}); status.Code(err) != codes.NotFound {
+		t.Errorf("restoring a missing item: got %v, want NotFound", err)
+	}
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	errorpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/error"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/item"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+)
+
+// Namespace specific codes carried in nexus.v4.error.AppMessage.
+const (
+	errCodeInvalidArgument      = "NEXUS-40000"
+	errCodeNotFound             = "NEXUS-40400"
+	errCodeConflict             = "NEXUS-40900"
+	errCodeUnsupportedMediaType = "NEXUS-41500"
+	errCodeInternal             = "NEXUS-50000"
+)
+
+// queryParamError reports a query parameter whose value is out of range or
+// not supported.
+type queryParamError struct {
+	param   string
+	message string
+}
+
+func (e *queryParamError) Error() string {
+	return fmt.Sprintf("%s: %s", e.param, e.message)
+}
+
+// toStatus converts an error raised while serving path into a gRPC status.
+// The matching nexus.v4.error.ErrorResponse is attached as a status detail
+// so that the REST gateway can render it as the response body.
+func toStatus(err error, path string) error {
+	var verr *item.ValidationError
+	var perr *item.PatchError
+	var qerr *queryParamError
+	var rerr *store.RestrictError
+	switch {
+	case errors.As(err, &verr):
+		return withDetails(codes.InvalidArgument, err, schemaValidationError("body", verr.Violations, path))
+	case errors.As(err, &qerr):
+		violation := item.Violation{AttributePath: qerr.param, Message: qerr.message}
+		return withDetails(codes.InvalidArgument, err, schemaValidationError("query", []item.Violation{violation}, path))
+	case errors.As(err, &rerr):
+		return withDetails(codes.FailedPrecondition, err, appMessageError(errCodeConflict, err))
+	case errors.Is(err, store.ErrNotFound):
+		return withDetails(codes.NotFound, err, appMessageError(errCodeNotFound, err))
+	case errors.Is(err, store.ErrAlreadyExists):
+		return withDetails(codes.AlreadyExists, err, appMessageError(errCodeConflict, err))
+	case errors.Is(err, item.ErrUnsupportedMediaType):
+		// The body is not invalid but of a kind the method does not
+		// support; HTTPStatus renders it as 415.
+		return withDetails(codes.Unimplemented, err, appMessageError(errCodeUnsupportedMediaType, err))
+	case errors.Is(err, item.ErrTestFailed):
+		return withDetails(codes.FailedPrecondition, err, appMessageError(errCodeConflict, err))
+	case errors.As(err, &perr):
+		return withDetails(codes.InvalidArgument, err, appMessageError(errCodeInvalidArgument, err))
+	}
+	return withDetails(codes.Internal, err, appMessageError(errCodeInternal, err))
+}
+
+// HTTPStatus returns the HTTP status the REST gateway responds with for err,
+// an error returned by the ItemService. Errors carrying a
+// nexus.v4.error.ErrorResponse take the status of its schema validation
+// error, or the one its app message code is named after, such as 415 for
+// NEXUS-41500. Others are mapped by their gRPC code.
+func HTTPStatus(err error) int {
+	st := status.Convert(err)
+	for _, d := range st.Details() {
+		resp, ok := d.(*errorpb.ErrorResponse)
+		if !ok {
+			continue
+		}
+		if n := resp.GetSchemaValidationErrorError().GetValue().GetStatusCode(); n != 0 {
+			return int(n)
+		}
+		for _, m := range resp.GetAppMessageArrayError().GetValue() {
+			// App codes are NEXUS- followed by the HTTP status and two
+			// digits telling apart errors of that status.
+			code, ok := strings.CutPrefix(m.GetCode(), "NEXUS-")
+			if !ok || len(code) != 5 {
+				continue
+			}
+			if n, err := strconv.Atoi(code[:3]); err == nil && http.StatusText(n) != "" {
+				return n
+			}
+		}
+	}
+	return httpStatusOf(st.Code())
+}
+
+func withDetails(code codes.Code, err error, resp *errorpb.ErrorResponse) error {
+	st, detailErr := status.New(code, err.Error()).WithDetails(resp)
+	if detailErr != nil {
+		return status.Error(code, err.Error())
+	}
+	return st.Err()
+}
+
+func appMessageError(code string, err error) *errorpb.ErrorResponse {
+	msg := &errorpb.AppMessage{
+		Message:  proto.String(err.Error()),
+		Severity: commonpb.MessageSeverityMessage_ERROR.Enum(),
+		Code:     proto.String(code),
+		Locale:   proto.String("en_US"),
+	}
+	return &errorpb.ErrorResponse{
+		Error: &errorpb.ErrorResponse_AppMessageArrayError{
+			AppMessageArrayError: &errorpb.AppMessageArrayWrapper{Value: []*errorpb.AppMessage{msg}},
+		},
+	}
+}
+
+func schemaValidationError(location string, violations []item.Violation, path string) *errorpb.ErrorResponse {
+	msgs := make([]*errorpb.SchemaValidationErrorMessage, 0, len(violations))
+	for _, v := range violations {
+		msgs = append(msgs, &errorpb.SchemaValidationErrorMessage{
+			Location:      proto.String(location),
+			Message:       proto.String(v.Message),
+			AttributePath: proto.String(v.AttributePath),
+		})
+	}
+	return &errorpb.ErrorResponse{
+		Error: &errorpb.ErrorResponse_SchemaValidationErrorError{
+			SchemaValidationErrorError: &errorpb.SchemaValidationErrorWrapper{
+				Value: &errorpb.SchemaValidationError{
+					Timestamp:               timestamppb.Now(),
+					StatusCode:              proto.Int32(http.StatusBadRequest),
+					Error:                   proto.String(http.StatusText(http.StatusBadRequest)),
+					Path:                    proto.String(path),
+					ValidationErrorMessages: &errorpb.SchemaValidationErrorMessageArrayWrapper{Value: msgs},
+				},
+			},
+		},
+	}
+}
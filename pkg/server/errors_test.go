@@ -0,0 +1,141 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	errorpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/error"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/item"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		code     codes.Code
+		http     int
+		appCode  string // empty for schema validation errors
+		location string // of schema validation errors
+	}{
+		{
+			name:     "validation",
+			err:      &item.ValidationError{Violations: []item.Violation{{AttributePath: "itemName", Message: "is required"}}},
+			code:     codes.InvalidArgument,
+			http:     http.StatusBadRequest,
+			location: "body",
+		},
+		{
+			name:     "query parameter",
+			err:      &queryParamError{param: "$limit", message: "must be positive"},
+			code:     codes.InvalidArgument,
+			http:     http.StatusBadRequest,
+			location: "query",
+		},
+		{
+			name:    "not found",
+			err:     fmt.Errorf("getting item: %w", store.ErrNotFound),
+			code:    codes.NotFound,
+			http:    http.StatusNotFound,
+			appCode: errCodeNotFound,
+		},
+		{
+			name:    "already exists",
+			err:     fmt.Errorf("%w: %s", store.ErrAlreadyExists, "x"),
+			code:    codes.AlreadyExists,
+			http:    http.StatusConflict,
+			appCode: errCodeConflict,
+		},
+		{
+			name:    "unsupported media type",
+			err:     fmt.Errorf("%w: %q", item.ErrUnsupportedMediaType, "text/plain"),
+			code:    codes.Unimplemented,
+			http:    http.StatusUnsupportedMediaType,
+			appCode: errCodeUnsupportedMediaType,
+		},
+		{
+			name:    "failed test operation",
+			err:     &item.PatchError{Op: "test", Path: "/itemName", Reason: "value does not match", Err: item.ErrTestFailed},
+			code:    codes.FailedPrecondition,
+			http:    http.StatusConflict,
+			appCode: errCodeConflict,
+		},
+		{
+			name:    "malformed patch",
+			err:     &item.PatchError{Reason: "unexpected end of JSON input"},
+			code:    codes.InvalidArgument,
+			http:    http.StatusBadRequest,
+			appCode: errCodeInvalidArgument,
+		},
+		{
+			name:    "restricted delete",
+			err:     &store.RestrictError{ExtId: "x", Associations: []*pb.ItemAssociation{{EntityType: proto.String("vm"), EntityId: proto.String("1")}}},
+			code:    codes.FailedPrecondition,
+			http:    http.StatusConflict,
+			appCode: errCodeConflict,
+		},
+		{
+			name:    "internal",
+			err:     errors.New("disk full"),
+			code:    codes.Internal,
+			http:    http.StatusInternalServerError,
+			appCode: errCodeInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := toStatus(tt.err, itemsPath)
+			st := status.Convert(err)
+			if st.Code() != tt.code || st.Message() != tt.err.Error() {
+				t.Errorf("got %v %q, want %v %q", st.Code(), st.Message(), tt.code, tt.err.Error())
+			}
+			if got := HTTPStatus(err); got != tt.http {
+				t.Errorf("HTTPStatus = %d, want %d", got, tt.http)
+			}
+			details := st.Details()
+			if len(details) != 1 {
+				t.Fatalf("got details %v, want an ErrorResponse", details)
+			}
+			resp, ok := details[0].(*errorpb.ErrorResponse)
+			if !ok {
+				t.Fatalf("got a %T detail, want an ErrorResponse", details[0])
+			}
+			if tt.appCode == "" {
+				v := resp.GetSchemaValidationErrorError().GetValue()
+				msgs := v.GetValidationErrorMessages().GetValue()
+				if v.GetPath() != itemsPath || len(msgs) != 1 || msgs[0].GetLocation() != tt.location {
+					t.Errorf("got schema validation error %v, want one message located in the %s of %s", v, tt.location, itemsPath)
+				}
+				return
+			}
+			msgs := resp.GetAppMessageArrayError().GetValue()
+			if len(msgs) != 1 || msgs[0].GetCode() != tt.appCode || msgs[0].GetMessage() != tt.err.Error() {
+				t.Errorf("got app messages %v, want %s: %s", msgs, tt.appCode, tt.err)
+			}
+		})
+	}
+}
+
+func TestHTTPStatusWithoutDetails(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{status.Error(codes.Unauthenticated, "no credentials"), http.StatusUnauthorized},
+		{status.Error(codes.PermissionDenied, "not an admin"), http.StatusForbidden},
+		{status.Error(codes.InvalidArgument, "bad tenant"), http.StatusBadRequest},
+		{errors.New("plain"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := HTTPStatus(tt.err); got != tt.want {
+			t.Errorf("HTTPStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+	responsepb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/response"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/auth"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+)
+
+// historyPath is the REST path of the change history of items.
+const historyPath = ServiceRootPath + "/item-history"
+
+// requestIdKey is the metadata key of the identifier clients and gateways
+// give requests, which is recorded with the changes they make.
+const requestIdKey = "x-request-id"
+
+// ItemChangeEntityType describes nexus.v4.config.ItemChange to the $filter
+// of listItemHistory. Changes are not an entity set of their own, so it has
+// no EDM binding.
+var ItemChangeEntityType = &odata.EntityType{
+	Name: "itemchange",
+	Properties: []*odata.Property{
+		{Name: "sequence", Type: string(edm.EdmInt64), IsFilterable: true},
+		{Name: "time", Type: string(edm.EdmDateTimeOffset), IsFilterable: true},
+		{Name: "actor", Type: string(edm.EdmString), IsFilterable: true},
+		{Name: "requestId", Type: string(edm.EdmString), IsFilterable: true},
+		{Name: "operation", Type: string(edm.EdmString), IsFilterable: true},
+		{Name: "itemExtId", Type: string(edm.EdmString), IsFilterable: true},
+		{Name: "entityType", Type: string(edm.EdmString), IsFilterable: true},
+		{Name: "entityId", Type: string(edm.EdmString), IsFilterable: true},
+	},
+}
+
+// ListItemHistory returns the requested page of the changes made to the
+// items visible to the tenant of the request and their associations that
+// match its $filter, oldest first. Listing the history is reserved to
+// administrators, so the items and associations it holds are not redacted.
+func (s *ItemService) ListItemHistory(ctx context.Context, arg *pb.ListItemHistoryArg) (*pb.ListItemHistoryRet, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q, err := historyQueryOf(arg)
+	if err != nil {
+		return nil, toStatus(err, historyPath)
+	}
+	changes, err := s.store.History(scope, store.HistoryQuery{Filter: q.filterExpr, Limit: (q.page+1)*q.limit + 1})
+	if err != nil {
+		return nil, toStatus(storeError(err), historyPath)
+	}
+	start, end := q.bounds(len(changes))
+	return &pb.ListItemHistoryRet{
+		Content: &pb.ListItemHistoryApiResponse{
+			Data: &pb.ListItemHistoryApiResponse_ItemChangeArrayData{
+				ItemChangeArrayData: &pb.ItemChangeArrayWrapper{Value: changes[start:end]},
+			},
+			Metadata: &responsepb.ApiResponseMetadata{
+				Links: q.pageLinks(historyURL(ctx), len(changes), ""),
+			},
+		},
+	}, nil
+}
+
+// historyQueryOf validates the query parameters in arg and fills in
+// defaults. Only the $filter, $page and $limit of the returned listQuery
+// are set.
+func historyQueryOf(arg *pb.ListItemHistoryArg) (listQuery, error) {
+	q := listQuery{
+		filter: arg.GetXFilter(),
+		page:   int(arg.GetXPage()),
+		limit:  defaultLimit,
+	}
+	if q.page < 0 {
+		return q, &queryParamError{param: "$page", message: "must be greater than or equal to 0"}
+	}
+	if arg.XLimit != nil {
+		q.limit = int(arg.GetXLimit())
+		if q.limit < 1 || q.limit > maxLimit {
+			return q, &queryParamError{param: "$limit", message: "must be between 1 and " + strconv.Itoa(maxLimit)}
+		}
+	}
+	var err error
+	if q.filterExpr, err = parseChangeFilter(q.filter); err != nil {
+		return q, err
+	}
+	return q, nil
+}
+
+// parseChangeFilter is parseFilter for the $filter of changes.
+func parseChangeFilter(filter string) (odata.Expr, error) {
+	if filter == "" {
+		return nil, nil
+	}
+	e, err := odata.ParseFilter(filter)
+	if err == nil {
+		err = odata.CheckFilter(e, ItemChangeEntityType)
+	}
+	if err != nil {
+		return nil, odataError("$filter", err)
+	}
+	return e, nil
+}
+
+// historyURL returns the URL of the change history of items as addressed by
+// the request in ctx.
+func historyURL(ctx context.Context) string {
+	return strings.TrimSuffix(collectionURL(ctx), itemsPath) + historyPath
+}
+
+// originOf returns the origin recorded with the changes made by the request
+// in ctx: its authenticated user and its X-Request-Id.
+func originOf(ctx context.Context) store.Origin {
+	var origin store.Origin
+	if id, ok := auth.FromContext(ctx); ok && id != nil {
+		origin.Actor = id.User
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	origin.RequestId = firstValue(md, requestIdKey)
+	return origin
+}
@@ -0,0 +1,303 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// makeChanges creates two items of testTenant and one of another tenant,
+// then renames the first as admin in the request req-1 and deletes the
+// second as admin, for 5 changes of testTenant. It returns the items of
+// testTenant.
+func makeChanges(t *testing.T, s *ItemService, st *store.ItemStore) []*pb.Item {
+	t.Helper()
+	items := createItems(t, st, "disk-1", "disk-2")
+	if _, err := st.PutAssociation(tenant.Scope{TenantId: testTenant}, &pb.ItemAssociation{
+		ItemId:     items[0].ExtId,
+		EntityType: proto.String("vm"),
+		EntityId:   proto.String("vm-1"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := st.Create(tenant.Scope{TenantId: "tenant-2"}, &pb.Item{ItemName: proto.String("other")}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := s.PatchItem(requestContext(admin, requestIdKey, "req-1"), &pb.PatchItemArg{
+		ExtId:       items[0].ExtId,
+		ContentType: proto.String("application/merge-patch+json"),
+		Body:        []byte(`{"itemName": "renamed"}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DeleteItem(requestContext(admin), &pb.DeleteItemArg{ExtId: items[1].ExtId}); err != nil {
+		t.Fatal(err)
+	}
+	return items
+}
+
+// changeLine describes a change as "sequence actor operation".
+func changeLine(seq int64, actor, op string) string {
+	if actor == "" {
+		actor = "-"
+	}
+	return fmt.Sprintf("%d %s %s", seq, actor, op)
+}
+
+func TestListItemHistory(t *testing.T) {
+	s, st := newTestService(t)
+	items := makeChanges(t, s, st)
+	ctx := requestContext(admin)
+
+	list := func(t *testing.T, arg *pb.ListItemHistoryArg) ([]string, map[string]string) {
+		t.Helper()
+		ret, err := s.ListItemHistory(ctx, arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, c := range ret.GetContent().GetItemChangeArrayData().GetValue() {
+			got = append(got, changeLine(c.GetSequence(), c.GetActor(), c.GetOperation()))
+		}
+		return got, links(ret.GetContent().GetMetadata().GetLinks())
+	}
+	tests := []struct {
+		name  string
+		arg   *pb.ListItemHistoryArg
+		want  []string
+		links map[string]string
+	}{
+		{
+			name:  "all",
+			arg:   &pb.ListItemHistoryArg{},
+			want:  []string{"1 - CREATE", "2 - CREATE", "3 - CREATE", "5 admin UPDATE", "6 admin DELETE"},
+			links: map[string]string{"self": historyPath + "?$page=0&$limit=50"},
+		},
+		{
+			name: "first page",
+			arg:  &pb.ListItemHistoryArg{XLimit: proto.Int32(2)},
+			want: []string{"1 - CREATE", "2 - CREATE"},
+			links: map[string]string{
+				"self": historyPath + "?$page=0&$limit=2",
+				"next": historyPath + "?$page=1&$limit=2",
+			},
+		},
+		{
+			name: "last page",
+			arg:  &pb.ListItemHistoryArg{XPage: proto.Int32(2), XLimit: proto.Int32(2)},
+			want: []string{"6 admin DELETE"},
+			links: map[string]string{
+				"self": historyPath + "?$page=2&$limit=2",
+				"prev": historyPath + "?$page=1&$limit=2",
+			},
+		},
+		{
+			name: "filter",
+			arg:  &pb.ListItemHistoryArg{XFilter: proto.String("actor eq 'admin'"), XLimit: proto.Int32(1)},
+			want: []string{"5 admin UPDATE"},
+			links: map[string]string{
+				"self": historyPath + "?$filter=actor+eq+%27admin%27&$page=0&$limit=1",
+				"next": historyPath + "?$filter=actor+eq+%27admin%27&$page=1&$limit=1",
+			},
+		},
+		{
+			name:  "request",
+			arg:   &pb.ListItemHistoryArg{XFilter: proto.String("requestId eq 'req-1'")},
+			want:  []string{"5 admin UPDATE"},
+			links: map[string]string{"self": historyPath + "?$filter=requestId+eq+%27req-1%27&$page=0&$limit=50"},
+		},
+		{
+			name:  "association",
+			arg:   &pb.ListItemHistoryArg{XFilter: proto.String("entityType eq 'vm'")},
+			want:  []string{"3 - CREATE"},
+			links: map[string]string{"self": historyPath + "?$filter=entityType+eq+%27vm%27&$page=0&$limit=50"},
+		},
+		{
+			name: "time",
+			arg:  &pb.ListItemHistoryArg{XFilter: proto.String("time gt 2024-05-01T12:00:00Z and itemExtId eq '" + items[1].GetExtId() + "'")},
+			want: []string{"2 - CREATE", "6 admin DELETE"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, links := list(t, tt.arg)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if tt.links != nil && !maps.Equal(links, tt.links) {
+				t.Errorf("got links %v, want %v", links, tt.links)
+			}
+		})
+	}
+
+	ret, err := s.ListItemHistory(requestContext(admin, forwardedHostKey, "pc.example.com"), &pb.ListItemHistoryArg{XFilter: proto.String("sequence eq 5")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := ret.GetContent().GetItemChangeArrayData().GetValue()
+	if len(changes) != 1 || changes[0].GetRequestId() != "req-1" || changes[0].GetItemAfter().GetItemName() != "renamed" {
+		t.Errorf("got %v, want the rename made by req-1", changes)
+	}
+	if self := links(ret.GetContent().GetMetadata().GetLinks())["self"]; !strings.HasPrefix(self, "https://pc.example.com"+historyPath+"?") {
+		t.Errorf("got self link %s", self)
+	}
+
+	errs := []struct {
+		arg  *pb.ListItemHistoryArg
+		want string
+	}{
+		{&pb.ListItemHistoryArg{XFilter: proto.String("itemName eq 'x'")}, "$filter"},
+		{&pb.ListItemHistoryArg{XFilter: proto.String("actor eq")}, "$filter"},
+		{&pb.ListItemHistoryArg{XPage: proto.Int32(-1)}, "$page"},
+		{&pb.ListItemHistoryArg{XLimit: proto.Int32(0)}, "$limit"},
+		{&pb.ListItemHistoryArg{XLimit: proto.Int32(maxLimit + 1)}, "$limit"},
+	}
+	for _, tt := range errs {
+		_, err := s.ListItemHistory(ctx, tt.arg)
+		if st := status.Convert(err); st.Code() != codes.InvalidArgument || !strings.Contains(st.Message(), tt.want) {
+			t.Errorf("ListItemHistory(%v): got %v, want InvalidArgument about %s", tt.arg, err, tt.want)
+		}
+	}
+
+	// Tenants see the changes of their own items alone.
+	other := tenant.NewContext(ctx, tenant.Scope{TenantId: "tenant-2"})
+	if ret, err := s.ListItemHistory(other, &pb.ListItemHistoryArg{}); err != nil || len(ret.GetContent().GetItemChangeArrayData().GetValue()) != 1 {
+		t.Errorf("got %v, %v for tenant-2, want the creation of its item alone", ret, err)
+	}
+}
+
+// auditServer serves the audit stream of st for testTenant.
+func auditServer(t *testing.T, st *store.ItemStore) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(AuditHandler(st, &tenant.Resolver{DefaultTenantId: testTenant}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// auditChange is the part of the ItemChange lines of the audit stream the
+// tests look at.
+type auditChange struct {
+	ObjectType string `json:"$objectType"`
+	Sequence   int64  `json:"sequence"`
+	Actor      string `json:"actor"`
+	Operation  string `json:"operation"`
+}
+
+func TestAuditHandler(t *testing.T) {
+	s, st := newTestService(t)
+	makeChanges(t, s, st)
+	srv := auditServer(t, st)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"1 - CREATE", "2 - CREATE", "3 - CREATE", "5 admin UPDATE", "6 admin DELETE"}},
+		{"after=3", []string{"5 admin UPDATE", "6 admin DELETE"}},
+		{"after=6", nil},
+		{"$filter=" + url.QueryEscape("operation ne 'CREATE'") + "&follow=false", []string{"5 admin UPDATE", "6 admin DELETE"}},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(srv.URL + AuditPath + "?" + tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ct := resp.Header.Get("Content-Type"); resp.StatusCode != http.StatusOK || ct != auditContentType {
+			t.Errorf("?%s: got %s of %s, want 200 OK of %s", tt.query, resp.Status, ct, auditContentType)
+		}
+		var got []string
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			var c auditChange
+			if err := json.Unmarshal(sc.Bytes(), &c); err != nil {
+				t.Fatalf("?%s: line %q: %v", tt.query, sc.Text(), err)
+			}
+			if c.ObjectType != "nexus.v4.config.ItemChange" {
+				t.Errorf("?%s: got $objectType %q", tt.query, c.ObjectType)
+			}
+			got = append(got, changeLine(c.Sequence, c.Actor, c.Operation))
+		}
+		resp.Body.Close()
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("?%s: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	errs := []struct {
+		method, query string
+		want          int
+	}{
+		{http.MethodPost, "", http.StatusMethodNotAllowed},
+		{http.MethodGet, "after=x", http.StatusBadRequest},
+		{http.MethodGet, "after=-1", http.StatusBadRequest},
+		{http.MethodGet, "follow=maybe", http.StatusBadRequest},
+		{http.MethodGet, "$filter=" + url.QueryEscape("itemName eq 'x'"), http.StatusBadRequest},
+	}
+	for _, tt := range errs {
+		req, err := http.NewRequest(tt.method, srv.URL+AuditPath+"?"+tt.query, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s ?%s: got %s, want %d", tt.method, tt.query, resp.Status, tt.want)
+		}
+	}
+}
+
+func TestAuditHandlerFollow(t *testing.T) {
+	s, st := newTestService(t)
+	items := createItems(t, st, "disk-1")
+	srv := auditServer(t, st)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+AuditPath+"?follow=true", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	dec := json.NewDecoder(resp.Body)
+	next := func() string {
+		t.Helper()
+		var c auditChange
+		if err := dec.Decode(&c); err != nil {
+			t.Fatal(err)
+		}
+		return changeLine(c.Sequence, c.Actor, c.Operation)
+	}
+	if got := next(); got != "1 - CREATE" {
+		t.Errorf("got %s, want the change made before following", got)
+	}
+	if _, err := s.DeleteItem(requestContext(admin), &pb.DeleteItemArg{ExtId: items[0].ExtId}); err != nil {
+		t.Fatal(err)
+	}
+	if got := next(); got != "2 admin DELETE" {
+		t.Errorf("got %s, want the change made while following", got)
+	}
+}
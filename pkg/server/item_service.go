@@ -0,0 +1,355 @@
+// Package server implements the gRPC services generated from the nexus API
+// definitions.
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	responsepb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/response"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/item"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/mappers"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/redact"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/search"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// itemsPath is the REST collection path served through Adonis for
+// nexus.v4.config.ItemService.
+const itemsPath = ServiceRootPath + "/items"
+
+// ItemService implements nexus.v4.config.ItemService on top of an ItemStore.
+// It expects the tenant scope of each request to have been resolved by
+// tenant.Resolver's interceptors.
+type ItemService struct {
+	pb.UnimplementedItemServiceServer
+	store     *store.ItemStore
+	index     *search.Index
+	stopIndex func()
+
+	// Redaction withholds item properties from callers lacking the roles
+	// to read them. When empty nothing is withheld.
+	Redaction redact.Policy
+	// TokenKey signs the $skiptoken continuation tokens of listItems.
+	// NewItemService sets a random key; services sharing clients, such as
+	// replicas behind a load balancer, must share one.
+	TokenKey []byte
+}
+
+// NewItemService returns an ItemService serving the items in s. It indexes
+// them for $search until Close is called.
+func NewItemService(s *store.ItemStore) (*ItemService, error) {
+	index := search.NewIndex()
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	stop, err := index.Follow(s)
+	if err != nil {
+		return nil, err
+	}
+	return &ItemService{store: s, index: index, stopIndex: stop, TokenKey: key}, nil
+}
+
+// Close stops indexing the items of the store for $search.
+func (s *ItemService) Close() {
+	s.stopIndex()
+}
+
+// ListItems returns the requested page of the items visible to the tenant of
+// the request that match its $search and $filter, sorted by its $orderby,
+// or by relevance to $search without one, and reduced to the properties of
+// its $select. Pagination links are returned in the response metadata,
+// along with the number of matching items when $count is true. With $apply
+// the rows it computes from the matching items are returned instead. With
+// asOf the items are listed as they were at that time.
+func (s *ItemService) ListItems(ctx context.Context, arg *pb.ListItemsArg) (*pb.ListItemsRet, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q, err := listQueryOf(arg, s.withheld(ctx))
+	if err != nil {
+		return nil, toStatus(err, itemsPath)
+	}
+	if q.after, err = s.position(q); err != nil {
+		return nil, toStatus(err, itemsPath)
+	}
+	if s, err = s.at(scope, q.at); err != nil {
+		return nil, toStatus(err, itemsPath)
+	}
+	var scores map[string]float64
+	if q.searchExpr != nil {
+		// The words of properties withheld from the caller are not
+		// searched, lest matches reveal them.
+		scores = s.index.Search(q.searchExpr, s.withheld(ctx)...)
+	}
+	sq := q.storeQuery()
+	items, err := s.store.List(scope, sq)
+	if err != nil {
+		return nil, toStatus(storeError(err), itemsPath)
+	}
+	reserved := s.diagnostics(arg, sq)
+	items = q.matchSearch(items, scores)
+	if q.applied != nil {
+		return s.listAggregates(ctx, scope, q, items, reserved)
+	}
+	var total *int32
+	if q.count {
+		n := len(items)
+		if sq.Limit > 0 {
+			if n, err = s.store.Count(scope, sq); err != nil {
+				return nil, toStatus(storeError(err), itemsPath)
+			}
+		}
+		total = proto.Int32(int32(n))
+	}
+	start, end := q.bounds(len(items))
+	page := items[start:end]
+	var next string
+	if q.keyset() && end < len(items) {
+		pos, err := sq.PositionOf(page[len(page)-1])
+		if err == nil {
+			next, err = s.skipToken(q, pos)
+		}
+		if err != nil {
+			return nil, toStatus(storeError(err), itemsPath)
+		}
+	}
+	base := collectionURL(ctx)
+	for _, it := range page {
+		extId := it.GetExtId()
+		odata.Select(it.ProtoReflect(), q.selected)
+		if err := s.decorate(scope, base, extId, it, q.expand); err != nil {
+			return nil, toStatus(err, itemsPath)
+		}
+	}
+	return &pb.ListItemsRet{
+		Content: &pb.ListItemsApiResponse{
+			Data: &pb.ListItemsApiResponse_ItemArrayData{
+				ItemArrayData: &pb.ItemArrayWrapper{Value: page},
+			},
+			Metadata: &responsepb.ApiResponseMetadata{
+				Links:                 q.pageLinks(base, len(items), next),
+				TotalAvailableResults: total,
+				Messages:              s.redactItems(ctx, page...),
+			},
+		},
+		Reserved: reserved,
+	}, nil
+}
+
+// listAggregates returns the requested page of the rows of the $apply of q
+// over items, along with the reserved response headers.
+func (s *ItemService) listAggregates(ctx context.Context, scope tenant.Scope, q listQuery, items []*pb.Item, reserved map[string]string) (*pb.ListItemsRet, error) {
+	rows, msgs, err := s.aggregate(ctx, scope, q, items)
+	if err != nil {
+		return nil, toStatus(err, itemsPath)
+	}
+	var total *int32
+	if q.count {
+		total = proto.Int32(int32(len(rows)))
+	}
+	start, end := q.bounds(len(rows))
+	return &pb.ListItemsRet{
+		Content: &pb.ListItemsApiResponse{
+			Data: &pb.ListItemsApiResponse_ItemAggregateArrayData{
+				ItemAggregateArrayData: &pb.ItemAggregateArrayWrapper{Value: rows[start:end]},
+			},
+			Metadata: &responsepb.ApiResponseMetadata{
+				Links:                 q.pageLinks(collectionURL(ctx), len(rows), ""),
+				TotalAvailableResults: total,
+				Messages:              msgs,
+			},
+		},
+		Reserved: reserved,
+	}, nil
+}
+
+// diagnostics returns the reserved response headers of the diagnostics the
+// X-Debug header of arg asks for: the plan the store lists the items of sq
+// with, for query-plan.
+func (s *ItemService) diagnostics(arg *pb.ListItemsArg, sq store.Query) map[string]string {
+	if header(arg.GetReserved(), debugHeader) != debugQueryPlan {
+		return nil
+	}
+	return map[string]string{queryPlanHeader: s.store.Explain(sq).String()}
+}
+
+// CountItems returns the number of items visible to the tenant of the
+// request that match its $filter. Items are matched in the store rather than
+// listed, so counting does not copy them.
+func (s *ItemService) CountItems(ctx context.Context, arg *pb.CountItemsArg) (*pb.CountItemsRet, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := itemsPath + "/$count"
+	e, err := parseFilter(arg.GetXFilter(), s.withheld(ctx))
+	if err != nil {
+		return nil, toStatus(err, path)
+	}
+	n, err := s.store.Count(scope, store.Query{Filter: e})
+	if err != nil {
+		return nil, toStatus(storeError(err), path)
+	}
+	return &pb.CountItemsRet{Content: proto.Int64(int64(n))}, nil
+}
+
+// GetItem returns the item identified by arg.ExtId, as it was at the time
+// of its asOf when set.
+func (s *ItemService) GetItem(ctx context.Context, arg *pb.GetItemArg) (*pb.GetItemRet, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := itemsPath + "/" + arg.GetExtId()
+	if err := checkExpand(arg.GetXExpand()); err != nil {
+		return nil, toStatus(err, path)
+	}
+	at, err := parseAsOf(arg.GetAsOf())
+	if err != nil {
+		return nil, toStatus(err, path)
+	}
+	if s, err = s.at(scope, at); err != nil {
+		return nil, toStatus(err, path)
+	}
+	found, err := s.store.Get(scope, arg.GetExtId())
+	if err != nil {
+		return nil, toStatus(err, path)
+	}
+	if err := s.decorate(scope, collectionURL(ctx), found.GetExtId(), found, arg.GetXExpand()); err != nil {
+		return nil, toStatus(err, path)
+	}
+	return &pb.GetItemRet{
+		Content: &pb.GetItemApiResponse{
+			Data:     &pb.GetItemApiResponse_ItemData{ItemData: found},
+			Metadata: redactedMetadata(s.redactItems(ctx, found)),
+		},
+	}, nil
+}
+
+// PatchItem applies the merge-patch or JSON-patch document in arg to the
+// item identified by arg.ExtId and returns the updated item. Like the other
+// changes made through the service, the update is recorded in the change
+// history of items along with the caller and the X-Request-Id of the
+// request.
+func (s *ItemService) PatchItem(ctx context.Context, arg *pb.PatchItemArg) (*pb.PatchItemRet, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var updated *pb.Item
+	err = s.store.TxnFrom(scope, originOf(ctx), func(tx *store.Txn) (err error) {
+		updated, err = tx.Update(arg.GetExtId(), func(current *pb.Item) (*pb.Item, error) {
+			patched, err := item.Patch(mappers.ItemToDto(current), arg.GetContentType(), arg.GetBody())
+			if err != nil {
+				return nil, err
+			}
+			return mappers.ItemFromDto(patched), nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, toStatus(err, itemsPath+"/"+arg.GetExtId())
+	}
+	updated.Links = mappers.ItemLinks(collectionURL(ctx), updated.GetExtId())
+	return &pb.PatchItemRet{
+		Content: &pb.PatchItemApiResponse{
+			Data:     &pb.PatchItemApiResponse_ItemData{ItemData: updated},
+			Metadata: redactedMetadata(s.redactItems(ctx, updated)),
+		},
+	}, nil
+}
+
+// DeleteItem deletes the item identified by arg.ExtId, which can be
+// restored until it is purged. Its associations are deleted when it is
+// purged, kept or prevent the deletion according to the DeletePolicy of the
+// store.
+func (s *ItemService) DeleteItem(ctx context.Context, arg *pb.DeleteItemArg) (*pb.DeleteItemRet, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = s.store.TxnFrom(scope, originOf(ctx), func(tx *store.Txn) error {
+		return tx.Delete(arg.GetExtId())
+	})
+	if err != nil {
+		return nil, toStatus(err, itemsPath+"/"+arg.GetExtId())
+	}
+	return &pb.DeleteItemRet{}, nil
+}
+
+// RestoreItem undeletes the item identified by arg.ExtId and returns it.
+func (s *ItemService) RestoreItem(ctx context.Context, arg *pb.RestoreItemArg) (*pb.RestoreItemRet, error) {
+	scope, err := scopeOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var restored *pb.Item
+	err = s.store.TxnFrom(scope, originOf(ctx), func(tx *store.Txn) (err error) {
+		restored, err = tx.Restore(arg.GetExtId())
+		return err
+	})
+	if err != nil {
+		return nil, toStatus(err, itemsPath+"/"+arg.GetExtId()+"/$actions/restore")
+	}
+	restored.Links = mappers.ItemLinks(collectionURL(ctx), restored.GetExtId())
+	return &pb.RestoreItemRet{
+		Content: &pb.RestoreItemApiResponse{
+			Data:     &pb.RestoreItemApiResponse_ItemData{ItemData: restored},
+			Metadata: redactedMetadata(s.redactItems(ctx, restored)),
+		},
+	}, nil
+}
+
+// at returns the service reading the items visible in scope as they were
+// at the given time, or s itself for the zero time. The returned service
+// shares everything but its store with s, so it is only meant for the reads
+// of one request.
+func (s *ItemService) at(scope tenant.Scope, t time.Time) (*ItemService, error) {
+	if t.IsZero() {
+		return s, nil
+	}
+	past, err := s.store.AsOf(scope, t)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	view := *s
+	view.store = past
+	return &view, nil
+}
+
+// decorate sets the links of it, the item with the given extId, resolved
+// against the collection at base, and fills in its associations when expand
+// asks for them. The extId is passed separately as $select may have
+// cleared it from the item.
+func (s *ItemService) decorate(scope tenant.Scope, base, extId string, it *pb.Item, expand string) error {
+	it.Links = mappers.ItemLinks(base, extId)
+	if expand != expandAssociations {
+		return nil
+	}
+	assocs, err := s.store.ListAssociations(scope, extId)
+	if err != nil {
+		return err
+	}
+	it.Associations = &pb.ItemAssociationArrayWrapper{Value: assocs}
+	return nil
+}
+
+// scopeOf returns the tenant scope resolved for the request in ctx.
+func scopeOf(ctx context.Context) (tenant.Scope, error) {
+	scope, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.Scope{}, status.Error(codes.Unauthenticated, "request has no tenant scope")
+	}
+	return scope, nil
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	responsepb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/response"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/auth"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// testTenant is the tenant the requests of the tests act for.
+const testTenant = "tenant-1"
+
+var (
+	admin  = &auth.Identity{User: "admin", Roles: []string{auth.RoleAdmin}}
+	viewer = &auth.Identity{User: "viewer"}
+)
+
+// newTestService returns an ItemService over an empty in-memory store, and
+// the store.
+func newTestService(t *testing.T) (*ItemService, *store.ItemStore) {
+	t.Helper()
+	st := store.NewItemStore()
+	s, err := NewItemService(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		s.Close()
+		st.Close()
+	})
+	return s, st
+}
+
+// requestContext returns the context of a request of id for testTenant,
+// carrying the metadata of the key and value pairs of md, as set up by the
+// interceptors of the server.
+func requestContext(id *auth.Identity, md ...string) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(md...))
+	ctx = auth.NewContext(ctx, id)
+	return tenant.NewContext(ctx, tenant.Scope{TenantId: testTenant})
+}
+
+// createItems creates an item of testTenant for each of names, of the type
+// "disk" and described by their name, and returns them.
+func createItems(t *testing.T, st *store.ItemStore, names ...string) []*pb.Item {
+	t.Helper()
+	var items []*pb.Item
+	for _, name := range names {
+		it, err := st.Create(tenant.Scope{TenantId: testTenant}, &pb.Item{
+			ItemName:    proto.String(name),
+			ItemType:    proto.String("disk"),
+			Description: proto.String("the " + name),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, it)
+	}
+	return items
+}
+
+// links returns the hrefs of links keyed by their relation.
+func links(links *responsepb.ApiLinkArrayWrapper) map[string]string {
+	hrefs := make(map[string]string)
+	for _, l := range links.GetValue() {
+		hrefs[l.GetRel()] = l.GetHref()
+	}
+	return hrefs
+}
+
+// listNames lists the items of arg as id and returns their names and the
+// links of the response keyed by their relation.
+func listNames(t *testing.T, s *ItemService, id *auth.Identity, arg *pb.ListItemsArg) ([]string, map[string]string) {
+	t.Helper()
+	ret, err := s.ListItems(requestContext(id), arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, it := range ret.GetContent().GetItemArrayData().GetValue() {
+		names = append(names, it.GetItemName())
+	}
+	return names, links(ret.GetContent().GetMetadata().GetLinks())
+}
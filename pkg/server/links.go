@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+
+	responsepb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/response"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/mappers"
+)
+
+// Metadata keys the REST gateway forwards to describe where the original
+// request was addressed. Without them links are relative to the host.
+const (
+	forwardedHostKey  = "x-forwarded-host"
+	forwardedProtoKey = "x-forwarded-proto"
+)
+
+// pageLinks returns the self, next and prev links of the page of q within a
+// result set of size total. The next link continues with the token next,
+// unless it is empty. Pages reached by a continuation token have no prev
+// link, as tokens only lead forward.
+func (q listQuery) pageLinks(collectionURL string, total int, next string) *responsepb.ApiLinkArrayWrapper {
+	links := []*responsepb.ApiLink{
+		mappers.NewApiLink(mappers.RelSelf, collectionURL+"?"+q.encode(q.page, q.skiptoken)),
+	}
+	if (q.page+1)*q.limit < total {
+		links = append(links, mappers.NewApiLink(mappers.RelNext, collectionURL+"?"+q.encode(q.page+1, next)))
+	}
+	if q.page > 0 {
+		links = append(links, mappers.NewApiLink(mappers.RelPrev, collectionURL+"?"+q.encode(q.page-1, "")))
+	}
+	return &responsepb.ApiLinkArrayWrapper{Value: links}
+}
+
+// collectionURL returns the URL of the items collection as addressed by the
+// request in ctx.
+func collectionURL(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	host := firstValue(md, forwardedHostKey)
+	if host == "" {
+		return itemsPath
+	}
+	scheme := firstValue(md, forwardedProtoKey)
+	if scheme == "" {
+		scheme = "https"
+	}
+	return scheme + "://" + host + itemsPath
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
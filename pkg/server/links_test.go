@@ -0,0 +1,218 @@
+package server
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCollectionURL(t *testing.T) {
+	tests := []struct {
+		md   []string
+		want string
+	}{
+		{nil, itemsPath},
+		{[]string{forwardedProtoKey, "http"}, itemsPath},
+		{[]string{forwardedHostKey, "pc.example.com:9440"}, "https://pc.example.com:9440" + itemsPath},
+		{[]string{forwardedHostKey, "pc.example.com", forwardedProtoKey, "http"}, "http://pc.example.com" + itemsPath},
+	}
+	for _, tt := range tests {
+		if got := collectionURL(requestContext(viewer, tt.md...)); got != tt.want {
+			t.Errorf("collectionURL with %v = %q, want %q", tt.md, got, tt.want)
+		}
+	}
+}
+
+func TestPageLinks(t *testing.T) {
+	const base = "https://pc" + itemsPath
+	tests := []struct {
+		name  string
+		q     listQuery
+		total int
+		next  string
+		want  map[string]string
+	}{
+		{
+			name:  "single page",
+			q:     listQuery{limit: 50},
+			total: 3,
+			want:  map[string]string{"self": base + "?$page=0&$limit=50"},
+		},
+		{
+			name:  "first page",
+			q:     listQuery{limit: 2, filter: "itemType eq 'disk'"},
+			total: 5,
+			want: map[string]string{
+				"self": base + "?$filter=itemType+eq+%27disk%27&$page=0&$limit=2",
+				"next": base + "?$filter=itemType+eq+%27disk%27&$page=1&$limit=2",
+			},
+		},
+		{
+			name:  "middle page",
+			q:     listQuery{limit: 2, page: 1, orderby: "itemName desc", count: true},
+			total: 5,
+			want: map[string]string{
+				"self": base + "?$orderby=itemName+desc&$count=true&$page=1&$limit=2",
+				"next": base + "?$orderby=itemName+desc&$count=true&$page=2&$limit=2",
+				"prev": base + "?$orderby=itemName+desc&$count=true&$page=0&$limit=2",
+			},
+		},
+		{
+			name:  "last page",
+			q:     listQuery{limit: 2, page: 2},
+			total: 5,
+			want: map[string]string{
+				"self": base + "?$page=2&$limit=2",
+				"prev": base + "?$page=1&$limit=2",
+			},
+		},
+		{
+			name:  "page past the end",
+			q:     listQuery{limit: 2, page: 4},
+			total: 5,
+			want: map[string]string{
+				"self": base + "?$page=4&$limit=2",
+				"prev": base + "?$page=3&$limit=2",
+			},
+		},
+		{
+			name:  "continued",
+			q:     listQuery{limit: 2, skiptoken: "t1"},
+			total: 5,
+			next:  "t2",
+			want: map[string]string{
+				"self": base + "?$skiptoken=t1&$limit=2",
+				"next": base + "?$skiptoken=t2&$limit=2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := links(tt.q.pageLinks(base, tt.total, tt.next)); !maps.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemLinks(t *testing.T) {
+	s, st := newTestService(t)
+	it := createItems(t, st, "disk-1")[0]
+	ctx := requestContext(admin, forwardedHostKey, "pc.example.com")
+	self := "https://pc.example.com" + itemsPath + "/" + it.GetExtId()
+	want := map[string]string{"self": self, "associations": self + "?$expand=associations"}
+
+	got, err := s.GetItem(ctx, &pb.GetItemArg{ExtId: it.ExtId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := links(got.GetContent().GetItemData().GetLinks()); !maps.Equal(l, want) {
+		t.Errorf("GetItem links = %v, want %v", l, want)
+	}
+	// $select may leave out the extId, but not the links.
+	list, err := s.ListItems(ctx, &pb.ListItemsArg{XSelect: proto.String("itemName")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := list.GetContent().GetItemArrayData().GetValue()
+	if len(items) != 1 || !maps.Equal(links(items[0].GetLinks()), want) {
+		t.Errorf("ListItems returned %v, want an item linked by %v", items, want)
+	}
+	patched, err := s.PatchItem(ctx, &pb.PatchItemArg{
+		ExtId:       it.ExtId,
+		ContentType: proto.String("application/merge-patch+json"),
+		Body:        []byte(`{"description":"patched"}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := links(patched.GetContent().GetItemData().GetLinks()); !maps.Equal(l, want) {
+		t.Errorf("PatchItem links = %v, want %v", l, want)
+	}
+}
+
+func TestListItemsPages(t *testing.T) {
+	s, st := newTestService(t)
+	createItems(t, st, "disk-1", "disk-2", "disk-3", "disk-4", "disk-5")
+	base := itemsPath
+	tests := []struct {
+		name  string
+		arg   *pb.ListItemsArg
+		want  []string
+		total int32
+		links map[string]string
+	}{
+		{
+			name: "first page",
+			arg:  &pb.ListItemsArg{XLimit: proto.Int32(2)},
+			want: []string{"disk-1", "disk-2"},
+			links: map[string]string{
+				"self": base + "?$page=0&$limit=2",
+			},
+		},
+		{
+			name:  "middle page",
+			arg:   &pb.ListItemsArg{XOrderby: proto.String("itemName desc"), XPage: proto.Int32(1), XLimit: proto.Int32(2), XCount: proto.Bool(true)},
+			want:  []string{"disk-3", "disk-2"},
+			total: 5,
+			links: map[string]string{
+				"self": base + "?$orderby=itemName+desc&$count=true&$page=1&$limit=2",
+				"prev": base + "?$orderby=itemName+desc&$count=true&$page=0&$limit=2",
+			},
+		},
+		{
+			name:  "last page",
+			arg:   &pb.ListItemsArg{XFilter: proto.String("itemName ne 'disk-1'"), XPage: proto.Int32(1), XLimit: proto.Int32(3), XCount: proto.Bool(true)},
+			want:  []string{"disk-5"},
+			total: 4,
+			links: map[string]string{
+				"self": base + "?$filter=itemName+ne+%27disk-1%27&$count=true&$page=1&$limit=3",
+				"prev": base + "?$filter=itemName+ne+%27disk-1%27&$count=true&$page=0&$limit=3",
+			},
+		},
+		{
+			name:  "past the end",
+			arg:   &pb.ListItemsArg{XPage: proto.Int32(5), XLimit: proto.Int32(2), XCount: proto.Bool(true)},
+			total: 5,
+			links: map[string]string{
+				"self": base + "?$count=true&$page=5&$limit=2",
+				"prev": base + "?$count=true&$page=4&$limit=2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ret, err := s.ListItems(requestContext(admin), tt.arg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, it := range ret.GetContent().GetItemArrayData().GetValue() {
+				names = append(names, it.GetItemName())
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("got %v, want %v", names, tt.want)
+			}
+			md := ret.GetContent().GetMetadata()
+			if md.GetTotalAvailableResults() != tt.total {
+				t.Errorf("got a total of %d, want %d", md.GetTotalAvailableResults(), tt.total)
+			}
+			got := links(md.GetLinks())
+			// Next links carry continuation tokens, checked by the tests of
+			// $skiptoken.
+			if next := got["next"]; next != "" {
+				if !strings.Contains(next, "$skiptoken=") {
+					t.Errorf("got next link %s, want a continuation token", next)
+				}
+				delete(got, "next")
+			}
+			if !maps.Equal(got, tt.links) {
+				t.Errorf("got links %v, want %v", got, tt.links)
+			}
+		})
+	}
+}
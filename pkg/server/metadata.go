@@ -0,0 +1,50 @@
+package server
+
+import (
+	"net/http"
+
+	edmconfig "github.com/nutanix/ntnx-api-golang-mock-pc/generated-code/edm/nexus/v4/config"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/csdl"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/discovery"
+)
+
+// ServiceRootPath is the REST path of the config module. Its service
+// document is served there.
+const ServiceRootPath = "/api/nexus/v4.1/config"
+
+// MetadataPath is the REST path of the CSDL $metadata document of the
+// config module.
+const MetadataPath = ServiceRootPath + "/$metadata"
+
+// MetadataMethod stands for reads of the service and $metadata documents in
+// ItemServicePolicy. It is not an RPC of ItemService.
+const MetadataMethod = "/nexus.v4.config.ItemService/$metadata"
+
+// ConfigModule is the config module as listed by discovery documents.
+var ConfigModule = discovery.Module{
+	Namespace: "nexus",
+	Name:      "config",
+	Bindings:  edmconfig.GetAllEntityBindings(),
+}
+
+// metadataKeys declares the keys of the config entity types not keyed by
+// extId. An association is identified by the item and the entity it links.
+var metadataKeys = map[string][]string{
+	edmconfig.NewItemAssociation().EntityType.Name: {"itemId", "entityType", "entityId"},
+}
+
+// Metadata returns the CSDL document describing the entity types and entity
+// sets of the config module.
+func Metadata() (*csdl.Document, error) {
+	return csdl.Build(edmconfig.GetAllEntityBindings(), metadataKeys)
+}
+
+// MetadataHandler serves the document of Metadata, for mounting at
+// MetadataPath.
+func MetadataHandler() (http.Handler, error) {
+	doc, err := Metadata()
+	if err != nil {
+		return nil, err
+	}
+	return doc.Handler(), nil
+}
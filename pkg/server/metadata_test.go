@@ -0,0 +1,77 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc"
+
+	edmconfig "github.com/nutanix/ntnx-api-golang-mock-pc/generated-code/edm/nexus/v4/config"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/discovery"
+)
+
+func TestMetadata(t *testing.T) {
+	doc, err := Metadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	itemType := edmconfig.NewItem().EntitySet.EntityType
+	if want := strings.TrimSuffix(itemType, ".item"); doc.Namespace != want {
+		t.Errorf("got namespace %q, want %s, that of the EDM bindings", doc.Namespace, want)
+	}
+	keys := make(map[string][]string)
+	for _, et := range doc.EntityTypes {
+		keys[et.Name] = et.Key
+	}
+	if !slices.Equal(keys["item"], []string{"extId"}) {
+		t.Errorf("got key %v of item, want [extId]", keys["item"])
+	}
+	if want := metadataKeys["itemassociation"]; want == nil || !slices.Equal(keys["itemassociation"], want) {
+		t.Errorf("got key %v of itemassociation, want %v", keys["itemassociation"], want)
+	}
+	for _, set := range doc.EntitySets {
+		if set.Name != "items" {
+			continue
+		}
+		if !slices.Contains(set.NonFilterable, "description") || slices.Contains(set.NonFilterable, "itemName") {
+			t.Errorf("got non-filterable properties %v of items, want description and not itemName", set.NonFilterable)
+		}
+		if !slices.Contains(set.NonSortable, "extId") || slices.Contains(set.NonSortable, "itemId") {
+			t.Errorf("got non-sortable properties %v of items, want extId and not itemId", set.NonSortable)
+		}
+	}
+
+	h, err := MetadataHandler()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, MetadataPath, nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `<EntitySet Name="items" EntityType="`+itemType+`">`) {
+		t.Errorf("GET %s = %d\n%s\nwant the items entity set", MetadataPath, w.Code, w.Body)
+	}
+}
+
+func TestConfigModule(t *testing.T) {
+	srv := grpc.NewServer()
+	pb.RegisterItemServiceServer(srv, &ItemService{})
+	doc, err := discovery.New(srv, ConfigModule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := doc.Module(ConfigModule.Namespace, ConfigModule.Name)
+	if m.ServiceRoot != ServiceRootPath || m.Metadata != MetadataPath {
+		t.Errorf("got service root %s and $metadata %s, want %s and %s, the paths served", m.ServiceRoot, m.Metadata, ServiceRootPath, MetadataPath)
+	}
+	sets := make(map[string]string)
+	for _, s := range m.EntitySets {
+		sets[s.Name] = s.URL
+	}
+	if sets["items"] != itemsPath {
+		t.Errorf("got items at %q, want %s", sets["items"], itemsPath)
+	}
+}
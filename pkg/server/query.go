@@ -0,0 +1,390 @@
+package server
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+
+	edmconfig "github.com/nutanix/ntnx-api-golang-mock-pc/generated-code/edm/nexus/v4/config"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+)
+
+// Pagination bounds of list operations, as declared for $page and $limit in
+// itemEndpoint.yaml.
+const (
+	defaultLimit = 50
+	maxLimit     = 100
+)
+
+// expandAssociations is the only value $expand accepts on items.
+const expandAssociations = "associations"
+
+// ItemEntityType describes nexus.v4.config.Item to the query options of
+// listItems. It extends the generated EDM binding, which only lists the
+// primitive properties of Item, with its complex-typed tenantInfo and its
+// associations, which backends store in the table of the ItemAssociation
+// binding.
+var ItemEntityType = itemEntityType()
+
+func itemEntityType() *odata.EntityType {
+	t := odata.EntityTypeFromBinding(edmconfig.NewItem())
+	tenantInfo := &odata.ComplexType{
+		Name: string((&commonpb.TenantAwareModel{}).ProtoReflect().Descriptor().FullName()),
+		Properties: []*odata.Property{
+			{Name: "tenantId", Type: string(edm.EdmString), IsFilterable: true},
+		},
+	}
+	association := odata.EntityTypeFromBinding(edmconfig.NewItemAssociation())
+	associations := &odata.ComplexType{
+		Name:       string((&pb.ItemAssociation{}).ProtoReflect().Descriptor().FullName()),
+		Properties: association.Properties,
+	}
+	return &odata.EntityType{
+		Name:      t.Name,
+		EntitySet: t.EntitySet,
+		Table:     t.Table,
+		Properties: append(t.Properties,
+			&odata.Property{Name: "tenantInfo", Type: tenantInfo.Name, ComplexType: tenantInfo},
+			&odata.Property{
+				Name:         "associations",
+				Type:         associations.Name,
+				IsCollection: true,
+				ComplexType:  associations,
+				Join:         &odata.Join{Table: association.Table, ForeignKey: "item_id", Key: "extId"},
+			},
+		),
+	}
+}
+
+// itemFilterType describes Item to $filter, which may also refer to the
+// Boolean isDeleted pseudo-property to list deleted items, that the store
+// excludes otherwise.
+var itemFilterType = &odata.EntityType{
+	Name:      ItemEntityType.Name,
+	EntitySet: ItemEntityType.EntitySet,
+	Table:     ItemEntityType.Table,
+	Properties: append(slices.Clip(ItemEntityType.Properties),
+		&odata.Property{Name: store.DeletedProperty, Type: string(edm.EdmBoolean), IsFilterable: true},
+	),
+}
+
+// Headers of the diagnostics listItems returns on request.
+const (
+	debugHeader     = "X-Debug"
+	debugQueryPlan  = "query-plan"
+	queryPlanHeader = "X-Query-Plan"
+)
+
+// header returns the value of the named header in the reserved map of a
+// request, matching its name case-insensitively.
+func header(reserved map[string]string, name string) string {
+	for k, v := range reserved {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+// listQuery holds the normalised query parameters of a list request.
+type listQuery struct {
+	filter    string
+	orderby   string
+	selects   string
+	expand    string
+	page      int
+	limit     int
+	count     bool
+	search    string
+	applies   string
+	skiptoken string
+	asOf      string
+
+	filterExpr odata.Expr
+	searchExpr odata.SearchExpr
+	applied    *odata.Apply
+	order      []odata.OrderItem
+	selected   []*odata.Path
+	// after is the position $skiptoken resumes the listing after, once
+	// verified by the service.
+	after *store.Position
+	// at is the time asOf lists the items as they were at, zero for the
+	// current items.
+	at time.Time
+}
+
+// listQueryOf validates the query parameters in arg and fills in defaults.
+// $filter and $orderby may not refer to the properties at the paths in
+// withheld, which are withheld from the caller.
+func listQueryOf(arg *pb.ListItemsArg, withheld []string) (listQuery, error) {
+	q := listQuery{
+		filter:    arg.GetXFilter(),
+		orderby:   arg.GetXOrderby(),
+		selects:   arg.GetXSelect(),
+		expand:    arg.GetXExpand(),
+		page:      int(arg.GetXPage()),
+		limit:     defaultLimit,
+		count:     arg.GetXCount(),
+		search:    arg.GetXSearch(),
+		applies:   arg.GetXApply(),
+		skiptoken: arg.GetXSkiptoken(),
+		asOf:      arg.GetAsOf(),
+	}
+	if q.page < 0 {
+		return q, &queryParamError{param: "$page", message: "must be greater than or equal to 0"}
+	}
+	if arg.XLimit != nil {
+		q.limit = int(arg.GetXLimit())
+		if q.limit < 1 || q.limit > maxLimit {
+			return q, &queryParamError{param: "$limit", message: "must be between 1 and " + strconv.Itoa(maxLimit)}
+		}
+	}
+	if err := checkExpand(q.expand); err != nil {
+		return q, err
+	}
+	var err error
+	if q.filterExpr, err = parseFilter(q.filter, withheld); err != nil {
+		return q, err
+	}
+	if q.search != "" {
+		if q.searchExpr, err = odata.ParseSearch(q.search); err != nil {
+			return q, odataError("$search", err)
+		}
+	}
+	if q.applies != "" {
+		if q.applied, err = odata.ParseApply(q.applies); err == nil {
+			err = odata.CheckApply(q.applied, ItemEntityType)
+		}
+		if err != nil {
+			return q, odataError("$apply", err)
+		}
+		if q.selects != "" || q.expand != "" {
+			return q, &queryParamError{param: "$apply", message: "cannot be combined with $select or $expand"}
+		}
+	}
+	if q.orderby != "" {
+		// With $apply, $orderby names the columns of its rows, which are
+		// checked when they are sorted.
+		if q.order, err = odata.ParseOrderBy(q.orderby); err == nil && q.applied == nil {
+			err = odata.CheckOrderBy(q.order, ItemEntityType)
+		}
+		if err != nil {
+			return q, odataError("$orderby", err)
+		}
+		if q.applied == nil {
+			for _, o := range q.order {
+				if err := checkWithheld("$orderby", o.Path.Segments, withheld); err != nil {
+					return q, err
+				}
+			}
+		}
+	}
+	if q.selects != "" {
+		if q.selected, err = odata.ParseSelect(q.selects); err == nil {
+			err = odata.CheckSelect(q.selected, ItemEntityType)
+		}
+		if err != nil {
+			return q, odataError("$select", err)
+		}
+	}
+	if q.at, err = parseAsOf(q.asOf); err != nil {
+		return q, err
+	}
+	if !q.at.IsZero() && q.searchExpr != nil {
+		// The search index only holds the current items.
+		return q, &queryParamError{param: "asOf", message: "cannot be combined with $search"}
+	}
+	if q.skiptoken != "" {
+		switch {
+		case q.page > 0:
+			return q, &queryParamError{param: "$skiptoken", message: "cannot be combined with $page"}
+		case q.searchExpr != nil || q.applied != nil:
+			return q, &queryParamError{param: "$skiptoken", message: "cannot be combined with $search or $apply"}
+		}
+	}
+	return q, nil
+}
+
+// parseFilter parses and checks a $filter expression, which may not refer
+// to the properties at the paths in withheld. It returns nil if filter is
+// empty.
+func parseFilter(filter string, withheld []string) (odata.Expr, error) {
+	if filter == "" {
+		return nil, nil
+	}
+	e, err := odata.ParseFilter(filter)
+	if err == nil {
+		err = odata.CheckFilter(e, itemFilterType)
+	}
+	if err != nil {
+		return nil, odataError("$filter", err)
+	}
+	odata.Paths(e, func(segments []string) {
+		if err == nil {
+			err = checkWithheld("$filter", segments, withheld)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// checkWithheld rejects a reference of the query option param to the
+// property at the path of segments if it is, or is nested in, one of the
+// properties at the paths in withheld. Selecting or sorting items by the
+// properties withheld from the caller would reveal them.
+func checkWithheld(param string, segments []string, withheld []string) error {
+	for _, w := range withheld {
+		ws := strings.Split(w, "/")
+		if len(ws) <= len(segments) && slices.Equal(ws, segments[:len(ws)]) {
+			return &queryParamError{param: param, message: "cannot refer to " + w + ", which the caller lacks the role to read"}
+		}
+	}
+	return nil
+}
+
+// parseAsOf parses the RFC 3339 date-time of an asOf query parameter. It
+// returns the zero time if asOf is empty.
+func parseAsOf(asOf string) (time.Time, error) {
+	if asOf == "" {
+		return time.Time{}, nil
+	}
+	at, err := time.Parse(time.RFC3339Nano, asOf)
+	if err != nil {
+		return time.Time{}, &queryParamError{param: "asOf", message: "must be an RFC 3339 date-time"}
+	}
+	return at, nil
+}
+
+func checkExpand(expand string) error {
+	if expand != "" && expand != expandAssociations {
+		return &queryParamError{param: "$expand", message: "only " + expandAssociations + " can be expanded"}
+	}
+	return nil
+}
+
+// odataError reports an invalid OData query option as a queryParamError.
+func odataError(param string, err error) error {
+	var oerr *odata.Error
+	if errors.As(err, &oerr) {
+		return &queryParamError{param: param, message: oerr.Error()}
+	}
+	return err
+}
+
+// matchSearch restricts items, as listed by the store, to those matching the
+// $search of q, whose relevance scores holds keyed by extId. Without
+// $orderby or $apply they are ordered by relevance.
+func (q listQuery) matchSearch(items []*pb.Item, scores map[string]float64) []*pb.Item {
+	if q.searchExpr == nil {
+		return items
+	}
+	items = slices.DeleteFunc(items, func(it *pb.Item) bool {
+		_, ok := scores[it.GetExtId()]
+		return !ok
+	})
+	if len(q.order) == 0 && q.applied == nil {
+		sort.SliceStable(items, func(i, j int) bool {
+			return scores[items[i].GetExtId()] > scores[items[j].GetExtId()]
+		})
+	}
+	return items
+}
+
+// storeQuery returns the store query selecting and ordering the items of q.
+// With $apply, items are left in the order of the store, as $orderby
+// applies to the rows of $apply. Unless all matching items are needed, to
+// search or aggregate them, the store lists no more than those of the
+// requested page, plus one telling whether there is a next page.
+func (q listQuery) storeQuery() store.Query {
+	sq := store.Query{Filter: q.filterExpr, After: q.after}
+	if q.applied == nil {
+		sq.OrderBy = q.order
+	}
+	if q.keyset() {
+		sq.Limit = (q.page+1)*q.limit + 1
+	}
+	return sq
+}
+
+// keyset reports whether the listing of q can be resumed by a $skiptoken:
+// its items are listed in the order of the store rather than by relevance
+// to $search or as rows of $apply.
+func (q listQuery) keyset() bool {
+	return q.searchExpr == nil && q.applied == nil
+}
+
+// storeError reports an option of a store query that failed to evaluate,
+// or an asOf time beyond the history of the store, as an invalid query
+// option.
+func storeError(err error) error {
+	var qerr *store.QueryError
+	var rerr *store.RetentionError
+	switch {
+	case errors.As(err, &qerr):
+		return odataError(qerr.Option, qerr.Err)
+	case errors.As(err, &rerr):
+		return &queryParamError{param: "asOf", message: rerr.Error()}
+	}
+	return err
+}
+
+// encode returns the query string of q for the given page, or for the
+// continuation token skiptoken if not empty. Parameters are written in a
+// fixed order and "$" is left unescaped so that links read like the URLs
+// clients send.
+func (q listQuery) encode(page int, skiptoken string) string {
+	var params []string
+	add := func(name, value string) {
+		params = append(params, name+"="+url.QueryEscape(value))
+	}
+	if q.filter != "" {
+		add("$filter", q.filter)
+	}
+	if q.orderby != "" {
+		add("$orderby", q.orderby)
+	}
+	if q.selects != "" {
+		add("$select", q.selects)
+	}
+	if q.expand != "" {
+		add("$expand", q.expand)
+	}
+	if q.search != "" {
+		add("$search", q.search)
+	}
+	if q.applies != "" {
+		add("$apply", q.applies)
+	}
+	if q.asOf != "" {
+		add("asOf", q.asOf)
+	}
+	if q.count {
+		add("$count", "true")
+	}
+	if skiptoken != "" {
+		add("$skiptoken", skiptoken)
+	} else {
+		add("$page", strconv.Itoa(page))
+	}
+	add("$limit", strconv.Itoa(q.limit))
+	return strings.Join(params, "&")
+}
+
+// bounds returns the range of a result set of size total covered by the
+// page of q.
+func (q listQuery) bounds(total int) (start, end int) {
+	start = min(q.page*q.limit, total)
+	end = min(start+q.limit, total)
+	return start, end
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"maps"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestListQueryOf(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  *pb.ListItemsArg
+		// err is the error expected, empty if arg is valid.
+		err string
+	}{
+		{name: "no parameters", arg: &pb.ListItemsArg{}},
+		{name: "page", arg: &pb.ListItemsArg{XPage: proto.Int32(3), XLimit: proto.Int32(maxLimit)}},
+		{"negative page", &pb.ListItemsArg{XPage: proto.Int32(-1)}, "$page: must be greater than or equal to 0"},
+		{"zero limit", &pb.ListItemsArg{XLimit: proto.Int32(0)}, "$limit: must be between 1 and 100"},
+		{"limit too large", &pb.ListItemsArg{XLimit: proto.Int32(maxLimit + 1)}, "$limit: must be between 1 and 100"},
+		{name: "expand", arg: &pb.ListItemsArg{XExpand: proto.String(expandAssociations)}},
+		{"expand other", &pb.ListItemsArg{XExpand: proto.String("tenantInfo")}, "$expand: only associations can be expanded"},
+		{name: "nested filter", arg: &pb.ListItemsArg{XFilter: proto.String("tenantInfo/tenantId eq 'tenant-1' and itemType eq 'disk'")}},
+		{"invalid filter", &pb.ListItemsArg{XFilter: proto.String("itemName eq")}, "$filter: unexpected end of expression at position 11"},
+		{"filter by complex property", &pb.ListItemsArg{XFilter: proto.String("tenantInfo eq 'a'")}, "$filter: property tenantInfo is not a primitive property"},
+		{"filter by unknown nested property", &pb.ListItemsArg{XFilter: proto.String("tenantInfo/name eq 'a'")}, "$filter: unknown property tenantInfo/name"},
+		{name: "orderby", arg: &pb.ListItemsArg{XOrderby: proto.String("itemType,itemName desc")}},
+		{"orderby not sortable", &pb.ListItemsArg{XOrderby: proto.String("tenantInfo/tenantId")}, "$orderby: property tenantInfo/tenantId is not sortable"},
+		{"invalid orderby", &pb.ListItemsArg{XOrderby: proto.String("itemName up")}, `$orderby: expected "," at position 9`},
+		{name: "select", arg: &pb.ListItemsArg{XSelect: proto.String("itemName,tenantInfo/tenantId")}},
+		{"select unknown property", &pb.ListItemsArg{XSelect: proto.String("itemName,size")}, "$select: unknown property size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := listQueryOf(tt.arg, nil)
+			if got := errorString(err); got != tt.err {
+				t.Fatalf("got error %q, want %q", got, tt.err)
+			}
+			if err == nil && tt.arg.XLimit == nil && q.limit != defaultLimit {
+				t.Errorf("got limit %d, want %d", q.limit, defaultLimit)
+			}
+		})
+	}
+}
+
+func TestStoreQueryLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  *pb.ListItemsArg
+		want int
+	}{
+		{"first page", &pb.ListItemsArg{XLimit: proto.Int32(10)}, 11},
+		{"third page", &pb.ListItemsArg{XPage: proto.Int32(2), XLimit: proto.Int32(10)}, 31},
+		{"default limit", &pb.ListItemsArg{}, defaultLimit + 1},
+		{"counted", &pb.ListItemsArg{XLimit: proto.Int32(10), XCount: proto.Bool(true)}, 11},
+		// Items are ranked by relevance or aggregated once all of them are
+		// listed.
+		{"searched", &pb.ListItemsArg{XLimit: proto.Int32(10), XSearch: proto.String("disk")}, 0},
+		{"aggregated", &pb.ListItemsArg{XLimit: proto.Int32(10), XApply: proto.String("groupby((itemType))")}, 0},
+	}
+	for _, tt := range tests {
+		q, err := listQueryOf(tt.arg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := q.storeQuery().Limit; got != tt.want {
+			t.Errorf("%s: got limit %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQueryPlanHeader(t *testing.T) {
+	s, st := newTestService(t)
+	createItems(t, st, "disk-1", "disk-2", "disk-3")
+	tests := []struct {
+		name     string
+		arg      *pb.ListItemsArg
+		reserved map[string]string
+	}{
+		{"not asked for", &pb.ListItemsArg{XOrderby: proto.String("itemName"), XLimit: proto.Int32(1)}, nil},
+		{
+			"asked for",
+			&pb.ListItemsArg{XOrderby: proto.String("itemName"), XLimit: proto.Int32(1), Reserved: map[string]string{"x-debug": debugQueryPlan}},
+			map[string]string{queryPlanHeader: "index scan on itemName, 3 of 3 items, cost 3.0, sorted by index, stop after 2"},
+		},
+		{
+			"of a filter",
+			&pb.ListItemsArg{XFilter: proto.String("itemName eq 'disk-2'"), Reserved: map[string]string{debugHeader: debugQueryPlan}},
+			map[string]string{queryPlanHeader: "index scan on itemName where (itemName eq 'disk-2'), 1 of 3 items, cost 1.5"},
+		},
+		{
+			"of an aggregation",
+			&pb.ListItemsArg{XApply: proto.String("aggregate($count as n)"), Reserved: map[string]string{debugHeader: debugQueryPlan}},
+			map[string]string{queryPlanHeader: "full scan, 3 of 3 items, cost 4.0"},
+		},
+		{"other diagnostics", &pb.ListItemsArg{Reserved: map[string]string{debugHeader: "timing"}}, nil},
+	}
+	for _, tt := range tests {
+		ret, err := s.ListItems(requestContext(admin), tt.arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !maps.Equal(ret.GetReserved(), tt.reserved) {
+			t.Errorf("%s: got reserved headers %v, want %v", tt.name, ret.GetReserved(), tt.reserved)
+		}
+	}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
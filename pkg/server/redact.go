@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	responsepb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/response"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/auth"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/redact"
+)
+
+// msgCodeRedacted is the code of the REDACTED messages reporting withheld
+// properties.
+const msgCodeRedacted = "NEXUS-20400"
+
+// ItemRedaction masks the description of items and hides their
+// associations from callers without the admin role.
+var ItemRedaction = redact.Policy{
+	{Path: "description", Action: redact.Mask, Roles: []string{auth.RoleAdmin}},
+	{Path: "associations", Action: redact.Hide, Roles: []string{auth.RoleAdmin}},
+}
+
+// redactItems applies the redaction policy of s to items for the caller of
+// the request in ctx. It returns one REDACTED message per withheld
+// property, or nil when nothing was withheld.
+func (s *ItemService) redactItems(ctx context.Context, items ...*pb.Item) *commonpb.MessageArrayWrapper {
+	if len(s.Redaction) == 0 {
+		return nil
+	}
+	id, _ := auth.FromContext(ctx)
+	var order []redact.Redaction
+	counts := make(map[redact.Redaction]int)
+	for _, it := range items {
+		for _, r := range s.Redaction.Apply(it, id) {
+			if counts[r] == 0 {
+				order = append(order, r)
+			}
+			counts[r]++
+		}
+	}
+	if len(order) == 0 {
+		return nil
+	}
+	msgs := &commonpb.MessageArrayWrapper{}
+	for _, r := range order {
+		msgs.Value = append(msgs.Value, &commonpb.Message{
+			Code:     proto.String(msgCodeRedacted),
+			Message:  proto.String(redactedMessage(r, counts[r])),
+			Locale:   proto.String("en_US"),
+			Severity: commonpb.MessageSeverityMessage_REDACTED.Enum(),
+		})
+	}
+	return msgs
+}
+
+// withheld returns the paths of the properties the redaction policy of s
+// withholds from the caller of the request in ctx.
+func (s *ItemService) withheld(ctx context.Context) []string {
+	id, _ := auth.FromContext(ctx)
+	return s.Redaction.Withheld(id)
+}
+
+func redactedMessage(r redact.Redaction, n int) string {
+	noun := "item"
+	if n > 1 {
+		noun = "items"
+	}
+	property := strings.ReplaceAll(r.Path, "/", ".")
+	return fmt.Sprintf("Property %s is %s on %d %s because the caller lacks the role to read it", property, r.Action, n, noun)
+}
+
+// redactedMetadata returns the metadata reporting msgs, or nil when there
+// are none.
+func redactedMetadata(msgs *commonpb.MessageArrayWrapper) *responsepb.ApiResponseMetadata {
+	if msgs == nil {
+		return nil
+	}
+	return &responsepb.ApiResponseMetadata{Messages: msgs}
+}
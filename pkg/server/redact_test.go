@@ -0,0 +1,148 @@
+package server
+
+import (
+	"slices"
+	"testing"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/auth"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/redact"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// messages returns the texts of msgs, checking that they report
+// redactions.
+func messages(t *testing.T, msgs *commonpb.MessageArrayWrapper) []string {
+	t.Helper()
+	var texts []string
+	for _, m := range msgs.GetValue() {
+		if m.GetCode() != msgCodeRedacted || m.GetSeverity() != commonpb.MessageSeverityMessage_REDACTED {
+			t.Errorf("got message %v, want a REDACTED one", m)
+		}
+		texts = append(texts, m.GetMessage())
+	}
+	return texts
+}
+
+func TestRedaction(t *testing.T) {
+	s, st := newTestService(t)
+	s.Redaction = ItemRedaction
+	items := createItems(t, st, "disk-1", "disk-2")
+	if _, err := st.PutAssociation(tenant.Scope{TenantId: testTenant}, &pb.ItemAssociation{
+		ItemId:     items[0].ExtId,
+		EntityType: proto.String("vm"),
+		EntityId:   proto.String("vm-1"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		id           *auth.Identity
+		descriptions []string
+		associations int
+		messages     []string
+	}{
+		{
+			name:         "admin",
+			id:           admin,
+			descriptions: []string{"the disk-1", "the disk-2"},
+			associations: 1,
+		},
+		{
+			name:         "viewer",
+			id:           viewer,
+			descriptions: []string{redact.MaskedValue, redact.MaskedValue},
+			messages: []string{
+				"Property description is masked on 2 items because the caller lacks the role to read it",
+				"Property associations is hidden on 2 items because the caller lacks the role to read it",
+			},
+		},
+		{
+			name:         "anonymous",
+			descriptions: []string{redact.MaskedValue, redact.MaskedValue},
+			messages: []string{
+				"Property description is masked on 2 items because the caller lacks the role to read it",
+				"Property associations is hidden on 2 items because the caller lacks the role to read it",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := requestContext(tt.id)
+			ret, err := s.ListItems(ctx, &pb.ListItemsArg{XExpand: proto.String(expandAssociations)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var descriptions []string
+			associations := 0
+			for _, it := range ret.GetContent().GetItemArrayData().GetValue() {
+				descriptions = append(descriptions, it.GetDescription())
+				associations += len(it.GetAssociations().GetValue())
+			}
+			if !slices.Equal(descriptions, tt.descriptions) || associations != tt.associations {
+				t.Errorf("listed descriptions %q and %d associations, want %q and %d", descriptions, associations, tt.descriptions, tt.associations)
+			}
+			if got := messages(t, ret.GetContent().GetMetadata().GetMessages()); !slices.Equal(got, tt.messages) {
+				t.Errorf("listed with messages %q, want %q", got, tt.messages)
+			}
+
+			got, err := s.GetItem(ctx, &pb.GetItemArg{ExtId: items[1].ExtId})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := got.GetContent().GetItemData().GetDescription(); d != tt.descriptions[1] {
+				t.Errorf("got description %q, want %q", d, tt.descriptions[1])
+			}
+			var want []string
+			if tt.messages != nil {
+				want = []string{"Property description is masked on 1 item because the caller lacks the role to read it"}
+			}
+			if msgs := messages(t, got.GetContent().GetMetadata().GetMessages()); !slices.Equal(msgs, want) {
+				t.Errorf("got with messages %q, want %q", msgs, want)
+			}
+		})
+	}
+	// Redaction applies to responses, not to the items stored.
+	if got, err := st.Get(tenant.Scope{TenantId: testTenant}, items[0].GetExtId()); err != nil || got.GetDescription() != "the disk-1" {
+		t.Errorf("the store holds %v, %v, want the item as created", got, err)
+	}
+}
+
+// TestRedactionQueries checks that callers cannot filter or sort items by
+// the properties withheld from them, which would reveal their values.
+func TestRedactionQueries(t *testing.T) {
+	s, st := newTestService(t)
+	s.Redaction = append(slices.Clip(ItemRedaction), redact.Rule{Path: "itemType", Action: redact.Mask, Roles: []string{auth.RoleAdmin}})
+	createItems(t, st, "disk-1", "disk-2")
+	queries := []*pb.ListItemsArg{
+		{XFilter: proto.String("itemType eq 'disk'")},
+		{XFilter: proto.String("startswith(itemType, 'd') or itemName eq 'disk-1'")},
+		{XFilter: proto.String("associations/any(a: a/entityType eq 'vm')")},
+		{XOrderby: proto.String("itemName, itemType desc")},
+	}
+	for _, arg := range queries {
+		if _, err := s.ListItems(requestContext(viewer), arg); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("viewer listing with %v: got %v, want InvalidArgument", arg, err)
+		}
+		if _, err := s.ListItems(requestContext(admin), arg); err != nil {
+			t.Errorf("admin listing with %v: %v", arg, err)
+		}
+	}
+	if _, err := s.CountItems(requestContext(viewer), &pb.CountItemsArg{XFilter: proto.String("itemType eq 'disk'")}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("viewer counting by itemType: got %v, want InvalidArgument", err)
+	}
+	// Without a policy nothing is withheld or reported.
+	s.Redaction = nil
+	ret, err := s.ListItems(requestContext(viewer), &pb.ListItemsArg{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msgs := ret.GetContent().GetMetadata().GetMessages(); msgs != nil {
+		t.Errorf("got messages %v without a redaction policy", msgs)
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/auth"
+)
+
+// searchNames lists the items matching the $search of arg and returns
+// their names.
+func searchNames(t *testing.T, s *ItemService, id *auth.Identity, arg *pb.ListItemsArg) []string {
+	t.Helper()
+	ret, err := s.ListItems(requestContext(id), arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, it := range ret.GetContent().GetItemArrayData().GetValue() {
+		names = append(names, it.GetItemName())
+	}
+	return names
+}
+
+func TestSearch(t *testing.T) {
+	s, st := newTestService(t)
+	s.Redaction = ItemRedaction
+	// createItems describes every item by its name, as "the web", and
+	// gives them all the type disk.
+	createItems(t, st, "web server", "web", "backup backup", "archive")
+	// The index follows the store asynchronously.
+	all := &pb.ListItemsArg{XSearch: proto.String("disk")}
+	for deadline := time.Now().Add(time.Second); len(searchNames(t, s, admin, all)) < 4; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("the items were not indexed within a second")
+		}
+	}
+	tests := []struct {
+		name string
+		id   *auth.Identity
+		arg  *pb.ListItemsArg
+		want []string
+	}{
+		// backup is rarer than web, and more frequent where it occurs.
+		{"by relevance", admin, &pb.ListItemsArg{XSearch: proto.String("web OR backup")}, []string{"backup backup", "web server", "web"}},
+		{"ordered", admin, &pb.ListItemsArg{XSearch: proto.String("web OR backup"), XOrderby: proto.String("itemName")}, []string{"backup backup", "web", "web server"}},
+		{"filtered", admin, &pb.ListItemsArg{XSearch: proto.String("web"), XFilter: proto.String("itemName ne 'web'")}, []string{"web server"}},
+		{"boolean", admin, &pb.ListItemsArg{XSearch: proto.String("web NOT server OR archive")}, []string{"archive", "web"}},
+		{"paged", admin, &pb.ListItemsArg{XSearch: proto.String("web"), XLimit: proto.Int32(1), XPage: proto.Int32(1)}, []string{"web"}},
+		// The description is withheld from viewers: "the" only occurs
+		// there.
+		{"admin searching a withheld property", admin, &pb.ListItemsArg{XSearch: proto.String("the"), XOrderby: proto.String("itemName")}, []string{"archive", "backup backup", "web", "web server"}},
+		{"viewer searching a withheld property", viewer, &pb.ListItemsArg{XSearch: proto.String("the")}, nil},
+		{"viewer", viewer, &pb.ListItemsArg{XSearch: proto.String("backup")}, []string{"backup backup"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchNames(t, s, tt.id, tt.arg); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchInvalid(t *testing.T) {
+	s, _ := newTestService(t)
+	for _, arg := range []*pb.ListItemsArg{
+		{XSearch: proto.String("web AND")},
+		{XSearch: proto.String(`"web`)},
+		{XSearch: proto.String("web"), AsOf: proto.String("2024-05-01T12:00:00Z")},
+	} {
+		if _, err := s.ListItems(requestContext(admin), arg); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v: got %v, want InvalidArgument", arg, err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+)
+
+// skipToken is the payload of a $skiptoken: the position in the order of a
+// listing of the last item of a page, and a digest of the $filter, $orderby
+// and asOf of the listing, which the token is only valid for.
+type skipToken struct {
+	Query  string        `json:"q"`
+	Values []interface{} `json:"v"`
+	ItemId int32         `json:"i"`
+	ExtId  string        `json:"e"`
+}
+
+var errInvalidSkipToken = &queryParamError{param: "$skiptoken", message: "invalid continuation token"}
+
+// queryDigest returns the digest of the $filter, $orderby and asOf of q a
+// continuation token is bound to. asOf is digested as the time it parses
+// to, so that tokens survive a different spelling of the same instant.
+func (q listQuery) queryDigest() string {
+	h := sha256.New()
+	if q.filterExpr != nil {
+		h.Write([]byte(q.filterExpr.String()))
+	}
+	for _, o := range q.order {
+		h.Write([]byte{0})
+		h.Write([]byte(o.String()))
+	}
+	if !q.at.IsZero() {
+		h.Write([]byte{1})
+		h.Write([]byte(q.at.UTC().Format(time.RFC3339Nano)))
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:12])
+}
+
+// skipToken returns the continuation token resuming the listing of q after
+// the item at pos. Tokens are the base64url encoding of their JSON payload
+// followed by a dot and its HMAC-SHA256, so that clients cannot forge
+// positions.
+func (s *ItemService) skipToken(q listQuery, pos *store.Position) (string, error) {
+	payload, err := json.Marshal(skipToken{Query: q.queryDigest(), Values: pos.Values, ItemId: pos.ItemId, ExtId: pos.ExtId})
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), nil
+}
+
+// position returns the position the $skiptoken of q resumes its listing
+// after, or nil if q has none.
+func (s *ItemService) position(q listQuery) (*store.Position, error) {
+	if q.skiptoken == "" {
+		return nil, nil
+	}
+	encoded, sig, ok := strings.Cut(q.skiptoken, ".")
+	if !ok {
+		return nil, errInvalidSkipToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.sign(encoded)) {
+		return nil, errInvalidSkipToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidSkipToken
+	}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var t skipToken
+	if err := dec.Decode(&t); err != nil {
+		return nil, errInvalidSkipToken
+	}
+	if t.Query != q.queryDigest() || len(t.Values) != len(q.order) {
+		return nil, &queryParamError{param: "$skiptoken", message: "continuation token does not match $filter, $orderby and asOf"}
+	}
+	for i, v := range t.Values {
+		if n, ok := v.(json.Number); ok {
+			if t.Values[i], err = n.Int64(); err != nil {
+				t.Values[i], _ = n.Float64()
+			}
+		}
+	}
+	return &store.Position{Values: t.Values, ItemId: t.ItemId, ExtId: t.ExtId}, nil
+}
+
+func (s *ItemService) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.TokenKey)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
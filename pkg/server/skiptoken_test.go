@@ -0,0 +1,230 @@
+package server
+
+import (
+	"encoding/base64"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// createTyped creates an item of testTenant for each name and type pair of
+// namesAndTypes, and returns their extIds by name.
+func createTyped(t *testing.T, st *store.ItemStore, namesAndTypes ...string) map[string]string {
+	t.Helper()
+	extIds := make(map[string]string)
+	for i := 0; i < len(namesAndTypes); i += 2 {
+		it, err := st.Create(tenant.Scope{TenantId: testTenant}, &pb.Item{
+			ItemName: proto.String(namesAndTypes[i]),
+			ItemType: proto.String(namesAndTypes[i+1]),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		extIds[it.GetItemName()] = it.GetExtId()
+	}
+	return extIds
+}
+
+// skipTokenOf returns the $skiptoken of the link href, or "".
+func skipTokenOf(t *testing.T, href string) string {
+	t.Helper()
+	u, err := url.Parse(href)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Query().Get("$skiptoken")
+}
+
+// walk lists the items of arg by following the next links of its pages and
+// returns their names.
+func walk(t *testing.T, s *ItemService, arg *pb.ListItemsArg) []string {
+	t.Helper()
+	arg = proto.Clone(arg).(*pb.ListItemsArg)
+	var all []string
+	for i := 0; i < 20; i++ {
+		names, l := listNames(t, s, admin, arg)
+		all = append(all, names...)
+		if arg.XSkiptoken != nil {
+			if skipTokenOf(t, l["self"]) != arg.GetXSkiptoken() || l["prev"] != "" {
+				t.Errorf("got links %v of a continued page, want a self link with its token and no prev link", l)
+			}
+		}
+		if l["next"] == "" {
+			return all
+		}
+		token := skipTokenOf(t, l["next"])
+		if token == "" {
+			t.Fatalf("got next link %s, want a continuation token", l["next"])
+		}
+		arg.XPage, arg.XSkiptoken = nil, proto.String(token)
+	}
+	t.Fatal("the listing did not end after 20 pages")
+	return nil
+}
+
+func TestSkipTokenPagination(t *testing.T) {
+	s, st := newTestService(t)
+	createTyped(t, st, "d", "vm", "a", "disk", "f", "vm", "c", "host", "b", "disk", "g", "disk", "e", "vm")
+	tests := []struct {
+		name string
+		arg  *pb.ListItemsArg
+		want []string
+	}{
+		{"by itemId", &pb.ListItemsArg{XLimit: proto.Int32(2)}, []string{"d", "a", "f", "c", "b", "g", "e"}},
+		{"by name descending", &pb.ListItemsArg{XOrderby: proto.String("itemName desc"), XLimit: proto.Int32(3)}, []string{"g", "f", "e", "d", "c", "b", "a"}},
+		{"by type and name", &pb.ListItemsArg{XOrderby: proto.String("itemType,itemName"), XLimit: proto.Int32(2)}, []string{"a", "b", "g", "c", "d", "e", "f"}},
+		// Items of equal types are told apart by itemId.
+		{"by type", &pb.ListItemsArg{XOrderby: proto.String("itemType desc"), XLimit: proto.Int32(2)}, []string{"d", "f", "e", "c", "a", "b", "g"}},
+		{"filtered", &pb.ListItemsArg{XFilter: proto.String("itemType ne 'host'"), XOrderby: proto.String("itemName"), XLimit: proto.Int32(4)}, []string{"a", "b", "d", "e", "f", "g"}},
+		{"from the second page", &pb.ListItemsArg{XOrderby: proto.String("itemName"), XPage: proto.Int32(1), XLimit: proto.Int32(2)}, []string{"c", "d", "e", "f", "g"}},
+		{"on one page", &pb.ListItemsArg{XOrderby: proto.String("itemName"), XLimit: proto.Int32(7)}, []string{"a", "b", "c", "d", "e", "f", "g"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := walk(t, s, tt.arg); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The total counts every matching item, whatever page a token leads
+	// to.
+	arg := &pb.ListItemsArg{XOrderby: proto.String("itemName"), XLimit: proto.Int32(3), XCount: proto.Bool(true)}
+	_, l := listNames(t, s, admin, arg)
+	arg.XSkiptoken = proto.String(skipTokenOf(t, l["next"]))
+	ret, err := s.ListItems(requestContext(admin), arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total := ret.GetContent().GetMetadata().GetTotalAvailableResults(); total != 7 {
+		t.Errorf("got a total of %d on the second page, want 7", total)
+	}
+}
+
+func TestSkipTokenChanges(t *testing.T) {
+	s, st := newTestService(t)
+	extIds := createTyped(t, st, "b", "disk", "d", "disk", "f", "disk", "h", "disk", "j", "disk")
+	arg := &pb.ListItemsArg{XOrderby: proto.String("itemName"), XLimit: proto.Int32(2)}
+	names, l := listNames(t, s, admin, arg)
+	if want := []string{"b", "d"}; !slices.Equal(names, want) {
+		t.Fatalf("got %v, want %v", names, want)
+	}
+	// Items created before the position of the token, or deleted after it,
+	// neither repeat nor shift the items listed next.
+	createTyped(t, st, "a", "disk", "c", "disk", "g", "disk")
+	if err := st.Delete(tenant.Scope{TenantId: testTenant}, extIds["f"]); err != nil {
+		t.Fatal(err)
+	}
+	arg.XSkiptoken = proto.String(skipTokenOf(t, l["next"]))
+	if got, want := walk(t, s, arg), []string{"g", "h", "j"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	// Tokens survive the deletion of the item they follow.
+	if err := st.Delete(tenant.Scope{TenantId: testTenant}, extIds["d"]); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := walk(t, s, arg), []string{"g", "h", "j"}; !slices.Equal(got, want) {
+		t.Errorf("after deleting d: got %v, want %v", got, want)
+	}
+}
+
+func TestSkipTokenInvalid(t *testing.T) {
+	s, st := newTestService(t)
+	createTyped(t, st, "a", "disk", "b", "disk", "c", "vm")
+	now := time.Now().UTC()
+	valid := &pb.ListItemsArg{
+		XFilter:  proto.String("itemName ne 'z'"),
+		XOrderby: proto.String("itemName"),
+		XLimit:   proto.Int32(1),
+		AsOf:     proto.String(now.Format(time.RFC3339Nano)),
+	}
+	_, l := listNames(t, s, admin, valid)
+	token := skipTokenOf(t, l["next"])
+	payload, sig, _ := strings.Cut(token, ".")
+
+	// Tokens signed by another service, such as a replica not sharing its
+	// key.
+	other, otherStore := newTestService(t)
+	createTyped(t, otherStore, "a", "disk", "b", "disk")
+	_, l = listNames(t, other, admin, &pb.ListItemsArg{XOrderby: proto.String("itemName"), XLimit: proto.Int32(1)})
+	foreign := skipTokenOf(t, l["next"])
+	if foreign == "" {
+		t.Fatal("got no token from the other service")
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modified := base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(decoded), `"v":["a"]`, `"v":["b"]`, 1)))
+	if modified == payload {
+		t.Fatalf("payload %s holds no position after a", decoded)
+	}
+	badSig := "A" + sig[1:]
+	if badSig == sig {
+		badSig = "B" + sig[1:]
+	}
+	resigned := func(payload string) string {
+		return payload + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload))
+	}
+
+	const invalid = "$skiptoken: invalid continuation token"
+	const mismatch = "$skiptoken: continuation token does not match $filter, $orderby and asOf"
+	tests := []struct {
+		name   string
+		change func(arg *pb.ListItemsArg)
+		want   string
+	}{
+		{"valid", func(arg *pb.ListItemsArg) {}, ""},
+		{"asOf spelled otherwise", func(arg *pb.ListItemsArg) {
+			arg.AsOf = proto.String(now.In(time.FixedZone("", 2*60*60)).Format(time.RFC3339Nano))
+		}, ""},
+		{"no signature", func(arg *pb.ListItemsArg) { arg.XSkiptoken = proto.String(payload) }, invalid},
+		{"bad signature", func(arg *pb.ListItemsArg) { arg.XSkiptoken = proto.String(payload + "." + badSig) }, invalid},
+		{"signature not in base64", func(arg *pb.ListItemsArg) { arg.XSkiptoken = proto.String(payload + ".!") }, invalid},
+		{"modified payload", func(arg *pb.ListItemsArg) { arg.XSkiptoken = proto.String(modified + "." + sig) }, invalid},
+		{"signed with another key", func(arg *pb.ListItemsArg) { arg.XSkiptoken = proto.String(foreign) }, invalid},
+		{"signed payload not in base64", func(arg *pb.ListItemsArg) { arg.XSkiptoken = proto.String(resigned("!")) }, invalid},
+		{"signed payload not a token", func(arg *pb.ListItemsArg) {
+			arg.XSkiptoken = proto.String(resigned(base64.RawURLEncoding.EncodeToString([]byte("[1]"))))
+		}, invalid},
+		{"other filter", func(arg *pb.ListItemsArg) { arg.XFilter = proto.String("itemName ne 'y'") }, mismatch},
+		{"no filter", func(arg *pb.ListItemsArg) { arg.XFilter = nil }, mismatch},
+		{"other orderby", func(arg *pb.ListItemsArg) { arg.XOrderby = proto.String("itemName desc") }, mismatch},
+		{"other asOf", func(arg *pb.ListItemsArg) { arg.AsOf = proto.String(now.Add(time.Second).Format(time.RFC3339Nano)) }, mismatch},
+		{"no asOf", func(arg *pb.ListItemsArg) { arg.AsOf = nil }, mismatch},
+		{"with a page", func(arg *pb.ListItemsArg) { arg.XPage = proto.Int32(1) }, "$skiptoken: cannot be combined with $page"},
+		{"with an aggregation", func(arg *pb.ListItemsArg) { arg.XApply = proto.String("groupby((itemType))"); arg.XOrderby = nil }, "$skiptoken: cannot be combined with $search or $apply"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arg := proto.Clone(valid).(*pb.ListItemsArg)
+			arg.XSkiptoken = proto.String(token)
+			tt.change(arg)
+			ret, err := s.ListItems(requestContext(admin), arg)
+			if tt.want == "" {
+				names := []string{}
+				for _, it := range ret.GetContent().GetItemArrayData().GetValue() {
+					names = append(names, it.GetItemName())
+				}
+				if err != nil || !slices.Equal(names, []string{"b"}) {
+					t.Errorf("got %v, %v, want b", names, err)
+				}
+				return
+			}
+			if st := status.Convert(err); st.Code() != codes.InvalidArgument || st.Message() != tt.want {
+				t.Errorf("got %v, want InvalidArgument: %s", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"net/http"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/discovery"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/versioning"
+)
+
+// ConfigReleases lists the versions of the config module served from this
+// codebase, oldest first, with the properties each of them added.
+var ConfigReleases = []versioning.Release{
+	{Version: discovery.Version{Major: "4", Minor: "0"}},
+	{
+		Version: discovery.Version{Major: "4", Minor: "1"},
+		Added: map[string][]string{
+			"nexus.v4.config.Item":            {"tenantInfo", "links"},
+			"nexus.v4.config.ItemAssociation": {"tenantInfo"},
+		},
+	},
+	{
+		Version: discovery.Version{Major: "4", Minor: "2", ReleaseType: "b", ReleaseTypeRevision: "1"},
+		Added: map[string][]string{
+			"nexus.v4.config.Item": {"deletedAt"},
+		},
+	},
+}
+
+// NewVersionRouter serves every version of ConfigReleases with next, which
+// serves the REST routes of ItemService at the version of its
+// ntnx_api_version option.
+func NewVersionRouter(next http.Handler) (*versioning.Router, error) {
+	s, err := discovery.DescribeService(pb.ItemService_ServiceDesc.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	return &versioning.Router{
+		Namespace: ConfigModule.Namespace,
+		Module:    ConfigModule.Name,
+		Releases:  ConfigReleases,
+		Current:   s.Version,
+		ErrorCode: errCodeNotFound,
+		Next:      next,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/discovery"
+)
+
+func TestConfigReleases(t *testing.T) {
+	for i := 1; i < len(ConfigReleases); i++ {
+		if prev, v := ConfigReleases[i-1].Version, ConfigReleases[i].Version; prev.Compare(v) >= 0 {
+			t.Errorf("release %s follows %s, want releases oldest first", v, prev)
+		}
+	}
+	var served string
+	rt, err := NewVersionRouter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = r.URL.Path
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root := discovery.ServiceRoot(rt.Namespace, rt.Current, rt.Module); root != ServiceRootPath {
+		t.Errorf("got service root %s, want %s, the path served", root, ServiceRootPath)
+	}
+	for _, rel := range ConfigReleases {
+		served = ""
+		target := discovery.ServiceRoot(rt.Namespace, rel.Version, rt.Module) + "/items"
+		rt.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+		if served != itemsPath {
+			t.Errorf("GET %s was routed to %q, want %s", target, served, itemsPath)
+		}
+	}
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/nexus/v3.0/config/items", nil))
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), errCodeNotFound) {
+		t.Errorf("GET of v3.0 = %d %s, want %d with code %s", w.Code, w.Body, http.StatusNotFound, errCodeNotFound)
+	}
+}
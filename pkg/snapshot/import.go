@@ -0,0 +1,366 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	dto "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/nexus/v4/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	errorpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/error"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/item"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/mappers"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+)
+
+// Mode is how Import merges a snapshot with the entities of a store.
+type Mode string
+
+const (
+	// Upsert writes the items and associations of the snapshot in place of
+	// those with the same extId, or item, entityType and entityId, and
+	// keeps the others.
+	Upsert Mode = "upsert"
+	// Replace deletes every item of the store, along with its
+	// associations, before writing those of the snapshot.
+	Replace Mode = "replace"
+	// DryRun checks the snapshot as Upsert would import it, without
+	// writing anything.
+	DryRun Mode = "dry-run"
+)
+
+// ParseMode returns the Mode named s.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case Upsert, Replace, DryRun:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown import mode %q", s)
+}
+
+// Result counts the entities an import wrote, or would have written in
+// DryRun mode.
+type Result struct {
+	Items        int
+	Associations int
+	// Deleted is the number of items Replace deleted.
+	Deleted int
+}
+
+// ImportError reports the records of a snapshot that cannot be imported,
+// in which case nothing is. Each message of Details is located by the line
+// of a JSON Lines record or the position of a protobuf one, counting from
+// 1, and names the offending property in its attributePath.
+type ImportError struct {
+	Details *errorpb.SchemaValidationError
+}
+
+func (e *ImportError) Error() string {
+	msgs := e.Details.GetValidationErrorMessages().GetValue()
+	parts := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		s := m.GetLocation() + ": "
+		if m.GetAttributePath() != "" {
+			s += m.GetAttributePath() + ": "
+		}
+		parts = append(parts, s+m.GetMessage())
+	}
+	return "snapshot import failed: " + strings.Join(parts, "; ")
+}
+
+// errDryRun rolls back the transaction of a DryRun import.
+var errDryRun = errors.New("dry run")
+
+// record is a record read from a snapshot: an item or an association, or
+// the violations that make it invalid.
+type record struct {
+	location   string
+	item       *pb.Item
+	assoc      *pb.ItemAssociation
+	violations []item.Violation
+}
+
+// Import reads a snapshot in format f from r and writes its records to s
+// in mode. Records are written in a single transaction: if any is invalid,
+// Import returns an *ImportError listing every violation and s is left
+// unchanged. Snapshots whose framing cannot be read are reported by other
+// errors.
+func Import(r io.Reader, s *store.ItemStore, f Format, mode Mode) (Result, error) {
+	var res Result
+	if _, err := ParseMode(string(mode)); err != nil {
+		return res, err
+	}
+	var records []*record
+	var err error
+	switch f {
+	case JSONLines:
+		records, err = readJSONLines(r)
+	case Protobuf:
+		records, err = readProtobuf(r)
+	default:
+		_, err = ParseFormat(string(f))
+	}
+	if err != nil {
+		return res, err
+	}
+	checkDuplicates(records)
+	err = s.Txn(allTenants, func(tx *store.Txn) error {
+		if mode == Replace {
+			if res.Deleted, err = deleteAll(tx); err != nil {
+				return err
+			}
+		}
+		var rejected []*record
+		for _, rec := range records {
+			switch {
+			case len(rec.violations) > 0:
+			case rec.item != nil:
+				if _, err := tx.Put(rec.item); err != nil {
+					return err
+				}
+				res.Items++
+			default:
+				_, err := tx.PutAssociation(rec.assoc)
+				if errors.Is(err, store.ErrNotFound) {
+					rec.violations = append(rec.violations, item.Violation{AttributePath: "itemId", Message: "references no item"})
+					break
+				}
+				if err != nil {
+					return err
+				}
+				res.Associations++
+			}
+			if len(rec.violations) > 0 {
+				rejected = append(rejected, rec)
+			}
+		}
+		if len(rejected) > 0 {
+			return importError(rejected)
+		}
+		if mode == DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return Result{}, err
+	}
+	return res, nil
+}
+
+// deleteAll purges every item visible to tx, deleted ones included, along
+// with its associations, returning the number of items purged.
+func deleteAll(tx *store.Txn) (int, error) {
+	var extIds []string
+	err := tx.Items(func(it *pb.Item) bool {
+		extIds = append(extIds, it.GetExtId())
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, extId := range extIds {
+		assocs, err := tx.ListAssociations(extId)
+		if err != nil {
+			return 0, err
+		}
+		for _, a := range assocs {
+			if err := tx.DeleteAssociation(extId, a.GetEntityType(), a.GetEntityId()); err != nil {
+				return 0, err
+			}
+		}
+		if err := tx.Purge(extId); err != nil {
+			return 0, err
+		}
+	}
+	return len(extIds), nil
+}
+
+// checkDuplicates reports items sharing the extId of an earlier item of the
+// snapshot.
+func checkDuplicates(records []*record) {
+	seen := make(map[string]string)
+	for _, rec := range records {
+		if rec.item == nil || rec.item.GetExtId() == "" {
+			continue
+		}
+		if first, ok := seen[rec.item.GetExtId()]; ok {
+			rec.violations = append(rec.violations, item.Violation{AttributePath: "extId", Message: "duplicates the item of " + first})
+			continue
+		}
+		seen[rec.item.GetExtId()] = rec.location
+	}
+}
+
+// readJSONLines reads the records of a snapshot in JSON Lines. Blank lines
+// are skipped.
+func readJSONLines(r io.Reader) ([]*record, error) {
+	br := bufio.NewReader(r)
+	var records []*record
+	for n := 1; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			rec := decodeJSON(line)
+			rec.location = fmt.Sprintf("line %d", n)
+			records = append(records, rec)
+		}
+		if err == io.EOF {
+			return records, nil
+		}
+	}
+}
+
+// decodeJSON decodes a record from the JSON of its DTO.
+func decodeJSON(line []byte) *record {
+	var head struct {
+		ObjectType string `json:"$objectType"`
+	}
+	if err := json.Unmarshal(line, &head); err != nil {
+		return invalid("", "is not a JSON object: "+err.Error())
+	}
+	switch head.ObjectType {
+	case itemObjectType:
+		var d dto.Item
+		if err := json.Unmarshal(line, &d); err != nil {
+			return invalidJSON(err)
+		}
+		rec := itemRecord(mappers.ItemFromDto(&d))
+		rec.violations = append(unknownProperties(d.UnknownFields_), rec.violations...)
+		return rec
+	case associationObjectType:
+		var d dto.ItemAssociation
+		if err := json.Unmarshal(line, &d); err != nil {
+			return invalidJSON(err)
+		}
+		rec := associationRecord(mappers.ItemAssociationFromDto(&d))
+		rec.violations = append(unknownProperties(d.UnknownFields_), rec.violations...)
+		return rec
+	}
+	return invalid("$objectType", fmt.Sprintf("must be %s or %s", itemObjectType, associationObjectType))
+}
+
+func invalid(path, message string) *record {
+	return &record{violations: []item.Violation{{AttributePath: path, Message: message}}}
+}
+
+// invalidJSON reports a JSON value that does not decode into a property of
+// a DTO.
+func invalidJSON(err error) *record {
+	var terr *json.UnmarshalTypeError
+	if errors.As(err, &terr) {
+		return invalid(terr.Field, "cannot be a JSON "+terr.Value)
+	}
+	return invalid("", err.Error())
+}
+
+func unknownProperties(fields map[string]interface{}) []item.Violation {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var violations []item.Violation
+	for _, name := range names {
+		violations = append(violations, item.Violation{AttributePath: name, Message: "is not a known property"})
+	}
+	return violations
+}
+
+// readProtobuf reads the records of a snapshot in length-delimited
+// protobuf.
+func readProtobuf(r io.Reader) ([]*record, error) {
+	br := bufio.NewReader(r)
+	var records []*record
+	for n := 1; ; n++ {
+		var a anypb.Any
+		if err := protodelim.UnmarshalFrom(br, &a); err != nil {
+			if err == io.EOF {
+				return records, nil
+			}
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+		rec := decodeAny(&a)
+		rec.location = fmt.Sprintf("record %d", n)
+		records = append(records, rec)
+	}
+}
+
+// decodeAny decodes a record from the message held by a.
+func decodeAny(a *anypb.Any) *record {
+	m, err := a.UnmarshalNew()
+	if err != nil {
+		return invalid("", err.Error())
+	}
+	switch m := m.(type) {
+	case *pb.Item:
+		return itemRecord(m)
+	case *pb.ItemAssociation:
+		return associationRecord(m)
+	}
+	return invalid("", fmt.Sprintf("holds a %s rather than a %s or %s", m.ProtoReflect().Descriptor().FullName(), itemObjectType, associationObjectType))
+}
+
+// itemRecord returns the record of it, checking it against the Item schema
+// and for the properties a snapshot must carry that clients cannot set.
+func itemRecord(it *pb.Item) *record {
+	rec := &record{item: it}
+	var verr *item.ValidationError
+	if errors.As(item.Validate(mappers.ItemToDto(it)), &verr) {
+		rec.violations = verr.Violations
+	}
+	if it.GetExtId() == "" {
+		rec.violations = append(rec.violations, item.Violation{AttributePath: "extId", Message: "is required"})
+	}
+	if it.GetTenantInfo().GetTenantId() == "" {
+		rec.violations = append(rec.violations, item.Violation{AttributePath: "tenantInfo/tenantId", Message: "is required"})
+	}
+	return rec
+}
+
+// associationRecord returns the record of a, checking for the properties
+// identifying it.
+func associationRecord(a *pb.ItemAssociation) *record {
+	rec := &record{assoc: a}
+	for _, p := range []struct {
+		path  string
+		value string
+	}{{"itemId", a.GetItemId()}, {"entityType", a.GetEntityType()}, {"entityId", a.GetEntityId()}} {
+		if p.value == "" {
+			rec.violations = append(rec.violations, item.Violation{AttributePath: p.path, Message: "is required"})
+		}
+	}
+	return rec
+}
+
+func importError(records []*record) *ImportError {
+	var msgs []*errorpb.SchemaValidationErrorMessage
+	for _, rec := range records {
+		for _, v := range rec.violations {
+			msgs = append(msgs, &errorpb.SchemaValidationErrorMessage{
+				Location:      proto.String(rec.location),
+				Message:       proto.String(v.Message),
+				AttributePath: proto.String(v.AttributePath),
+			})
+		}
+	}
+	return &ImportError{Details: &errorpb.SchemaValidationError{
+		Timestamp:               timestamppb.Now(),
+		StatusCode:              proto.Int32(http.StatusBadRequest),
+		Error:                   proto.String(http.StatusText(http.StatusBadRequest)),
+		ValidationErrorMessages: &errorpb.SchemaValidationErrorMessageArrayWrapper{Value: msgs},
+	}}
+}
@@ -0,0 +1,131 @@
+// Package snapshot exports the items and item associations of an ItemStore
+// and imports them into another, to move mock data between environments.
+//
+// A snapshot is a sequence of records, each an Item or an ItemAssociation,
+// in one of two formats: JSON Lines of their DTOs, told apart by
+// $objectType, or length-delimited protobuf google.protobuf.Any messages,
+// told apart by their type URL. Exports list every item by itemId, each
+// followed by its associations, so that imports read items before the
+// associations referencing them.
+package snapshot
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	dto "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/nexus/v4/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/mappers"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// Format is the encoding of the records of a snapshot.
+type Format string
+
+const (
+	// JSONLines writes each record as the JSON of its DTO on a line of its
+	// own.
+	JSONLines Format = "jsonl"
+	// Protobuf writes each record as a google.protobuf.Any holding its
+	// protobuf message, preceded by its length as a varint.
+	Protobuf Format = "protobuf"
+)
+
+// ParseFormat returns the Format named s.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case JSONLines, Protobuf:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown snapshot format %q", s)
+}
+
+// allTenants is the scope snapshots are taken and restored in.
+var allTenants = tenant.Scope{AllTenants: true}
+
+// Export writes the items of every tenant in s and their associations to w
+// in format f. The records are read in a single transaction, so that they
+// are consistent with each other.
+func Export(w io.Writer, s *store.ItemStore, f Format) error {
+	if _, err := ParseFormat(string(f)); err != nil {
+		return err
+	}
+	var records []proto.Message
+	err := s.Txn(allTenants, func(tx *store.Txn) error {
+		var items []*pb.Item
+		err := tx.Items(func(item *pb.Item) bool {
+			items = append(items, item)
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		slices.SortFunc(items, func(a, b *pb.Item) int {
+			if c := cmp.Compare(a.GetItemId(), b.GetItemId()); c != 0 {
+				return c
+			}
+			return strings.Compare(a.GetExtId(), b.GetExtId())
+		})
+		for _, item := range items {
+			assocs, err := tx.ListAssociations(item.GetExtId())
+			if err != nil {
+				return err
+			}
+			records = append(records, item)
+			for _, a := range assocs {
+				records = append(records, a)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	for _, r := range records {
+		if err := writeRecord(bw, f, r); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func writeRecord(w *bufio.Writer, f Format, m proto.Message) error {
+	if f == Protobuf {
+		a, err := anypb.New(m)
+		if err != nil {
+			return err
+		}
+		_, err = protodelim.MarshalTo(w, a)
+		return err
+	}
+	var v interface{}
+	switch m := m.(type) {
+	case *pb.Item:
+		v = mappers.ItemToDto(m)
+	case *pb.ItemAssociation:
+		v = mappers.ItemAssociationToDto(m)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	w.Write(b)
+	return w.WriteByte('\n')
+}
+
+// Object types of the records of a snapshot in JSON Lines.
+var (
+	itemObjectType        = *dto.NewItem().ObjectType_
+	associationObjectType = *dto.NewItemAssociation().ObjectType_
+)
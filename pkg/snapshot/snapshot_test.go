@@ -0,0 +1,299 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/store"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// seed returns a store holding items of two tenants, some with
+// associations, and a deleted item.
+func seed(t *testing.T) *store.ItemStore {
+	t.Helper()
+	s := store.NewItemStore()
+	t.Cleanup(func() { s.Close() })
+	for _, tenantId := range []string{"a", "b"} {
+		scope := tenant.Scope{TenantId: tenantId}
+		for _, name := range []string{"disk", "vm"} {
+			it, err := s.Create(scope, &pb.Item{ItemName: proto.String(tenantId + "-" + name), ItemType: proto.String(name)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name == "vm" {
+				continue
+			}
+			for _, id := range []string{"1", "2"} {
+				if _, err := s.PutAssociation(scope, &pb.ItemAssociation{ItemId: it.ExtId, EntityType: proto.String("host"), EntityId: proto.String(id)}); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+	it, err := s.Create(tenant.Scope{TenantId: "a"}, &pb.Item{ItemName: proto.String("a-gone"), ItemType: proto.String("disk")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(tenant.Scope{TenantId: "a"}, it.GetExtId()); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// export returns the snapshot of s in format f.
+func export(t *testing.T, s *store.ItemStore, f Format) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Export(&buf, s, f); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// objectTypes returns the $objectType of each line of a snapshot in JSON
+// Lines, and the itemName of those of items.
+func objectTypes(t *testing.T, data []byte) []string {
+	t.Helper()
+	var types []string
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+		var head struct {
+			ObjectType string `json:"$objectType"`
+			ItemName   string `json:"itemName"`
+		}
+		if err := json.Unmarshal([]byte(line), &head); err != nil {
+			t.Fatalf("%s: %v", line, err)
+		}
+		types = append(types, strings.TrimSpace(head.ObjectType+" "+head.ItemName))
+	}
+	return types
+}
+
+func TestExport(t *testing.T) {
+	got := objectTypes(t, export(t, seed(t), JSONLines))
+	const item, assoc = "nexus.v4.config.Item", "nexus.v4.config.ItemAssociation"
+	want := []string{
+		item + " a-disk", assoc, assoc,
+		item + " a-vm",
+		item + " b-disk", assoc, assoc,
+		item + " b-vm",
+		item + " a-gone",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got records %v, want %v", got, want)
+	}
+	if err := Export(&bytes.Buffer{}, seed(t), "xml"); err == nil {
+		t.Error("exporting in an unknown format: got no error")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, f := range []Format{JSONLines, Protobuf} {
+		t.Run(string(f), func(t *testing.T) {
+			data := export(t, seed(t), f)
+			s := store.NewItemStore()
+			defer s.Close()
+			res, err := Import(bytes.NewReader(data), s, f, Upsert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := (Result{Items: 5, Associations: 4}); res != want {
+				t.Errorf("got %+v, want %+v", res, want)
+			}
+			// Items keep their extIds, itemIds, tenants and deletion times.
+			if again := export(t, s, f); !bytes.Equal(again, data) {
+				t.Errorf("exporting the import: got\n%s\nwant\n%s", again, data)
+			}
+			created, err := s.Create(tenant.Scope{TenantId: "a"}, &pb.Item{ItemName: proto.String("new"), ItemType: proto.String("disk")})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if created.GetItemId() <= 5 {
+				t.Errorf("got itemId %d after importing 5 items, want a new one", created.GetItemId())
+			}
+		})
+	}
+}
+
+func TestImportModes(t *testing.T) {
+	data := export(t, seed(t), JSONLines)
+	head := strings.Replace(string(data[:bytes.IndexByte(data, '\n')]), `"a-disk"`, `"a-renamed"`, 1)
+	tests := []struct {
+		mode  Mode
+		want  Result
+		names []string
+	}{
+		{Upsert, Result{Items: 5, Associations: 4}, []string{"a-disk", "a-vm", "b-disk", "b-vm", "c-kept"}},
+		{Replace, Result{Items: 5, Associations: 4, Deleted: 2}, []string{"a-disk", "a-vm", "b-disk", "b-vm"}},
+		{DryRun, Result{Items: 5, Associations: 4}, []string{"a-renamed", "c-kept"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			s := store.NewItemStore()
+			defer s.Close()
+			// The store holds an item of the snapshot under another name,
+			// with an association the snapshot lacks, and an item the
+			// snapshot lacks.
+			if _, err := Import(strings.NewReader(head), s, JSONLines, Upsert); err != nil {
+				t.Fatal(err)
+			}
+			a := tenant.Scope{TenantId: "a"}
+			renamed, err := s.List(a, store.Query{})
+			if err != nil || len(renamed) != 1 {
+				t.Fatalf("got %v, %v, want the renamed item", renamed, err)
+			}
+			if _, err := s.PutAssociation(a, &pb.ItemAssociation{ItemId: renamed[0].ExtId, EntityType: proto.String("vm"), EntityId: proto.String("9")}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Create(tenant.Scope{TenantId: "c"}, &pb.Item{ItemName: proto.String("c-kept"), ItemType: proto.String("vm")}); err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := Import(bytes.NewReader(data), s, JSONLines, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res != tt.want {
+				t.Errorf("got %+v, want %+v", res, tt.want)
+			}
+			var names []string
+			for _, it := range listAll(t, s) {
+				if it.DeletedAt == nil {
+					names = append(names, it.GetItemName())
+				}
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, tt.names) {
+				t.Errorf("got items %v, want %v", names, tt.names)
+			}
+			assocs, err := s.ListAssociations(a, renamed[0].GetExtId())
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Upserts keep the associations of the items they overwrite,
+			// replacing those the snapshot holds.
+			wantAssocs := map[Mode]int{Upsert: 3, Replace: 2, DryRun: 1}[tt.mode]
+			if len(assocs) != wantAssocs {
+				t.Errorf("got %d associations of the item, want %d", len(assocs), wantAssocs)
+			}
+		})
+	}
+	if _, err := Import(bytes.NewReader(data), store.NewItemStore(), JSONLines, "merge"); err == nil {
+		t.Error("importing in an unknown mode: got no error")
+	}
+	if _, err := Import(bytes.NewReader(data), store.NewItemStore(), "xml", Upsert); err == nil {
+		t.Error("importing an unknown format: got no error")
+	}
+}
+
+// listAll returns every item of s, deleted ones included, sorted by name.
+func listAll(t *testing.T, s *store.ItemStore) []*pb.Item {
+	t.Helper()
+	var items []*pb.Item
+	err := s.Txn(allTenants, func(tx *store.Txn) error {
+		return tx.Items(func(it *pb.Item) bool {
+			items = append(items, it)
+			return true
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.SortFunc(items, func(a, b *pb.Item) int { return strings.Compare(a.GetItemName(), b.GetItemName()) })
+	return items
+}
+
+// violation is a message of an ImportError.
+type violation struct {
+	location, path, message string
+}
+
+func violations(t *testing.T, err error) []violation {
+	t.Helper()
+	var ierr *ImportError
+	if !errors.As(err, &ierr) {
+		t.Fatalf("got %v, want an *ImportError", err)
+	}
+	var got []violation
+	for _, m := range ierr.Details.GetValidationErrorMessages().GetValue() {
+		got = append(got, violation{m.GetLocation(), m.GetAttributePath(), m.GetMessage()})
+	}
+	return got
+}
+
+func TestImportInvalid(t *testing.T) {
+	const extId = "4c6a1b4e-0000-4000-8000-000000000001"
+	lines := []string{
+		`{"$objectType": "nexus.v4.config.Item", "extId": "` + extId + `", "itemName": "disk", "itemType": "disk", "tenantInfo": {"tenantId": "a"}}`,
+		``,
+		`{"$objectType": "nexus.v4.config.ItemAssociation", "itemId": "` + extId + `", "entityType": "vm", "entityId": "1"}`,
+		`{"$objectType": "nexus.v4.config.Vm"}`,
+		`{"$objectType": "nexus.v4.config.Item", "itemType": "disk", "colour": "red"}`,
+		`{"$objectType": "nexus.v4.config.Item", "extId": "` + extId + `", "itemName": "copy", "itemType": "disk", "tenantInfo": {"tenantId": "a"}}`,
+		`{"$objectType": "nexus.v4.config.Item", "itemName": 5}`,
+		`{"$objectType": "nexus.v4.config.ItemAssociation", "itemId": "4c6a1b4e-0000-4000-8000-000000000002", "entityType": "vm", "entityId": "1"}`,
+		`{"$objectType": "nexus.v4.config.ItemAssociation", "itemId": "` + extId + `", "entityId": "2"}`,
+	}
+	want := []violation{
+		{"line 4", "$objectType", "must be nexus.v4.config.Item or nexus.v4.config.ItemAssociation"},
+		{"line 5", "colour", "is not a known property"},
+		{"line 5", "itemName", "is required"},
+		{"line 5", "extId", "is required"},
+		{"line 5", "tenantInfo/tenantId", "is required"},
+		{"line 6", "extId", "duplicates the item of line 1"},
+		{"line 7", "itemName", "cannot be a JSON number"},
+		{"line 8", "itemId", "references no item"},
+		{"line 9", "entityType", "is required"},
+	}
+	for _, mode := range []Mode{Upsert, Replace, DryRun} {
+		s := seed(t)
+		before := export(t, s, JSONLines)
+		_, err := Import(strings.NewReader(strings.Join(lines, "\n")), s, JSONLines, mode)
+		if got := violations(t, err); !slices.Equal(got, want) {
+			t.Errorf("%s: got %v, want %v", mode, got, want)
+		}
+		if after := export(t, s, JSONLines); !bytes.Equal(after, before) {
+			t.Errorf("%s: the store changed on a failed import", mode)
+		}
+	}
+
+	_, err := Import(strings.NewReader("[1]\n"), store.NewItemStore(), JSONLines, Upsert)
+	if got := violations(t, err); len(got) != 1 || got[0].location != "line 1" || !strings.HasPrefix(got[0].message, "is not a JSON object") {
+		t.Errorf("importing a JSON array: got %v", got)
+	}
+}
+
+func TestImportProtobuf(t *testing.T) {
+	var buf bytes.Buffer
+	a, err := anypb.New(&emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := protodelim.MarshalTo(&buf, a); err != nil {
+		t.Fatal(err)
+	}
+	_, err = Import(bytes.NewReader(buf.Bytes()), store.NewItemStore(), Protobuf, Upsert)
+	want := []violation{{"record 1", "", "holds a google.protobuf.Empty rather than a nexus.v4.config.Item or nexus.v4.config.ItemAssociation"}}
+	if got := violations(t, err); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Records cut short cannot be framed, so they are not reported as
+	// invalid records.
+	data := export(t, seed(t), Protobuf)
+	_, err = Import(bytes.NewReader(data[:len(data)-1]), store.NewItemStore(), Protobuf, Upsert)
+	var ierr *ImportError
+	if err == nil || errors.As(err, &ierr) || !strings.HasPrefix(err.Error(), "record 9: ") {
+		t.Errorf("importing a truncated snapshot: got %v, want an error framing record 9", err)
+	}
+}
@@ -0,0 +1,203 @@
+package store
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// RetentionError is returned by AsOf for times older than the history of
+// items is kept.
+type RetentionError struct {
+	Retention time.Duration
+}
+
+func (e *RetentionError) Error() string {
+	return fmt.Sprintf("the history of items is only kept for %s", e.Retention)
+}
+
+// SetHistoryRetention sets how long the changes recorded in the history of
+// items are kept, and so how far back AsOf reads and how many changes it
+// reads to do so. Changes older than retention are dropped by PruneHistory
+// and SweepHistory. The history of a new store is kept forever, which a
+// retention of zero restores.
+func (s *ItemStore) SetHistoryRetention(retention time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retention = retention
+}
+
+// PruneHistory drops the changes made before the given time from the
+// history of items and returns how many were dropped. The last change is
+// always kept, so that the sequences of changes are never reused.
+func (s *ItemStore) PruneHistory(before time.Time) (pruned int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var upTo int64
+	err = s.backend.Changes(0, func(c *pb.ItemChange) bool {
+		if c.GetSequence() == s.lastSeq || !c.GetTime().AsTime().Before(before) {
+			return false
+		}
+		upTo = c.GetSequence()
+		pruned++
+		return true
+	})
+	if err != nil || pruned == 0 {
+		return 0, err
+	}
+	if err := s.backend.Commit([]Write{{DropChanges: upTo}}); err != nil {
+		return 0, err
+	}
+	s.pasts = nil
+	return pruned, nil
+}
+
+// SweepHistory drops the changes older than the history retention of the
+// store every interval until the returned function is called, passing
+// report the number of changes dropped, or the error that prevented
+// dropping them. Nothing is dropped while the history is kept forever.
+func (s *ItemStore) SweepHistory(interval time.Duration, report func(pruned int, err error)) (stop func()) {
+	return sweep(interval, report, func() (int, error) {
+		s.mu.RLock()
+		retention := s.retention
+		s.mu.RUnlock()
+		if retention <= 0 {
+			return 0, nil
+		}
+		return s.PruneHistory(time.Now().Add(-retention))
+	})
+}
+
+// asOfCacheSize is the number of stores AsOf keeps for reuse.
+const asOfCacheSize = 8
+
+// past is a store built by AsOf.
+type past struct {
+	scope tenant.Scope
+	at    time.Time
+	store *ItemStore
+}
+
+// AsOf returns a store holding the items visible in scope and their
+// associations as they were at the given time, which must be within the
+// history retention of s. The returned store is a snapshot independent of
+// s, meant to be read with the usual queries; it may be shared by other
+// calls and must not be written to.
+//
+// Every entity is taken in the state before the first change made to it
+// after that time, or as it is if it has not changed since. Entities last
+// changed before the history was recorded are thus taken as they are.
+//
+// Building a snapshot reads the whole history kept, whose length the
+// history retention bounds, and every entity of s, which it then indexes
+// anew; writes to s wait meanwhile. Snapshots are reused until s changes,
+// so that the pages of a listing as of the same time cost a single one.
+func (s *ItemStore) AsOf(scope tenant.Scope, at time.Time) (*ItemStore, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.retention > 0 && at.Before(time.Now().Add(-s.retention)) {
+		return nil, &RetentionError{Retention: s.retention}
+	}
+	s.pastsMu.Lock()
+	for _, p := range s.pasts {
+		if p.scope == scope && p.at.Equal(at) {
+			s.pastsMu.Unlock()
+			return p.store, nil
+		}
+	}
+	s.pastsMu.Unlock()
+	snapshot, err := s.asOf(scope, at)
+	if err != nil {
+		return nil, err
+	}
+	s.pastsMu.Lock()
+	defer s.pastsMu.Unlock()
+	if len(s.pasts) == asOfCacheSize {
+		s.pasts = slices.Delete(s.pasts, 0, 1)
+	}
+	s.pasts = append(s.pasts, past{scope: scope, at: at, store: snapshot})
+	return snapshot, nil
+}
+
+// asOf builds the snapshot AsOf returns. The caller must hold s.mu.
+func (s *ItemStore) asOf(scope tenant.Scope, at time.Time) (*ItemStore, error) {
+	type assocRef struct {
+		itemExtId string
+		key       assocKey
+	}
+	items := make(map[string]*pb.Item)
+	assocs := make(map[assocRef]*pb.ItemAssociation)
+	err := s.backend.Changes(0, func(c *pb.ItemChange) bool {
+		if !c.GetTime().AsTime().After(at) {
+			return true
+		}
+		if c.ItemBefore != nil || c.ItemAfter != nil {
+			if _, ok := items[c.GetItemExtId()]; !ok {
+				items[c.GetItemExtId()] = c.ItemBefore
+			}
+			return true
+		}
+		ref := assocRef{itemExtId: c.GetItemExtId(), key: assocKey{entityType: c.GetEntityType(), entityId: c.GetEntityId()}}
+		if _, ok := assocs[ref]; !ok {
+			assocs[ref] = c.AssociationBefore
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	var writes []Write
+	err = s.backend.Items(func(item *pb.Item) bool {
+		if _, changed := items[item.GetExtId()]; !changed && scope.Includes(tenantOf(item.GetTenantInfo())) {
+			writes = append(writes, Write{PutItem: item})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item != nil && scope.Includes(tenantOf(item.GetTenantInfo())) {
+			writes = append(writes, Write{PutItem: item})
+		}
+	}
+	var list []*pb.ItemAssociation
+	err = s.backend.AllAssociations(func(a *pb.ItemAssociation) bool {
+		if _, changed := assocs[assocRef{itemExtId: a.GetItemId(), key: keyOf(a)}]; !changed {
+			list = append(list, a)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assocs {
+		if a != nil {
+			list = append(list, a)
+		}
+	}
+	// Associations are put in a fixed order, which the snapshot lists
+	// them in.
+	slices.SortFunc(list, func(a, b *pb.ItemAssociation) int {
+		return cmp.Or(
+			cmp.Compare(a.GetItemId(), b.GetItemId()),
+			cmp.Compare(a.GetEntityType(), b.GetEntityType()),
+			cmp.Compare(a.GetEntityId(), b.GetEntityId()),
+		)
+	})
+	for _, a := range list {
+		if scope.Includes(tenantOf(a.GetTenantInfo())) {
+			writes = append(writes, Write{PutAssociation: a})
+		}
+	}
+	b := newMemoryBackend()
+	if err := b.Commit(writes); err != nil {
+		return nil, err
+	}
+	return New(b)
+}
@@ -0,0 +1,248 @@
+package store
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// instant returns the current time, making sure that it differs from the
+// times of the changes made just before and after it.
+func instant() time.Time {
+	time.Sleep(time.Millisecond)
+	defer time.Sleep(time.Millisecond)
+	return time.Now()
+}
+
+func TestAsOf(t *testing.T) {
+	s := NewItemStore()
+	defer s.Close()
+	scope := tenant.Scope{TenantId: "a"}
+	create := func(scope tenant.Scope, name string) *pb.Item {
+		t.Helper()
+		it, err := s.Create(scope, &pb.Item{ItemName: proto.String(name), ItemType: proto.String("disk")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return it
+	}
+	first := create(scope, "first")
+	create(tenant.Scope{TenantId: "b"}, "other tenant")
+	t1 := instant()
+	if _, err := s.Update(scope, first.GetExtId(), func(it *pb.Item) (*pb.Item, error) {
+		it.ItemName = proto.String("renamed")
+		return it, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	second := create(scope, "second")
+	assoc := &pb.ItemAssociation{ItemId: first.ExtId, EntityType: proto.String("vm"), EntityId: proto.String("vm-1")}
+	if _, err := s.PutAssociation(scope, assoc); err != nil {
+		t.Fatal(err)
+	}
+	t2 := instant()
+	if err := s.Delete(scope, second.GetExtId()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Txn(scope, func(tx *Txn) error {
+		return tx.DeleteAssociation(first.GetExtId(), "vm", "vm-1")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t3 := instant()
+
+	tests := []struct {
+		name   string
+		at     time.Time
+		items  []string
+		assocs int
+	}{
+		{"before the first change", t1.Add(-time.Hour), nil, 0},
+		{"after creating", t1, []string{"first"}, 0},
+		{"after updating", t2, []string{"renamed", "second"}, 1},
+		{"after deleting", t3, []string{"renamed"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			past, err := s.AsOf(scope, tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := names(t, past, tenant.Scope{AllTenants: true}, Query{}); !slices.Equal(got, tt.items) {
+				t.Errorf("items = %v, want %v", got, tt.items)
+			}
+			if tt.items == nil {
+				return
+			}
+			assocs, err := past.ListAssociations(scope, first.GetExtId())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(assocs) != tt.assocs {
+				t.Errorf("got %d associations, want %d", len(assocs), tt.assocs)
+			}
+		})
+	}
+	// A past is confined to the scope it was read in, whatever scope it is
+	// then listed in.
+	past, err := s.AsOf(tenant.Scope{TenantId: "b"}, t1.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(t, past, tenant.Scope{AllTenants: true}, Query{}); len(got) != 0 {
+		t.Errorf("before any change tenant b had %v", got)
+	}
+	if past, err = s.AsOf(tenant.Scope{TenantId: "b"}, t3); err != nil {
+		t.Fatal(err)
+	}
+	if got := names(t, past, tenant.Scope{AllTenants: true}, Query{}); !slices.Equal(got, []string{"other tenant"}) {
+		t.Errorf("tenant b had %v, want only its own item", got)
+	}
+
+	s.SetHistoryRetention(time.Minute)
+	var rerr *RetentionError
+	if _, err := s.AsOf(scope, time.Now().Add(-time.Hour)); !errors.As(err, &rerr) {
+		t.Errorf("reading beyond the retention: got %v, want a *RetentionError", err)
+	}
+}
+
+func TestAsOfReuse(t *testing.T) {
+	s := NewItemStore()
+	defer s.Close()
+	scope := tenant.Scope{TenantId: "a"}
+	if _, err := s.Create(scope, &pb.Item{ItemName: proto.String("first")}); err != nil {
+		t.Fatal(err)
+	}
+	at := instant()
+	past, err := s.AsOf(scope, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := s.AsOf(scope, at); err != nil || again != past {
+		t.Errorf("AsOf built another snapshot for the same time: %v", err)
+	}
+	if other, err := s.AsOf(tenant.Scope{TenantId: "b"}, at); err != nil || other == past {
+		t.Errorf("AsOf reused the snapshot of another scope: %v", err)
+	}
+	// Snapshots are dropped on every write, even one that leaves the past
+	// they hold as it was.
+	if _, err := s.Create(scope, &pb.Item{ItemName: proto.String("second")}); err != nil {
+		t.Fatal(err)
+	}
+	rebuilt, err := s.AsOf(scope, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rebuilt == past {
+		t.Error("AsOf reused a snapshot after the store changed")
+	}
+	if got := names(t, rebuilt, scope, Query{}); !slices.Equal(got, []string{"first"}) {
+		t.Errorf("items = %v, want [first]", got)
+	}
+	if got := names(t, s, scope, Query{}); !slices.Equal(got, []string{"first", "second"}) {
+		t.Errorf("the live store holds %v, want [first second]", got)
+	}
+	for i := range asOfCacheSize + 1 {
+		if _, err := s.AsOf(scope, at.Add(time.Duration(i+1)*time.Nanosecond)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(s.pasts) != asOfCacheSize {
+		t.Errorf("%d snapshots are kept, want %d", len(s.pasts), asOfCacheSize)
+	}
+}
+
+func TestPruneHistory(t *testing.T) {
+	for _, spec := range []string{"memory", "file:" + t.TempDir()} {
+		t.Run(spec, func(t *testing.T) {
+			s, err := Open(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			scope := tenant.Scope{TenantId: "a"}
+			create := func(name string) {
+				t.Helper()
+				if _, err := s.Create(scope, &pb.Item{ItemName: proto.String(name)}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			sequences := func() []int64 {
+				t.Helper()
+				changes, err := s.History(scope, HistoryQuery{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				var got []int64
+				for _, c := range changes {
+					got = append(got, c.GetSequence())
+				}
+				return got
+			}
+			create("first")
+			create("second")
+			between := instant()
+			create("third")
+
+			if n, err := s.PruneHistory(between); err != nil || n != 2 {
+				t.Fatalf("PruneHistory = %d, %v, want 2", n, err)
+			}
+			if got := sequences(); !slices.Equal(got, []int64{3}) {
+				t.Errorf("got sequences %v, want [3]", got)
+			}
+			// The last change is kept, so that its sequence is not reused.
+			if n, err := s.PruneHistory(time.Now().Add(time.Hour)); err != nil || n != 0 {
+				t.Errorf("pruning the last change: got %d, %v, want 0", n, err)
+			}
+			create("fourth")
+			if got := sequences(); !slices.Equal(got, []int64{3, 4}) {
+				t.Errorf("got sequences %v, want [3 4]", got)
+			}
+			// The items are left as they are.
+			if got := names(t, s, scope, Query{}); len(got) != 4 {
+				t.Errorf("got items %v, want 4", got)
+			}
+
+			reports := make(chan int, 1)
+			report := func(pruned int, err error) {
+				if err != nil {
+					t.Error(err)
+				}
+				select {
+				case reports <- pruned:
+				default:
+				}
+			}
+			// Nothing is dropped while the history is kept forever.
+			stop := s.SweepHistory(time.Millisecond, report)
+			time.Sleep(10 * time.Millisecond)
+			stop()
+			select {
+			case n := <-reports:
+				t.Errorf("the sweep dropped %d changes without a retention", n)
+			default:
+			}
+			s.SetHistoryRetention(time.Millisecond)
+			stop = s.SweepHistory(time.Millisecond, report)
+			defer stop()
+			select {
+			case n := <-reports:
+				if n != 1 {
+					t.Errorf("the sweep dropped %d changes, want 1", n)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("the sweep dropped nothing")
+			}
+			stop()
+			if got := sequences(); !slices.Equal(got, []int64{4}) {
+				t.Errorf("got sequences %v after the sweep, want [4]", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package store
+
+import (
+	"sort"
+	"sync"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+)
+
+// Backend persists the items and item associations of an ItemStore. The
+// store serializes calls to Commit and never calls it concurrently with
+// reads; reads may run concurrently with each other.
+//
+// Entities returned by a Backend are not modified by the store, and those
+// passed to Commit are not modified after it returns, so that a Backend
+// holding them in memory need not copy them.
+type Backend interface {
+	// Item returns the item with the given extId, or nil if there is none.
+	Item(extId string) (*pb.Item, error)
+	// Items calls fn with every item, in no particular order, until fn
+	// returns false.
+	Items(fn func(*pb.Item) bool) error
+	// Associations returns the associations of the item with the given
+	// extId, in an order of the backend's choosing that only changes when
+	// they are written.
+	Associations(itemExtId string) ([]*pb.ItemAssociation, error)
+	// AllAssociations calls fn with every association, whether its item
+	// exists or not, in no particular order, until fn returns false.
+	AllAssociations(fn func(*pb.ItemAssociation) bool) error
+	// Changes calls fn with the recorded changes whose sequence is greater
+	// than after, in the order of their sequence, until fn returns false.
+	Changes(after int64, fn func(*pb.ItemChange) bool) error
+	// Commit applies writes atomically: after a crash either all or none
+	// of them are visible.
+	Commit(writes []Write) error
+	// Close releases the resources of the backend.
+	Close() error
+}
+
+// Write is a change committed to a Backend. Exactly one field is set.
+type Write struct {
+	// PutItem creates or replaces the item with its extId.
+	PutItem *pb.Item
+	// DeleteItem deletes the item with the given extId.
+	DeleteItem string
+	// PutAssociation creates or replaces the association of its item with
+	// its entityType and entityId.
+	PutAssociation *pb.ItemAssociation
+	// DeleteAssociation deletes the association of its item with its
+	// entityType and entityId.
+	DeleteAssociation *pb.ItemAssociation
+	// AppendChange records a change in the history of items. Changes are
+	// appended in the order of their sequence.
+	AppendChange *pb.ItemChange
+	// DropChanges drops the recorded changes whose sequence is not
+	// greater than it.
+	DropChanges int64
+}
+
+// assocKey identifies an association within the associations of an item.
+type assocKey struct {
+	entityType string
+	entityId   string
+}
+
+func keyOf(a *pb.ItemAssociation) assocKey {
+	return assocKey{entityType: a.GetEntityType(), entityId: a.GetEntityId()}
+}
+
+// memoryBackend keeps entities in maps. It is not durable.
+type memoryBackend struct {
+	mu           sync.RWMutex
+	items        map[string]*pb.Item
+	associations map[string][]*pb.ItemAssociation
+	// changes holds the recorded changes in the order of their sequence.
+	changes []*pb.ItemChange
+}
+
+// NewMemoryBackend returns an empty Backend holding its entities in memory.
+func NewMemoryBackend() Backend {
+	return newMemoryBackend()
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{
+		items:        make(map[string]*pb.Item),
+		associations: make(map[string][]*pb.ItemAssociation),
+	}
+}
+
+func (b *memoryBackend) Item(extId string) (*pb.Item, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.items[extId], nil
+}
+
+func (b *memoryBackend) Items(fn func(*pb.Item) bool) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, item := range b.items {
+		if !fn(item) {
+			break
+		}
+	}
+	return nil
+}
+
+func (b *memoryBackend) Associations(itemExtId string) ([]*pb.ItemAssociation, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.associations[itemExtId], nil
+}
+
+func (b *memoryBackend) AllAssociations(fn func(*pb.ItemAssociation) bool) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, list := range b.associations {
+		for _, a := range list {
+			if !fn(a) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func (b *memoryBackend) Changes(after int64, fn func(*pb.ItemChange) bool) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	i := sort.Search(len(b.changes), func(i int) bool { return b.changes[i].GetSequence() > after })
+	for _, c := range b.changes[i:] {
+		if !fn(c) {
+			break
+		}
+	}
+	return nil
+}
+
+func (b *memoryBackend) Commit(writes []Write) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, w := range writes {
+		b.apply(w)
+	}
+	return nil
+}
+
+// apply applies w. The caller must hold b.mu for writing.
+func (b *memoryBackend) apply(w Write) {
+	switch {
+	case w.PutItem != nil:
+		b.items[w.PutItem.GetExtId()] = w.PutItem
+	case w.DeleteItem != "":
+		delete(b.items, w.DeleteItem)
+	case w.PutAssociation != nil:
+		b.associations[w.PutAssociation.GetItemId()] = append(b.without(w.PutAssociation), w.PutAssociation)
+	case w.DeleteAssociation != nil:
+		list := b.without(w.DeleteAssociation)
+		if len(list) == 0 {
+			delete(b.associations, w.DeleteAssociation.GetItemId())
+		} else {
+			b.associations[w.DeleteAssociation.GetItemId()] = list
+		}
+	case w.AppendChange != nil:
+		// Changes replayed twice, as a file backend may after a crash,
+		// are only recorded once.
+		if n := len(b.changes); n == 0 || b.changes[n-1].GetSequence() < w.AppendChange.GetSequence() {
+			b.changes = append(b.changes, w.AppendChange)
+		}
+	case w.DropChanges > 0:
+		i := sort.Search(len(b.changes), func(i int) bool { return b.changes[i].GetSequence() > w.DropChanges })
+		// The kept changes are copied so that the dropped ones can be
+		// collected.
+		b.changes = append([]*pb.ItemChange(nil), b.changes[i:]...)
+	}
+}
+
+// without returns the associations of the item of a other than a itself. The
+// stored slice is never modified in place, as it may have been returned by
+// Associations.
+func (b *memoryBackend) without(a *pb.ItemAssociation) []*pb.ItemAssociation {
+	list := b.associations[a.GetItemId()]
+	for i, existing := range list {
+		if keyOf(existing) == keyOf(a) {
+			return append(list[:i:i], list[i+1:]...)
+		}
+	}
+	return list[:len(list):len(list)]
+}
+
+func (b *memoryBackend) Close() error {
+	return nil
+}
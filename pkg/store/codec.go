@@ -0,0 +1,159 @@
+package store
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// Operations of encoded writes.
+const (
+	opPutItem byte = iota + 1
+	opDeleteItem
+	opPutAssociation
+	opDeleteAssociation
+	opAppendChange
+	opDropChanges
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// errTornRecord reports a record cut short or corrupted, as left behind by
+// a crash in the middle of an append.
+var errTornRecord = errors.New("torn record")
+
+// encodeWrites encodes writes as a sequence of operations, each followed
+// by its length-delimited operand: the protobuf encoding of the entity or
+// change, the extId of a deleted item, or the varint of the sequence up to
+// which changes are dropped.
+func encodeWrites(writes []Write) ([]byte, error) {
+	var b []byte
+	for _, w := range writes {
+		var op byte
+		var operand []byte
+		var err error
+		switch {
+		case w.PutItem != nil:
+			op = opPutItem
+			operand, err = proto.Marshal(w.PutItem)
+		case w.DeleteItem != "":
+			op, operand = opDeleteItem, []byte(w.DeleteItem)
+		case w.PutAssociation != nil:
+			op = opPutAssociation
+			operand, err = proto.Marshal(w.PutAssociation)
+		case w.DeleteAssociation != nil:
+			op = opDeleteAssociation
+			operand, err = proto.Marshal(w.DeleteAssociation)
+		case w.AppendChange != nil:
+			op = opAppendChange
+			operand, err = proto.Marshal(w.AppendChange)
+		case w.DropChanges > 0:
+			op, operand = opDropChanges, protowire.AppendVarint(nil, uint64(w.DropChanges))
+		default:
+			return nil, errors.New("empty write")
+		}
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, op)
+		b = protowire.AppendBytes(b, operand)
+	}
+	return b, nil
+}
+
+// decodeWrites decodes the writes encoded by encodeWrites.
+func decodeWrites(b []byte) ([]Write, error) {
+	var writes []Write
+	for len(b) > 0 {
+		op := b[0]
+		operand, n := protowire.ConsumeBytes(b[1:])
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[1+n:]
+		var w Write
+		switch op {
+		case opPutItem:
+			w.PutItem = &pb.Item{}
+			if err := proto.Unmarshal(operand, w.PutItem); err != nil {
+				return nil, err
+			}
+		case opDeleteItem:
+			w.DeleteItem = string(operand)
+		case opPutAssociation, opDeleteAssociation:
+			a := &pb.ItemAssociation{}
+			if err := proto.Unmarshal(operand, a); err != nil {
+				return nil, err
+			}
+			if op == opPutAssociation {
+				w.PutAssociation = a
+			} else {
+				w.DeleteAssociation = a
+			}
+		case opAppendChange:
+			w.AppendChange = &pb.ItemChange{}
+			if err := proto.Unmarshal(operand, w.AppendChange); err != nil {
+				return nil, err
+			}
+		case opDropChanges:
+			sequence, n := protowire.ConsumeVarint(operand)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			w.DropChanges = int64(sequence)
+		default:
+			return nil, fmt.Errorf("unknown operation %d", op)
+		}
+		writes = append(writes, w)
+	}
+	return writes, nil
+}
+
+// appendRecord appends payload to b framed as a record: its length as a
+// varint, the payload and its CRC-32C.
+func appendRecord(b, payload []byte) []byte {
+	b = protowire.AppendVarint(b, uint64(len(payload)))
+	b = append(b, payload...)
+	return binary.LittleEndian.AppendUint32(b, crc32.Checksum(payload, crcTable))
+}
+
+// readRecords calls fn with the payload of every record in r, which holds
+// length bytes. It returns the number of bytes of the complete records
+// read, along with errTornRecord if the last record is incomplete or
+// corrupted. Records claiming to extend past the end of r are corrupted, so
+// that a corrupted length never makes it allocate more than r holds.
+func readRecords(r io.Reader, length int64, fn func(payload []byte) error) (int64, error) {
+	br := bufio.NewReader(r)
+	var good int64
+	for {
+		size, err := binary.ReadUvarint(br)
+		if err == io.EOF {
+			return good, nil
+		}
+		if err != nil {
+			return good, errTornRecord
+		}
+		if remaining := length - good - int64(protowire.SizeVarint(size)) - 4; remaining < 0 || size > uint64(remaining) {
+			return good, errTornRecord
+		}
+		record := make([]byte, size+4)
+		if _, err := io.ReadFull(br, record); err != nil {
+			return good, errTornRecord
+		}
+		payload := record[:size]
+		if crc32.Checksum(payload, crcTable) != binary.LittleEndian.Uint32(record[size:]) {
+			return good, errTornRecord
+		}
+		if err := fn(payload); err != nil {
+			return good, err
+		}
+		good += int64(protowire.SizeVarint(size)) + int64(size) + 4
+	}
+}
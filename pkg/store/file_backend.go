@@ -0,0 +1,236 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Files of a file backend within its directory.
+const (
+	snapshotFile = "snapshot"
+	logFile      = "log"
+)
+
+// compactEvery is the number of commits after which a file backend folds
+// its log into a new snapshot.
+const compactEvery = 1024
+
+// snapshotChanges is the number of recorded changes a file backend writes
+// per record of its snapshot.
+const snapshotChanges = 1024
+
+// fileBackend keeps its entities in memory and makes them durable in an
+// append-only log of commits. The log is periodically compacted into a
+// snapshot of all entities, from which the backend is reloaded along with
+// the commits logged since.
+type fileBackend struct {
+	*memoryBackend
+	dir     string
+	log     *os.File
+	commits int
+	// failed is the error that left the log in an unknown state, after
+	// which commits are refused.
+	failed error
+	// compactErr is the error of the last compaction, which is retried
+	// on every commit until it succeeds.
+	compactErr error
+}
+
+// OpenFileBackend opens the file backend in dir, creating it if needed.
+// A commit cut short by a crash is discarded.
+func OpenFileBackend(dir string) (Backend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	b := &fileBackend{memoryBackend: newMemoryBackend(), dir: dir}
+	if err := b.replay(snapshotFile, false); err != nil {
+		return nil, err
+	}
+	if err := b.replay(logFile, true); err != nil {
+		return nil, err
+	}
+	log, err := os.OpenFile(filepath.Join(dir, logFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	b.log = log
+	return b, nil
+}
+
+// replay applies the commits recorded in the named file. Unless the file
+// is the log, whose tail may have been torn by a crash and is then cut off,
+// it must be intact.
+func (b *fileBackend) replay(name string, isLog bool) error {
+	path := filepath.Join(b.dir, name)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	good, err := readRecords(f, info.Size(), func(payload []byte) error {
+		writes, err := decodeWrites(payload)
+		if err != nil {
+			return err
+		}
+		for _, w := range writes {
+			b.apply(w)
+		}
+		if isLog {
+			b.commits++
+		}
+		return nil
+	})
+	if errors.Is(err, errTornRecord) && isLog {
+		return os.Truncate(path, good)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Commit appends writes to the log. Should the append fail, the log is
+// truncated back to where it was, so that a partial record does not cut
+// off the commits logged after it when the log is replayed; should that
+// fail too, the backend refuses all further commits. Once the append
+// succeeds the commit is durable and Commit returns nil, even if the
+// compaction it triggers fails.
+func (b *fileBackend) Commit(writes []Write) error {
+	if b.failed != nil {
+		return fmt.Errorf("file backend failed: %w", b.failed)
+	}
+	payload, err := encodeWrites(writes)
+	if err != nil {
+		return err
+	}
+	info, err := b.log.Stat()
+	if err != nil {
+		return err
+	}
+	if err := b.append(appendRecord(nil, payload)); err != nil {
+		if terr := b.log.Truncate(info.Size()); terr != nil {
+			b.failed = err
+		} else if serr := b.log.Sync(); serr != nil {
+			b.failed = err
+		}
+		return err
+	}
+	if err := b.memoryBackend.Commit(writes); err != nil {
+		return err
+	}
+	b.commits++
+	if b.commits >= compactEvery {
+		// The log keeps growing until a later commit compacts it.
+		b.compactErr = b.compact()
+	}
+	return nil
+}
+
+// compact writes a snapshot of all entities and changes and empties the
+// log. A crash after the snapshot is in place but before the log is emptied
+// only makes the next open replay commits the snapshot already holds, which
+// is harmless as every write replaces or deletes an entity whole and
+// changes already recorded are skipped.
+func (b *fileBackend) compact() error {
+	b.mu.RLock()
+	var buf []byte
+	var err error
+	for extId, item := range b.items {
+		writes := []Write{{PutItem: item}}
+		for _, a := range b.associations[extId] {
+			writes = append(writes, Write{PutAssociation: a})
+		}
+		if buf, err = appendCommit(buf, writes); err != nil {
+			break
+		}
+	}
+	for extId, list := range b.associations {
+		if _, ok := b.items[extId]; ok || err != nil {
+			continue
+		}
+		// Associations outlive the items they belong to.
+		writes := make([]Write, len(list))
+		for i, a := range list {
+			writes[i] = Write{PutAssociation: a}
+		}
+		buf, err = appendCommit(buf, writes)
+	}
+	for i := 0; i < len(b.changes) && err == nil; i += snapshotChanges {
+		chunk := b.changes[i:min(i+snapshotChanges, len(b.changes))]
+		writes := make([]Write, len(chunk))
+		for j, c := range chunk {
+			writes[j] = Write{AppendChange: c}
+		}
+		buf, err = appendCommit(buf, writes)
+	}
+	b.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	if err := writeFileSync(filepath.Join(b.dir, snapshotFile), buf); err != nil {
+		return err
+	}
+	if err := b.log.Truncate(0); err != nil {
+		return err
+	}
+	b.commits = 0
+	return b.log.Sync()
+}
+
+// append writes record at the end of the log and syncs it.
+func (b *fileBackend) append(record []byte) error {
+	if _, err := b.log.Write(record); err != nil {
+		return err
+	}
+	return b.log.Sync()
+}
+
+func appendCommit(buf []byte, writes []Write) ([]byte, error) {
+	payload, err := encodeWrites(writes)
+	if err != nil {
+		return nil, err
+	}
+	return appendRecord(buf, payload), nil
+}
+
+// writeFileSync atomically replaces the file at path by data.
+func writeFileSync(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+func (b *fileBackend) Close() error {
+	return b.log.Close()
+}
@@ -0,0 +1,372 @@
+package store
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+func putItem(extId, name string) Write {
+	return Write{PutItem: &pb.Item{ExtId: proto.String(extId), ItemName: proto.String(name)}}
+}
+
+func appendChange(sequence int64) Write {
+	return Write{AppendChange: &pb.ItemChange{Sequence: proto.Int64(sequence)}}
+}
+
+// backendState returns the names of the items of b keyed by extId, and the
+// sequences of its changes.
+func backendState(t *testing.T, b Backend) (map[string]string, []int64) {
+	t.Helper()
+	items := make(map[string]string)
+	if err := b.Items(func(item *pb.Item) bool {
+		items[item.GetExtId()] = item.GetItemName()
+		return true
+	}); err != nil {
+		t.Fatalf("Items: %v", err)
+	}
+	var changes []int64
+	if err := b.Changes(0, func(c *pb.ItemChange) bool {
+		changes = append(changes, c.GetSequence())
+		return true
+	}); err != nil {
+		t.Fatalf("Changes: %v", err)
+	}
+	return items, changes
+}
+
+func checkState(t *testing.T, b Backend, wantItems map[string]string, wantChanges []int64) {
+	t.Helper()
+	items, changes := backendState(t, b)
+	if !maps.Equal(items, wantItems) {
+		t.Errorf("items = %v, want %v", items, wantItems)
+	}
+	if len(changes) != len(wantChanges) {
+		t.Errorf("changes = %v, want %v", changes, wantChanges)
+		return
+	}
+	for i := range changes {
+		if changes[i] != wantChanges[i] {
+			t.Errorf("changes = %v, want %v", changes, wantChanges)
+			return
+		}
+	}
+}
+
+func TestFileBackendRecovery(t *testing.T) {
+	tests := []struct {
+		name string
+		// damage alters the log at path, in which the last commit takes
+		// the bytes from start on.
+		damage      func(t *testing.T, path string, start int64)
+		wantItems   map[string]string
+		wantChanges []int64
+	}{
+		{
+			name:        "intact",
+			damage:      func(*testing.T, string, int64) {},
+			wantItems:   map[string]string{"a": "a2", "b": "b1"},
+			wantChanges: []int64{1, 2},
+		},
+		{
+			name: "torn in the middle of the last commit",
+			damage: func(t *testing.T, path string, start int64) {
+				if err := os.Truncate(path, start+3); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantItems:   map[string]string{"a": "a1"},
+			wantChanges: []int64{1},
+		},
+		{
+			name: "torn checksum",
+			damage: func(t *testing.T, path string, start int64) {
+				info, err := os.Stat(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Truncate(path, info.Size()-1); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantItems:   map[string]string{"a": "a1"},
+			wantChanges: []int64{1},
+		},
+		{
+			name: "corrupted commit",
+			damage: func(t *testing.T, path string, start int64) {
+				f, err := os.OpenFile(path, os.O_RDWR, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer f.Close()
+				if _, err := f.WriteAt([]byte{0xff}, start+4); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantItems:   map[string]string{"a": "a1"},
+			wantChanges: []int64{1},
+		},
+		{
+			name: "length claiming more than the log holds",
+			damage: func(t *testing.T, path string, start int64) {
+				f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer f.Close()
+				if _, err := f.Write(protowire.AppendVarint(nil, 1<<62)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantItems:   map[string]string{"a": "a2", "b": "b1"},
+			wantChanges: []int64{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			log := filepath.Join(dir, logFile)
+			b, err := OpenFileBackend(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Commit([]Write{putItem("a", "a1"), appendChange(1)}); err != nil {
+				t.Fatal(err)
+			}
+			info, err := os.Stat(log)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Commit([]Write{putItem("a", "a2"), putItem("b", "b1"), appendChange(2)}); err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Close(); err != nil {
+				t.Fatal(err)
+			}
+			tt.damage(t, log, info.Size())
+
+			b, err = OpenFileBackend(dir)
+			if err != nil {
+				t.Fatalf("reopening: %v", err)
+			}
+			checkState(t, b, tt.wantItems, tt.wantChanges)
+			// The discarded tail must not hide the commits that follow.
+			if err := b.Commit([]Write{putItem("c", "c1"), appendChange(3)}); err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Close(); err != nil {
+				t.Fatal(err)
+			}
+			b, err = OpenFileBackend(dir)
+			if err != nil {
+				t.Fatalf("reopening after committing: %v", err)
+			}
+			defer b.Close()
+			wantItems := maps.Clone(tt.wantItems)
+			wantItems["c"] = "c1"
+			checkState(t, b, wantItems, append(tt.wantChanges, 3))
+		})
+	}
+}
+
+func TestFileBackendCompaction(t *testing.T) {
+	tests := []struct {
+		name string
+		// crash, when set, restores the log as it was before compacting,
+		// as a crash between writing the snapshot and emptying the log
+		// leaves it.
+		crash bool
+	}{
+		{name: "completed"},
+		{name: "crashed before emptying the log", crash: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			log := filepath.Join(dir, logFile)
+			backend, err := OpenFileBackend(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b := backend.(*fileBackend)
+			commits := [][]Write{
+				{putItem("a", "a1"), appendChange(1)},
+				{putItem("b", "b1"), appendChange(2)},
+				{putItem("a", "a2"), appendChange(3)},
+				{{DeleteItem: "b"}, appendChange(4)},
+				{{DropChanges: 1}},
+			}
+			for _, writes := range commits {
+				if err := b.Commit(writes); err != nil {
+					t.Fatal(err)
+				}
+			}
+			logged, err := os.ReadFile(log)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := b.compact(); err != nil {
+				t.Fatalf("compact: %v", err)
+			}
+			if err := b.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if tt.crash {
+				if err := os.WriteFile(log, logged, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			backend, err = OpenFileBackend(dir)
+			if err != nil {
+				t.Fatalf("reopening: %v", err)
+			}
+			defer backend.Close()
+			wantItems := map[string]string{"a": "a2"}
+			checkState(t, backend, wantItems, []int64{2, 3, 4})
+			if err := backend.Commit([]Write{putItem("c", "c1"), appendChange(5)}); err != nil {
+				t.Fatal(err)
+			}
+			wantItems["c"] = "c1"
+			checkState(t, backend, wantItems, []int64{2, 3, 4, 5})
+		})
+	}
+}
+
+func TestFileBackendFailedCommit(t *testing.T) {
+	dir := t.TempDir()
+	backend, err := OpenFileBackend(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := backend.(*fileBackend)
+	if err := b.Commit([]Write{putItem("a", "a1")}); err != nil {
+		t.Fatal(err)
+	}
+	// With its log opened read-only, the backend can neither append to it
+	// nor truncate it back, so it must refuse further commits.
+	b.log.Close()
+	if b.log, err = os.Open(filepath.Join(dir, logFile)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Commit([]Write{putItem("b", "b1")}); err == nil {
+		t.Fatal("committing to a read-only log succeeded")
+	}
+	if b.failed == nil {
+		t.Fatal("the backend did not fail after the log could not be truncated back")
+	}
+	if err := b.Commit([]Write{putItem("c", "c1")}); err == nil {
+		t.Fatal("the failed backend accepted a commit")
+	}
+	checkState(t, b, map[string]string{"a": "a1"}, nil)
+
+	reopened, err := OpenFileBackend(dir)
+	if err != nil {
+		t.Fatalf("reopening: %v", err)
+	}
+	defer reopened.Close()
+	checkState(t, reopened, map[string]string{"a": "a1"}, nil)
+}
+
+func TestFileBackendFailedCompaction(t *testing.T) {
+	dir := t.TempDir()
+	backend, err := OpenFileBackend(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := backend.(*fileBackend)
+	b.commits = compactEvery - 1
+	// A directory in the way of the new snapshot makes compact fail.
+	tmp := filepath.Join(dir, snapshotFile+".tmp")
+	if err := os.Mkdir(tmp, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scope := tenant.Scope{TenantId: "t1"}
+	one, err := s.Create(scope, &pb.Item{ItemName: proto.String("one")})
+	if err != nil {
+		t.Fatalf("Create failed with its compaction: %v", err)
+	}
+	if b.compactErr == nil {
+		t.Fatal("compaction did not fail")
+	}
+	two, err := s.Create(scope, &pb.Item{ItemName: proto.String("two")})
+	if err != nil {
+		t.Fatalf("Create failed with its compaction: %v", err)
+	}
+	if one.GetItemId() == two.GetItemId() {
+		t.Errorf("both items got itemId %d", one.GetItemId())
+	}
+	// The indexes and the history of the store must cover both items.
+	filter, err := odata.ParseFilter("itemName eq 'one'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := odata.CheckFilter(filter, itemEntityType); err != nil {
+		t.Fatal(err)
+	}
+	if p := s.plan(Query{Filter: filter}, false); p.Index != "itemName" {
+		t.Fatalf("itemName eq 'one' is planned over %q, want the itemName index", p.Index)
+	}
+	items, err := s.List(scope, Query{Filter: filter})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].GetExtId() != one.GetExtId() {
+		t.Errorf("List(itemName eq 'one') = %v, want %s", items, one.GetExtId())
+	}
+	checkSequences := func(want ...int64) {
+		t.Helper()
+		changes, err := s.History(scope, HistoryQuery{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []int64
+		for _, c := range changes {
+			got = append(got, c.GetSequence())
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("history sequences = %v, want %v", got, want)
+		}
+	}
+	checkSequences(1, 2)
+
+	// The next commit retries the compaction.
+	if err := os.Remove(tmp); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create(scope, &pb.Item{ItemName: proto.String("three")}); err != nil {
+		t.Fatal(err)
+	}
+	if b.compactErr != nil || b.commits != 0 {
+		t.Errorf("after retrying the compaction, %d commits are logged and its error is %v", b.commits, b.compactErr)
+	}
+	checkSequences(1, 2, 3)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if backend, err = OpenFileBackend(dir); err != nil {
+		t.Fatalf("reopening: %v", err)
+	}
+	if s, err = New(backend); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	checkSequences(1, 2, 3)
+	if n, err := s.Count(scope, Query{}); err != nil || n != 3 {
+		t.Errorf("Count = %d, %v after reopening, want 3", n, err)
+	}
+}
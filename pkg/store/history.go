@@ -0,0 +1,281 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// Operations of the changes recorded in the history of items.
+const (
+	OpCreate  = "CREATE"
+	OpUpdate  = "UPDATE"
+	OpDelete  = "DELETE"
+	OpRestore = "RESTORE"
+	OpPurge   = "PURGE"
+)
+
+// Origin identifies who made the changes of a transaction, for the history
+// of items.
+type Origin struct {
+	// Actor names the user making the changes.
+	Actor string
+	// RequestId identifies the request making the changes.
+	RequestId string
+}
+
+// TxnFrom is Txn for changes made on behalf of origin. Every change the
+// transaction commits is recorded in the history of items along with
+// origin; those of Txn are recorded with an empty Origin.
+func (s *ItemStore) TxnFrom(scope tenant.Scope, origin Origin, fn func(tx *Txn) error) error {
+	return s.txn(scope, origin, fn)
+}
+
+// HistoryQuery selects changes from the history of items.
+type HistoryQuery struct {
+	// Filter selects the changes to return by their properties, such as
+	// actor and time. A nil Filter matches all changes.
+	Filter odata.Expr
+	// After skips the changes up to and including the one with this
+	// sequence.
+	After int64
+	// Limit caps the number of changes returned when positive.
+	Limit int
+}
+
+// History returns the changes made to the items visible in scope and their
+// associations that match q, in the order they were made.
+func (s *ItemStore) History(scope tenant.Scope, q HistoryQuery) ([]*pb.ItemChange, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var changes []*pb.ItemChange
+	var matchErr error
+	err := s.backend.Changes(q.After, func(c *pb.ItemChange) bool {
+		if !scope.Includes(tenantOf(c.GetTenantInfo())) {
+			return true
+		}
+		if q.Filter != nil {
+			ok, err := odata.Match(q.Filter, c.ProtoReflect())
+			if err != nil {
+				matchErr = &QueryError{Option: "$filter", Err: err}
+				return false
+			}
+			if !ok {
+				return true
+			}
+		}
+		changes = append(changes, proto.Clone(c).(*pb.ItemChange))
+		return q.Limit <= 0 || len(changes) < q.Limit
+	})
+	if err == nil {
+		err = matchErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// lastSequence returns the sequence of the last change recorded in b, or 0
+// if there is none.
+func lastSequence(b Backend) (int64, error) {
+	var last int64
+	err := b.Changes(0, func(c *pb.ItemChange) bool {
+		last = c.GetSequence()
+		return true
+	})
+	return last, err
+}
+
+// history returns the writes recording the changes the writes of tx make to
+// the entities of the store, numbered from the last sequence of the store
+// and timed at. Writes leaving an entity as it was are not recorded.
+func (tx *Txn) history(at time.Time) ([]Write, error) {
+	items := make(map[string]*pb.Item)
+	assocs := make(map[string]map[assocKey]*pb.ItemAssociation)
+	var writes []Write
+	record := func(c *pb.ItemChange) {
+		c.Sequence = proto.Int64(tx.s.lastSeq + int64(len(writes)) + 1)
+		c.Time = timestamppb.New(at)
+		if tx.origin.Actor != "" {
+			c.Actor = proto.String(tx.origin.Actor)
+		}
+		if tx.origin.RequestId != "" {
+			c.RequestId = proto.String(tx.origin.RequestId)
+		}
+		writes = append(writes, Write{AppendChange: c})
+	}
+	for _, w := range tx.writes {
+		switch {
+		case w.PutItem != nil || w.DeleteItem != "":
+			extId := w.DeleteItem
+			if w.PutItem != nil {
+				extId = w.PutItem.GetExtId()
+			}
+			before, ok := items[extId]
+			if !ok {
+				var err error
+				if before, err = tx.s.backend.Item(extId); err != nil {
+					return nil, err
+				}
+			}
+			items[extId] = w.PutItem
+			if c := itemChange(before, w.PutItem); c != nil {
+				record(c)
+			}
+		case w.PutAssociation != nil || w.DeleteAssociation != nil:
+			a := w.PutAssociation
+			if a == nil {
+				a = w.DeleteAssociation
+			}
+			written, ok := assocs[a.GetItemId()]
+			if !ok {
+				stored, err := tx.s.backend.Associations(a.GetItemId())
+				if err != nil {
+					return nil, err
+				}
+				written = make(map[assocKey]*pb.ItemAssociation, len(stored))
+				for _, s := range stored {
+					written[keyOf(s)] = s
+				}
+				assocs[a.GetItemId()] = written
+			}
+			before := written[keyOf(a)]
+			written[keyOf(a)] = w.PutAssociation
+			if c := associationChange(before, w.PutAssociation); c != nil {
+				record(c)
+			}
+		}
+	}
+	return writes, nil
+}
+
+// itemChange returns the change turning the item before into after, either
+// of which is nil when the item does not exist, or nil if they are equal.
+func itemChange(before, after *pb.Item) *pb.ItemChange {
+	if before == nil && after == nil || proto.Equal(before, after) {
+		return nil
+	}
+	c := &pb.ItemChange{ItemBefore: before, ItemAfter: after}
+	var op string
+	switch {
+	case before == nil:
+		op = OpCreate
+	case after == nil:
+		op = OpPurge
+	case before.DeletedAt == nil && after.DeletedAt != nil:
+		op = OpDelete
+	case before.DeletedAt != nil && after.DeletedAt == nil:
+		op = OpRestore
+	default:
+		op = OpUpdate
+	}
+	c.Operation = proto.String(op)
+	current := after
+	if current == nil {
+		current = before
+	}
+	c.ItemExtId = current.ExtId
+	c.TenantInfo = tenantInfoOf(current.GetTenantInfo())
+	c.Changes = diff(before, after)
+	return c
+}
+
+// associationChange is the counterpart of itemChange for associations.
+func associationChange(before, after *pb.ItemAssociation) *pb.ItemChange {
+	if before == nil && after == nil || proto.Equal(before, after) {
+		return nil
+	}
+	c := &pb.ItemChange{AssociationBefore: before, AssociationAfter: after}
+	current := after
+	switch {
+	case before == nil:
+		c.Operation = proto.String(OpCreate)
+	case after == nil:
+		c.Operation = proto.String(OpDelete)
+		current = before
+	default:
+		c.Operation = proto.String(OpUpdate)
+	}
+	c.ItemExtId = current.ItemId
+	c.EntityType = current.EntityType
+	c.EntityId = current.EntityId
+	c.TenantInfo = tenantInfoOf(current.GetTenantInfo())
+	c.Changes = diff(before, after)
+	return c
+}
+
+func tenantInfoOf(t *commonpb.TenantAwareModel) *commonpb.TenantAwareModel {
+	if t == nil {
+		return nil
+	}
+	return proto.Clone(t).(*commonpb.TenantAwareModel)
+}
+
+// diff returns the properties whose JSON encoding differs between the
+// entities before and after, either of which may be nil, ordered by name.
+func diff(before, after proto.Message) *pb.PropertyChangeArrayWrapper {
+	b, a := jsonProperties(before), jsonProperties(after)
+	names := make([]string, 0, len(b)+len(a))
+	for name := range b {
+		names = append(names, name)
+	}
+	for name := range a {
+		if _, ok := b[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	changes := &pb.PropertyChangeArrayWrapper{}
+	for _, name := range names {
+		bv, bok := b[name]
+		av, aok := a[name]
+		if bok && aok && bv == av {
+			continue
+		}
+		pc := &pb.PropertyChange{Path: proto.String(name)}
+		if bok {
+			pc.Before = proto.String(bv)
+		}
+		if aok {
+			pc.After = proto.String(av)
+		}
+		changes.Value = append(changes.Value, pc)
+	}
+	return changes
+}
+
+// jsonProperties returns the compact JSON encoding of the properties set on
+// m, keyed by their JSON name.
+func jsonProperties(m proto.Message) map[string]string {
+	if !m.ProtoReflect().IsValid() {
+		return nil
+	}
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil
+	}
+	props := make(map[string]string, len(raw))
+	for name, v := range raw {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, v); err != nil {
+			continue
+		}
+		props[name] = buf.String()
+	}
+	return props
+}
@@ -0,0 +1,214 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// describe returns the sequence, actor, operation and entity of each of
+// changes, such as "3 alice UPDATE item" or "4 - CREATE vm/1".
+func describe(changes []*pb.ItemChange) []string {
+	var got []string
+	for _, c := range changes {
+		actor, entity := c.GetActor(), "item"
+		if actor == "" {
+			actor = "-"
+		}
+		if c.EntityType != nil {
+			entity = c.GetEntityType() + "/" + c.GetEntityId()
+		}
+		got = append(got, fmt.Sprintf("%d %s %s %s", c.GetSequence(), actor, c.GetOperation(), entity))
+	}
+	return got
+}
+
+// historyFilter parses filter for a HistoryQuery.
+func historyFilter(t *testing.T, filter string) odata.Expr {
+	t.Helper()
+	e, err := odata.ParseFilter(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestHistory(t *testing.T) {
+	s := NewItemStore()
+	defer s.Close()
+	s.SetDeletePolicy(Cascade)
+	a := tenant.Scope{TenantId: "a"}
+	alice := Origin{Actor: "alice", RequestId: "req-1"}
+	var extId string
+	err := s.TxnFrom(a, alice, func(tx *Txn) error {
+		it, err := tx.Create(&pb.Item{ItemName: proto.String("disk"), ItemType: proto.String("disk")})
+		if err != nil {
+			return err
+		}
+		extId = it.GetExtId()
+		_, err = tx.PutAssociation(&pb.ItemAssociation{ItemId: it.ExtId, EntityType: proto.String("vm"), EntityId: proto.String("1"), Count: proto.Int32(1)})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create(tenant.Scope{TenantId: "b"}, &pb.Item{ItemName: proto.String("other")}); err != nil {
+		t.Fatal(err)
+	}
+	between := instant()
+	bob := Origin{Actor: "bob"}
+	err = s.TxnFrom(a, bob, func(tx *Txn) error {
+		rename := func(it *pb.Item) (*pb.Item, error) {
+			it.ItemName = proto.String("renamed")
+			return it, nil
+		}
+		if _, err := tx.Update(extId, rename); err != nil {
+			return err
+		}
+		// Writes leaving an entity as it was are not recorded.
+		if _, err := tx.Update(extId, rename); err != nil {
+			return err
+		}
+		_, err := tx.PutAssociation(&pb.ItemAssociation{ItemId: proto.String(extId), EntityType: proto.String("vm"), EntityId: proto.String("1"), Count: proto.Int32(4)})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(a, extId); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Restore(a, extId); err != nil {
+		t.Fatal(err)
+	}
+	err = s.Txn(a, func(tx *Txn) error {
+		if err := tx.Delete(extId); err != nil {
+			return err
+		}
+		return tx.Purge(extId)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := s.History(a, HistoryQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"1 alice CREATE item",
+		"2 alice CREATE vm/1",
+		"4 bob UPDATE item",
+		"5 bob UPDATE vm/1",
+		"6 - DELETE item",
+		"7 - RESTORE item",
+		"8 - DELETE item",
+		"9 - DELETE vm/1",
+		"10 - PURGE item",
+	}
+	if got := describe(changes); !slices.Equal(got, want) {
+		t.Fatalf("got history %v, want %v", got, want)
+	}
+	for _, c := range changes {
+		if c.GetItemExtId() != extId || c.GetTenantInfo().GetTenantId() != "a" {
+			t.Errorf("change %d is of item %s of tenant %s, want %s of a", c.GetSequence(), c.GetItemExtId(), c.GetTenantInfo().GetTenantId(), extId)
+		}
+	}
+	if c := changes[0]; c.GetRequestId() != "req-1" || c.ItemBefore != nil || c.GetItemAfter().GetItemName() != "disk" {
+		t.Errorf("got creation %v, want the item created by req-1", c)
+	}
+	if c := changes[2]; c.RequestId != nil || c.GetItemBefore().GetItemName() != "disk" || c.GetItemAfter().GetItemName() != "renamed" {
+		t.Errorf("got update %v, want the item before and after renaming it", c)
+	}
+	if c := changes[8]; c.GetItemBefore().DeletedAt == nil || c.ItemAfter != nil {
+		t.Errorf("got purge %v, want the deleted item before and nothing after", c)
+	}
+
+	// Changes list the properties that differ, by name, in compact JSON.
+	diffs := func(c *pb.ItemChange) []string {
+		var got []string
+		for _, pc := range c.GetChanges().GetValue() {
+			got = append(got, fmt.Sprintf("%s: %s -> %s", pc.GetPath(), pc.GetBefore(), pc.GetAfter()))
+		}
+		return got
+	}
+	if got, want := diffs(changes[2]), []string{`itemName: "disk" -> "renamed"`}; !slices.Equal(got, want) {
+		t.Errorf("got changes %v of the update, want %v", got, want)
+	}
+	if got, want := diffs(changes[3]), []string{"count: 1 -> 4"}; !slices.Equal(got, want) {
+		t.Errorf("got changes %v of the association, want %v", got, want)
+	}
+	for _, c := range changes[1].GetChanges().GetValue() {
+		if c.Before != nil || c.After == nil {
+			t.Errorf("got change %v creating the association, want nothing before", c)
+		}
+	}
+	for _, c := range changes[7].GetChanges().GetValue() {
+		if c.Before == nil || c.After != nil {
+			t.Errorf("got change %v deleting the association, want nothing after", c)
+		}
+	}
+	if got := changes[5].GetChanges().GetValue(); len(got) != 1 || got[0].GetPath() != "deletedAt" || got[0].Before == nil || got[0].After != nil {
+		t.Errorf("got changes %v restoring the item, want deletedAt unset", got)
+	}
+
+	tests := []struct {
+		name string
+		q    HistoryQuery
+		want []int64
+	}{
+		{"after", HistoryQuery{After: 5}, []int64{6, 7, 8, 9, 10}},
+		{"limit", HistoryQuery{Limit: 2}, []int64{1, 2}},
+		{"after and limit", HistoryQuery{After: 2, Limit: 3}, []int64{4, 5, 6}},
+		{"after the last", HistoryQuery{After: 10}, nil},
+		{"actor", HistoryQuery{Filter: historyFilter(t, "actor eq 'bob'")}, []int64{4, 5}},
+		{"no actor", HistoryQuery{Filter: historyFilter(t, "actor eq null")}, []int64{6, 7, 8, 9, 10}},
+		{"request", HistoryQuery{Filter: historyFilter(t, "requestId eq 'req-1'")}, []int64{1, 2}},
+		{"operation", HistoryQuery{Filter: historyFilter(t, "operation in ('RESTORE', 'PURGE')")}, []int64{7, 10}},
+		{"entity", HistoryQuery{Filter: historyFilter(t, "entityType eq 'vm' and operation ne 'CREATE'")}, []int64{5, 9}},
+		{"time", HistoryQuery{Filter: historyFilter(t, "time lt "+between.UTC().Format(time.RFC3339Nano))}, []int64{1, 2}},
+		{"filter and limit", HistoryQuery{Filter: historyFilter(t, "entityType eq null"), After: 1, Limit: 2}, []int64{4, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := s.History(a, tt.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []int64
+			for _, c := range changes {
+				got = append(got, c.GetSequence())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got sequences %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Tenants see the changes of their own items alone.
+	if changes, err := s.History(tenant.Scope{TenantId: "b"}, HistoryQuery{}); err != nil || !slices.Equal(describe(changes), []string{"3 - CREATE item"}) {
+		t.Errorf("got history %v, %v of tenant b, want the creation of its item", describe(changes), err)
+	}
+	if changes, err := s.History(tenant.Scope{AllTenants: true}, HistoryQuery{}); err != nil || len(changes) != 10 {
+		t.Errorf("got %d changes, %v across tenants, want 10", len(changes), err)
+	}
+
+	// Changes are copies.
+	changes[0].Actor = proto.String("mallory")
+	if again, err := s.History(a, HistoryQuery{Limit: 1}); err != nil || again[0].GetActor() != "alice" {
+		t.Errorf("got %v, %v after changing a returned change", again, err)
+	}
+
+	var qerr *QueryError
+	if _, err := s.History(a, HistoryQuery{Filter: historyFilter(t, "length(actor) eq 'x'")}); !errors.As(err, &qerr) {
+		t.Errorf("matching an ill-typed filter: got %v, want a *QueryError", err)
+	}
+}
@@ -0,0 +1,236 @@
+package store
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+
+	edmconfig "github.com/nutanix/ntnx-api-golang-mock-pc/generated-code/edm/nexus/v4/config"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+)
+
+// itemEntityType describes the properties of items the store indexes.
+var itemEntityType = odata.EntityTypeFromBinding(edmconfig.NewItem())
+
+// indexEntry is the entry of an item in an index.
+type indexEntry struct {
+	value  interface{}
+	itemId int32
+	extId  string
+}
+
+// index is a secondary index of the items of a store on one of their
+// properties. Its entries are ordered by value, nulls first, then by itemId
+// and extId, so that walking it lists items in the order List sorts them
+// by the property in.
+type index struct {
+	path *odata.Path
+	// kind is the kind of literal the values of the property compare with:
+	// string, bool or number.
+	kind    string
+	entries []indexEntry
+}
+
+// newIndexes returns empty indexes on every primitive property of t that
+// is filterable or sortable and resolves in items, keyed by path.
+func newIndexes(t *odata.EntityType) map[string]*index {
+	indexes := make(map[string]*index)
+	for _, paths := range [][]string{t.FilterablePaths(), t.SortablePaths()} {
+		for _, path := range paths {
+			if _, ok := indexes[path]; ok {
+				continue
+			}
+			props, err := t.Lookup(strings.Split(path, "/"))
+			if err != nil {
+				continue
+			}
+			kind := literalKind(props[len(props)-1].Type)
+			p := &odata.Path{Segments: strings.Split(path, "/")}
+			if _, err := odata.Value(p, (&pb.Item{}).ProtoReflect()); kind == "" || err != nil {
+				continue
+			}
+			indexes[path] = &index{path: p, kind: kind}
+		}
+	}
+	return indexes
+}
+
+// literalKind returns the kind of literal values of the Edm primitive type
+// typ compare with, or "" if values of typ are not indexed.
+func literalKind(typ string) string {
+	switch typ {
+	case string(edm.EdmString):
+		return "string"
+	case string(edm.EdmBoolean):
+		return "bool"
+	case string(edm.EdmInt32), string(edm.EdmInt64), "Edm.Byte", "Edm.SByte", "Edm.Int16", "Edm.Single", "Edm.Double", "Edm.Decimal":
+		return "number"
+	}
+	return ""
+}
+
+// accepts reports whether the literal value v compares with the values of
+// x, so that looking it up in x agrees with evaluating the filter.
+func (x *index) accepts(v interface{}) bool {
+	switch v.(type) {
+	case string:
+		return x.kind == "string"
+	case bool:
+		return x.kind == "bool"
+	case int64, float64:
+		return x.kind == "number"
+	}
+	return false
+}
+
+// entryOf returns the entry of item in x. The path of x is known to resolve
+// in items, see newIndexes.
+func (x *index) entryOf(item *pb.Item) indexEntry {
+	v, _ := odata.Value(x.path, item.ProtoReflect())
+	return indexEntry{value: v, itemId: item.GetItemId(), extId: item.GetExtId()}
+}
+
+func compareEntries(a, b indexEntry) int {
+	if c := odata.Compare(a.value, b.value); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.itemId, b.itemId); c != 0 {
+		return c
+	}
+	return strings.Compare(a.extId, b.extId)
+}
+
+func (x *index) insert(item *pb.Item) {
+	e := x.entryOf(item)
+	i, _ := slices.BinarySearchFunc(x.entries, e, compareEntries)
+	x.entries = slices.Insert(x.entries, i, e)
+}
+
+func (x *index) remove(item *pb.Item) {
+	if i, ok := slices.BinarySearchFunc(x.entries, x.entryOf(item), compareEntries); ok {
+		x.entries = slices.Delete(x.entries, i, i+1)
+	}
+}
+
+// keyRange is a range of the values of an index. Null values are only in
+// the range of eq null.
+type keyRange struct {
+	// lo and hi bound the range, unless nil; loOpen and hiOpen exclude
+	// them.
+	lo, hi         interface{}
+	loOpen, hiOpen bool
+	// prefix, if hasPrefix, starts every value of the range.
+	prefix    string
+	hasPrefix bool
+	null      bool
+	empty     bool
+}
+
+// point reports whether r holds a single value.
+func (r *keyRange) point() bool {
+	return r.null || r.lo != nil && r.hi != nil && odata.Compare(r.lo, r.hi) == 0
+}
+
+// raise raises the lower bound of r to v.
+func (r *keyRange) raise(v interface{}, open bool) {
+	if r.lo == nil {
+		r.lo, r.loOpen = v, open
+		return
+	}
+	if c := odata.Compare(v, r.lo); c > 0 || c == 0 && open {
+		r.lo, r.loOpen = v, open
+	}
+}
+
+// lower lowers the upper bound of r to v.
+func (r *keyRange) lower(v interface{}, open bool) {
+	if r.hi == nil {
+		r.hi, r.hiOpen = v, open
+		return
+	}
+	if c := odata.Compare(v, r.hi); c < 0 || c == 0 && open {
+		r.hi, r.hiOpen = v, open
+	}
+}
+
+// restrict restricts r to values with the given prefix.
+func (r *keyRange) restrict(prefix string) {
+	switch {
+	case !r.hasPrefix || strings.HasPrefix(prefix, r.prefix):
+		r.prefix, r.hasPrefix = prefix, true
+	case !strings.HasPrefix(r.prefix, prefix):
+		r.empty = true
+	}
+}
+
+// bounds returns the entries of x in r as a half-open interval.
+func (x *index) bounds(r keyRange) (int, int) {
+	entries := x.entries
+	nonNull := sort.Search(len(entries), func(i int) bool { return entries[i].value != nil })
+	if r.null {
+		if r.empty || r.lo != nil || r.hi != nil || r.hasPrefix {
+			return 0, 0
+		}
+		return 0, nonNull
+	}
+	if r.empty {
+		return 0, 0
+	}
+	i, j := nonNull, len(entries)
+	if r.lo != nil {
+		i = max(i, sort.Search(len(entries), func(k int) bool {
+			c := odata.Compare(entries[k].value, r.lo)
+			return c > 0 || c == 0 && !r.loOpen
+		}))
+	}
+	if r.hasPrefix {
+		i = max(i, sort.Search(len(entries), func(k int) bool {
+			return odata.Compare(entries[k].value, r.prefix) >= 0
+		}))
+	}
+	if r.hi != nil {
+		j = sort.Search(len(entries), func(k int) bool {
+			c := odata.Compare(entries[k].value, r.hi)
+			return c > 0 || c == 0 && r.hiOpen
+		})
+	}
+	if r.hasPrefix && i < j {
+		// Values with the prefix are the first ones not below it.
+		j = i + sort.Search(j-i, func(k int) bool {
+			s, _ := entries[i+k].value.(string)
+			return !strings.HasPrefix(s, r.prefix)
+		})
+	}
+	return i, max(i, j)
+}
+
+// walk calls fn with the extIds of the entries of x in [i, j) until fn
+// returns false. In reverse, entries with equal values are still listed by
+// itemId, so that walking x in reverse lists items in the order List sorts
+// them by the property in descending.
+func (x *index) walk(i, j int, reverse bool, fn func(extId string) bool) {
+	if !reverse {
+		for _, e := range x.entries[i:j] {
+			if !fn(e.extId) {
+				return
+			}
+		}
+		return
+	}
+	for end := j; end > i; {
+		start := end - 1
+		for start > i && odata.Compare(x.entries[start-1].value, x.entries[end-1].value) == 0 {
+			start--
+		}
+		for _, e := range x.entries[start:end] {
+			if !fn(e.extId) {
+				return
+			}
+		}
+		end = start
+	}
+}
@@ -0,0 +1,375 @@
+// Package store keeps nexus.v4.config items and their associations for the
+// mock ItemService.
+//
+// An ItemStore implements tenant scoping, queries, transactions, watches and
+// a history of the changes made to items, from which it reads them as they
+// were at a point in time, on top of a pluggable Backend persisting the
+// entities. NewMemoryBackend keeps them in memory, OpenFileBackend in an
+// append-only log compacted into snapshots, and OpenKVBackend in an embedded
+// key-value database, so that small deployments and tests run without IDF.
+package store
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// ErrNotFound is returned when no item with the requested extId is visible
+// in the caller's tenant scope.
+var ErrNotFound = errors.New("item not found")
+
+// ErrAlreadyExists is returned when an item is created with the extId of
+// an existing item. It is reported alike whichever tenant owns that item.
+var ErrAlreadyExists = errors.New("item already exists")
+
+// watchBuffer is the number of events a watcher may fall behind before it is
+// dropped.
+const watchBuffer = 64
+
+// ItemStore stores items and item associations in a Backend. Every entity
+// is owned by a tenant and all operations are confined to a tenant.Scope.
+// Entities handed out by the store are copies; callers may modify them
+// freely.
+type ItemStore struct {
+	mu       sync.RWMutex
+	backend  Backend
+	nextId   int32
+	watchers map[*watcher]struct{}
+	// indexes holds the secondary indexes of items, keyed by the path of
+	// their property.
+	indexes map[string]*index
+	// onDelete is what becomes of the associations of deleted items.
+	onDelete DeletePolicy
+	// lastSeq is the sequence of the last change recorded in the history
+	// of items.
+	lastSeq int64
+	// retention is how long changes are kept in the history of items,
+	// forever when zero.
+	retention time.Duration
+	// pasts holds the latest stores AsOf built, oldest first, which are
+	// discarded whenever the entities or the history of s change. pastsMu
+	// guards it, as AsOf only read-locks mu.
+	pasts   []past
+	pastsMu sync.Mutex
+}
+
+// NewItemStore returns an empty ItemStore holding its entities in memory.
+func NewItemStore() *ItemStore {
+	s, _ := New(NewMemoryBackend())
+	return s
+}
+
+// New returns an ItemStore over the entities of b. The store takes
+// ownership of b, which is closed by Close. Items are indexed on every
+// property the EDM marks filterable or sortable.
+func New(b Backend) (*ItemStore, error) {
+	s := &ItemStore{
+		backend:  b,
+		nextId:   1,
+		watchers: make(map[*watcher]struct{}),
+		indexes:  newIndexes(itemEntityType),
+	}
+	err := b.Items(func(item *pb.Item) bool {
+		if item.GetItemId() >= s.nextId {
+			s.nextId = item.GetItemId() + 1
+		}
+		for _, x := range s.indexes {
+			x.entries = append(x.entries, x.entryOf(item))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range s.indexes {
+		slices.SortFunc(x.entries, compareEntries)
+	}
+	if s.lastSeq, err = lastSequence(b); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Open returns an ItemStore over the backend named by spec: "memory" for
+// a new memory backend, file:DIR for the file backend in the directory DIR
+// and kv:PATH for the KV backend in the database file PATH.
+func Open(spec string) (*ItemStore, error) {
+	kind, path, _ := strings.Cut(spec, ":")
+	var b Backend
+	var err error
+	switch {
+	case spec == "memory":
+		b = NewMemoryBackend()
+	case kind == "file" && path != "":
+		b, err = OpenFileBackend(path)
+	case kind == "kv" && path != "":
+		b, err = OpenKVBackend(path)
+	default:
+		return nil, fmt.Errorf("store %q is neither memory, file:DIR nor kv:PATH", spec)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s, err := New(b)
+	if err != nil {
+		b.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the backend of the store and the channels of its watchers.
+func (s *ItemStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for w := range s.watchers {
+		delete(s.watchers, w)
+		close(w.ch)
+	}
+	return s.backend.Close()
+}
+
+// Create stores a copy of item for the tenant of scope, assigning its extId
+// and itemId when they are not already set, and returns the stored item.
+// Associations on item are ignored; use PutAssociation. Links are derived
+// per response and are not stored either.
+func (s *ItemStore) Create(scope tenant.Scope, item *pb.Item) (created *pb.Item, err error) {
+	err = s.Txn(scope, func(tx *Txn) error {
+		created, err = tx.Create(item)
+		return err
+	})
+	return created, err
+}
+
+// Get returns the item with the given extId.
+func (s *ItemStore) Get(scope tenant.Scope, extId string) (*pb.Item, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	item, err := s.lookup(scope, extId)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(item).(*pb.Item), nil
+}
+
+// List returns the items visible in scope that match q and follow its
+// After, sorted by its OrderBy and then by itemId and extId, up to its
+// Limit.
+func (s *ItemStore) List(scope tenant.Scope, q Query) ([]*pb.Item, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var items []*pb.Item
+	p := s.plan(q, true)
+	var followErr error
+	err := s.scan(scope, q, p, func(item *pb.Item) bool {
+		if q.After != nil {
+			ok, err := q.follows(item)
+			if err != nil {
+				followErr = err
+				return false
+			}
+			if !ok {
+				return true
+			}
+		}
+		items = append(items, proto.Clone(item).(*pb.Item))
+		return p.Limit <= 0 || len(items) < p.Limit
+	})
+	if err == nil {
+		err = followErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !p.Sorted {
+		sort.Slice(items, func(i, j int) bool {
+			if a, b := items[i].GetItemId(), items[j].GetItemId(); a != b {
+				return a < b
+			}
+			return items[i].GetExtId() < items[j].GetExtId()
+		})
+		if err := odata.Sort(items, q.OrderBy); err != nil {
+			return nil, &QueryError{Option: "$orderby", Err: err}
+		}
+	}
+	if q.Limit > 0 && len(items) > q.Limit {
+		items = items[:q.Limit]
+	}
+	return items, nil
+}
+
+// Count returns the number of items visible in scope that match q. Items
+// are matched as stored, so counting does not copy them.
+func (s *ItemStore) Count(scope tenant.Scope, q Query) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	n := 0
+	err := s.scan(scope, q, s.plan(q, false), func(*pb.Item) bool { n++; return true })
+	return n, err
+}
+
+// scan calls fn with every stored item visible in scope that matches the
+// filter of q, reading them as planned by p, until fn returns false. The
+// caller must hold s.mu.
+func (s *ItemStore) scan(scope tenant.Scope, q Query, p Plan, fn func(*pb.Item) bool) error {
+	match := q.matcher()
+	var err error
+	visit := func(item *pb.Item) bool {
+		if !scope.Includes(tenantOf(item.GetTenantInfo())) {
+			return true
+		}
+		var ok bool
+		ok, err = match(item, func() ([]*pb.ItemAssociation, error) {
+			return s.backend.Associations(item.GetExtId())
+		})
+		if err != nil {
+			return false
+		}
+		return !ok || fn(item)
+	}
+	if p.Index == "" {
+		if scanErr := s.backend.Items(visit); scanErr != nil {
+			return scanErr
+		}
+		return err
+	}
+	x := s.indexes[p.Index]
+	i, j := 0, len(x.entries)
+	if p.rng != nil {
+		i, j = x.bounds(*p.rng)
+	}
+	x.walk(i, j, p.Reverse, func(extId string) bool {
+		var item *pb.Item
+		if item, err = s.backend.Item(extId); err != nil {
+			return false
+		}
+		return visit(item)
+	})
+	return err
+}
+
+// reindex updates the indexes of the store for the replacement of the item
+// old by the item new, either of which is nil when the item is created or
+// deleted. The caller must hold s.mu for writing.
+func (s *ItemStore) reindex(old, new *pb.Item) {
+	for _, x := range s.indexes {
+		if old != nil {
+			x.remove(old)
+		}
+		if new != nil {
+			x.insert(new)
+		}
+	}
+}
+
+// Update replaces the item with the given extId by the result of fn, which
+// receives a copy of the current item. The read-modify-write is atomic with
+// respect to other store operations. The stored item keeps its extId, itemId
+// and tenant whatever fn returns.
+func (s *ItemStore) Update(scope tenant.Scope, extId string, fn func(*pb.Item) (*pb.Item, error)) (updated *pb.Item, err error) {
+	err = s.Txn(scope, func(tx *Txn) error {
+		updated, err = tx.Update(extId, fn)
+		return err
+	})
+	return updated, err
+}
+
+// Delete marks the item with the given extId deleted at the current time.
+// Deleted items are not found by Get and Update, nor listed unless asked
+// for, but are kept along with their associations until Restore undeletes
+// them or they are purged. Under the Restrict DeletePolicy, items with
+// associations cannot be deleted.
+func (s *ItemStore) Delete(scope tenant.Scope, extId string) error {
+	return s.Txn(scope, func(tx *Txn) error {
+		return tx.Delete(extId)
+	})
+}
+
+// PutAssociation stores a copy of assoc for the item named by its itemId,
+// replacing any association of that item with the same entityType and
+// entityId. The association is owned by the item's tenant.
+func (s *ItemStore) PutAssociation(scope tenant.Scope, assoc *pb.ItemAssociation) (stored *pb.ItemAssociation, err error) {
+	err = s.Txn(scope, func(tx *Txn) error {
+		stored, err = tx.PutAssociation(assoc)
+		return err
+	})
+	return stored, err
+}
+
+// ListAssociations returns the associations of the item with the given
+// extId, which may be deleted.
+func (s *ItemStore) ListAssociations(scope tenant.Scope, itemExtId string) ([]*pb.ItemAssociation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, err := s.find(scope, itemExtId); err != nil {
+		return nil, err
+	}
+	list, err := s.backend.Associations(itemExtId)
+	if err != nil {
+		return nil, err
+	}
+	return cloneAssociations(list), nil
+}
+
+// lookup returns the stored item with the given extId if it is visible in
+// scope and not deleted. The caller must hold s.mu.
+func (s *ItemStore) lookup(scope tenant.Scope, extId string) (*pb.Item, error) {
+	item, err := s.find(scope, extId)
+	if err != nil {
+		return nil, err
+	}
+	if item.DeletedAt != nil {
+		return nil, ErrNotFound
+	}
+	return item, nil
+}
+
+// find returns the stored item with the given extId, deleted or not, if it
+// is visible in scope. Items of other tenants are reported as not found so
+// that their existence is not disclosed. The caller must hold s.mu.
+func (s *ItemStore) find(scope tenant.Scope, extId string) (*pb.Item, error) {
+	item, err := s.backend.Item(extId)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil || !scope.Includes(tenantOf(item.GetTenantInfo())) {
+		return nil, ErrNotFound
+	}
+	return item, nil
+}
+
+func cloneAssociations(list []*pb.ItemAssociation) []*pb.ItemAssociation {
+	out := make([]*pb.ItemAssociation, 0, len(list))
+	for _, a := range list {
+		out = append(out, proto.Clone(a).(*pb.ItemAssociation))
+	}
+	return out
+}
+
+func tenantOf(info *commonpb.TenantAwareModel) string {
+	return info.GetTenantId()
+}
+
+// newUUID returns a random (version 4) UUID string.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,253 @@
+package store
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// names returns the names of the items of s listed in scope by q.
+func names(t *testing.T, s *ItemStore, scope tenant.Scope, q Query) []string {
+	t.Helper()
+	items, err := s.List(scope, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, it := range items {
+		names = append(names, it.GetItemName())
+	}
+	return names
+}
+
+func TestCreateExisting(t *testing.T) {
+	s := NewItemStore()
+	defer s.Close()
+	const extId = "4c6a1b4e-0000-4000-8000-000000000001"
+	if _, err := s.Create(tenant.Scope{TenantId: "a"}, &pb.Item{ExtId: proto.String(extId), ItemName: proto.String("disk")}); err != nil {
+		t.Fatal(err)
+	}
+	var messages []string
+	for _, scope := range []tenant.Scope{{TenantId: "a"}, {TenantId: "b"}, {TenantId: "b", AllTenants: true}} {
+		_, err := s.Create(scope, &pb.Item{ExtId: proto.String(extId), ItemName: proto.String("other")})
+		if !errors.Is(err, ErrAlreadyExists) {
+			t.Fatalf("creating the item again in %+v: got %v, want ErrAlreadyExists", scope, err)
+		}
+		messages = append(messages, err.Error())
+	}
+	// Whether the existing item is visible must not show in the error.
+	for _, m := range messages[1:] {
+		if m != messages[0] {
+			t.Errorf("got %q and %q creating the same extId from different tenants", messages[0], m)
+		}
+	}
+	err := s.Txn(tenant.Scope{TenantId: "b"}, func(tx *Txn) error {
+		_, err := tx.Put(&pb.Item{
+			ExtId:      proto.String(extId),
+			ItemName:   proto.String("other"),
+			TenantInfo: &commonpb.TenantAwareModel{TenantId: proto.String("b")},
+		})
+		return err
+	})
+	if !errors.Is(err, ErrAlreadyExists) || err.Error() != messages[0] {
+		t.Errorf("putting over the item of another tenant: got %v, want %q", err, messages[0])
+	}
+	if _, err := s.Get(tenant.Scope{TenantId: "b"}, extId); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get from another tenant: got %v, want ErrNotFound", err)
+	}
+	got, err := s.Get(tenant.Scope{TenantId: "a"}, extId)
+	if err != nil || got.GetItemName() != "disk" {
+		t.Errorf("Get = %v, %v, want the item as created", got, err)
+	}
+}
+
+func TestTenantIsolation(t *testing.T) {
+	s := NewItemStore()
+	defer s.Close()
+	a, b := tenant.Scope{TenantId: "a"}, tenant.Scope{TenantId: "b"}
+	events, cancel := s.Watch(a)
+	defer cancel()
+	ofA, err := s.Create(a, &pb.Item{ItemName: proto.String("of a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create(b, &pb.Item{ItemName: proto.String("of b")}); err != nil {
+		t.Fatal(err)
+	}
+	if got := ofA.GetTenantInfo().GetTenantId(); got != "a" {
+		t.Errorf("created an item for tenant %q, want a", got)
+	}
+	if _, err := s.PutAssociation(a, &pb.ItemAssociation{ItemId: ofA.ExtId, EntityType: proto.String("vm"), EntityId: proto.String("1")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every access of tenant b to the item of tenant a fails as if the
+	// item did not exist.
+	extId := ofA.GetExtId()
+	rename := func(it *pb.Item) (*pb.Item, error) {
+		it.ItemName = proto.String("renamed")
+		return it, nil
+	}
+	tests := []struct {
+		name string
+		op   func() error
+	}{
+		{"get", func() error { _, err := s.Get(b, extId); return err }},
+		{"update", func() error { _, err := s.Update(b, extId, rename); return err }},
+		{"delete", func() error { return s.Delete(b, extId) }},
+		{"put association", func() error {
+			_, err := s.PutAssociation(b, &pb.ItemAssociation{ItemId: ofA.ExtId, EntityType: proto.String("vm"), EntityId: proto.String("2")})
+			return err
+		}},
+		{"list associations", func() error { _, err := s.ListAssociations(b, extId); return err }},
+		{"delete association", func() error {
+			return s.Txn(b, func(tx *Txn) error { return tx.DeleteAssociation(extId, "vm", "1") })
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.op(); !errors.Is(err, ErrNotFound) {
+				t.Errorf("got %v, want ErrNotFound", err)
+			}
+		})
+	}
+	if got, err := s.Get(a, extId); err != nil || got.GetItemName() != "of a" {
+		t.Errorf("Get = %v, %v, want the item of a unchanged", got, err)
+	}
+	if assocs, err := s.ListAssociations(a, extId); err != nil || len(assocs) != 1 {
+		t.Errorf("ListAssociations = %v, %v, want the association of a alone", assocs, err)
+	}
+
+	lists := []struct {
+		scope tenant.Scope
+		want  []string
+	}{
+		{a, []string{"of a"}},
+		{b, []string{"of b"}},
+		{tenant.Scope{TenantId: "c"}, nil},
+		{tenant.Scope{TenantId: "b", AllTenants: true}, []string{"of a", "of b"}},
+	}
+	for _, l := range lists {
+		if got := names(t, s, l.scope, Query{}); !slices.Equal(got, l.want) {
+			t.Errorf("List in %+v = %v, want %v", l.scope, got, l.want)
+		}
+		if n, err := s.Count(l.scope, Query{}); err != nil || n != len(l.want) {
+			t.Errorf("Count in %+v = %d, %v, want %d", l.scope, n, err, len(l.want))
+		}
+	}
+
+	// An administrator acting for all tenants may change the item of a,
+	// which keeps its tenant.
+	updated, err := s.Update(tenant.Scope{TenantId: "b", AllTenants: true}, extId, rename)
+	if err != nil || updated.GetTenantInfo().GetTenantId() != "a" {
+		t.Errorf("Update across tenants = %v, %v, want the item of a renamed", updated, err)
+	}
+
+	// The watcher of a hears of the changes of a alone.
+	var got []EventType
+	for len(events) > 0 {
+		e := <-events
+		if e.TenantId() != "a" {
+			t.Errorf("the watcher of tenant a received %v", e)
+		}
+		got = append(got, e.Type)
+	}
+	if want := []EventType{Created, Created, Updated}; !slices.Equal(got, want) {
+		t.Errorf("the watcher of tenant a received %v, want %v", got, want)
+	}
+}
+
+func TestListLambda(t *testing.T) {
+	for _, spec := range []string{"memory", "file:" + t.TempDir()} {
+		t.Run(spec, func(t *testing.T) {
+			s, err := Open(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			scope := tenant.Scope{TenantId: "a"}
+			associations := map[string][]*pb.ItemAssociation{
+				"vms and host": {
+					{EntityType: proto.String("vm"), EntityId: proto.String("vm-1"), Count: proto.Int32(5)},
+					{EntityType: proto.String("host"), EntityId: proto.String("host-1"), Count: proto.Int32(1)},
+				},
+				"vm": {
+					{EntityType: proto.String("vm"), EntityId: proto.String("vm-2"), Count: proto.Int32(1)},
+				},
+				"none": nil,
+			}
+			for _, name := range []string{"vms and host", "vm", "none"} {
+				it, err := s.Create(scope, &pb.Item{ItemName: proto.String(name)})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, assoc := range associations[name] {
+					assoc.ItemId = it.ExtId
+					if _, err := s.PutAssociation(scope, assoc); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			tests := []struct {
+				filter string
+				want   []string
+			}{
+				{"associations/any()", []string{"vms and host", "vm"}},
+				{"not associations/any()", []string{"none"}},
+				{"associations/any(a: a/entityType eq 'vm' and a/count gt 3)", []string{"vms and host"}},
+				{"associations/all(a: a/entityType eq 'vm')", []string{"vm", "none"}},
+				{"associations/any(a: a/entityType eq 'host') or itemName eq 'none'", []string{"vms and host", "none"}},
+			}
+			for _, tt := range tests {
+				e, err := odata.ParseFilter(tt.filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := names(t, s, scope, Query{Filter: e}); !slices.Equal(got, tt.want) {
+					t.Errorf("$filter=%s: got %v, want %v", tt.filter, got, tt.want)
+				}
+				if n, err := s.Count(scope, Query{Filter: e}); err != nil || n != len(tt.want) {
+					t.Errorf("counting $filter=%s: got %d, %v, want %d", tt.filter, n, err, len(tt.want))
+				}
+			}
+			// Matching associations leaves them out of the items listed.
+			items, err := s.List(scope, Query{Filter: &odata.Lambda{Collection: &odata.Path{Segments: []string{"associations"}}, Op: "any"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, it := range items {
+				if it.Associations != nil {
+					t.Errorf("listed %s with its associations", it.GetItemName())
+				}
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	for _, spec := range []string{"memory", "file:" + dir + "/file", "kv:" + dir + "/kv.db"} {
+		s, err := Open(spec)
+		if err != nil {
+			t.Errorf("Open(%s): %v", spec, err)
+			continue
+		}
+		s.Close()
+	}
+	for _, spec := range []string{"", "memory:x", "file:", "kv:", "disk:" + dir} {
+		s, err := Open(spec)
+		if err == nil {
+			s.Close()
+		}
+		if want := `store "` + spec + `" is neither memory, file:DIR nor kv:PATH`; err == nil || err.Error() != want {
+			t.Errorf("Open(%s): got %v, want %s", spec, err, want)
+		}
+	}
+}
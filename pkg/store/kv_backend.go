@@ -0,0 +1,180 @@
+package store
+
+import (
+	"fmt"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/kv"
+)
+
+// Key prefixes of the entities and changes in a KV backend. Associations
+// are keyed by their item, entityType and entityId, so that those of an
+// item are adjacent, and changes by their zero-padded sequence, so that they
+// are in order.
+const (
+	itemPrefix   = "item\x00"
+	assocPrefix  = "assoc\x00"
+	changePrefix = "change\x00"
+)
+
+// kvBackend stores entities in an embedded key-value database, encoded as
+// protobuf. Unlike the other backends it reads them from disk, so that the
+// entities need not fit in memory.
+type kvBackend struct {
+	db *kv.DB
+}
+
+// OpenKVBackend opens the KV backend in the database file at path, creating
+// it if needed.
+func OpenKVBackend(path string) (Backend, error) {
+	db, err := kv.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &kvBackend{db: db}, nil
+}
+
+func itemKey(extId string) string {
+	return itemPrefix + extId
+}
+
+func assocKeyOf(a *pb.ItemAssociation) string {
+	return assocPrefix + a.GetItemId() + "\x00" + a.GetEntityType() + "\x00" + a.GetEntityId()
+}
+
+func changeKey(sequence int64) string {
+	return fmt.Sprintf("%s%020d", changePrefix, sequence)
+}
+
+func (b *kvBackend) Item(extId string) (*pb.Item, error) {
+	value, ok, err := b.db.Get(itemKey(extId))
+	if err != nil || !ok {
+		return nil, err
+	}
+	item := &pb.Item{}
+	if err := proto.Unmarshal(value, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (b *kvBackend) Items(fn func(*pb.Item) bool) error {
+	var err error
+	scanErr := b.db.Scan(itemPrefix, func(_ string, value []byte) bool {
+		item := &pb.Item{}
+		if err = proto.Unmarshal(value, item); err != nil {
+			return false
+		}
+		return fn(item)
+	})
+	if scanErr != nil {
+		return scanErr
+	}
+	return err
+}
+
+// Associations returns the associations of an item ordered by entityType
+// and entityId.
+func (b *kvBackend) Associations(itemExtId string) ([]*pb.ItemAssociation, error) {
+	var list []*pb.ItemAssociation
+	var err error
+	scanErr := b.db.Scan(assocPrefix+itemExtId+"\x00", func(_ string, value []byte) bool {
+		a := &pb.ItemAssociation{}
+		if err = proto.Unmarshal(value, a); err != nil {
+			return false
+		}
+		list = append(list, a)
+		return true
+	})
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	return list, err
+}
+
+func (b *kvBackend) AllAssociations(fn func(*pb.ItemAssociation) bool) error {
+	var err error
+	scanErr := b.db.Scan(assocPrefix, func(_ string, value []byte) bool {
+		a := &pb.ItemAssociation{}
+		if err = proto.Unmarshal(value, a); err != nil {
+			return false
+		}
+		return fn(a)
+	})
+	if scanErr != nil {
+		return scanErr
+	}
+	return err
+}
+
+func (b *kvBackend) Changes(after int64, fn func(*pb.ItemChange) bool) error {
+	var err error
+	from := changeKey(after)
+	scanErr := b.db.Scan(changePrefix, func(key string, value []byte) bool {
+		if key <= from {
+			return true
+		}
+		c := &pb.ItemChange{}
+		if err = proto.Unmarshal(value, c); err != nil {
+			return false
+		}
+		return fn(c)
+	})
+	if scanErr != nil {
+		return scanErr
+	}
+	return err
+}
+
+func (b *kvBackend) Commit(writes []Write) error {
+	ops := make([]kv.Op, 0, len(writes))
+	for _, w := range writes {
+		var op kv.Op
+		var err error
+		switch {
+		case w.PutItem != nil:
+			op.Key = itemKey(w.PutItem.GetExtId())
+			op.Value, err = proto.Marshal(w.PutItem)
+		case w.DeleteItem != "":
+			op = kv.Op{Key: itemKey(w.DeleteItem), Delete: true}
+		case w.PutAssociation != nil:
+			op.Key = assocKeyOf(w.PutAssociation)
+			op.Value, err = proto.Marshal(w.PutAssociation)
+		case w.DeleteAssociation != nil:
+			op = kv.Op{Key: assocKeyOf(w.DeleteAssociation), Delete: true}
+		case w.AppendChange != nil:
+			op.Key = changeKey(w.AppendChange.GetSequence())
+			op.Value, err = proto.Marshal(w.AppendChange)
+		case w.DropChanges > 0:
+			if ops, err = b.dropChanges(ops, w.DropChanges); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		ops = append(ops, op)
+	}
+	return b.db.Batch(ops)
+}
+
+// dropChanges appends to ops the deletions of the changes whose sequence is
+// not greater than upTo.
+func (b *kvBackend) dropChanges(ops []kv.Op, upTo int64) ([]kv.Op, error) {
+	last := changeKey(upTo)
+	err := b.db.Scan(changePrefix, func(key string, _ []byte) bool {
+		if key > last {
+			return false
+		}
+		ops = append(ops, kv.Op{Key: key, Delete: true})
+		return true
+	})
+	return ops, err
+}
+
+func (b *kvBackend) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+)
+
+// DeletePolicy is what becomes of the associations of an item when it is
+// deleted and purged. Deleting an item only marks it deleted, so that its
+// associations stay in place, and are restored with it, until it is purged.
+type DeletePolicy int
+
+const (
+	// Orphan leaves the associations of purged items in place, where
+	// Orphans finds them.
+	Orphan DeletePolicy = iota
+	// Cascade deletes the associations of an item when it is purged.
+	Cascade
+	// Restrict refuses to delete or purge items that have associations
+	// with a RestrictError. Items deleted with associations under another
+	// policy are then left deleted by PurgeDeleted.
+	Restrict
+)
+
+func (p DeletePolicy) String() string {
+	switch p {
+	case Orphan:
+		return "orphan"
+	case Cascade:
+		return "cascade"
+	case Restrict:
+		return "restrict"
+	}
+	return "unknown"
+}
+
+// ParseDeletePolicy returns the DeletePolicy named s, as returned by its
+// String method.
+func ParseDeletePolicy(s string) (DeletePolicy, error) {
+	for _, p := range []DeletePolicy{Orphan, Cascade, Restrict} {
+		if s == p.String() {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown delete policy %q", s)
+}
+
+// RestrictError is returned when deleting or purging an item that has
+// associations under the Restrict policy.
+type RestrictError struct {
+	ExtId        string
+	Associations []*pb.ItemAssociation
+}
+
+// maxListedAssociations is the number of associations a RestrictError
+// names in its message.
+const maxListedAssociations = 5
+
+func (e *RestrictError) Error() string {
+	names := make([]string, 0, maxListedAssociations)
+	for _, a := range e.Associations[:min(len(e.Associations), maxListedAssociations)] {
+		names = append(names, a.GetEntityType()+" "+a.GetEntityId())
+	}
+	if n := len(e.Associations) - len(names); n > 0 {
+		names = append(names, fmt.Sprintf("%d more", n))
+	}
+	return fmt.Sprintf("item %s cannot be deleted while it has %d associations (%s); delete them first",
+		e.ExtId, len(e.Associations), strings.Join(names, ", "))
+}
+
+// SetDeletePolicy sets what becomes of the associations of deleted items.
+// The policy of a new store is Orphan.
+func (s *ItemStore) SetDeletePolicy(p DeletePolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onDelete = p
+}
+
+// Orphans returns the associations whose itemId names no item, whatever
+// their tenant, ordered by itemId, entityType and entityId.
+func (s *ItemStore) Orphans() ([]*pb.ItemAssociation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var all []*pb.ItemAssociation
+	if err := s.backend.AllAssociations(func(a *pb.ItemAssociation) bool {
+		all = append(all, a)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	var orphans []*pb.ItemAssociation
+	resolved := make(map[string]bool)
+	for _, a := range all {
+		ok, seen := resolved[a.GetItemId()]
+		if !seen {
+			item, err := s.backend.Item(a.GetItemId())
+			if err != nil {
+				return nil, err
+			}
+			ok = item != nil
+			resolved[a.GetItemId()] = ok
+		}
+		if !ok {
+			orphans = append(orphans, proto.Clone(a).(*pb.ItemAssociation))
+		}
+	}
+	slices.SortFunc(orphans, func(a, b *pb.ItemAssociation) int {
+		return cmp.Or(
+			strings.Compare(a.GetItemId(), b.GetItemId()),
+			strings.Compare(a.GetEntityType(), b.GetEntityType()),
+			strings.Compare(a.GetEntityId(), b.GetEntityId()),
+		)
+	})
+	return orphans, nil
+}
+
+// SweepOrphans looks for orphaned associations every interval until the
+// returned function is called, passing report those it finds, or the error
+// that prevented finding them. Orphans are only reported, not deleted.
+func (s *ItemStore) SweepOrphans(interval time.Duration, report func([]*pb.ItemAssociation, error)) (stop func()) {
+	// sweep calls report right after fn on the same goroutine, so orphans
+	// always holds what the latest call found.
+	var orphans []*pb.ItemAssociation
+	return sweep(interval, func(_ int, err error) { report(orphans, err) }, func() (n int, err error) {
+		orphans, err = s.Orphans()
+		return len(orphans), err
+	})
+}
@@ -0,0 +1,224 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// createAssociated creates an item named name in scope with an association
+// with a vm for each of entityIds, and returns its extId.
+func createAssociated(t *testing.T, s *ItemStore, scope tenant.Scope, name string, entityIds ...string) string {
+	t.Helper()
+	it, err := s.Create(scope, &pb.Item{ItemName: proto.String(name)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range entityIds {
+		if _, err := s.PutAssociation(scope, &pb.ItemAssociation{ItemId: it.ExtId, EntityType: proto.String("vm"), EntityId: proto.String(id)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return it.GetExtId()
+}
+
+// entityIds returns the entityId of each of assocs.
+func entityIds(assocs []*pb.ItemAssociation) []string {
+	var ids []string
+	for _, a := range assocs {
+		ids = append(ids, a.GetEntityId())
+	}
+	return ids
+}
+
+func TestParseDeletePolicy(t *testing.T) {
+	for _, p := range []DeletePolicy{Orphan, Cascade, Restrict} {
+		if got, err := ParseDeletePolicy(p.String()); err != nil || got != p {
+			t.Errorf("ParseDeletePolicy(%s) = %v, %v", p, got, err)
+		}
+	}
+	if _, err := ParseDeletePolicy("unknown"); err == nil {
+		t.Error("ParseDeletePolicy(unknown): got no error")
+	}
+}
+
+func TestDeletePolicy(t *testing.T) {
+	scope := tenant.Scope{TenantId: "a"}
+	tests := []struct {
+		policy DeletePolicy
+		// left is the entityIds of the associations of the item left once
+		// it is purged, which are then orphans.
+		left []string
+	}{
+		{Orphan, []string{"1", "2"}},
+		{Cascade, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			s := NewItemStore()
+			defer s.Close()
+			s.SetDeletePolicy(tt.policy)
+			extId := createAssociated(t, s, scope, "disk", "1", "2")
+			kept := createAssociated(t, s, scope, "kept", "3")
+			if err := s.Delete(scope, extId); err != nil {
+				t.Fatal(err)
+			}
+			// Deleted items keep their associations until purged.
+			assocs, err := s.ListAssociations(scope, extId)
+			if err != nil || len(assocs) != 2 {
+				t.Errorf("got associations %v, %v of the deleted item, want 2", assocs, err)
+			}
+			if orphans, err := s.Orphans(); err != nil || len(orphans) != 0 {
+				t.Errorf("got orphans %v, %v before purging", orphans, err)
+			}
+			if n, err := s.PurgeDeleted(time.Now().Add(time.Second)); err != nil || n != 1 {
+				t.Fatalf("PurgeDeleted = %d, %v, want 1", n, err)
+			}
+			left, err := s.backend.Associations(extId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := entityIds(left); !slices.Equal(got, tt.left) {
+				t.Errorf("got associations %v of the purged item, want %v", got, tt.left)
+			}
+			orphans, err := s.Orphans()
+			if got := entityIds(orphans); err != nil || !slices.Equal(got, tt.left) {
+				t.Errorf("got orphans %v, %v, want %v", got, err, tt.left)
+			}
+			if assocs, err := s.ListAssociations(scope, kept); err != nil || len(assocs) != 1 {
+				t.Errorf("got associations %v, %v of another item, want 1", assocs, err)
+			}
+		})
+	}
+}
+
+func TestDeleteRestrict(t *testing.T) {
+	scope := tenant.Scope{TenantId: "a"}
+	s := NewItemStore()
+	defer s.Close()
+	var ids []string
+	for i := 1; i <= 7; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+	extId := createAssociated(t, s, scope, "disk", ids...)
+	deleted := createAssociated(t, s, scope, "deleted", "8")
+	if err := s.Delete(scope, deleted); err != nil {
+		t.Fatal(err)
+	}
+	s.SetDeletePolicy(Restrict)
+
+	err := s.Delete(scope, extId)
+	var rerr *RestrictError
+	if !errors.As(err, &rerr) || rerr.ExtId != extId || len(rerr.Associations) != 7 {
+		t.Fatalf("got %v, want a RestrictError listing 7 associations", err)
+	}
+	want := "item " + extId + " cannot be deleted while it has 7 associations (vm 1, vm 2, vm 3, vm 4, vm 5, 2 more); delete them first"
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err, want)
+	}
+	if _, err := s.Get(scope, extId); err != nil {
+		t.Errorf("getting the item after refusing to delete it: %v", err)
+	}
+
+	// Items deleted with associations before the policy was set are left
+	// deleted.
+	if n, err := s.PurgeDeleted(time.Now().Add(time.Second)); err != nil || n != 0 {
+		t.Errorf("PurgeDeleted = %d, %v, want 0", n, err)
+	}
+	err = s.Txn(scope, func(tx *Txn) error { return tx.Purge(deleted) })
+	if !errors.As(err, &rerr) || rerr.ExtId != deleted {
+		t.Errorf("purging the deleted item: got %v, want a RestrictError", err)
+	}
+	if _, err := s.Restore(scope, deleted); err != nil {
+		t.Errorf("restoring the item left deleted: %v", err)
+	}
+
+	// Items without associations are deleted.
+	err = s.Txn(scope, func(tx *Txn) error {
+		for _, id := range ids {
+			if err := tx.DeleteAssociation(extId, "vm", id); err != nil {
+				return err
+			}
+		}
+		return tx.Delete(extId)
+	})
+	if err != nil {
+		t.Errorf("deleting the item without associations: %v", err)
+	}
+}
+
+func TestOrphans(t *testing.T) {
+	s := NewItemStore()
+	defer s.Close()
+	a, b := tenant.Scope{TenantId: "a"}, tenant.Scope{TenantId: "b"}
+	// orphaned holds the entityIds of the associations of purged items,
+	// by itemId.
+	orphaned := make(map[string][]string)
+	for _, x := range []struct {
+		scope     tenant.Scope
+		name      string
+		entityIds []string
+	}{
+		{b, "b-gone", []string{"5", "4"}},
+		{a, "a-gone", []string{"2", "1"}},
+		{a, "a-kept", []string{"3"}},
+	} {
+		extId := createAssociated(t, s, x.scope, x.name, x.entityIds...)
+		if x.name == "a-kept" {
+			continue
+		}
+		orphaned[extId] = slices.Sorted(slices.Values(x.entityIds))
+		err := s.Txn(x.scope, func(tx *Txn) error { return tx.Purge(extId) })
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	orphans, err := s.Orphans()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, o := range orphans {
+		got = append(got, o.GetItemId()+" "+o.GetEntityId())
+	}
+	// Orphans of every tenant, ordered by itemId and entityId.
+	var want []string
+	for _, extId := range slices.Sorted(maps.Keys(orphaned)) {
+		for _, id := range orphaned[extId] {
+			want = append(want, extId+" "+id)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got orphans %v, want %v", got, want)
+	}
+
+	reports := make(chan []*pb.ItemAssociation, 1)
+	stop := s.SweepOrphans(time.Millisecond, func(orphans []*pb.ItemAssociation, err error) {
+		if err != nil {
+			t.Error(err)
+		}
+		select {
+		case reports <- orphans:
+		default:
+		}
+	})
+	defer stop()
+	select {
+	case orphans := <-reports:
+		if len(orphans) != 4 {
+			t.Errorf("the sweep reported %d orphans, want 4", len(orphans))
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("the sweep reported no orphans")
+	}
+	stop()
+	stop()
+}
@@ -0,0 +1,271 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+)
+
+// Costs of the operations of a plan, relative to reading an item during a
+// full scan.
+const (
+	// lookupCost is the cost of looking up an item read from an index.
+	lookupCost = 1.5
+	// compareCost is the cost of a comparison sorting items.
+	compareCost = 0.2
+)
+
+// Plan describes how the store evaluates a Query: the items it reads and
+// whether it sorts them. Whatever the plan, read items are matched against
+// the whole filter.
+type Plan struct {
+	// Index is the path of the property whose index items are read from,
+	// or "" if every item is read.
+	Index string
+	// Predicates are the conjuncts of the filter that bound the range of
+	// the index read. Without any the whole index is read.
+	Predicates []string
+	// Reverse reports whether the index is read in descending order.
+	Reverse bool
+	// Sorted reports whether items are read in the order of the query, so
+	// that they need not be sorted.
+	Sorted bool
+	// Limit, if positive, is the number of matching items after which a
+	// sorted read stops.
+	Limit int
+	// Rows is the number of items in the range read, out of Items stored.
+	Rows, Items int
+	// Cost is the estimated cost of the plan, in units of items read by a
+	// full scan.
+	Cost float64
+
+	rng *keyRange
+}
+
+func (p Plan) String() string {
+	s := "full scan"
+	if p.Index != "" {
+		s = "index scan on " + p.Index
+		if len(p.Predicates) > 0 {
+			s += " where " + strings.Join(p.Predicates, " and ")
+		}
+		if p.Reverse {
+			s += " in reverse"
+		}
+	}
+	s += fmt.Sprintf(", %d of %d items, cost %.1f", p.Rows, p.Items, p.Cost)
+	if p.Sorted {
+		s += ", sorted by index"
+	}
+	if p.Limit > 0 {
+		s += fmt.Sprintf(", stop after %d", p.Limit)
+	}
+	return s
+}
+
+// Explain returns the plan the store lists the items of q with.
+func (s *ItemStore) Explain(q Query) Plan {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.plan(q, true)
+}
+
+// plan returns the cheapest plan evaluating q, reading every item or the
+// items in the range of an index the filter restricts. When ordered, the
+// items are to be listed in the order of q and plans reading them in that
+// order from an index need not sort them, stop after the limit of q and
+// start from its After when ascending. The caller must hold s.mu.
+//
+// Costs are estimated from the exact number of items in the ranges of the
+// indexes, assuming that the conjuncts of the filter are independent.
+func (s *ItemStore) plan(q Query, ordered bool) Plan {
+	ranges := make(map[string]*keyRange)
+	predicates := make(map[string][]string)
+	var paths []string
+	restrict := func(path string, c odata.Expr, bound func(*keyRange)) {
+		r, seen := ranges[path]
+		if !seen {
+			r = &keyRange{}
+			ranges[path] = r
+			paths = append(paths, path)
+		}
+		bound(r)
+		predicates[path] = append(predicates[path], c.String())
+	}
+	for _, c := range conjuncts(q.Filter) {
+		if path, bound, ok := s.sargable(c); ok {
+			restrict(path, c, bound)
+		}
+	}
+	orderPath, desc := s.orderIndex(q)
+	if !ordered {
+		orderPath = ""
+	}
+	if q.After != nil && orderPath != "" && !desc {
+		// Items following q.After in ascending order have values not below
+		// its own, unless it is null.
+		v := interface{}(int64(q.After.ItemId))
+		if len(q.OrderBy) > 0 {
+			v = q.After.Values[0]
+		}
+		if v != nil && s.indexes[orderPath].accepts(v) {
+			c := &odata.Binary{Op: "ge", Left: s.indexes[orderPath].path, Right: &odata.Literal{Value: v}}
+			restrict(orderPath, c, func(r *keyRange) { r.raise(v, false) })
+		}
+	}
+	n := s.size()
+	rows := make(map[string]int, len(paths))
+	// matches estimates the number of items matching the filter.
+	matches := float64(n)
+	for _, path := range paths {
+		i, j := s.indexes[path].bounds(*ranges[path])
+		rows[path] = j - i
+		if n > 0 {
+			matches *= float64(j-i) / float64(n)
+		}
+	}
+	sortCost := 0.0
+	if ordered && matches > 1 {
+		sortCost = matches * math.Log2(matches) * compareCost
+	}
+	// reads estimates the number of items a sorted read of a range of r
+	// items looks up before it finds the first q.Limit matches.
+	reads := func(r int) float64 {
+		if q.Limit <= 0 || matches == 0 {
+			return float64(r)
+		}
+		return min(float64(r), math.Ceil(float64(q.Limit)*float64(r)/matches))
+	}
+	best := Plan{Rows: n, Items: n, Cost: float64(n) + sortCost}
+	consider := func(p Plan) {
+		if p.Cost < best.Cost {
+			best = p
+		}
+	}
+	for _, path := range paths {
+		p := Plan{Index: path, Predicates: predicates[path], Rows: rows[path], Items: n, rng: ranges[path]}
+		if path == orderPath {
+			p.Reverse, p.Sorted, p.Limit = desc, true, q.Limit
+			p.Cost = reads(p.Rows) * lookupCost
+		} else {
+			p.Cost = float64(p.Rows)*lookupCost + sortCost
+		}
+		consider(p)
+	}
+	if _, ok := ranges[orderPath]; orderPath != "" && !ok {
+		consider(Plan{Index: orderPath, Reverse: desc, Sorted: true, Limit: q.Limit, Rows: n, Items: n, Cost: reads(n) * lookupCost})
+	}
+	return best
+}
+
+// orderIndex returns the path of the index that lists items in the order
+// of q, and whether it is to be read in reverse, or "" if there is none. As
+// items are listed by itemId after OrderBy, the index of itemId lists them
+// in order when q has no OrderBy.
+func (s *ItemStore) orderIndex(q Query) (string, bool) {
+	path, desc := "itemId", false
+	switch len(q.OrderBy) {
+	case 0:
+	case 1:
+		path, desc = q.OrderBy[0].Path.String(), q.OrderBy[0].Desc
+	default:
+		return "", false
+	}
+	if _, ok := s.indexes[path]; !ok {
+		return "", false
+	}
+	return path, desc
+}
+
+// size returns the number of items in the store. The caller must hold s.mu.
+func (s *ItemStore) size() int {
+	for _, x := range s.indexes {
+		return len(x.entries)
+	}
+	n := 0
+	s.backend.Items(func(*pb.Item) bool { n++; return true })
+	return n
+}
+
+// conjuncts returns the operands of the top-level and operators of e.
+func conjuncts(e odata.Expr) []odata.Expr {
+	if b, ok := e.(*odata.Binary); ok && b.Op == "and" {
+		return append(conjuncts(b.Left), conjuncts(b.Right)...)
+	}
+	if e == nil {
+		return nil
+	}
+	return []odata.Expr{e}
+}
+
+// flipped maps comparison operators to those comparing their operands the
+// other way around.
+var flipped = map[string]string{"eq": "eq", "gt": "lt", "ge": "le", "lt": "gt", "le": "ge"}
+
+// sargable reports whether an index can find the items satisfying the
+// filter conjunct c, returning the path of its property and a function
+// bounding a range of the index by c.
+func (s *ItemStore) sargable(c odata.Expr) (string, func(*keyRange), bool) {
+	switch c := c.(type) {
+	case *odata.Binary:
+		op, ok := flipped[c.Op]
+		if !ok {
+			return "", nil, false
+		}
+		operand, lit := c.Left, c.Right
+		if _, ok := c.Left.(*odata.Path); ok {
+			op = c.Op
+		} else {
+			operand, lit = c.Right, c.Left
+		}
+		path, v, ok := s.indexedLiteral(operand, lit)
+		if !ok || v == nil && op != "eq" {
+			return "", nil, false
+		}
+		switch op {
+		case "eq":
+			if v == nil {
+				return path, func(r *keyRange) { r.null = true }, true
+			}
+			return path, func(r *keyRange) { r.raise(v, false); r.lower(v, false) }, true
+		case "gt", "ge":
+			return path, func(r *keyRange) { r.raise(v, op == "gt") }, true
+		default:
+			return path, func(r *keyRange) { r.lower(v, op == "lt") }, true
+		}
+	case *odata.Call:
+		if c.Name != "startswith" || len(c.Args) != 2 {
+			return "", nil, false
+		}
+		path, v, ok := s.indexedLiteral(c.Args[0], c.Args[1])
+		prefix, isString := v.(string)
+		if !ok || !isString {
+			return "", nil, false
+		}
+		return path, func(r *keyRange) { r.restrict(prefix) }, true
+	}
+	return "", nil, false
+}
+
+// indexedLiteral reports whether path is the path of an indexed property
+// and lit a literal comparable with its values, returning the path and the
+// value of the literal.
+func (s *ItemStore) indexedLiteral(path, lit odata.Expr) (string, interface{}, bool) {
+	p, ok := path.(*odata.Path)
+	if !ok {
+		return "", nil, false
+	}
+	l, ok := lit.(*odata.Literal)
+	if !ok {
+		return "", nil, false
+	}
+	x, ok := s.indexes[p.String()]
+	if !ok || l.Value != nil && !x.accepts(l.Value) {
+		return "", nil, false
+	}
+	return p.String(), l.Value, true
+}
@@ -0,0 +1,396 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/nutanix-core/ntnx-api-odata-go/odata/edm"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// planStore returns a store of 30 items named item-01 to item-30, every
+// fifth of them owned by tenant b and the others by tenant a, of the types
+// disk, vm, host and none in turn. item-03 is then renamed renamed-03,
+// item-08 loses its type and item-10 is deleted, so that the indexes have
+// been updated as well as built.
+func planStore(t *testing.T) *ItemStore {
+	t.Helper()
+	s := NewItemStore()
+	t.Cleanup(func() { s.Close() })
+	types := []string{"disk", "vm", "host", ""}
+	extIds := make(map[string]string)
+	for i := 1; i <= 30; i++ {
+		owner := "a"
+		if i%5 == 0 {
+			owner = "b"
+		}
+		it := &pb.Item{ItemName: proto.String(fmt.Sprintf("item-%02d", i))}
+		if typ := types[i%4]; typ != "" {
+			it.ItemType = proto.String(typ)
+		}
+		created, err := s.Create(tenant.Scope{TenantId: owner}, it)
+		if err != nil {
+			t.Fatal(err)
+		}
+		extIds[it.GetItemName()] = created.GetExtId()
+	}
+	scope := tenant.Scope{AllTenants: true}
+	if _, err := s.Update(scope, extIds["item-03"], func(it *pb.Item) (*pb.Item, error) {
+		it.ItemName = proto.String("renamed-03")
+		return it, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Update(scope, extIds["item-08"], func(it *pb.Item) (*pb.Item, error) {
+		it.ItemType = nil
+		return it, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(scope, extIds["item-10"]); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// fullScan lists the items of s in scope matching q by reading every item,
+// as List would without indexes.
+func fullScan(t *testing.T, s *ItemStore, scope tenant.Scope, q Query) []string {
+	t.Helper()
+	match := q.matcher()
+	var items []*pb.Item
+	err := s.backend.Items(func(it *pb.Item) bool {
+		if !scope.Includes(tenantOf(it.GetTenantInfo())) {
+			return true
+		}
+		ok, err := match(it, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok && q.After != nil {
+			if ok, err = q.follows(it); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if ok {
+			items = append(items, it)
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].GetItemId() < items[j].GetItemId() })
+	if err := odata.Sort(items, q.OrderBy); err != nil {
+		t.Fatal(err)
+	}
+	if q.Limit > 0 && len(items) > q.Limit {
+		items = items[:q.Limit]
+	}
+	var names []string
+	for _, it := range items {
+		names = append(names, it.GetItemName())
+	}
+	return names
+}
+
+// filterType describes Item to the $filter of queries, which may refer to
+// DeletedProperty.
+var filterType = &odata.EntityType{
+	Name:       itemEntityType.Name,
+	Properties: append(slices.Clip(itemEntityType.Properties), &odata.Property{Name: DeletedProperty, Type: string(edm.EdmBoolean), IsFilterable: true}),
+}
+
+// query returns the checked query of the given $filter and $orderby.
+func query(t *testing.T, filter, orderby string, limit int) Query {
+	t.Helper()
+	q := Query{Limit: limit}
+	if filter != "" {
+		e, err := odata.ParseFilter(filter)
+		if err == nil {
+			err = odata.CheckFilter(e, filterType)
+		}
+		if err != nil {
+			t.Fatalf("$filter=%s: %v", filter, err)
+		}
+		q.Filter = e
+	}
+	if orderby != "" {
+		order, err := odata.ParseOrderBy(orderby)
+		if err == nil {
+			err = odata.CheckOrderBy(order, itemEntityType)
+		}
+		if err != nil {
+			t.Fatalf("$orderby=%s: %v", orderby, err)
+		}
+		q.OrderBy = order
+	}
+	return q
+}
+
+func TestPlanMatchesFullScan(t *testing.T) {
+	s := planStore(t)
+	filters := []string{
+		"",
+		"itemName eq 'item-05'",
+		"itemName eq 'item-03'",
+		"itemName gt 'item-20'",
+		"itemName ge 'item-10' and itemName lt 'item-15'",
+		"itemName le 'item-04' and itemName ge 'item-04'",
+		"startswith(itemName, 'item-1')",
+		"startswith(itemName, 'item-1') and itemName gt 'item-12'",
+		"startswith(itemName, 'item-1') and startswith(itemName, 'item-')",
+		"startswith(itemName, 'item') and startswith(itemName, 'ren')",
+		"itemType eq null",
+		"itemType eq 'disk' and itemId gt 10",
+		"10 lt itemId and itemId lt 20",
+		"itemId gt 2.5 and itemId le 6",
+		"itemId gt 20 and itemId lt 10",
+		"itemId le 3 or itemName eq 'item-29'",
+		"itemType ne 'vm'",
+		"not (itemId lt 25)",
+		"isDeleted",
+		"isDeleted eq false and itemName ge 'item-08'",
+		"isDeleted or itemName eq 'item-05'",
+		"itemName eq 'item-10' and not isDeleted",
+	}
+	orders := []string{"", "itemName", "itemName desc", "itemType desc", "itemType,itemName desc", "itemId desc"}
+	for _, scope := range []tenant.Scope{{TenantId: "a"}, {AllTenants: true}} {
+		for _, filter := range filters {
+			for _, orderby := range orders {
+				for _, limit := range []int{0, 3} {
+					q := query(t, filter, orderby, limit)
+					want := fullScan(t, s, scope, q)
+					if got := names(t, s, scope, q); !slices.Equal(got, want) {
+						t.Errorf("%+v: $filter=%s&$orderby=%s&$limit=%d planned as %s: got %v, want %v", scope, filter, orderby, limit, s.Explain(q), got, want)
+					}
+					if limit > 0 {
+						continue
+					}
+					if n, err := s.Count(scope, q); err != nil || n != len(want) {
+						t.Errorf("%+v: counting $filter=%s: got %d, %v, want %d", scope, filter, n, err, len(want))
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPlanIndex(t *testing.T) {
+	s := planStore(t)
+	tests := []struct {
+		filter     string
+		index      string
+		predicates []string
+		rows       int
+	}{
+		{"itemName eq 'item-05'", "itemName", []string{"(itemName eq 'item-05')"}, 1},
+		{"'item-05' eq itemName", "itemName", []string{"('item-05' eq itemName)"}, 1},
+		{"itemName ge 'item-10' and itemName lt 'item-13' and itemId gt 0", "itemName", []string{"(itemName ge 'item-10')", "(itemName lt 'item-13')"}, 3},
+		{"startswith(itemName, 'renamed')", "itemName", []string{"startswith(itemName,'renamed')"}, 1},
+		{"itemId gt 20 and itemId lt 10", "itemId", []string{"(itemId gt 20)", "(itemId lt 10)"}, 0},
+		// Deleted items are still indexed.
+		{"itemId ge 9 and itemId le 10", "itemId", []string{"(itemId ge 9)", "(itemId le 10)"}, 2},
+		{"itemType eq null and itemName eq 'item-08'", "itemName", []string{"(itemName eq 'item-08')"}, 1},
+		// Neither a disjunction nor an unindexed property bounds an index,
+		// but reading that of itemId whole lists items in order, without
+		// sorting them.
+		{"itemId le 3 or itemName eq 'item-29'", "itemId", nil, 30},
+		{"description eq 'x'", "itemId", nil, 30},
+	}
+	for _, tt := range tests {
+		q := Query{Filter: mustParseFilter(t, tt.filter)}
+		p := s.Explain(q)
+		if p.Index != tt.index || !slices.Equal(p.Predicates, tt.predicates) || p.Rows != tt.rows || p.Items != 30 {
+			t.Errorf("$filter=%s: got plan %s %q, want %d rows read from index %q %q", tt.filter, p, p.Predicates, tt.rows, tt.index, tt.predicates)
+		}
+	}
+}
+
+// mustParseFilter parses filter without checking it against the entity
+// type of items.
+func mustParseFilter(t *testing.T, filter string) odata.Expr {
+	t.Helper()
+	e, err := odata.ParseFilter(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestIndexBounds(t *testing.T) {
+	x := &index{path: &odata.Path{Segments: []string{"itemType"}}, kind: "string"}
+	for i, typ := range []string{"vm", "", "disk", "host", "disk", "", "hostgroup", "vmgroup"} {
+		it := &pb.Item{ItemId: proto.Int32(int32(i + 1)), ExtId: proto.String(fmt.Sprint(i + 1))}
+		if typ != "" {
+			it.ItemType = proto.String(typ)
+		}
+		x.insert(it)
+	}
+	// The index holds null, null, disk, disk, host, hostgroup, vm and
+	// vmgroup, by itemIds 2, 6, 3, 5, 4, 7, 1 and 8.
+	tests := []struct {
+		name  string
+		bound func(r *keyRange)
+		want  []string
+	}{
+		{"all", func(r *keyRange) {}, []string{"3", "5", "4", "7", "1", "8"}},
+		{"null", func(r *keyRange) { r.null = true }, []string{"2", "6"}},
+		{"null and a bound", func(r *keyRange) { r.null = true; r.raise("a", false) }, nil},
+		{"eq", func(r *keyRange) { r.raise("disk", false); r.lower("disk", false) }, []string{"3", "5"}},
+		{"gt", func(r *keyRange) { r.raise("host", true) }, []string{"7", "1", "8"}},
+		{"ge", func(r *keyRange) { r.raise("host", false) }, []string{"4", "7", "1", "8"}},
+		{"lt", func(r *keyRange) { r.lower("host", true) }, []string{"3", "5"}},
+		{"raised twice", func(r *keyRange) { r.raise("vm", false); r.raise("disk", false) }, []string{"1", "8"}},
+		{"lowered twice", func(r *keyRange) { r.lower("disk", false); r.lower("host", false) }, []string{"3", "5"}},
+		{"open at an equal bound", func(r *keyRange) { r.raise("vm", false); r.raise("vm", true) }, []string{"8"}},
+		{"empty", func(r *keyRange) { r.raise("vm", false); r.lower("host", false) }, nil},
+		{"prefix", func(r *keyRange) { r.restrict("host") }, []string{"4", "7"}},
+		{"longer prefix", func(r *keyRange) { r.restrict("h"); r.restrict("hostg") }, []string{"7"}},
+		{"shorter prefix", func(r *keyRange) { r.restrict("hostg"); r.restrict("h") }, []string{"7"}},
+		{"disjoint prefixes", func(r *keyRange) { r.restrict("host"); r.restrict("vm") }, nil},
+		{"prefix and bound", func(r *keyRange) { r.restrict("host"); r.raise("host", true) }, []string{"7"}},
+		{"no match", func(r *keyRange) { r.restrict("z") }, nil},
+	}
+	for _, tt := range tests {
+		r := keyRange{}
+		tt.bound(&r)
+		i, j := x.bounds(r)
+		var got []string
+		x.walk(i, j, false, func(extId string) bool {
+			got = append(got, extId)
+			return true
+		})
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIndexWalk(t *testing.T) {
+	x := &index{path: &odata.Path{Segments: []string{"itemType"}}, kind: "string"}
+	for i, typ := range []string{"vm", "disk", "vm", "disk", "host"} {
+		x.insert(&pb.Item{ItemId: proto.Int32(int32(i + 1)), ExtId: proto.String(fmt.Sprint(i + 1)), ItemType: proto.String(typ)})
+	}
+	walk := func(reverse bool, limit int) []string {
+		var got []string
+		x.walk(0, len(x.entries), reverse, func(extId string) bool {
+			got = append(got, extId)
+			return len(got) < limit
+		})
+		return got
+	}
+	// Items with equal values are listed by itemId either way.
+	if got, want := walk(false, 5), []string{"2", "4", "5", "1", "3"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := walk(true, 5), []string{"1", "3", "5", "2", "4"}; !slices.Equal(got, want) {
+		t.Errorf("walking in reverse: got %v, want %v", got, want)
+	}
+	if got, want := walk(true, 2), []string{"1", "3"}; !slices.Equal(got, want) {
+		t.Errorf("stopping after 2: got %v, want %v", got, want)
+	}
+
+	x.remove(&pb.Item{ItemId: proto.Int32(3), ExtId: proto.String("3"), ItemType: proto.String("vm")})
+	x.remove(&pb.Item{ItemId: proto.Int32(4), ExtId: proto.String("4"), ItemType: proto.String("vm")})
+	if got, want := walk(false, 5), []string{"2", "4", "5", "1"}; !slices.Equal(got, want) {
+		t.Errorf("after removing item 3 and an entry not in the index: got %v, want %v", got, want)
+	}
+}
+
+func TestPlanCost(t *testing.T) {
+	s := planStore(t)
+	tests := []struct {
+		name    string
+		q       Query
+		ordered bool
+		want    string
+	}{
+		{"count", Query{}, false, "full scan, 30 of 30 items, cost 30.0"},
+		{"list by itemId", Query{}, true, "index scan on itemId, 30 of 30 items, cost 45.0, sorted by index"},
+		{"sorted by two properties", query(t, "", "itemType,itemName", 0), true, "full scan, 30 of 30 items, cost 59.4"},
+		{"first page", query(t, "", "itemName", 3), true, "index scan on itemName, 30 of 30 items, cost 4.5, sorted by index, stop after 3"},
+		{"first page descending", query(t, "", "itemName desc", 3), true, "index scan on itemName in reverse, 30 of 30 items, cost 4.5, sorted by index, stop after 3"},
+		{"count of a wide range", query(t, "itemId ge 2", "", 0), false, "full scan, 30 of 30 items, cost 30.0"},
+		{"list of a wide range", query(t, "itemId ge 2", "", 0), true, "index scan on itemId where (itemId ge 2), 29 of 30 items, cost 43.5, sorted by index"},
+		// Reading the one item the filter selects beats reading items in
+		// order until the page is full.
+		{"narrow filter", query(t, "itemName eq 'item-05'", "itemType desc", 3), true, "index scan on itemName where (itemName eq 'item-05'), 1 of 30 items, cost 1.5"},
+		// 12 items are named from item-2 on, 2 of which are expected among
+		// the first 5 by itemId.
+		{"small page of a selective filter", query(t, "itemName ge 'item-2'", "", 2), true, "index scan on itemId, 30 of 30 items, cost 7.5, sorted by index, stop after 2"},
+		{"whole selective filter", query(t, "itemName ge 'item-2'", "itemType", 0), true, "index scan on itemName where (itemName ge 'item-2'), 12 of 30 items, cost 26.6"},
+	}
+	for _, tt := range tests {
+		s.mu.RLock()
+		p := s.plan(tt.q, tt.ordered)
+		s.mu.RUnlock()
+		if got := p.String(); got != tt.want {
+			t.Errorf("%s: got plan %s, want %s", tt.name, got, tt.want)
+		}
+		if tt.ordered {
+			if got := s.Explain(tt.q); got.String() != p.String() {
+				t.Errorf("%s: Explain = %s, want %s", tt.name, got, p)
+			}
+		}
+	}
+}
+
+func TestListAfter(t *testing.T) {
+	s := planStore(t)
+	scope := tenant.Scope{AllTenants: true}
+	filters := []string{"", "itemType ne 'vm'", "itemName ge 'item-12'", "itemId gt 4 and itemId lt 27"}
+	orders := []string{"", "itemName", "itemName desc", "itemType", "itemType desc", "itemType desc,itemName", "itemId desc"}
+	for _, filter := range filters {
+		for _, orderby := range orders {
+			all := fullScan(t, s, scope, query(t, filter, orderby, 0))
+			// Resume the listing, 4 items at a time, after the last item
+			// of each page.
+			var got []string
+			q := query(t, filter, orderby, 4)
+			for page := 0; page < 10; page++ {
+				items, err := s.List(scope, q)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, it := range items {
+					got = append(got, it.GetItemName())
+				}
+				if want := fullScan(t, s, scope, q); len(items) != len(want) {
+					t.Errorf("$filter=%s&$orderby=%s: got a page of %d items after %+v planned as %s, want %v", filter, orderby, len(items), q.After, s.Explain(q), want)
+				}
+				if len(items) < q.Limit {
+					break
+				}
+				if q.After, err = q.PositionOf(items[len(items)-1]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !slices.Equal(got, all) {
+				t.Errorf("$filter=%s&$orderby=%s: got %v page by page, want %v", filter, orderby, got, all)
+			}
+		}
+	}
+
+	// After bounds the range of an ascending sorted read.
+	items, err := s.List(scope, query(t, "itemName eq 'item-20'", "", 0))
+	if err != nil || len(items) != 1 {
+		t.Fatalf("got %v, %v, want item-20", items, err)
+	}
+	q := query(t, "", "itemName", 2)
+	if q.After, err = q.PositionOf(items[0]); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Explain(q).String(), "index scan on itemName where (itemName ge 'item-20'), 12 of 30 items, cost 3.0, sorted by index, stop after 2"; got != want {
+		t.Errorf("got plan %s, want %s", got, want)
+	}
+	if got, want := names(t, s, scope, q), []string{"item-21", "item-22"}; !slices.Equal(got, want) {
+		t.Errorf("got %v after item-20, want %v", got, want)
+	}
+}
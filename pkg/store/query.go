@@ -0,0 +1,147 @@
+package store
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/odata"
+)
+
+// associationsProperty is the property of Item holding its associations,
+// which a Query may refer to although they are stored apart from items.
+const associationsProperty = "associations"
+
+// DeletedProperty is the Boolean pseudo-property of Item telling whether it
+// is deleted, which a Query may refer to although items only store the time
+// they were deleted at.
+const DeletedProperty = "isDeleted"
+
+// Query selects and orders the items of a List or Count.
+type Query struct {
+	// Filter is a checked $filter expression items must satisfy, or nil.
+	// Deleted items are excluded unless it refers to DeletedProperty.
+	Filter odata.Expr
+	// OrderBy is the checked $orderby items are sorted by. Items it does
+	// not order are listed by itemId.
+	OrderBy []odata.OrderItem
+	// Limit, if positive, is the number of items listed: the first ones
+	// in order. Count ignores it.
+	Limit int
+	// After, if set, restricts List to the items following it in order,
+	// so that a listing can resume where a previous one stopped however
+	// items changed in between. Count ignores it.
+	After *Position
+}
+
+// Position is the position of an item in the order of a Query: the values
+// of its OrderBy properties, in the representation of OData literals, then
+// its itemId and extId, which tell apart items with equal values.
+type Position struct {
+	Values []interface{}
+	ItemId int32
+	ExtId  string
+}
+
+// PositionOf returns the position of item in the order of q.
+func (q Query) PositionOf(item *pb.Item) (*Position, error) {
+	p := &Position{Values: make([]interface{}, len(q.OrderBy)), ItemId: item.GetItemId(), ExtId: item.GetExtId()}
+	for i, o := range q.OrderBy {
+		v, err := odata.Value(o.Path, item.ProtoReflect())
+		if err != nil {
+			return nil, &QueryError{Option: "$orderby", Err: err}
+		}
+		p.Values[i] = v
+	}
+	return p, nil
+}
+
+// follows reports whether item comes after q.After in the order of q.
+func (q Query) follows(item *pb.Item) (bool, error) {
+	p, err := q.PositionOf(item)
+	if err != nil {
+		return false, err
+	}
+	return q.compare(p, q.After) > 0, nil
+}
+
+// compare orders the positions a and b in the order of q. Both hold a value
+// for every term of its OrderBy.
+func (q Query) compare(a, b *Position) int {
+	for i, o := range q.OrderBy {
+		c := odata.Compare(a.Values[i], b.Values[i])
+		if o.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	if c := cmp.Compare(a.ItemId, b.ItemId); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ExtId, b.ExtId)
+}
+
+// QueryError reports a failure evaluating an option of a Query.
+type QueryError struct {
+	// Option is the OData query option that failed, "$filter" or
+	// "$orderby".
+	Option string
+	Err    error
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Option, e.Err)
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// matcher returns a function reporting whether an item satisfies the filter
+// of q. Items hold no associations, so when the filter refers to them a
+// copy of the item is matched with the associations returned by assocs.
+// Deleted items only match filters referring to DeletedProperty, which is
+// substituted by whether the item is deleted. The items passed to the
+// function are never modified. Errors raised evaluating the filter are
+// *QueryError.
+func (q Query) matcher() func(it *pb.Item, assocs func() ([]*pb.ItemAssociation, error)) (bool, error) {
+	if q.Filter == nil {
+		return func(it *pb.Item, _ func() ([]*pb.ItemAssociation, error)) (bool, error) {
+			return it.DeletedAt == nil, nil
+		}
+	}
+	joined := odata.References(q.Filter, associationsProperty)
+	withDeleted := odata.References(q.Filter, DeletedProperty)
+	live, deleted := q.Filter, q.Filter
+	if withDeleted {
+		live = odata.Substitute(q.Filter, DeletedProperty, &odata.Literal{Value: false})
+		deleted = odata.Substitute(q.Filter, DeletedProperty, &odata.Literal{Value: true})
+	}
+	return func(it *pb.Item, assocs func() ([]*pb.ItemAssociation, error)) (bool, error) {
+		filter := live
+		if it.DeletedAt != nil {
+			if !withDeleted {
+				return false, nil
+			}
+			filter = deleted
+		}
+		if joined {
+			list, err := assocs()
+			if err != nil {
+				return false, err
+			}
+			it = proto.Clone(it).(*pb.Item)
+			it.Associations = &pb.ItemAssociationArrayWrapper{Value: list}
+		}
+		ok, err := odata.Match(filter, it.ProtoReflect())
+		if err != nil {
+			return false, &QueryError{Option: "$filter", Err: err}
+		}
+		return ok, nil
+	}
+}
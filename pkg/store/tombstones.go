@@ -0,0 +1,128 @@
+package store
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// Restore undeletes the item with the given extId, which is returned as
+// restored, along with its associations. Restoring an item that is not
+// deleted returns it unchanged.
+func (s *ItemStore) Restore(scope tenant.Scope, extId string) (restored *pb.Item, err error) {
+	err = s.Txn(scope, func(tx *Txn) error {
+		restored, err = tx.Restore(extId)
+		return err
+	})
+	return restored, err
+}
+
+// Restore is the transactional counterpart of ItemStore.Restore.
+func (tx *Txn) Restore(extId string) (*pb.Item, error) {
+	item, err := tx.find(extId)
+	if err != nil {
+		return nil, err
+	}
+	if item.DeletedAt != nil {
+		item = proto.Clone(item).(*pb.Item)
+		item.DeletedAt = nil
+		tx.putItem(item, Created)
+	}
+	return proto.Clone(item).(*pb.Item), nil
+}
+
+// Purge removes the item with the given extId for good, whether deleted or
+// not. What happens to its associations depends on the DeletePolicy of the
+// store. Purging a deleted item reports its deletion to watchers again.
+func (tx *Txn) Purge(extId string) error {
+	item, err := tx.find(extId)
+	if err != nil {
+		return err
+	}
+	if tx.s.onDelete != Orphan {
+		assocs, err := tx.associations(extId)
+		if err != nil {
+			return err
+		}
+		if len(assocs) > 0 && tx.s.onDelete == Restrict {
+			return &RestrictError{ExtId: extId, Associations: cloneAssociations(assocs)}
+		}
+		for _, a := range assocs {
+			tx.deleteAssociation(a)
+		}
+	}
+	tx.items[extId] = nil
+	tx.writes = append(tx.writes, Write{DeleteItem: extId})
+	tx.events = append(tx.events, Event{Type: Deleted, Item: item})
+	return nil
+}
+
+// PurgeDeleted purges the items of every tenant deleted before the given
+// time and returns how many were purged. Items the Restrict DeletePolicy
+// keeps from being purged, which gained associations before the policy was
+// set, are left deleted.
+func (s *ItemStore) PurgeDeleted(before time.Time) (purged int, err error) {
+	err = s.Txn(tenant.Scope{AllTenants: true}, func(tx *Txn) error {
+		var expired []string
+		err := tx.Items(func(item *pb.Item) bool {
+			if item.DeletedAt != nil && item.GetDeletedAt().AsTime().Before(before) {
+				expired = append(expired, item.GetExtId())
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		for _, extId := range expired {
+			var rerr *RestrictError
+			switch err := tx.Purge(extId); {
+			case errors.As(err, &rerr):
+			case err != nil:
+				return err
+			default:
+				purged++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+// SweepDeleted purges the items deleted for longer than retention every
+// interval until the returned function is called, passing report the
+// number of items purged, or the error that prevented purging them.
+func (s *ItemStore) SweepDeleted(interval, retention time.Duration, report func(purged int, err error)) (stop func()) {
+	return sweep(interval, report, func() (int, error) {
+		return s.PurgeDeleted(time.Now().Add(-retention))
+	})
+}
+
+// sweep calls fn every interval until the returned function is called,
+// passing report what fn returns unless it removed nothing.
+func sweep(interval time.Duration, report func(n int, err error), fn func() (int, error)) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if n, err := fn(); err != nil || n > 0 {
+					report(n, err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
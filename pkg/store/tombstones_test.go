@@ -0,0 +1,184 @@
+package store
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+func TestSoftDelete(t *testing.T) {
+	s := NewItemStore()
+	defer s.Close()
+	a := tenant.Scope{TenantId: "a"}
+	extIds := make(map[string]string)
+	for _, name := range []string{"disk-1", "disk-2", "disk-3"} {
+		extIds[name] = createAssociated(t, s, a, name, "1")
+	}
+	before := time.Now()
+	if err := s.Delete(a, extIds["disk-2"]); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Get(a, extIds["disk-2"]); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get: got %v, want ErrNotFound", err)
+	}
+	_, err := s.Update(a, extIds["disk-2"], func(it *pb.Item) (*pb.Item, error) { return it, nil })
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update: got %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(a, extIds["disk-2"]); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleting again: got %v, want ErrNotFound", err)
+	}
+	if _, err := s.Create(a, &pb.Item{ExtId: proto.String(extIds["disk-2"]), ItemName: proto.String("new")}); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("creating over the deleted item: got %v, want ErrAlreadyExists", err)
+	}
+	if _, err := s.PutAssociation(a, &pb.ItemAssociation{ItemId: proto.String(extIds["disk-2"]), EntityType: proto.String("vm"), EntityId: proto.String("2")}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("associating the deleted item: got %v, want ErrNotFound", err)
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"", []string{"disk-1", "disk-3"}},
+		{"itemName eq 'disk-2'", nil},
+		{"isDeleted", []string{"disk-2"}},
+		{"isDeleted eq true", []string{"disk-2"}},
+		{"isDeleted eq false", []string{"disk-1", "disk-3"}},
+		{"not isDeleted", []string{"disk-1", "disk-3"}},
+		{"isDeleted or itemName eq 'disk-1'", []string{"disk-1", "disk-2"}},
+		{"isDeleted eq true or isDeleted eq false", []string{"disk-1", "disk-2", "disk-3"}},
+		{"isDeleted and itemName eq 'disk-1'", nil},
+	}
+	for _, tt := range tests {
+		q := query(t, tt.filter, "", 0)
+		if got := names(t, s, a, q); !slices.Equal(got, tt.want) {
+			t.Errorf("$filter=%s: got %v, want %v", tt.filter, got, tt.want)
+		}
+		if n, err := s.Count(a, q); err != nil || n != len(tt.want) {
+			t.Errorf("counting $filter=%s: got %d, %v, want %d", tt.filter, n, err, len(tt.want))
+		}
+	}
+	deleted, err := s.List(a, query(t, "isDeleted", "", 0))
+	if err != nil || len(deleted) != 1 {
+		t.Fatalf("got %v, %v, want the deleted item", deleted, err)
+	}
+	if at := deleted[0].GetDeletedAt().AsTime(); at.Before(before) || at.After(time.Now()) {
+		t.Errorf("got deletedAt %v, want the time of the deletion", at)
+	}
+	if got := names(t, s, tenant.Scope{TenantId: "b"}, query(t, "isDeleted", "", 0)); got != nil {
+		t.Errorf("got the deleted items %v of another tenant", got)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	s := NewItemStore()
+	defer s.Close()
+	a := tenant.Scope{TenantId: "a"}
+	extId := createAssociated(t, s, a, "disk", "1", "2")
+	live := createAssociated(t, s, a, "live")
+	if err := s.Delete(a, extId); err != nil {
+		t.Fatal(err)
+	}
+	events, cancel := s.Watch(a)
+	defer cancel()
+
+	if _, err := s.Restore(tenant.Scope{TenantId: "b"}, extId); !errors.Is(err, ErrNotFound) {
+		t.Errorf("restoring the item of another tenant: got %v, want ErrNotFound", err)
+	}
+	restored, err := s.Restore(a, extId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.DeletedAt != nil || restored.GetItemName() != "disk" {
+		t.Errorf("got %v, want the item undeleted", restored)
+	}
+	if e := <-events; e.Type != Created || e.Item.GetExtId() != extId {
+		t.Errorf("got event %v, want the item created again", e)
+	}
+	got, err := s.Get(a, extId)
+	if err != nil || !proto.Equal(got, restored) {
+		t.Errorf("Get = %v, %v, want %v", got, err, restored)
+	}
+	if assocs, err := s.ListAssociations(a, extId); err != nil || len(assocs) != 2 {
+		t.Errorf("got associations %v, %v, want both restored", assocs, err)
+	}
+
+	// Restoring an item that is not deleted changes nothing.
+	again, err := s.Restore(a, live)
+	if err != nil || again.GetItemName() != "live" {
+		t.Errorf("restoring a live item: got %v, %v", again, err)
+	}
+	select {
+	case e := <-events:
+		t.Errorf("got event %v restoring a live item", e)
+	default:
+	}
+	if _, err := s.Restore(a, "4c6a1b4e-0000-4000-8000-000000000001"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("restoring a missing item: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	s := NewItemStore()
+	defer s.Close()
+	s.SetDeletePolicy(Cascade)
+	a, b := tenant.Scope{TenantId: "a"}, tenant.Scope{TenantId: "b"}
+	first := createAssociated(t, s, a, "first", "1")
+	second := createAssociated(t, s, b, "second")
+	live := createAssociated(t, s, a, "live")
+	if err := s.Delete(a, first); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	between := time.Now()
+	time.Sleep(2 * time.Millisecond)
+	if err := s.Delete(b, second); err != nil {
+		t.Fatal(err)
+	}
+
+	// Items deleted before the given time are purged, whatever their
+	// tenant.
+	if n, err := s.PurgeDeleted(between); err != nil || n != 1 {
+		t.Fatalf("PurgeDeleted = %d, %v, want 1", n, err)
+	}
+	if _, err := s.Restore(a, first); !errors.Is(err, ErrNotFound) {
+		t.Errorf("restoring a purged item: got %v, want ErrNotFound", err)
+	}
+	if assocs, err := s.backend.Associations(first); err != nil || len(assocs) != 0 {
+		t.Errorf("got associations %v, %v of the purged item, want none", assocs, err)
+	}
+	if _, err := s.Create(a, &pb.Item{ExtId: proto.String(first), ItemName: proto.String("reused")}); err != nil {
+		t.Errorf("reusing the extId of a purged item: %v", err)
+	}
+
+	reports := make(chan int, 1)
+	stop := s.SweepDeleted(time.Millisecond, 0, func(purged int, err error) {
+		if err != nil {
+			t.Error(err)
+		}
+		reports <- purged
+	})
+	defer stop()
+	select {
+	case n := <-reports:
+		if n != 1 {
+			t.Errorf("the sweep purged %d items, want 1", n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the sweep purged nothing")
+	}
+	stop()
+	if _, err := s.Restore(b, second); !errors.Is(err, ErrNotFound) {
+		t.Errorf("restoring a swept item: got %v, want ErrNotFound", err)
+	}
+	if _, err := s.Get(a, live); err != nil {
+		t.Errorf("getting a live item after the sweep: %v", err)
+	}
+}
@@ -0,0 +1,366 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	commonpb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/common/v1/config"
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// Txn is a transaction over the entities of an ItemStore visible in a
+// tenant scope. Its reads see its own writes, which are committed to the
+// backend at once when the transaction succeeds.
+type Txn struct {
+	s     *ItemStore
+	scope tenant.Scope
+	// items holds the items written by the transaction, nil for deleted
+	// ones.
+	items map[string]*pb.Item
+	// assocs holds the associations written by the transaction, by item,
+	// nil for deleted ones.
+	assocs map[string]map[assocKey]*pb.ItemAssociation
+	writes []Write
+	events []Event
+	nextId int32
+	origin Origin
+}
+
+// Txn runs fn in a transaction confined to scope. Its writes are committed
+// if fn returns nil and discarded otherwise; watchers are notified of them
+// once committed. Transactions are serializable: they run one at a time and
+// exclude all other operations on the store. fn must not call back into
+// the store other than through tx.
+func (s *ItemStore) Txn(scope tenant.Scope, fn func(tx *Txn) error) error {
+	return s.txn(scope, Origin{}, fn)
+}
+
+func (s *ItemStore) txn(scope tenant.Scope, origin Origin, fn func(tx *Txn) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := &Txn{
+		s:      s,
+		scope:  scope,
+		items:  make(map[string]*pb.Item),
+		assocs: make(map[string]map[assocKey]*pb.ItemAssociation),
+		nextId: s.nextId,
+		origin: origin,
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if len(tx.writes) == 0 {
+		return nil
+	}
+	old := make(map[string]*pb.Item, len(tx.items))
+	for extId := range tx.items {
+		item, err := s.backend.Item(extId)
+		if err != nil {
+			return err
+		}
+		old[extId] = item
+	}
+	changes, err := tx.history(time.Now())
+	if err != nil {
+		return err
+	}
+	if err := s.backend.Commit(append(tx.writes, changes...)); err != nil {
+		return err
+	}
+	s.pasts = nil
+	for extId, item := range tx.items {
+		s.reindex(old[extId], item)
+	}
+	s.nextId = tx.nextId
+	s.lastSeq += int64(len(changes))
+	for _, e := range tx.events {
+		s.notify(e)
+	}
+	return nil
+}
+
+// item returns the item with the given extId as seen by tx, whatever its
+// tenant, or nil.
+func (tx *Txn) item(extId string) (*pb.Item, error) {
+	if item, ok := tx.items[extId]; ok {
+		return item, nil
+	}
+	return tx.s.backend.Item(extId)
+}
+
+// lookup is the transactional counterpart of ItemStore.lookup.
+func (tx *Txn) lookup(extId string) (*pb.Item, error) {
+	item, err := tx.find(extId)
+	if err != nil {
+		return nil, err
+	}
+	if item.DeletedAt != nil {
+		return nil, ErrNotFound
+	}
+	return item, nil
+}
+
+// find is the transactional counterpart of ItemStore.find.
+func (tx *Txn) find(extId string) (*pb.Item, error) {
+	item, err := tx.item(extId)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil || !tx.scope.Includes(tenantOf(item.GetTenantInfo())) {
+		return nil, ErrNotFound
+	}
+	return item, nil
+}
+
+func (tx *Txn) putItem(item *pb.Item, event EventType) {
+	tx.items[item.GetExtId()] = item
+	tx.writes = append(tx.writes, Write{PutItem: item})
+	tx.events = append(tx.events, Event{Type: event, Item: item})
+}
+
+// Get returns the item with the given extId.
+func (tx *Txn) Get(extId string) (*pb.Item, error) {
+	item, err := tx.lookup(extId)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(item).(*pb.Item), nil
+}
+
+// Create is the transactional counterpart of ItemStore.Create.
+func (tx *Txn) Create(item *pb.Item) (*pb.Item, error) {
+	item = proto.Clone(item).(*pb.Item)
+	item.Associations = nil
+	item.Links = nil
+	item.DeletedAt = nil
+	item.TenantInfo = &commonpb.TenantAwareModel{TenantId: proto.String(tx.scope.TenantId)}
+	if item.ExtId == nil {
+		extId, err := newUUID()
+		if err != nil {
+			return nil, err
+		}
+		item.ExtId = proto.String(extId)
+	}
+	existing, err := tx.item(item.GetExtId())
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyExists, item.GetExtId())
+	}
+	if item.ItemId == nil {
+		item.ItemId = proto.Int32(tx.nextId)
+	}
+	if item.GetItemId() >= tx.nextId {
+		tx.nextId = item.GetItemId() + 1
+	}
+	tx.putItem(item, Created)
+	return proto.Clone(item).(*pb.Item), nil
+}
+
+// Update is the transactional counterpart of ItemStore.Update.
+func (tx *Txn) Update(extId string, fn func(*pb.Item) (*pb.Item, error)) (*pb.Item, error) {
+	current, err := tx.lookup(extId)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := fn(proto.Clone(current).(*pb.Item))
+	if err != nil {
+		return nil, err
+	}
+	updated = proto.Clone(updated).(*pb.Item)
+	updated.ExtId = current.ExtId
+	updated.ItemId = current.ItemId
+	updated.TenantInfo = current.TenantInfo
+	updated.Associations = nil
+	updated.Links = nil
+	updated.DeletedAt = nil
+	tx.putItem(updated, Updated)
+	return proto.Clone(updated).(*pb.Item), nil
+}
+
+// Put stores a copy of item as is, keeping its extId, itemId, tenant and
+// deletion time, in place of any item with the same extId. It assigns an
+// itemId when item has none. Put is meant for restoring items read from
+// another store, so the scope of tx must include the tenants of both item
+// and the item it replaces.
+func (tx *Txn) Put(item *pb.Item) (*pb.Item, error) {
+	item = proto.Clone(item).(*pb.Item)
+	item.Associations = nil
+	item.Links = nil
+	if item.GetExtId() == "" {
+		return nil, errors.New("item has no extId")
+	}
+	if !tx.scope.Includes(tenantOf(item.GetTenantInfo())) {
+		return nil, fmt.Errorf("item %s belongs to tenant %q outside the scope", item.GetExtId(), tenantOf(item.GetTenantInfo()))
+	}
+	existing, err := tx.item(item.GetExtId())
+	if err != nil {
+		return nil, err
+	}
+	event := Created
+	if existing != nil {
+		if !tx.scope.Includes(tenantOf(existing.GetTenantInfo())) {
+			return nil, fmt.Errorf("%w: %s", ErrAlreadyExists, item.GetExtId())
+		}
+		event = Updated
+	}
+	if item.ItemId == nil {
+		item.ItemId = proto.Int32(tx.nextId)
+	}
+	if item.GetItemId() >= tx.nextId {
+		tx.nextId = item.GetItemId() + 1
+	}
+	tx.putItem(item, event)
+	return proto.Clone(item).(*pb.Item), nil
+}
+
+// Items calls fn with a copy of every item visible in the scope of tx,
+// deleted ones included, as seen by tx, in no particular order, until fn
+// returns false. fn must not write through tx.
+func (tx *Txn) Items(fn func(*pb.Item) bool) error {
+	more := true
+	err := tx.s.backend.Items(func(item *pb.Item) bool {
+		if _, written := tx.items[item.GetExtId()]; written || !tx.scope.Includes(tenantOf(item.GetTenantInfo())) {
+			return true
+		}
+		more = fn(proto.Clone(item).(*pb.Item))
+		return more
+	})
+	if err != nil || !more {
+		return err
+	}
+	for _, item := range tx.items {
+		if item != nil && tx.scope.Includes(tenantOf(item.GetTenantInfo())) && !fn(proto.Clone(item).(*pb.Item)) {
+			break
+		}
+	}
+	return nil
+}
+
+// Delete is the transactional counterpart of ItemStore.Delete.
+func (tx *Txn) Delete(extId string) error {
+	item, err := tx.lookup(extId)
+	if err != nil {
+		return err
+	}
+	if tx.s.onDelete == Restrict {
+		assocs, err := tx.associations(extId)
+		if err != nil {
+			return err
+		}
+		if len(assocs) > 0 {
+			return &RestrictError{ExtId: extId, Associations: cloneAssociations(assocs)}
+		}
+	}
+	item = proto.Clone(item).(*pb.Item)
+	item.DeletedAt = timestamppb.Now()
+	tx.putItem(item, Deleted)
+	return nil
+}
+
+// PutAssociation is the transactional counterpart of
+// ItemStore.PutAssociation.
+func (tx *Txn) PutAssociation(assoc *pb.ItemAssociation) (*pb.ItemAssociation, error) {
+	item, err := tx.lookup(assoc.GetItemId())
+	if err != nil {
+		return nil, err
+	}
+	assoc = proto.Clone(assoc).(*pb.ItemAssociation)
+	assoc.TenantInfo = proto.Clone(item.GetTenantInfo()).(*commonpb.TenantAwareModel)
+	list, err := tx.associations(assoc.GetItemId())
+	if err != nil {
+		return nil, err
+	}
+	event := Event{Type: Created, Association: assoc}
+	for _, a := range list {
+		if keyOf(a) == keyOf(assoc) {
+			event.Type = Updated
+			break
+		}
+	}
+	tx.setAssociation(assoc.GetItemId(), keyOf(assoc), assoc)
+	tx.writes = append(tx.writes, Write{PutAssociation: assoc})
+	tx.events = append(tx.events, event)
+	return proto.Clone(assoc).(*pb.ItemAssociation), nil
+}
+
+// DeleteAssociation deletes the association of the item with the given
+// extId, which may be deleted, with entityType and entityId. It reports
+// ErrNotFound if the item has no such association.
+func (tx *Txn) DeleteAssociation(itemExtId, entityType, entityId string) error {
+	if _, err := tx.find(itemExtId); err != nil {
+		return err
+	}
+	list, err := tx.associations(itemExtId)
+	if err != nil {
+		return err
+	}
+	key := assocKey{entityType: entityType, entityId: entityId}
+	for _, a := range list {
+		if keyOf(a) == key {
+			tx.deleteAssociation(a)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (tx *Txn) deleteAssociation(a *pb.ItemAssociation) {
+	tx.setAssociation(a.GetItemId(), keyOf(a), nil)
+	tx.writes = append(tx.writes, Write{DeleteAssociation: a})
+	tx.events = append(tx.events, Event{Type: Deleted, Association: a})
+}
+
+// ListAssociations is the transactional counterpart of
+// ItemStore.ListAssociations.
+func (tx *Txn) ListAssociations(itemExtId string) ([]*pb.ItemAssociation, error) {
+	if _, err := tx.find(itemExtId); err != nil {
+		return nil, err
+	}
+	list, err := tx.associations(itemExtId)
+	if err != nil {
+		return nil, err
+	}
+	return cloneAssociations(list), nil
+}
+
+// associations returns the associations of the item with the given extId
+// as seen by tx: the stored ones it has not written, followed by those it
+// has put, in the order they were written.
+func (tx *Txn) associations(itemExtId string) ([]*pb.ItemAssociation, error) {
+	stored, err := tx.s.backend.Associations(itemExtId)
+	if err != nil {
+		return nil, err
+	}
+	written := tx.assocs[itemExtId]
+	if len(written) == 0 {
+		return stored, nil
+	}
+	list := make([]*pb.ItemAssociation, 0, len(stored)+len(written))
+	for _, a := range stored {
+		if _, ok := written[keyOf(a)]; !ok {
+			list = append(list, a)
+		}
+	}
+	for _, w := range tx.writes {
+		if a := w.PutAssociation; a != nil && a.GetItemId() == itemExtId && written[keyOf(a)] == a {
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
+func (tx *Txn) setAssociation(itemExtId string, key assocKey, a *pb.ItemAssociation) {
+	written, ok := tx.assocs[itemExtId]
+	if !ok {
+		written = make(map[assocKey]*pb.ItemAssociation)
+		tx.assocs[itemExtId] = written
+	}
+	written[key] = a
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	pb "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/protobuf/nexus/v4/config"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/tenant"
+)
+
+// EventType identifies the kind of change an Event reports.
+type EventType int
+
+const (
+	Created EventType = iota + 1
+	Updated
+	Deleted
+)
+
+func (t EventType) String() string {
+	switch t {
+	case Created:
+		return "CREATED"
+	case Updated:
+		return "UPDATED"
+	case Deleted:
+		return "DELETED"
+	}
+	return "UNKNOWN"
+}
+
+// Event describes a change to an item or an item association. Exactly one of
+// Item and Association is set.
+type Event struct {
+	Type        EventType
+	Item        *pb.Item
+	Association *pb.ItemAssociation
+}
+
+// TenantId returns the tenant owning the changed entity.
+func (e Event) TenantId() string {
+	if e.Item != nil {
+		return tenantOf(e.Item.GetTenantInfo())
+	}
+	return tenantOf(e.Association.GetTenantInfo())
+}
+
+type watcher struct {
+	scope tenant.Scope
+	ch    chan Event
+}
+
+// Watch subscribes to changes of entities visible in scope. Events are
+// delivered in the order the changes were applied. A watcher that falls more
+// than watchBuffer events behind has its channel closed and must re-list and
+// watch again. The returned function cancels the subscription.
+func (s *ItemStore) Watch(scope tenant.Scope) (<-chan Event, func()) {
+	w := &watcher{scope: scope, ch: make(chan Event, watchBuffer)}
+	s.mu.Lock()
+	s.watchers[w] = struct{}{}
+	s.mu.Unlock()
+	return w.ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.watchers[w]; ok {
+			delete(s.watchers, w)
+			close(w.ch)
+		}
+	}
+}
+
+// notify fans e out to the watchers whose scope includes its tenant. The
+// caller must hold s.mu for writing.
+func (s *ItemStore) notify(e Event) {
+	tenantId := e.TenantId()
+	for w := range s.watchers {
+		if !w.scope.Includes(tenantId) {
+			continue
+		}
+		select {
+		case w.ch <- clone(e):
+		default:
+			delete(s.watchers, w)
+			close(w.ch)
+		}
+	}
+}
+
+func clone(e Event) Event {
+	if e.Item != nil {
+		e.Item = proto.Clone(e.Item).(*pb.Item)
+	}
+	if e.Association != nil {
+		e.Association = proto.Clone(e.Association).(*pb.ItemAssociation)
+	}
+	return e
+}
@@ -0,0 +1,137 @@
+// Package tenant resolves the tenant a request acts on behalf of and carries
+// it through the request context.
+//
+// Every item and association belongs to exactly one tenant. Reads are
+// confined to the tenant of the request unless the caller is an
+// administrator and explicitly opts into a cross-tenant query.
+package tenant
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Request metadata keys understood by the Resolver.
+const (
+	// TenantIdKey carries the id of the tenant the request acts for.
+	TenantIdKey = "x-ntnx-tenant-id"
+	// AllTenantsKey opts an administrator into a cross-tenant query when set
+	// to a true boolean value.
+	AllTenantsKey = "x-ntnx-all-tenants"
+)
+
+// Scope is the set of tenants a request may see.
+type Scope struct {
+	// TenantId is the tenant of the request. New entities are created for
+	// this tenant.
+	TenantId string
+	// AllTenants widens reads, updates and deletes to every tenant.
+	AllTenants bool
+}
+
+// Includes reports whether an entity owned by tenantId is visible in s.
+func (s Scope) Includes(tenantId string) bool {
+	return s.AllTenants || s.TenantId == tenantId
+}
+
+type scopeKey struct{}
+
+// NewContext returns a copy of ctx carrying s.
+func NewContext(ctx context.Context, s Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, s)
+}
+
+// FromContext returns the Scope stored in ctx by NewContext.
+func FromContext(ctx context.Context) (Scope, bool) {
+	s, ok := ctx.Value(scopeKey{}).(Scope)
+	return s, ok
+}
+
+// Resolver derives a Scope from incoming gRPC metadata.
+type Resolver struct {
+	// DefaultTenantId is used for requests that carry no tenant id. When
+	// empty such requests are rejected.
+	DefaultTenantId string
+	// IsAdmin reports whether the caller may opt into cross-tenant queries.
+	// When nil nobody may.
+	IsAdmin func(ctx context.Context) bool
+	// TenantOf returns the tenant the authenticated caller is bound to, or
+	// "" when the caller may act for any tenant. Bound callers default to
+	// their tenant and cannot name another one. When nil no caller is
+	// bound.
+	TenantOf func(ctx context.Context) string
+}
+
+// Resolve returns the Scope for the request in ctx.
+func (r *Resolver) Resolve(ctx context.Context) (Scope, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	scope := Scope{TenantId: first(md, TenantIdKey)}
+	if r.TenantOf != nil {
+		if bound := r.TenantOf(ctx); bound != "" {
+			if scope.TenantId != "" && scope.TenantId != bound {
+				return Scope{}, status.Errorf(codes.PermissionDenied, "caller cannot act for tenant %s", scope.TenantId)
+			}
+			scope.TenantId = bound
+		}
+	}
+	if scope.TenantId == "" {
+		scope.TenantId = r.DefaultTenantId
+	}
+	if scope.TenantId == "" {
+		return Scope{}, status.Errorf(codes.Unauthenticated, "request metadata carries no %s", TenantIdKey)
+	}
+	if v := first(md, AllTenantsKey); v != "" {
+		all, err := strconv.ParseBool(v)
+		if err != nil {
+			return Scope{}, status.Errorf(codes.InvalidArgument, "invalid %s value %q", AllTenantsKey, v)
+		}
+		if all && (r.IsAdmin == nil || !r.IsAdmin(ctx)) {
+			return Scope{}, status.Error(codes.PermissionDenied, "cross-tenant queries require administrator access")
+		}
+		scope.AllTenants = all
+	}
+	return scope, nil
+}
+
+// UnaryServerInterceptor resolves the Scope of every unary call and stores
+// it in the handler's context.
+func (r *Resolver) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		scope, err := r.Resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(NewContext(ctx, scope), req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func (r *Resolver) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		scope, err := r.Resolve(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &scopedStream{ServerStream: ss, ctx: NewContext(ss.Context(), scope)})
+	}
+}
+
+type scopedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *scopedStream) Context() context.Context { return s.ctx }
+
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type callerKey struct{}
+
+// caller is the authenticated caller of the requests of the tests.
+type caller struct {
+	admin  bool
+	tenant string
+}
+
+func TestResolve(t *testing.T) {
+	r := &Resolver{
+		IsAdmin:  func(ctx context.Context) bool { return ctx.Value(callerKey{}).(caller).admin },
+		TenantOf: func(ctx context.Context) string { return ctx.Value(callerKey{}).(caller).tenant },
+	}
+	withDefault := *r
+	withDefault.DefaultTenantId = "default"
+	tests := []struct {
+		name   string
+		r      *Resolver
+		caller caller
+		md     []string
+		want   Scope
+		code   codes.Code
+	}{
+		{name: "tenant", r: r, md: []string{TenantIdKey, "a"}, want: Scope{TenantId: "a"}},
+		{name: "no tenant", r: r, code: codes.Unauthenticated},
+		{name: "default tenant", r: &withDefault, want: Scope{TenantId: "default"}},
+		{name: "named over default", r: &withDefault, md: []string{TenantIdKey, "a"}, want: Scope{TenantId: "a"}},
+		{name: "bound", r: r, caller: caller{tenant: "a"}, want: Scope{TenantId: "a"}},
+		{name: "bound over default", r: &withDefault, caller: caller{tenant: "a"}, want: Scope{TenantId: "a"}},
+		{name: "bound naming itself", r: r, caller: caller{tenant: "a"}, md: []string{TenantIdKey, "a"}, want: Scope{TenantId: "a"}},
+		{name: "bound naming another", r: r, caller: caller{tenant: "a"}, md: []string{TenantIdKey, "b"}, code: codes.PermissionDenied},
+		{
+			name:   "all tenants",
+			r:      r,
+			caller: caller{admin: true},
+			md:     []string{TenantIdKey, "a", AllTenantsKey, "true"},
+			want:   Scope{TenantId: "a", AllTenants: true},
+		},
+		{
+			name: "all tenants declined",
+			r:    r,
+			md:   []string{TenantIdKey, "a", AllTenantsKey, "false"},
+			want: Scope{TenantId: "a"},
+		},
+		{name: "all tenants without admin", r: r, md: []string{TenantIdKey, "a", AllTenantsKey, "1"}, code: codes.PermissionDenied},
+		{
+			name:   "all tenants without an admin check",
+			r:      &Resolver{},
+			caller: caller{admin: true},
+			md:     []string{TenantIdKey, "a", AllTenantsKey, "true"},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "malformed all tenants",
+			r:      r,
+			caller: caller{admin: true},
+			md:     []string{TenantIdKey, "a", AllTenantsKey, "yes"},
+			code:   codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), callerKey{}, tt.caller)
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(tt.md...))
+			got, err := tt.r.Resolve(ctx)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got %v, want code %v", err, tt.code)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeIncludes(t *testing.T) {
+	tests := []struct {
+		scope    Scope
+		tenantId string
+		want     bool
+	}{
+		{Scope{TenantId: "a"}, "a", true},
+		{Scope{TenantId: "a"}, "b", false},
+		{Scope{TenantId: "a"}, "", false},
+		{Scope{TenantId: "a", AllTenants: true}, "b", true},
+	}
+	for _, tt := range tests {
+		if got := tt.scope.Includes(tt.tenantId); got != tt.want {
+			t.Errorf("%+v.Includes(%q) = %v, want %v", tt.scope, tt.tenantId, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Package versioning serves every supported version of a module's REST API
+// from a handler implementing one of them.
+//
+// Requests are addressed as /api/{namespace}/{version}/{module}/..., with
+// versions such as v4.0, v4.1 or v4.2.b1. A Router rewrites the path of
+// requests for any supported version to the version its handler serves,
+// and downgrades the JSON responses of older versions: properties the
+// requested version does not know are removed from every object by its
+// $objectType, the "$fv" reserved marker is set to the requested version
+// and links are pointed back at it. Requests for unsupported versions are
+// answered with an ErrorResponse.
+package versioning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	import1 "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/common/v1/config"
+	errordto "github.com/nutanix/ntnx-api-golang-nexus-pc/generated-code/dto/models/nexus/v4/error"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/discovery"
+)
+
+// Release is a version of a module and the properties it introduced.
+type Release struct {
+	Version discovery.Version
+	// Added maps the $objectType of DTOs to the properties this version
+	// added to them.
+	Added map[string][]string
+}
+
+// Router routes the requests of every release of a module to Next.
+type Router struct {
+	Namespace string
+	Module    string
+	// Releases lists the supported versions, oldest first. Next serves the
+	// properties of all of them.
+	Releases []Release
+	// Current is the version of the paths Next serves.
+	Current discovery.Version
+	// ErrorCode is the code of the AppMessage reporting unsupported
+	// versions.
+	ErrorCode string
+	Next      http.Handler
+}
+
+type contextKey struct{}
+
+// FromContext returns the version requested by the request whose context
+// is ctx, as routed by a Router.
+func FromContext(ctx context.Context) (discovery.Version, bool) {
+	v, ok := ctx.Value(contextKey{}).(discovery.Version)
+	return v, ok
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	prefix := "/api/" + rt.Namespace + "/"
+	rest, ok := strings.CutPrefix(r.URL.Path, prefix)
+	if !ok {
+		rt.Next.ServeHTTP(w, r)
+		return
+	}
+	segment, tail, _ := strings.Cut(rest, "/")
+	if module, _, _ := strings.Cut(tail, "/"); module != rt.Module {
+		rt.Next.ServeHTTP(w, r)
+		return
+	}
+	v, err := discovery.ParseVersion(segment)
+	if err == nil && !rt.supports(v) {
+		err = fmt.Errorf("API version %s is not supported", v)
+	}
+	if err != nil {
+		rt.unsupported(w, err)
+		return
+	}
+	r = r.WithContext(context.WithValue(r.Context(), contextKey{}, v))
+	if v == rt.Current {
+		rt.Next.ServeHTTP(w, r)
+		return
+	}
+
+	requestedRoot := discovery.ServiceRoot(rt.Namespace, v, rt.Module)
+	currentRoot := discovery.ServiceRoot(rt.Namespace, rt.Current, rt.Module)
+	r.URL.Path = currentRoot + strings.TrimPrefix(r.URL.Path, requestedRoot)
+	r.URL.RawPath = ""
+	r.RequestURI = r.URL.RequestURI()
+
+	buf := &bufferedWriter{header: make(http.Header), status: http.StatusOK}
+	rt.Next.ServeHTTP(buf, r)
+	body := buf.body.Bytes()
+	if isJSON(buf.header.Get("Content-Type")) && len(body) > 0 {
+		if downgraded, err := rt.downgrade(body, v, currentRoot, requestedRoot); err == nil {
+			body = downgraded
+		}
+	}
+	for k, values := range buf.header {
+		w.Header()[k] = values
+	}
+	if w.Header().Get("Content-Length") != "" {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	}
+	w.WriteHeader(buf.status)
+	w.Write(body)
+}
+
+func (rt *Router) supports(v discovery.Version) bool {
+	for _, rel := range rt.Releases {
+		if rel.Version == v {
+			return true
+		}
+	}
+	return false
+}
+
+// unsupported answers a request for an unknown version with a Not Found
+// ErrorResponse listing the supported ones.
+func (rt *Router) unsupported(w http.ResponseWriter, err error) {
+	versions := make([]string, len(rt.Releases))
+	for i, rel := range rt.Releases {
+		versions[i] = rel.Version.String()
+	}
+	msg := errordto.NewAppMessage()
+	msg.Message = new(string)
+	*msg.Message = fmt.Sprintf("%v; %s.%s supports %s", err, rt.Namespace, rt.Module, strings.Join(versions, ", "))
+	msg.Code = new(string)
+	*msg.Code = rt.ErrorCode
+	msg.Locale = new(string)
+	*msg.Locale = "en_US"
+	msg.Severity = new(import1.MessageSeverity)
+	*msg.Severity = import1.MESSAGESEVERITY_ERROR
+	resp := errordto.NewErrorResponse()
+	resp.Error = errordto.NewOneOfErrorResponseError()
+	if err := resp.Error.SetValue([]errordto.AppMessage{*msg}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	body, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	w.Write(body)
+}
+
+// downgrade rewrites the JSON body of a response of Next for a request of
+// version v.
+func (rt *Router) downgrade(body []byte, v discovery.Version, currentRoot, requestedRoot string) ([]byte, error) {
+	removed := make(map[string][]string)
+	for _, rel := range rt.Releases {
+		if rel.Version.Compare(v) > 0 {
+			for objectType, props := range rel.Added {
+				removed[objectType] = append(removed[objectType], props...)
+			}
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	d := &downgrader{
+		removed:  removed,
+		older:    v.Compare(rt.Current) < 0,
+		fromFV:   rt.Current.FormatVersion(),
+		toFV:     v.FormatVersion(),
+		fromRoot: currentRoot,
+		toRoot:   requestedRoot,
+	}
+	return json.Marshal(d.walk(doc))
+}
+
+type downgrader struct {
+	removed map[string][]string
+	// older reports whether the requested version is older than the one
+	// served, in which case "$fv" markers are downgraded too.
+	older            bool
+	fromFV, toFV     string
+	fromRoot, toRoot string
+}
+
+func (d *downgrader) walk(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if objectType, ok := v["$objectType"].(string); ok {
+			for _, prop := range d.removed[objectType] {
+				delete(v, prop)
+			}
+		}
+		if reserved, ok := v["$reserved"].(map[string]interface{}); ok && d.older && reserved["$fv"] == d.fromFV {
+			reserved["$fv"] = d.toFV
+		}
+		if href, ok := v["href"].(string); ok {
+			v["href"] = d.rewriteLink(href)
+		}
+		for k, child := range v {
+			v[k] = d.walk(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = d.walk(child)
+		}
+	}
+	return v
+}
+
+// rewriteLink points href, absolute or not, at the requested version.
+func (d *downgrader) rewriteLink(href string) string {
+	i := strings.Index(href, d.fromRoot)
+	if i < 0 {
+		return href
+	}
+	rest := href[i+len(d.fromRoot):]
+	if rest != "" && rest[0] != '/' && rest[0] != '?' {
+		return href
+	}
+	return href[:i] + d.toRoot + rest
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// bufferedWriter holds a response so that it can be rewritten before it is
+// sent.
+type bufferedWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedWriter) Header() http.Header { return b.header }
+
+func (b *bufferedWriter) WriteHeader(status int) { b.status = status }
+
+func (b *bufferedWriter) Write(p []byte) (int, error) { return b.body.Write(p) }
@@ -0,0 +1,221 @@
+package versioning
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nutanix/ntnx-api-golang-mock-pc/pkg/discovery"
+)
+
+// servedBody is the response of the handler routed to, at v4.1.
+const servedBody = `{
+	"$objectType": "nexus.v4.config.ListItemsApiResponse",
+	"$reserved": {"$fv": "v4.r1"},
+	"data": [{
+		"$objectType": "nexus.v4.config.Item",
+		"$reserved": {"$fv": "v4.r1"},
+		"itemName": "disk-1",
+		"tenantInfo": {"tenantId": "tenant-1"},
+		"deletedAt": "2024-05-01T12:00:00Z",
+		"links": [{"href": "https://pc:9440/api/nexus/v4.1/config/items/1", "rel": "self"}]
+	}],
+	"metadata": {
+		"$objectType": "common.v1.response.ApiResponseMetadata",
+		"links": [
+			{"href": "/api/nexus/v4.1/config/items?$page=1", "rel": "next"},
+			{"href": "/api/nexus/v4.1/configs", "rel": "other"}
+		]
+	}
+}`
+
+// routed records the request last routed to the handler of testRouter.
+type routed struct {
+	path, query string
+	version     discovery.Version
+}
+
+// testRouter returns a router of the nexus config module at v4.1 routing
+// to a handler serving servedBody, or plain text under /text.
+func testRouter(got *routed) *Router {
+	return &Router{
+		Namespace: "nexus",
+		Module:    "config",
+		Releases: []Release{
+			{Version: discovery.Version{Major: "4", Minor: "0"}},
+			{
+				Version: discovery.Version{Major: "4", Minor: "1"},
+				Added:   map[string][]string{"nexus.v4.config.Item": {"tenantInfo", "links"}},
+			},
+			{
+				Version: discovery.Version{Major: "4", Minor: "2", ReleaseType: "b", ReleaseTypeRevision: "1"},
+				Added:   map[string][]string{"nexus.v4.config.Item": {"deletedAt"}},
+			},
+		},
+		Current:   discovery.Version{Major: "4", Minor: "1"},
+		ErrorCode: "TEST-404",
+		Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*got = routed{path: r.URL.Path, query: r.URL.RawQuery}
+			got.version, _ = FromContext(r.Context())
+			if strings.HasSuffix(r.URL.Path, "/text") {
+				w.Header().Set("Content-Type", "text/plain")
+				io.WriteString(w, "/api/nexus/v4.1/config/text")
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Length", strconv.Itoa(len(servedBody)))
+			w.WriteHeader(http.StatusAccepted)
+			io.WriteString(w, servedBody)
+		}),
+	}
+}
+
+// downgraded returns servedBody without the properties of Item listed, with
+// "$fv" markers set to fv and links pointed at root.
+func downgraded(t *testing.T, fv, root string, without ...string) interface{} {
+	t.Helper()
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.ReplaceAll(servedBody, "/api/nexus/v4.1/config/", root+"/")), &doc); err != nil {
+		t.Fatal(err)
+	}
+	item := doc["data"].([]interface{})[0].(map[string]interface{})
+	for _, prop := range without {
+		delete(item, prop)
+	}
+	doc["$reserved"].(map[string]interface{})["$fv"] = fv
+	item["$reserved"].(map[string]interface{})["$fv"] = fv
+	return doc
+}
+
+func TestRouter(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		path    string
+		version string
+		want    interface{}
+	}{
+		{
+			name:    "current",
+			target:  "/api/nexus/v4.1/config/items?$page=0",
+			path:    "/api/nexus/v4.1/config/items",
+			version: "v4.1",
+			want:    downgraded(t, "v4.r1", "/api/nexus/v4.1/config"),
+		},
+		{
+			name:    "older",
+			target:  "/api/nexus/v4.0/config/items?$page=0",
+			path:    "/api/nexus/v4.1/config/items",
+			version: "v4.0",
+			want:    downgraded(t, "v4.r0", "/api/nexus/v4.0/config", "tenantInfo", "links", "deletedAt"),
+		},
+		// Next serves the properties of newer releases too, so none are
+		// removed, and "$fv" markers are left as served.
+		{
+			name:    "newer",
+			target:  "/api/nexus/v4.2.b1/config/items?$page=0",
+			path:    "/api/nexus/v4.1/config/items",
+			version: "v4.2.b1",
+			want:    downgraded(t, "v4.r1", "/api/nexus/v4.2.b1/config"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got routed
+			w := httptest.NewRecorder()
+			testRouter(&got).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if got.path != tt.path || got.query != "$page=0" || got.version.String() != tt.version {
+				t.Errorf("routed %s?%s at %s, want %s?$page=0 at %s", got.path, got.query, got.version, tt.path, tt.version)
+			}
+			if w.Code != http.StatusAccepted {
+				t.Errorf("got status %d, want %d", w.Code, http.StatusAccepted)
+			}
+			if n := w.Header().Get("Content-Length"); n != strconv.Itoa(w.Body.Len()) {
+				t.Errorf("got Content-Length %s, want %d", n, w.Body.Len())
+			}
+			var body interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(body, tt.want) {
+				t.Errorf("got %s\nwant %v", w.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouterPassThrough(t *testing.T) {
+	tests := []struct {
+		target string
+		path   string
+		body   string
+	}{
+		{"/api/nexus/v4.0/stats/items", "/api/nexus/v4.0/stats/items", servedBody},
+		{"/api/vmm/v4.0/config/items", "/api/vmm/v4.0/config/items", servedBody},
+		{"/healthz", "/healthz", servedBody},
+		// Only JSON is rewritten.
+		{"/api/nexus/v4.0/config/text", "/api/nexus/v4.1/config/text", "/api/nexus/v4.1/config/text"},
+	}
+	for _, tt := range tests {
+		var got routed
+		w := httptest.NewRecorder()
+		testRouter(&got).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if got.path != tt.path || w.Body.String() != tt.body {
+			t.Errorf("GET %s: routed %s, got %s, want %s, %s", tt.target, got.path, w.Body, tt.path, tt.body)
+		}
+	}
+}
+
+func TestRouterUnsupported(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"/api/nexus/v4.3/config/items", "API version v4.3 is not supported; nexus.config supports v4.0, v4.1, v4.2.b1"},
+		{"/api/nexus/v4.2/config/items", "API version v4.2 is not supported; nexus.config supports v4.0, v4.1, v4.2.b1"},
+		{"/api/nexus/latest/config/items", `"latest" is not an API version; nexus.config supports v4.0, v4.1, v4.2.b1`},
+	}
+	for _, tt := range tests {
+		var got routed
+		w := httptest.NewRecorder()
+		testRouter(&got).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if got.path != "" {
+			t.Errorf("GET %s was routed to %s", tt.target, got.path)
+		}
+		var resp struct {
+			Error []struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("GET %s: %v\n%s", tt.target, err, w.Body)
+		}
+		if w.Code != http.StatusNotFound || len(resp.Error) != 1 || resp.Error[0].Code != "TEST-404" || resp.Error[0].Message != tt.want {
+			t.Errorf("GET %s = %d %s, want %d with message %s", tt.target, w.Code, w.Body, http.StatusNotFound, tt.want)
+		}
+	}
+}
+
+func TestRewriteLink(t *testing.T) {
+	d := &downgrader{fromRoot: "/api/nexus/v4.1/config", toRoot: "/api/nexus/v4.0/config"}
+	tests := []struct {
+		href, want string
+	}{
+		{"/api/nexus/v4.1/config", "/api/nexus/v4.0/config"},
+		{"/api/nexus/v4.1/config/items/1", "/api/nexus/v4.0/config/items/1"},
+		{"https://pc:9440/api/nexus/v4.1/config?$top=1", "https://pc:9440/api/nexus/v4.0/config?$top=1"},
+		{"/api/nexus/v4.1/configs", "/api/nexus/v4.1/configs"},
+		{"/api/vmm/v4.1/config/vms", "/api/vmm/v4.1/config/vms"},
+	}
+	for _, tt := range tests {
+		if got := d.rewriteLink(tt.href); got != tt.want {
+			t.Errorf("rewriteLink(%s) = %s, want %s", tt.href, got, tt.want)
+		}
+	}
+}